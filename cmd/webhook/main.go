@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -43,19 +46,60 @@ type SuccessResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
-// webhookSecretCache holds the cached webhook secret from Parameter Store
-var webhookSecretCache string
+// Cached webhook secrets expire after webhookSecretCacheTTL. A signature that none of the cached
+// secrets verifies reloads them early, at most once per webhookSecretReloadInterval, so a rotated
+// secret is picked up by warm containers without letting bad requests hammer Parameter Store.
+// A failed reload keeps the cached secrets and is retried after the same interval.
+const (
+	webhookSecretCacheTTL       = 5 * time.Minute
+	webhookSecretReloadInterval = 30 * time.Second
+)
+
+// webhookSecretCache holds the cached webhook secrets from Parameter Store, when they were
+// loaded and when a load was last attempted
+var (
+	webhookSecretCache       []string
+	webhookSecretLoadedAt    time.Time
+	webhookSecretAttemptedAt time.Time
+)
+
+// loadWebhookSecretsFromSSM loads the Typeform webhook secrets from Parameter Store. Cached
+// secrets are returned until they expire; refresh reloads them if they are older than the
+// reload interval. If a reload fails the cached secrets are kept, so an error is only returned
+// when no secret has ever loaded.
+func loadWebhookSecretsFromSSM(ctx context.Context, refresh bool) ([]string, error) {
+	// Return cached value if still fresh, or if a reload was just attempted
+	if len(webhookSecretCache) > 0 {
+		fresh := !refresh && time.Since(webhookSecretLoadedAt) < webhookSecretCacheTTL
+		if fresh || time.Since(webhookSecretAttemptedAt) < webhookSecretReloadInterval {
+			return webhookSecretCache, nil
+		}
+	}
 
-// loadWebhookSecretFromSSM loads the Typeform webhook secret from Parameter Store
-func loadWebhookSecretFromSSM(ctx context.Context) (string, error) {
-	// Return cached value if already loaded
-	if webhookSecretCache != "" {
-		return webhookSecretCache, nil
+	webhookSecretAttemptedAt = time.Now()
+	secrets, err := getWebhookSecrets(ctx)
+	if err != nil {
+		if len(webhookSecretCache) > 0 {
+			log.Printf("⚠️  Failed to reload Typeform webhook secrets, keeping %d cached secret(s): %v", len(webhookSecretCache), err)
+			return webhookSecretCache, nil
+		}
+		return nil, err
 	}
 
+	// Cache the secrets
+	webhookSecretCache = secrets
+	webhookSecretLoadedAt = webhookSecretAttemptedAt
+	log.Printf("✅ Successfully loaded %d Typeform webhook secret(s) from Parameter Store", len(secrets))
+
+	return webhookSecretCache, nil
+}
+
+// getWebhookSecrets reads the webhook secret parameter. During a rotation it holds the new and
+// old secrets separated by a comma or newline.
+func getWebhookSecrets(ctx context.Context) ([]string, error) {
 	cfg, err := awsconfig.LoadDefaultConfig(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	client := ssm.NewFromConfig(cfg)
@@ -71,14 +115,15 @@ func loadWebhookSecretFromSSM(ctx context.Context) (string, error) {
 		WithDecryption: aws.Bool(true), // Important for SecureString parameters
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Cache the secret
-	webhookSecretCache = *result.Parameter.Value
-	log.Printf("✅ Successfully loaded Typeform webhook secret from Parameter Store")
+	secrets := typeform.ParseWebhookSecrets(aws.ToString(result.Parameter.Value))
+	if len(secrets) == 0 {
+		return nil, fmt.Errorf("parameter %s is empty", parameterPath)
+	}
 
-	return webhookSecretCache, nil
+	return secrets, nil
 }
 
 func main() {
@@ -140,62 +185,19 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 		return createErrorResponse(401, "Unauthorized", "Missing Typeform-Signature header"), nil
 	}
 
-	// Load webhook secret from Parameter Store
-	secret, err := loadWebhookSecretFromSSM(ctx)
+	// Load webhook secrets from Parameter Store
+	secrets, err := loadWebhookSecretsFromSSM(ctx, false)
+	if err == nil && !typeform.ValidateWebhookSignatures([]byte(request.Body), signature, secrets) {
+		// The secret may have been rotated since it was cached
+		secrets, err = loadWebhookSecretsFromSSM(ctx, true)
+	}
 	if err != nil {
+		// Only reached when no secret has ever loaded; a failed reload keeps the cached secrets
 		logger.Error("failed to load webhook secret from parameter store",
 			"error", err)
 		return createErrorResponse(500, "Internal server error", "Failed to load webhook secret"), nil
 	}
 
-	// Validate signature
-	payload := []byte(request.Body)
-	if !typeform.ValidateWebhookSignature(payload, signature, secret) {
-		logger.Warn("invalid webhook signature",
-			"signature", signature)
-		return createErrorResponse(401, "Unauthorized", "Invalid webhook signature"), nil
-	}
-
-	logger.Info("webhook signature validated successfully")
-
-	// Parse webhook payload
-	var webhook typeform.WebhookPayload
-	if err := json.Unmarshal(payload, &webhook); err != nil {
-		logger.Error("failed to parse webhook payload",
-			"error", err)
-		return createErrorResponse(400, "Bad request", "Invalid webhook payload"), nil
-	}
-
-	logger.Info("webhook payload parsed",
-		"event_id", webhook.EventID,
-		"event_type", webhook.EventType,
-		"form_id", webhook.FormResponse.FormID)
-
-	// Extract hidden fields
-	customerCode := webhook.FormResponse.Hidden["customer_code"]
-	year := webhook.FormResponse.Hidden["year"]
-	quarter := webhook.FormResponse.Hidden["quarter"]
-	userLogin := webhook.FormResponse.Hidden["user_login"]
-	eventType := webhook.FormResponse.Hidden["event_type"]
-	eventSubtype := webhook.FormResponse.Hidden["event_subtype"]
-
-	logger.Info("extracted hidden fields",
-		"customer_code", customerCode,
-		"year", year,
-		"quarter", quarter,
-		"user_login", userLogin,
-		"event_type", eventType,
-		"event_subtype", eventSubtype)
-
-	// Validate required hidden fields
-	if customerCode == "" || year == "" || quarter == "" {
-		logger.Warn("missing required hidden fields",
-			"customer_code", customerCode,
-			"year", year,
-			"quarter", quarter)
-		// Continue processing but log the warning
-	}
-
 	// Load AWS configuration
 	bucketName := os.Getenv("S3_BUCKET")
 	if bucketName == "" {
@@ -215,17 +217,24 @@ func handleRequest(ctx context.Context, request events.APIGatewayProxyRequest) (
 
 	// Create webhook handler
 	webhookHandler := typeform.NewWebhookHandler(s3Client, bucketName, logger)
+	webhookHandler.Secrets = secrets
 
-	// Process webhook (stores results in S3)
-	if err := webhookHandler.HandleWebhook(ctx, payload, signature); err != nil {
-		logger.Error("failed to process webhook",
+	// Validate, dedupe and store the webhook. The status code tells Typeform whether to retry.
+	statusCode, err := webhookHandler.HandleWebhook(ctx, []byte(request.Body), signature)
+	if err != nil {
+		logger.Warn("webhook not processed",
+			"status", statusCode,
 			"error", err)
-		return createErrorResponse(500, "Internal server error", "Failed to process webhook"), nil
+		message := err.Error()
+		if statusCode >= 500 {
+			// Don't expose internal errors to the caller
+			message = "Failed to process webhook"
+		}
+		return createErrorResponse(statusCode, http.StatusText(statusCode), message), nil
 	}
 
 	logger.Info("webhook processed successfully",
-		"form_id", webhook.FormResponse.FormID,
-		"customer_code", customerCode)
+		"request_id", request.RequestContext.RequestID)
 
 	// Return success response
 	return createSuccessResponse("Webhook processed successfully"), nil
@@ -13,10 +13,12 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/route53 v1.58.5
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.88.3
 	github.com/aws/aws-sdk-go-v2/service/sesv2 v1.53.3
+	github.com/aws/aws-sdk-go-v2/service/sns v1.38.6
 	github.com/aws/aws-sdk-go-v2/service/ssm v1.65.1
 	github.com/aws/aws-sdk-go-v2/service/ssoadmin v1.36.3
 	github.com/aws/aws-sdk-go-v2/service/sts v1.28.6
 	github.com/aws/smithy-go v1.23.1
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
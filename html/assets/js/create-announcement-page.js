@@ -455,6 +455,7 @@ function collectFormData() {
         content: document.getElementById('announcementContent').value.trim(),
         customers: getSelectedCustomers(),
         include_meeting: meetingRequired,
+        priority: document.getElementById('announcementPriority').value,
         meeting_metadata: null,
         attachments: uploadedFiles.map(file => ({
             name: file.name,
@@ -0,0 +1,322 @@
+// Package archive maintains a local, incrementally refreshed index of the change and
+// announcement objects stored under archive/ in the metadata bucket
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ArchivePrefix is the S3 prefix holding the authoritative change and announcement objects
+const ArchivePrefix = "archive/"
+
+// Entry kinds
+const (
+	KindChange       = "change"
+	KindAnnouncement = "announcement"
+)
+
+// Entry is a single indexed archive object
+type Entry struct {
+	Key          string    `json:"key"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+
+	Kind        string    `json:"kind"`
+	ObjectType  string    `json:"object_type,omitempty"`
+	ID          string    `json:"id,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Customers   []string  `json:"customers,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	SubmittedBy string    `json:"submitted_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	StartTime   time.Time `json:"start_time,omitempty"` // Implementation start (changes) or posted date (announcements)
+	EndTime     time.Time `json:"end_time,omitempty"`   // Implementation end (changes only)
+	SnowTicket  string    `json:"snow_ticket,omitempty"`
+	JiraTicket  string    `json:"jira_ticket,omitempty"`
+	Text        string    `json:"text,omitempty"` // Searchable free text (reason, summary, content)
+
+	// Problem is set when the object could not be parsed or failed validation
+	Problem string `json:"problem,omitempty"`
+}
+
+// Malformed reports whether the object failed to load or validate
+func (e *Entry) Malformed() bool {
+	return e.Problem != ""
+}
+
+// ObjectInfo describes an archive object as listed from S3
+type ObjectInfo struct {
+	Key          string
+	ETag         string
+	LastModified time.Time
+}
+
+// Loader loads archive objects. Load downloads an object once and returns it as a change, or as
+// an announcement when its object_type starts with "announcement_".
+type Loader struct {
+	Load                 func(ctx context.Context, key string) (*types.ChangeMetadata, *types.AnnouncementMetadata, error)
+	ValidateAnnouncement func(announcement *types.AnnouncementMetadata) error
+}
+
+// RefreshStats summarizes an index refresh
+type RefreshStats struct {
+	Listed    int
+	Added     int
+	Updated   int
+	Unchanged int
+	Removed   int
+	Malformed int
+}
+
+// Index is the local archive index, keyed by S3 key
+type Index struct {
+	entries map[string]*Entry
+}
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{entries: make(map[string]*Entry)}
+}
+
+// LoadIndex reads an index from a JSON lines file. A missing file yields an empty index.
+func LoadIndex(path string) (*Index, error) {
+	idx := NewIndex()
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, fmt.Errorf("failed to open index %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse index %s line %d: %w", path, line, err)
+		}
+		idx.entries[entry.Key] = &entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read index %s: %w", path, err)
+	}
+
+	return idx, nil
+}
+
+// Save writes the index as JSON lines, sorted by key, replacing the file atomically
+func (idx *Index) Save(path string) error {
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create index %s: %w", tmpPath, err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, entry := range idx.Entries() {
+		if err := encoder.Encode(entry); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write index entry %s: %w", entry.Key, err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write index %s: %w", tmpPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close index %s: %w", tmpPath, err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// Entries returns all entries sorted by key
+func (idx *Index) Entries() []*Entry {
+	entries := make([]*Entry, 0, len(idx.entries))
+	for _, entry := range idx.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// Len returns the number of indexed objects
+func (idx *Index) Len() int {
+	return len(idx.entries)
+}
+
+// Refresh brings the index in line with the listed objects. Only objects whose ETag changed
+// (or that are new) are downloaded; objects no longer listed are removed.
+func (idx *Index) Refresh(ctx context.Context, objects []ObjectInfo, loader Loader) RefreshStats {
+	stats := RefreshStats{Listed: len(objects)}
+	seen := make(map[string]bool, len(objects))
+
+	for _, obj := range objects {
+		seen[obj.Key] = true
+
+		existing, exists := idx.entries[obj.Key]
+		if exists && existing.ETag == obj.ETag {
+			stats.Unchanged++
+			if existing.Malformed() {
+				stats.Malformed++
+			}
+			continue
+		}
+
+		entry := loadEntry(ctx, obj, loader)
+		idx.entries[obj.Key] = entry
+
+		if exists {
+			stats.Updated++
+		} else {
+			stats.Added++
+		}
+		if entry.Malformed() {
+			stats.Malformed++
+		}
+	}
+
+	for key := range idx.entries {
+		if !seen[key] {
+			delete(idx.entries, key)
+			stats.Removed++
+		}
+	}
+
+	return stats
+}
+
+// loadEntry loads and validates a single archive object into an index entry
+func loadEntry(ctx context.Context, obj ObjectInfo, loader Loader) *Entry {
+	entry := &Entry{
+		Key:          obj.Key,
+		ETag:         obj.ETag,
+		LastModified: obj.LastModified,
+		Kind:         KindChange,
+		ID:           strings.TrimSuffix(strings.TrimPrefix(obj.Key, ArchivePrefix), ".json"),
+	}
+
+	metadata, announcement, err := loader.Load(ctx, obj.Key)
+	if err != nil {
+		entry.Problem = err.Error()
+		return entry
+	}
+
+	if announcement != nil {
+		entry.Kind = KindAnnouncement
+		entry.ObjectType = announcement.ObjectType
+		fillFromAnnouncement(entry, announcement)
+
+		if loader.ValidateAnnouncement != nil {
+			if err := loader.ValidateAnnouncement(announcement); err != nil {
+				entry.Problem = err.Error()
+			}
+		}
+		return entry
+	}
+
+	fillFromChange(entry, metadata)
+	if err := metadata.ValidateLegacyMetadata(); err != nil {
+		entry.Problem = err.Error()
+	}
+
+	return entry
+}
+
+// fillFromChange copies the indexed fields from a change
+func fillFromChange(entry *Entry, metadata *types.ChangeMetadata) {
+	entry.ObjectType = metadata.ObjectType
+	if metadata.ChangeID != "" {
+		entry.ID = metadata.ChangeID
+	}
+	entry.Title = metadata.ChangeTitle
+	entry.Status = metadata.Status
+	entry.Customers = metadata.Customers
+	entry.CreatedBy = metadata.CreatedBy
+	entry.SubmittedBy = metadata.SubmittedBy
+	entry.CreatedAt = metadata.CreatedAt
+	entry.StartTime = metadata.ImplementationStart
+	entry.EndTime = metadata.ImplementationEnd
+	entry.SnowTicket = metadata.SnowTicket
+	entry.JiraTicket = metadata.JiraTicket
+	entry.Text = strings.Join(nonEmpty(metadata.ChangeReason, metadata.CustomerImpact, metadata.ImplementationPlan), "\n")
+}
+
+// fillFromAnnouncement copies the indexed fields from an announcement
+func fillFromAnnouncement(entry *Entry, announcement *types.AnnouncementMetadata) {
+	if announcement.AnnouncementID != "" {
+		entry.ID = announcement.AnnouncementID
+	}
+	entry.Title = announcement.Title
+	entry.Status = announcement.Status
+	entry.Customers = announcement.Customers
+	entry.CreatedBy = announcement.CreatedBy
+	entry.SubmittedBy = announcement.SubmittedBy
+	entry.CreatedAt = announcement.CreatedAt
+	entry.StartTime = announcement.PostedDate
+	entry.Text = strings.Join(nonEmpty(announcement.Summary, announcement.Content), "\n")
+}
+
+// nonEmpty returns the non-blank values
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// ListArchiveObjects lists all JSON objects under archive/ with their ETags
+func ListArchiveObjects(ctx context.Context, s3Client *s3.Client, bucket string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(ArchivePrefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s in %s: %w", ArchivePrefix, bucket, err)
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			if !strings.HasSuffix(key, ".json") {
+				continue
+			}
+			objects = append(objects, ObjectInfo{
+				Key:          key,
+				ETag:         strings.Trim(aws.ToString(obj.ETag), `"`),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+
+	return objects, nil
+}
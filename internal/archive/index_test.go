@@ -0,0 +1,168 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// fakeArchive serves archive objects from memory and counts downloads
+type fakeArchive struct {
+	changes       map[string]*types.ChangeMetadata
+	announcements map[string]*types.AnnouncementMetadata
+	downloads     int
+}
+
+func (f *fakeArchive) loader() Loader {
+	return Loader{
+		Load: func(ctx context.Context, key string) (*types.ChangeMetadata, *types.AnnouncementMetadata, error) {
+			f.downloads++
+			if announcement, ok := f.announcements[key]; ok {
+				return nil, announcement, nil
+			}
+			if change, ok := f.changes[key]; ok {
+				return change, nil, nil
+			}
+			return nil, nil, fmt.Errorf("failed to parse metadata: unexpected end of JSON input")
+		},
+	}
+}
+
+func newFakeArchive() *fakeArchive {
+	start := time.Date(2025, 4, 10, 14, 0, 0, 0, time.UTC)
+	return &fakeArchive{
+		changes: map[string]*types.ChangeMetadata{
+			"archive/CHG-1.json": {
+				ChangeID: "CHG-1", ChangeTitle: "Upgrade RDS engine", ChangeReason: "Security patch for PostgreSQL",
+				Status: "approved", Customers: []string{"hts", "cds"}, CreatedBy: "alice@example.com",
+				ImplementationStart: start, ImplementationEnd: start.Add(2 * time.Hour), SnowTicket: "CHG0012345",
+			},
+			"archive/CHG-2.json": {
+				ChangeID: "CHG-2", ChangeTitle: "Rotate certificates", Status: "completed",
+				Customers: []string{"cds"}, CreatedBy: "bob@example.com", JiraTicket: "OPS-77",
+				ImplementationStart: start.AddDate(0, 1, 0), ImplementationEnd: start.AddDate(0, 1, 0).Add(time.Hour),
+			},
+			"archive/CHG-3.json": {
+				ChangeID: "CHG-3", ChangeTitle: "Legacy object", Status: "submitted",
+				Metadata: map[string]interface{}{"request_type": "approval_request"},
+			},
+		},
+		announcements: map[string]*types.AnnouncementMetadata{
+			"archive/FIN-1.json": {
+				ObjectType: "announcement_finops", AnnouncementID: "FIN-1", Title: "Savings plan renewal",
+				Summary: "Quarterly savings plan update", Status: "approved", Customers: []string{"hts"},
+				CreatedBy: "carol@example.com", PostedDate: start.AddDate(0, 0, 3),
+			},
+		},
+	}
+}
+
+func listed(keys ...string) []ObjectInfo {
+	var objects []ObjectInfo
+	for _, key := range keys {
+		objects = append(objects, ObjectInfo{Key: key, ETag: "v1"})
+	}
+	return objects
+}
+
+func TestIndexRefreshIsIncremental(t *testing.T) {
+	fake := newFakeArchive()
+	idx := NewIndex()
+	ctx := context.Background()
+
+	objects := listed("archive/CHG-1.json", "archive/CHG-2.json", "archive/CHG-3.json", "archive/FIN-1.json", "archive/broken.json")
+	stats := idx.Refresh(ctx, objects, fake.loader())
+	if stats.Added != 5 || stats.Malformed != 2 {
+		t.Fatalf("Unexpected first refresh stats: %+v", stats)
+	}
+	if fake.downloads != 5 {
+		t.Errorf("Expected each object, announcements included, to be downloaded once, got %d downloads", fake.downloads)
+	}
+
+	// Round-trip through the JSON lines file
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	idx, err := LoadIndex(path)
+	if err != nil {
+		t.Fatalf("LoadIndex failed: %v", err)
+	}
+
+	// Second refresh: one object changed, one removed
+	fake.downloads = 0
+	objects = listed("archive/CHG-1.json", "archive/CHG-2.json", "archive/CHG-3.json", "archive/FIN-1.json")
+	objects[1].ETag = "v2"
+	stats = idx.Refresh(ctx, objects, fake.loader())
+
+	if stats.Updated != 1 || stats.Unchanged != 3 || stats.Removed != 1 || stats.Added != 0 {
+		t.Errorf("Unexpected incremental refresh stats: %+v", stats)
+	}
+	if fake.downloads != 1 {
+		t.Errorf("Expected only the changed object to be downloaded, got %d downloads", fake.downloads)
+	}
+	if idx.Len() != 4 {
+		t.Errorf("Expected 4 entries, got %d", idx.Len())
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	fake := newFakeArchive()
+	idx := NewIndex()
+	idx.Refresh(context.Background(), listed("archive/CHG-1.json", "archive/CHG-2.json", "archive/CHG-3.json", "archive/FIN-1.json"), fake.loader())
+
+	tests := []struct {
+		name     string
+		query    Query
+		expected []string
+	}{
+		{"customer", Query{Customer: "HTS"}, []string{"FIN-1", "CHG-1"}},
+		{"status list", Query{Status: "approved,completed", Kind: KindChange}, []string{"CHG-2", "CHG-1"}},
+		{"status list with spaces", Query{Status: "approved, completed, ", Kind: KindChange}, []string{"CHG-2", "CHG-1"}},
+		{"date range", Query{From: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC)}, []string{"CHG-1"}},
+		{"ticket", Query{Ticket: "ops-77"}, []string{"CHG-2"}},
+		{"creator", Query{Creator: "alice"}, []string{"CHG-1"}},
+		{"free text", Query{Text: "postgresql patch"}, []string{"CHG-1"}},
+		{"announcement text", Query{Text: "savings"}, []string{"FIN-1"}},
+		{"malformed", Query{MalformedOnly: true}, []string{"CHG-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range idx.Search(tt.query) {
+				got = append(got, e.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	entries := []*Entry{{ID: "CHG-1", Kind: KindChange, Status: "approved", Title: "Upgrade, \"quoted\"", Customers: []string{"hts", "cds"}}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, entries, FormatCSV); err != nil {
+		t.Fatalf("CSV write failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"Upgrade, ""quoted"""`) || !strings.Contains(buf.String(), "hts;cds") {
+		t.Errorf("Unexpected CSV output: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := Write(&buf, nil, FormatJSON); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected empty JSON array, got %q (err %v)", buf.String(), err)
+	}
+
+	if err := Write(&buf, entries, "xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
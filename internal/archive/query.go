@@ -0,0 +1,216 @@
+package archive
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Query filters index entries. Empty fields match everything.
+type Query struct {
+	Kind          string    // "change" or "announcement"
+	Customer      string    // Customer code
+	Status        string    // Exact status, comma-separated for several
+	From          time.Time // Event window overlaps [From, To]
+	To            time.Time
+	Ticket        string // Matches SNOW or Jira ticket (case-insensitive substring)
+	Creator       string // Matches created_by or submitted_by (case-insensitive substring)
+	Text          string // Free text over ID, title and body (case-insensitive, all words must match)
+	MalformedOnly bool
+}
+
+// Matches reports whether an entry satisfies the query
+func (q Query) Matches(e *Entry) bool {
+	if q.MalformedOnly && !e.Malformed() {
+		return false
+	}
+
+	if q.Kind != "" && !strings.EqualFold(q.Kind, e.Kind) {
+		return false
+	}
+
+	if q.Customer != "" && !containsFold(e.Customers, q.Customer) {
+		return false
+	}
+
+	if statuses := q.statuses(); len(statuses) > 0 && !containsFold(statuses, e.Status) {
+		return false
+	}
+
+	if !q.From.IsZero() || !q.To.IsZero() {
+		if e.StartTime.IsZero() {
+			return false
+		}
+		end := e.EndTime
+		if end.IsZero() {
+			end = e.StartTime
+		}
+		if !q.From.IsZero() && end.Before(q.From) {
+			return false
+		}
+		if !q.To.IsZero() && e.StartTime.After(q.To) {
+			return false
+		}
+	}
+
+	if q.Ticket != "" && !containsAnyFold(q.Ticket, e.SnowTicket, e.JiraTicket) {
+		return false
+	}
+
+	if q.Creator != "" && !containsAnyFold(q.Creator, e.CreatedBy, e.SubmittedBy) {
+		return false
+	}
+
+	if q.Text != "" {
+		haystack := strings.ToLower(strings.Join([]string{e.ID, e.Title, e.Text}, "\n"))
+		for _, word := range strings.Fields(strings.ToLower(q.Text)) {
+			if !strings.Contains(haystack, word) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// statuses returns the trimmed, non-empty statuses of a comma-separated Status
+func (q Query) statuses() []string {
+	var statuses []string
+	for _, status := range strings.Split(q.Status, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// Search returns the entries matching the query, most recent event first
+func (idx *Index) Search(q Query) []*Entry {
+	var results []*Entry
+	for _, entry := range idx.entries {
+		if q.Matches(entry) {
+			results = append(results, entry)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := sortTime(results[i]), sortTime(results[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return results[i].Key < results[j].Key
+	})
+
+	return results
+}
+
+// sortTime returns the time used to order search results
+func sortTime(e *Entry) time.Time {
+	if !e.StartTime.IsZero() {
+		return e.StartTime
+	}
+	if !e.CreatedAt.IsZero() {
+		return e.CreatedAt
+	}
+	return e.LastModified
+}
+
+// containsFold reports whether values contains target, ignoring case and surrounding whitespace
+func containsFold(values []string, target string) bool {
+	target = strings.TrimSpace(target)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), target) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnyFold reports whether any value contains needle, ignoring case
+func containsAnyFold(needle string, values ...string) bool {
+	needle = strings.ToLower(strings.TrimSpace(needle))
+	for _, v := range values {
+		if v != "" && strings.Contains(strings.ToLower(v), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// csvHeader is the column order for CSV output
+var csvHeader = []string{"id", "kind", "status", "title", "customers", "start", "end", "created_by", "snow_ticket", "jira_ticket", "key", "problem"}
+
+// Write renders entries in the requested format
+func Write(w io.Writer, entries []*Entry, format string) error {
+	switch format {
+	case FormatJSON:
+		if entries == nil {
+			entries = []*Entry{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := writer.Write([]string{
+				e.ID, e.Kind, e.Status, e.Title, strings.Join(e.Customers, ";"),
+				formatTime(e.StartTime), formatTime(e.EndTime), e.CreatedBy,
+				e.SnowTicket, e.JiraTicket, e.Key, e.Problem,
+			}); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case FormatTable, "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tKIND\tSTATUS\tSTART\tCUSTOMERS\tCREATED BY\tTITLE")
+		for _, e := range entries {
+			title := truncate(e.Title, 50)
+			if e.Malformed() {
+				title = "⚠️  " + truncate(e.Problem, 50)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				e.ID, e.Kind, e.Status, formatTime(e.StartTime), strings.Join(e.Customers, ","), e.CreatedBy, title)
+		}
+		return tw.Flush()
+
+	default:
+		return fmt.Errorf("unsupported output format: %s (use table, json or csv)", format)
+	}
+}
+
+// formatTime formats a timestamp for tabular output
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04")
+}
+
+// truncate shortens s to at most n runes
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
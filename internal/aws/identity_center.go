@@ -133,6 +133,20 @@ func convertToIdentityCenterUser(user identitystoreTypes.User) types.IdentityCen
 		email = *user.Emails[0].Value
 	}
 
+	// Prefer the primary phone number, falling back to the first one listed
+	var phoneNumber string
+	for _, phone := range user.PhoneNumbers {
+		if phone.Value == nil {
+			continue
+		}
+		if phone.Primary || phoneNumber == "" {
+			phoneNumber = *phone.Value
+		}
+		if phone.Primary {
+			break
+		}
+	}
+
 	var firstName, lastName string
 	if user.Name != nil {
 		if user.Name.GivenName != nil {
@@ -150,6 +164,7 @@ func convertToIdentityCenterUser(user identitystoreTypes.User) types.IdentityCen
 		Email:       email,
 		GivenName:   firstName,
 		FamilyName:  lastName,
+		PhoneNumber: phoneNumber,
 		Active:      true, // Assume active when listing
 	}
 }
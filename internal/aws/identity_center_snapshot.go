@@ -0,0 +1,509 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/identitystore"
+	identitystoreTypes "github.com/aws/aws-sdk-go-v2/service/identitystore/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"ccoe-customer-contact-manager/internal/config"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// IdentityCenterSnapshot is the Identity Center state seen by the last successful import for a
+// customer, with content hashes so later runs can tell which users changed
+type IdentityCenterSnapshot struct {
+	CustomerCode string                  `json:"customer_code"`
+	InstanceID   string                  `json:"instance_id"`
+	TakenAt      time.Time               `json:"taken_at"`
+	Users        map[string]SnapshotUser `json:"users"` // Keyed by user ID
+}
+
+// SnapshotUser is one user in a snapshot
+type SnapshotUser struct {
+	User       types.IdentityCenterUser `json:"user"`
+	Groups     []string                 `json:"groups"`      // Sorted group names
+	UserHash   string                   `json:"user_hash"`   // Hash of the user's attributes
+	GroupsHash string                   `json:"groups_hash"` // Hash of the user's groups
+}
+
+// UserChangeType classifies a change to a user between two snapshots
+type UserChangeType string
+
+const (
+	UserJoined        UserChangeType = "joined"
+	UserLeft          UserChangeType = "left"
+	UserGroupsChanged UserChangeType = "groups_changed"
+	UserDeactivated   UserChangeType = "deactivated"
+	UserUpdated       UserChangeType = "updated" // Other attributes, such as the phone number
+)
+
+// UserChange is a single user-level change. For left users, User is the previous state.
+type UserChange struct {
+	Type           UserChangeType            `json:"type"`
+	User           types.IdentityCenterUser  `json:"user"`
+	Groups         []string                  `json:"groups,omitempty"`
+	PreviousUser   *types.IdentityCenterUser `json:"previous_user,omitempty"`
+	PreviousGroups []string                  `json:"previous_groups,omitempty"`
+}
+
+// IdentityCenterDelta is the difference between two snapshots of the same instance
+type IdentityCenterDelta struct {
+	InstanceID string       `json:"instance_id"`
+	Since      time.Time    `json:"since"`
+	Changes    []UserChange `json:"changes"`
+	Unchanged  int          `json:"unchanged"`
+}
+
+// IdentityCenterSync is the result of retrieving Identity Center data for a customer. A full sync
+// carries the complete data for a full SES reconcile; an incremental sync also carries the delta.
+type IdentityCenterSync struct {
+	Data     *IdentityCenterData
+	Snapshot *IdentityCenterSnapshot // Save after the SES import succeeds
+	Delta    *IdentityCenterDelta    // Nil for a full sync
+	Full     bool
+	Reason   string // Why a full sync was done
+}
+
+// SnapshotStore persists Identity Center snapshots
+type SnapshotStore interface {
+	Load(ctx context.Context, customerCode, instanceID string) (*IdentityCenterSnapshot, error) // Nil if there is none
+	Save(ctx context.Context, snapshot *IdentityCenterSnapshot) error
+}
+
+// NewIdentityCenterSnapshot builds a snapshot from retrieved data
+func NewIdentityCenterSnapshot(customerCode string, data *IdentityCenterData, takenAt time.Time) *IdentityCenterSnapshot {
+	groupsByUser := make(map[string][]string, len(data.Memberships))
+	for _, membership := range data.Memberships {
+		groupsByUser[membership.UserId] = membership.Groups
+	}
+
+	snapshot := &IdentityCenterSnapshot{
+		CustomerCode: customerCode,
+		InstanceID:   data.InstanceID,
+		TakenAt:      takenAt.UTC(),
+		Users:        make(map[string]SnapshotUser, len(data.Users)),
+	}
+	for _, user := range data.Users {
+		groups := append([]string(nil), groupsByUser[user.UserId]...)
+		sort.Strings(groups)
+		snapshot.Users[user.UserId] = SnapshotUser{
+			User:       user,
+			Groups:     groups,
+			UserHash:   hashUser(user),
+			GroupsHash: hashStrings(groups),
+		}
+	}
+	return snapshot
+}
+
+// DiffIdentityCenterSnapshots computes the user-level delta from previous to current. A changed
+// email address is reported as the old address leaving and the new one joining, because SES
+// contacts are keyed by email.
+func DiffIdentityCenterSnapshots(previous, current *IdentityCenterSnapshot) *IdentityCenterDelta {
+	delta := &IdentityCenterDelta{InstanceID: current.InstanceID, Since: previous.TakenAt}
+
+	for _, userID := range sortedUserIDs(current.Users) {
+		now := current.Users[userID]
+		before, existed := previous.Users[userID]
+
+		switch {
+		case !existed:
+			delta.Changes = append(delta.Changes, UserChange{Type: UserJoined, User: now.User, Groups: now.Groups})
+		case before.User.Active && !now.User.Active:
+			delta.Changes = append(delta.Changes, UserChange{Type: UserDeactivated, User: now.User, Groups: now.Groups, PreviousGroups: before.Groups})
+		case !before.User.Active && now.User.Active:
+			delta.Changes = append(delta.Changes, UserChange{Type: UserJoined, User: now.User, Groups: now.Groups})
+		case !strings.EqualFold(before.User.Email, now.User.Email):
+			delta.Changes = append(delta.Changes,
+				UserChange{Type: UserLeft, User: before.User, Groups: before.Groups},
+				UserChange{Type: UserJoined, User: now.User, Groups: now.Groups})
+		default:
+			changed := false
+			if before.GroupsHash != now.GroupsHash {
+				delta.Changes = append(delta.Changes, UserChange{Type: UserGroupsChanged, User: now.User, Groups: now.Groups, PreviousGroups: before.Groups})
+				changed = true
+			}
+			if before.UserHash != now.UserHash {
+				previousUser := before.User
+				delta.Changes = append(delta.Changes, UserChange{Type: UserUpdated, User: now.User, Groups: now.Groups, PreviousUser: &previousUser})
+				changed = true
+			}
+			if !changed {
+				delta.Unchanged++
+			}
+		}
+	}
+
+	for _, userID := range sortedUserIDs(previous.Users) {
+		if _, exists := current.Users[userID]; !exists {
+			before := previous.Users[userID]
+			delta.Changes = append(delta.Changes, UserChange{Type: UserLeft, User: before.User, Groups: before.Groups})
+		}
+	}
+
+	return delta
+}
+
+// Count returns the number of changes of a type
+func (d *IdentityCenterDelta) Count(changeType UserChangeType) int {
+	count := 0
+	for _, change := range d.Changes {
+		if change.Type == changeType {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty reports whether nothing changed
+func (d *IdentityCenterDelta) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Summary describes the delta in one line, e.g. "2 joined, 1 left, 0 groups changed, 0 deactivated, 3 updated"
+func (d *IdentityCenterDelta) Summary() string {
+	return fmt.Sprintf("%d joined, %d left, %d groups changed, %d deactivated, %d updated, %d unchanged",
+		d.Count(UserJoined), d.Count(UserLeft), d.Count(UserGroupsChanged), d.Count(UserDeactivated), d.Count(UserUpdated), d.Unchanged)
+}
+
+// SyncIdentityCenterWithLogger retrieves Identity Center data for a customer. Without a previous
+// snapshot, or with fullResync, every user's memberships are listed as before and the result is a
+// full sync. Otherwise memberships are listed per group, which takes far fewer requests, and the
+// delta against the previous snapshot is returned. The Identity Store API has no change feed or
+// modification timestamps, so users and groups are always listed; only the per-user membership
+// lookups are avoided.
+func SyncIdentityCenterWithLogger(
+	ctx context.Context,
+	roleArn string,
+	customerCode string,
+	store SnapshotStore,
+	fullResync bool,
+	maxConcurrency int,
+	requestsPerSecond int,
+	logger Logger,
+) (*IdentityCenterSync, error) {
+	logger.Printf("🔐 Assuming Identity Center role: %s", roleArn)
+
+	cfg, err := assumeRoleAndGetConfig(roleArn, "identity-center-data-retrieval")
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume Identity Center role: %w", err)
+	}
+
+	logger.Printf("✅ Successfully assumed role")
+
+	instanceID, err := DiscoverIdentityCenterInstanceIDWithLogger(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover Identity Center instance: %w", err)
+	}
+
+	identityStoreClient := identitystore.NewFromConfig(cfg)
+
+	var previous *IdentityCenterSnapshot
+	reason := "--full-resync"
+	if !fullResync {
+		previous, err = store.Load(ctx, customerCode, instanceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Identity Center snapshot: %w", err)
+		}
+		reason = "no previous snapshot"
+	}
+
+	// Full sync: the established per-user retrieval
+	if previous == nil {
+		logger.Printf("🔄 Full Identity Center sync (%s)", reason)
+
+		users, err := ListIdentityCenterUsersAllWithLogger(identityStoreClient, instanceID, maxConcurrency, requestsPerSecond, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve users: %w", err)
+		}
+
+		memberships, err := ListIdentityCenterGroupMembershipsAllWithLogger(identityStoreClient, instanceID, users, maxConcurrency, requestsPerSecond, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve group memberships: %w", err)
+		}
+
+		data := &IdentityCenterData{Users: users, Memberships: memberships, InstanceID: instanceID}
+		logger.Printf("✅ Identity Center data retrieval complete: %d users, %d memberships", len(users), len(memberships))
+
+		return &IdentityCenterSync{
+			Data:     data,
+			Snapshot: NewIdentityCenterSnapshot(customerCode, data, time.Now()),
+			Full:     true,
+			Reason:   reason,
+		}, nil
+	}
+
+	logger.Printf("⚡ Incremental Identity Center sync since %s", previous.TakenAt.Format(time.RFC3339))
+
+	users, err := ListIdentityCenterUsersAllWithLogger(identityStoreClient, instanceID, maxConcurrency, requestsPerSecond, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve users: %w", err)
+	}
+
+	memberships, err := listGroupMembershipsByGroup(identityStoreClient, instanceID, users, maxConcurrency, requestsPerSecond, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve group memberships: %w", err)
+	}
+
+	data := &IdentityCenterData{Users: users, Memberships: memberships, InstanceID: instanceID}
+	snapshot := NewIdentityCenterSnapshot(customerCode, data, time.Now())
+	delta := DiffIdentityCenterSnapshots(previous, snapshot)
+
+	logger.Printf("✅ Identity Center delta: %s", delta.Summary())
+
+	return &IdentityCenterSync{
+		Data:     data,
+		Snapshot: snapshot,
+		Delta:    delta,
+	}, nil
+}
+
+// listGroupMembershipsByGroup builds user-centric memberships by listing the members of each group,
+// one paginated request per group rather than one per user. Users without groups get an empty entry.
+func listGroupMembershipsByGroup(identityStoreClient *identitystore.Client, identityStoreId string, users []types.IdentityCenterUser, maxConcurrency int, requestsPerSecond int, logger Logger) ([]types.IdentityCenterGroupMembership, error) {
+	rateLimiter := NewRateLimiter(requestsPerSecond)
+	defer rateLimiter.Stop()
+
+	groupMap, err := listAllGroups(identityStoreClient, identityStoreId, rateLimiter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	logger.Printf("🔍 Retrieving members of %d groups", len(groupMap))
+
+	groupsByUser := make(map[string][]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+
+	groupChan := make(chan string, len(groupMap))
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for groupID := range groupChan {
+				members, err := listGroupMemberIDs(identityStoreClient, identityStoreId, groupID, rateLimiter)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to list members of group %s: %w", groupMap[groupID], err)
+					}
+				} else {
+					for _, userID := range members {
+						groupsByUser[userID] = append(groupsByUser[userID], groupMap[groupID])
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for groupID := range groupMap {
+		groupChan <- groupID
+	}
+	close(groupChan)
+	wg.Wait()
+
+	// A partial membership list would look like users leaving groups, so any failure is fatal
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	memberships := make([]types.IdentityCenterGroupMembership, 0, len(users))
+	for _, user := range users {
+		memberships = append(memberships, types.IdentityCenterGroupMembership{
+			UserId:      user.UserId,
+			UserName:    user.UserName,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Groups:      groupsByUser[user.UserId],
+		})
+	}
+
+	logger.Printf("✅ Successfully retrieved group memberships for %d users", len(memberships))
+	return memberships, nil
+}
+
+// listGroupMemberIDs lists the user IDs that are members of a group
+func listGroupMemberIDs(client *identitystore.Client, identityStoreId string, groupID string, rateLimiter *types.RateLimiter) ([]string, error) {
+	var userIDs []string
+	var nextToken *string
+
+	for {
+		rateLimiter.Wait()
+
+		result, err := client.ListGroupMemberships(context.Background(), &identitystore.ListGroupMembershipsInput{
+			IdentityStoreId: aws.String(identityStoreId),
+			GroupId:         aws.String(groupID),
+			MaxResults:      aws.Int32(100),
+			NextToken:       nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, membership := range result.GroupMemberships {
+			if member, ok := membership.MemberId.(*identitystoreTypes.MemberIdMemberUserId); ok {
+				userIDs = append(userIDs, member.Value)
+			}
+		}
+
+		nextToken = result.NextToken
+		if nextToken == nil {
+			return userIDs, nil
+		}
+	}
+}
+
+// NewSnapshotStore returns the snapshot store for a location: an s3://bucket/prefix URI, or a local
+// directory (defaulting to the config path)
+func NewSnapshotStore(ctx context.Context, location string) (SnapshotStore, error) {
+	if !strings.HasPrefix(location, "s3://") {
+		if location == "" {
+			location = config.GetConfigPath()
+		}
+		return &FileSnapshotStore{Dir: location}, nil
+	}
+
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, "s3://"), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid snapshot location %q (expected s3://bucket/prefix)", location)
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &S3SnapshotStore{Client: s3.NewFromConfig(awsCfg), Bucket: bucket, Prefix: strings.Trim(prefix, "/")}, nil
+}
+
+// SnapshotFileName returns the snapshot name for a customer and instance. Snapshots are kept per
+// customer because each customer's SES contact list is brought up to date separately.
+func SnapshotFileName(customerCode, instanceID string) string {
+	return fmt.Sprintf("identity-center-snapshot-%s-%s.json", customerCode, instanceID)
+}
+
+// FileSnapshotStore keeps snapshots as JSON files in a local directory
+type FileSnapshotStore struct {
+	Dir string
+}
+
+// Load reads the snapshot file, returning nil if it does not exist
+func (f *FileSnapshotStore) Load(ctx context.Context, customerCode, instanceID string) (*IdentityCenterSnapshot, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, SnapshotFileName(customerCode, instanceID)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseSnapshot(data)
+}
+
+// Save writes the snapshot file
+func (f *FileSnapshotStore) Save(ctx context.Context, snapshot *IdentityCenterSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	return os.WriteFile(filepath.Join(f.Dir, SnapshotFileName(snapshot.CustomerCode, snapshot.InstanceID)), data, 0644)
+}
+
+// S3SnapshotStore keeps snapshots as JSON objects under a bucket prefix
+type S3SnapshotStore struct {
+	Client *s3.Client
+	Bucket string
+	Prefix string
+}
+
+func (s *S3SnapshotStore) key(customerCode, instanceID string) string {
+	if s.Prefix == "" {
+		return SnapshotFileName(customerCode, instanceID)
+	}
+	return s.Prefix + "/" + SnapshotFileName(customerCode, instanceID)
+}
+
+// Load downloads the snapshot, returning nil if it does not exist
+func (s *S3SnapshotStore) Load(ctx context.Context, customerCode, instanceID string) (*IdentityCenterSnapshot, error) {
+	output, err := s.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.key(customerCode, instanceID)),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseSnapshot(data)
+}
+
+// Save uploads the snapshot
+func (s *S3SnapshotStore) Save(ctx context.Context, snapshot *IdentityCenterSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	_, err = s.Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.Bucket),
+		Key:         aws.String(s.key(snapshot.CustomerCode, snapshot.InstanceID)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
+
+func parseSnapshot(data []byte) (*IdentityCenterSnapshot, error) {
+	var snapshot IdentityCenterSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// hashUser hashes the attributes of a user that matter to the SES import
+func hashUser(user types.IdentityCenterUser) string {
+	data, _ := json.Marshal(user)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashStrings hashes a sorted list of strings
+func hashStrings(values []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func sortedUserIDs(users map[string]SnapshotUser) []string {
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
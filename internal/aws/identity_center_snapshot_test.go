@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func snapshotOf(users []types.IdentityCenterUser, groups map[string][]string) *IdentityCenterSnapshot {
+	data := &IdentityCenterData{Users: users, InstanceID: "d-1234567890"}
+	for _, user := range users {
+		data.Memberships = append(data.Memberships, types.IdentityCenterGroupMembership{
+			UserId:   user.UserId,
+			UserName: user.UserName,
+			Email:    user.Email,
+			Groups:   groups[user.UserId],
+		})
+	}
+	return NewIdentityCenterSnapshot("hts", data, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestDiffIdentityCenterSnapshots(t *testing.T) {
+	alice := types.IdentityCenterUser{UserId: "u1", UserName: "alice", Email: "alice@example.com", Active: true}
+	bob := types.IdentityCenterUser{UserId: "u2", UserName: "bob", Email: "bob@example.com", Active: true}
+	carol := types.IdentityCenterUser{UserId: "u3", UserName: "carol", Email: "carol@example.com", Active: true}
+	dave := types.IdentityCenterUser{UserId: "u4", UserName: "dave", Email: "dave@example.com", Active: true}
+	erin := types.IdentityCenterUser{UserId: "u5", UserName: "erin", Email: "erin@example.com", Active: true}
+
+	previous := snapshotOf(
+		[]types.IdentityCenterUser{alice, bob, carol, dave},
+		map[string][]string{"u1": {"admins"}, "u2": {"devs"}, "u3": {"devs"}, "u4": {"ops"}},
+	)
+
+	alice.PhoneNumber = "+15555550100"
+	carol.Active = false
+	current := snapshotOf(
+		[]types.IdentityCenterUser{alice, carol, dave, erin},
+		map[string][]string{"u1": {"admins"}, "u3": {"devs"}, "u4": {"security", "ops"}, "u5": {"devs"}},
+	)
+
+	delta := DiffIdentityCenterSnapshots(previous, current)
+
+	want := map[UserChangeType]string{
+		UserUpdated:       "alice@example.com",
+		UserDeactivated:   "carol@example.com",
+		UserGroupsChanged: "dave@example.com",
+		UserJoined:        "erin@example.com",
+		UserLeft:          "bob@example.com",
+	}
+	if len(delta.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(delta.Changes), len(want), delta.Changes)
+	}
+	for _, change := range delta.Changes {
+		if want[change.Type] != change.User.Email {
+			t.Errorf("%s: got %s, want %s", change.Type, change.User.Email, want[change.Type])
+		}
+		if change.Type == UserGroupsChanged && len(change.PreviousGroups) != 1 {
+			t.Errorf("previous groups = %v, want [ops]", change.PreviousGroups)
+		}
+	}
+	if delta.Unchanged != 0 {
+		t.Errorf("unchanged = %d, want 0", delta.Unchanged)
+	}
+}
+
+func TestDiffIdentityCenterSnapshotsUnchanged(t *testing.T) {
+	users := []types.IdentityCenterUser{{UserId: "u1", UserName: "alice", Email: "alice@example.com", Active: true}}
+	// Group order must not matter
+	previous := snapshotOf(users, map[string][]string{"u1": {"b", "a"}})
+	current := snapshotOf(users, map[string][]string{"u1": {"a", "b"}})
+
+	delta := DiffIdentityCenterSnapshots(previous, current)
+	if !delta.IsEmpty() || delta.Unchanged != 1 {
+		t.Errorf("expected no changes and 1 unchanged, got %s", delta.Summary())
+	}
+}
+
+func TestDiffIdentityCenterSnapshotsEmailChange(t *testing.T) {
+	before := types.IdentityCenterUser{UserId: "u1", UserName: "alice", Email: "alice@old.example.com", Active: true}
+	after := before
+	after.Email = "alice@example.com"
+
+	delta := DiffIdentityCenterSnapshots(
+		snapshotOf([]types.IdentityCenterUser{before}, nil),
+		snapshotOf([]types.IdentityCenterUser{after}, nil),
+	)
+
+	if delta.Count(UserLeft) != 1 || delta.Count(UserJoined) != 1 || len(delta.Changes) != 2 {
+		t.Fatalf("expected old address to leave and new one to join, got %s", delta.Summary())
+	}
+	if delta.Changes[0].User.Email != before.Email || delta.Changes[1].User.Email != after.Email {
+		t.Errorf("unexpected changes: %+v", delta.Changes)
+	}
+}
+
+func TestFileSnapshotStore(t *testing.T) {
+	store := &FileSnapshotStore{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	loaded, err := store.Load(ctx, "hts", "d-1234567890")
+	if err != nil || loaded != nil {
+		t.Fatalf("expected no snapshot, got %v, %v", loaded, err)
+	}
+
+	snapshot := snapshotOf([]types.IdentityCenterUser{{UserId: "u1", Email: "alice@example.com", Active: true}}, map[string][]string{"u1": {"devs"}})
+	if err := store.Save(ctx, snapshot); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err = store.Load(ctx, "hts", "d-1234567890")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !DiffIdentityCenterSnapshots(snapshot, loaded).IsEmpty() {
+		t.Errorf("loaded snapshot differs from saved one")
+	}
+
+	// Snapshots are kept per customer
+	if other, _ := store.Load(ctx, "other", "d-1234567890"); other != nil {
+		t.Errorf("expected no snapshot for another customer")
+	}
+}
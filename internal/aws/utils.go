@@ -124,14 +124,18 @@ func ValidateRoleAssumption(roleArn string, sessionName string) error {
 
 // GetAllAccountsInOrganization lists all accounts in the organization
 func GetAllAccountsInOrganization(OrganizationsServiceConnection *organizations.Client) ([]organizationsTypes.Account, error) {
-	input := &organizations.ListAccountsInput{}
-	result, err := OrganizationsServiceConnection.ListAccounts(context.Background(), input)
-	if err != nil {
-		fmt.Println("Error", err)
+	var OrgAccounts []organizationsTypes.Account
+
+	// ListAccounts returns at most 20 accounts per page
+	paginator := organizations.NewListAccountsPaginator(OrganizationsServiceConnection, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts in organization: %w", err)
+		}
+		OrgAccounts = append(OrgAccounts, page.Accounts...)
 	}
 
-	OrgAccounts := result.Accounts
-
 	return OrgAccounts, nil
 }
 
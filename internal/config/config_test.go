@@ -325,3 +325,94 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateContactSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source *types.ContactSourceConfig
+		fields []string
+	}{
+		{"unset", nil, nil},
+		{"identity center", &types.ContactSourceConfig{Type: "identity_center"}, nil},
+		{"scim", &types.ContactSourceConfig{Type: "scim", URL: "https://example.okta.com/scim/v2", TokenEnv: "OKTA_TOKEN"}, nil},
+		{"scim without url or token", &types.ContactSourceConfig{Type: "scim"}, []string{"cs.url", "cs.token_parameter"}},
+		{"file", &types.ContactSourceConfig{Type: "file", Path: "s3://bucket/entra.csv"}, nil},
+		{"file without path", &types.ContactSourceConfig{Type: "file", Format: "xml"}, []string{"cs.path", "cs.format"}},
+		{"unknown type", &types.ContactSourceConfig{Type: "ldap"}, []string{"cs.type"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := &ValidationErrors{}
+			validateContactSource(errs, "cs", tt.source)
+
+			if len(errs.Errors) != len(tt.fields) {
+				t.Fatalf("got %d errors, want %d: %v", len(errs.Errors), len(tt.fields), errs)
+			}
+			for i, field := range tt.fields {
+				if errs.Errors[i].Field != field {
+					t.Errorf("error %d field = %s, want %s", i, errs.Errors[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateGroupPatterns(t *testing.T) {
+	patterns := []types.GroupPattern{
+		{Name: "okta", Regex: `^aws-(?P<account_name>[a-z]+-(?P<environment>prod|dev))-(?P<role>[a-z]+)$`},
+		{Name: "okta", Regex: `^aws-(?P<role>[a-z]+)$`},
+		{Name: "no-role", Regex: `^aws-(?P<account_name>.+)$`},
+		{Name: "bad", Regex: `^aws-(`},
+		{Name: "unknown", Regex: `^(?P<team>.+)-(?P<role>.+)$`},
+		{Regex: ""},
+	}
+
+	errs := &ValidationErrors{}
+	validateGroupPatterns(errs, "gp", patterns)
+
+	want := []string{"gp[1].name", "gp[2].regex", "gp[3].regex", "gp[4].regex", "gp[5].name", "gp[5].regex"}
+	if len(errs.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs.Errors), len(want), errs)
+	}
+	for i, field := range want {
+		if errs.Errors[i].Field != field {
+			t.Errorf("error %d field = %s, want %s", i, errs.Errors[i].Field, field)
+		}
+	}
+}
+
+func TestValidateAlternateContactConfig(t *testing.T) {
+	billing := &types.AlternateContactDetails{Name: "Billing", Title: "Finance", Email: "billing@example.com", Phone: "+15550100001"}
+	contactConfig := types.AlternateContactConfig{
+		SecurityEmail: "security@example.com",
+		Overrides: []types.AlternateContactOverride{
+			{Name: "prod-billing", OrganizationalUnit: "ou-ab12-cd34ef56", Billing: billing},
+			{Name: "prod-billing", AccountID: "123456789012", Billing: billing},
+			{Name: "everyone", Billing: billing},
+			{Name: "bad-ids", OrganizationalUnit: "Production", AccountID: "1234", Billing: billing},
+			{Name: "no-contacts", Organization: "hts-prod"},
+			{Name: "no-email", Tags: map[string]string{"team": "payments"}, Security: &types.AlternateContactDetails{Name: "Payments"}},
+		},
+	}
+
+	err := ValidateAlternateContactConfig(contactConfig)
+	errs, ok := err.(*ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+
+	want := []string{"overrides[1].name", "overrides[2]", "overrides[3].organizational_unit", "overrides[3].account_id", "overrides[4]", "overrides[5].security.email"}
+	if len(errs.Errors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs.Errors), len(want), errs)
+	}
+	for i, field := range want {
+		if errs.Errors[i].Field != field {
+			t.Errorf("error %d field = %s, want %s", i, errs.Errors[i].Field, field)
+		}
+	}
+
+	if err := ValidateAlternateContactConfig(types.AlternateContactConfig{SecurityEmail: "security@example.com"}); err != nil {
+		t.Errorf("Expected a config without overrides to be valid, got %v", err)
+	}
+}
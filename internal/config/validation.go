@@ -3,6 +3,9 @@ package config
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 
 	"ccoe-customer-contact-manager/internal/types"
@@ -87,6 +90,16 @@ func ValidateSESConfig(config *types.Config, requireRoute53 bool) error {
 				errors.Add(prefix+".identity_center_role_arn", err.Error())
 			}
 		}
+
+		validateContactSource(errors, prefix+".contact_source", customer.ContactSource)
+		validateGroupPatterns(errors, prefix+".group_patterns", customer.GroupPatterns)
+		if customer.Offboarding != nil {
+			for j, protected := range customer.Offboarding.ProtectedContacts {
+				if _, err := path.Match(protected, ""); err != nil {
+					errors.Add(fmt.Sprintf("%s.offboarding.protected_contacts[%d]", prefix, j), fmt.Sprintf("invalid pattern: %s", protected))
+				}
+			}
+		}
 	}
 
 	// Validate Route53 config if required
@@ -201,6 +214,138 @@ func ValidateCustomerCode(config *types.Config, customerCode string) error {
 	return nil
 }
 
+// validateContactSource checks the fields each contact source type needs
+func validateContactSource(errors *ValidationErrors, prefix string, source *types.ContactSourceConfig) {
+	if source == nil {
+		return
+	}
+
+	switch source.GetType() {
+	case types.ContactSourceIdentityCenter:
+	case types.ContactSourceSCIM:
+		if source.URL == "" {
+			errors.Add(prefix+".url", "is required for scim sources")
+		} else if !isValidURL(source.URL) {
+			errors.Add(prefix+".url", fmt.Sprintf("invalid URL: %s", source.URL))
+		}
+		if source.TokenParameter == "" && source.TokenEnv == "" {
+			errors.Add(prefix+".token_parameter", "token_parameter or token_env is required for scim sources")
+		}
+	case types.ContactSourceFile:
+		if source.Path == "" {
+			errors.Add(prefix+".path", "is required for file sources")
+		}
+		if source.Format != "" && source.Format != "json" && source.Format != "csv" {
+			errors.Add(prefix+".format", fmt.Sprintf("must be json or csv, got: %s", source.Format))
+		}
+	default:
+		errors.Add(prefix+".type", fmt.Sprintf("must be identity_center, scim or file, got: %s", source.Type))
+	}
+}
+
+// ValidateGroupPatterns validates a customer's group patterns before they are used to parse groups
+func ValidateGroupPatterns(patterns []types.GroupPattern) error {
+	errors := &ValidationErrors{}
+	validateGroupPatterns(errors, "group_patterns", patterns)
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateGroupPatterns checks that each group pattern is named, compiles, captures a role and
+// uses only known capture group names
+func validateGroupPatterns(errors *ValidationErrors, prefix string, patterns []types.GroupPattern) {
+	names := make(map[string]bool)
+	for i, pattern := range patterns {
+		field := fmt.Sprintf("%s[%d]", prefix, i)
+		if pattern.Name == "" {
+			errors.Add(field+".name", "is required")
+		} else if names[pattern.Name] {
+			errors.Add(field+".name", fmt.Sprintf("duplicate pattern name: %s", pattern.Name))
+		}
+		names[pattern.Name] = true
+
+		if pattern.Regex == "" {
+			errors.Add(field+".regex", "is required")
+			continue
+		}
+		re, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			errors.Add(field+".regex", fmt.Sprintf("invalid regex: %v", err))
+			continue
+		}
+
+		hasRole := false
+		for _, capture := range re.SubexpNames() {
+			if capture == "" {
+				continue
+			}
+			if !slices.Contains(types.GroupPatternCaptures, capture) {
+				errors.Add(field+".regex", fmt.Sprintf("unknown capture group %q (use %s)", capture, strings.Join(types.GroupPatternCaptures, ", ")))
+			}
+			if capture == types.GroupCaptureRole {
+				hasRole = true
+			}
+		}
+		if !hasRole {
+			errors.Add(field+".regex", "must have a (?P<role>...) capture group")
+		}
+	}
+}
+
+// ValidateAlternateContactConfig validates the overrides of an alternate contact configuration
+func ValidateAlternateContactConfig(contactConfig types.AlternateContactConfig) error {
+	errors := &ValidationErrors{}
+	names := make(map[string]bool)
+	for i, override := range contactConfig.Overrides {
+		field := fmt.Sprintf("overrides[%d]", i)
+		if override.Name == "" {
+			errors.Add(field+".name", "is required")
+		} else if names[override.Name] {
+			errors.Add(field+".name", fmt.Sprintf("duplicate override name: %s", override.Name))
+		}
+		names[override.Name] = true
+
+		if override.Organization == "" && override.OrganizationalUnit == "" && override.AccountID == "" && len(override.Tags) == 0 {
+			errors.Add(field, "must select an organization, organizational_unit, account_id or tags (use the top-level fields for global contacts)")
+		}
+		if override.OrganizationalUnit != "" && !ouIdPattern.MatchString(override.OrganizationalUnit) {
+			errors.Add(field+".organizational_unit", fmt.Sprintf("must be an OU ID like ou-ab12-cd34ef56, got: %s", override.OrganizationalUnit))
+		}
+		if override.AccountID != "" && !accountIdPattern.MatchString(override.AccountID) {
+			errors.Add(field+".account_id", fmt.Sprintf("must be a 12-digit account ID, got: %s", override.AccountID))
+		}
+		for key := range override.Tags {
+			if key == "" {
+				errors.Add(field+".tags", "tag keys cannot be empty")
+			}
+		}
+
+		if override.Security == nil && override.Billing == nil && override.Operations == nil {
+			errors.Add(field, "must set a security, billing or operations contact")
+		}
+		if override.Security != nil && override.Security.Email == "" {
+			errors.Add(field+".security.email", "is required")
+		}
+		if override.Billing != nil && override.Billing.Email == "" {
+			errors.Add(field+".billing.email", "is required")
+		}
+		if override.Operations != nil && override.Operations.Email == "" {
+			errors.Add(field+".operations.email", "is required")
+		}
+	}
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+var (
+	ouIdPattern      = regexp.MustCompile(`^ou-[0-9a-z]{4,32}-[0-9a-z]{8,32}$`)
+	accountIdPattern = regexp.MustCompile(`^\d{12}$`)
+)
+
 // ValidateIdentityCenterRoleArn validates the Identity Center role ARN format
 // This is an optional field, so empty values are valid
 func ValidateIdentityCenterRoleArn(roleArn string) error {
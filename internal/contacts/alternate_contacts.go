@@ -2,13 +2,13 @@
 package contacts
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -22,8 +22,13 @@ import (
 	"ccoe-customer-contact-manager/internal/types"
 )
 
+// AlternateContactReader reads alternate contacts; *account.Client satisfies it
+type AlternateContactReader interface {
+	GetAlternateContact(ctx context.Context, params *account.GetAlternateContactInput, optFns ...func(*account.Options)) (*account.GetAlternateContactOutput, error)
+}
+
 // GetAlternateContact retrieves the alternate contact information for an account
-func GetAlternateContact(AccountServiceConnection *account.Client, accountId string, contactType accountTypes.AlternateContactType) (*accountTypes.AlternateContact, error) {
+func GetAlternateContact(AccountServiceConnection AlternateContactReader, accountId string, contactType accountTypes.AlternateContactType) (*accountTypes.AlternateContact, error) {
 	input := &account.GetAlternateContactInput{
 		AccountId:            aws.String(accountId),
 		AlternateContactType: contactType,
@@ -43,7 +48,7 @@ func GetAlternateContact(AccountServiceConnection *account.Client, accountId str
 }
 
 // SetAlternateContact sets or updates the alternate contact information for an account
-func SetAlternateContact(AccountServiceConnection *account.Client, accountId string, contactType accountTypes.AlternateContactType, name, title, email, phone string) error {
+func SetAlternateContact(AccountServiceConnection AlternateContactWriter, accountId string, contactType accountTypes.AlternateContactType, name, title, email, phone string) error {
 	input := &account.PutAlternateContactInput{
 		AccountId:            aws.String(accountId),
 		AlternateContactType: contactType,
@@ -62,7 +67,7 @@ func SetAlternateContact(AccountServiceConnection *account.Client, accountId str
 }
 
 // DeleteAlternateContact removes the alternate contact information for an account
-func DeleteAlternateContact(AccountServiceConnection *account.Client, accountId string, contactType accountTypes.AlternateContactType) error {
+func DeleteAlternateContact(AccountServiceConnection AlternateContactWriter, accountId string, contactType accountTypes.AlternateContactType) error {
 	input := &account.DeleteAlternateContactInput{
 		AccountId:            aws.String(accountId),
 		AlternateContactType: contactType,
@@ -76,406 +81,191 @@ func DeleteAlternateContact(AccountServiceConnection *account.Client, accountId
 	return nil
 }
 
-// CheckIfContactExists checks if an alternate contact exists and returns true/false
-func CheckIfContactExists(AccountServiceConnection *account.Client, accountId string, contactType accountTypes.AlternateContactType) (bool, error) {
-	contact, err := GetAlternateContact(AccountServiceConnection, accountId, contactType)
-	if err != nil {
-		return false, err
-	}
-	return contact != nil, nil
+// SetContactsForSingleOrganization sets the resolved contacts of every account in one organization
+func SetContactsForSingleOrganization(contactConfigFile string, orgPrefix string, options ContactWriteOptions) (*ContactRunSummary, error) {
+	return setContacts(contactConfigFile, orgPrefix, options)
 }
 
-// SetAlternateContactIfNotExists sets an alternate contact only if it doesn't already exist
-func SetAlternateContactIfNotExists(AccountServiceConnection *account.Client, accountId string, contactType accountTypes.AlternateContactType, name, title, email, phone string, overwrite bool) error {
-	exists, err := CheckIfContactExists(AccountServiceConnection, accountId, contactType)
+// SetContactsForAllOrganizations sets the resolved contacts of every account in every organization
+// in OrgConfig.json
+func SetContactsForAllOrganizations(contactConfigFile string, options ContactWriteOptions) (*ContactRunSummary, error) {
+	return setContacts(contactConfigFile, "", options)
+}
+
+// setContacts sets contacts in one organization, or all of them when orgPrefix is empty. Accounts
+// are processed concurrently, with a shared rate limit and retries, and progress is checkpointed.
+func setContacts(contactConfigFile string, orgPrefix string, options ContactWriteOptions) (*ContactRunSummary, error) {
+	started := time.Now()
+	logger := slog.Default()
+
+	contactConfig, err := LoadContactConfig(contactConfigFile)
 	if err != nil {
-		return fmt.Errorf("failed to check if contact exists: %w", err)
+		return nil, err
 	}
-
-	if exists && !overwrite {
-		fmt.Printf("Alternate contact %s already exists for account %s, will not overwrite.\n", contactType, accountId)
-		return nil
+	if err := config.ValidateAlternateContactConfig(contactConfig); err != nil {
+		return nil, fmt.Errorf("invalid contact config %s: %w", contactConfigFile, err)
 	}
-
-	err = SetAlternateContact(AccountServiceConnection, accountId, contactType, name, title, email, phone)
+	orgConfig, err := LoadOrgConfig()
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := selectOrganizations(orgConfig, orgPrefix)
 	if err != nil {
-		return fmt.Errorf("failed to set alternate contact %s for account %s: %w", contactType, accountId, err)
+		return nil, err
 	}
 
-	if exists {
-		fmt.Printf("Successfully updated alternate contact %s for account: %s\n", contactType, accountId)
-	} else {
-		fmt.Printf("Successfully added alternate contact %s for account: %s\n", contactType, accountId)
+	hash, err := checkpointHash(struct {
+		Organization  string
+		ContactConfig types.AlternateContactConfig
+		Overwrite     bool
+	}{orgPrefix, contactConfig, options.Overwrite})
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := OpenContactCheckpoint(options.CheckpointFile, ContactActionSet, hash, options.Resume)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// SetContactsForSingleOrganization sets contacts for a single organization
-func SetContactsForSingleOrganization(contactConfigFile *string, orgPrefix *string, overwrite *bool) {
-	ConfigPath := config.GetConfigPath()
-	fmt.Println("Working in Config Path: " + ConfigPath)
+	run := newContactRun(options, checkpoint, logger)
+	defer run.stop()
 
-	//Read the Contact Config Json File
-	ContactJson, err := os.ReadFile(ConfigPath + *contactConfigFile)
+	tasks, results := run.organizationTasks(orgs, orgConfig, contactConfig)
+	processed, err := run.runAccounts(ContactActionSet, tasks, setAccountContacts)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	fmt.Println("Successfully opened " + ConfigPath + *contactConfigFile)
+	return run.finish(ContactActionSet, append(results, processed...), started), nil
+}
 
-	var ContactConfig types.AlternateContactConfig
-	json.NewDecoder(bytes.NewReader(ContactJson)).Decode(&ContactConfig)
+// selectOrganizations returns the organization with the prefix, or all of them when it is empty
+func selectOrganizations(orgConfig []types.Organization, orgPrefix string) ([]types.Organization, error) {
+	if orgPrefix == "" {
+		return orgConfig, nil
+	}
+	for _, org := range orgConfig {
+		if org.Prefix == orgPrefix {
+			return []types.Organization{org}, nil
+		}
+	}
+	return nil, fmt.Errorf("organization prefix %s not found in OrgConfig.json", orgPrefix)
+}
 
-	//Read the Org Json File
-	OrgJson, err := os.ReadFile(ConfigPath + "OrgConfig.json")
+// LoadContactConfig reads an alternate contact configuration file from the config path
+func LoadContactConfig(contactConfigFile string) (types.AlternateContactConfig, error) {
+	var contactConfig types.AlternateContactConfig
+	data, err := os.ReadFile(config.GetConfigPath() + contactConfigFile)
 	if err != nil {
-		panic(err)
+		return contactConfig, fmt.Errorf("failed to read contact config: %w", err)
 	}
-	fmt.Println("Successfully opened " + ConfigPath + "OrgConfig.json")
-
-	var OrgConfig []types.Organization
-	json.NewDecoder(bytes.NewReader(OrgJson)).Decode(&OrgConfig)
+	if err := json.Unmarshal(data, &contactConfig); err != nil {
+		return contactConfig, fmt.Errorf("failed to parse contact config %s: %w", contactConfigFile, err)
+	}
+	return contactConfig, nil
+}
 
-	ManagementAccountId, err := awsutils.GetManagementAccountIdByPrefix(*orgPrefix, OrgConfig)
+// LoadOrgConfig reads the organizations from OrgConfig.json in the config path
+func LoadOrgConfig() ([]types.Organization, error) {
+	data, err := os.ReadFile(config.GetConfigPath() + "OrgConfig.json")
 	if err != nil {
-		fmt.Printf("failed to get management account ID: %v\n", err)
-		return
+		return nil, fmt.Errorf("failed to read organization config: %w", err)
 	}
-	fmt.Printf("Management Account ID for prefix %s: %s\n", *orgPrefix, ManagementAccountId)
+	var orgConfig []types.Organization
+	if err := json.Unmarshal(data, &orgConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse OrgConfig.json: %w", err)
+	}
+	return orgConfig, nil
+}
 
-	var SecureTokenServiceConnection *sts.Client
-	var CurrentAccountId string
+// ManagementAccountConfig returns an AWS configuration for an organization's management account,
+// assuming the alt-contact-manager role there unless already running in it
+func ManagementAccountConfig(orgPrefix string, orgConfig []types.Organization) (aws.Config, error) {
+	managementAccountId, err := awsutils.GetManagementAccountIdByPrefix(orgPrefix, orgConfig)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to get management account ID: %w", err)
+	}
 
-	// Load the default AWS configuration
 	cfg, err := awsconfig.LoadDefaultConfig(context.TODO())
 	if err != nil {
-		fmt.Printf("failed to load AWS configuration: %v\n", err)
-		return
+		return aws.Config{}, fmt.Errorf("failed to load AWS configuration: %w", err)
 	}
-
-	// Use the default credentials from the role this ECS task is running as
 	creds, err := cfg.Credentials.Retrieve(context.TODO())
 	if err != nil {
-		fmt.Printf("failed to retrieve AWS credentials: %v\n", err)
-		return
+		return aws.Config{}, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
 	}
-
-	fmt.Println("Using credentials from the role this ECS task is running as")
-	fmt.Printf("Access Key ID: %s\n", creds.AccessKeyID)
-
-	InitialCreds := aws.Credentials{
+	cfg, err = awsutils.CreateConnectionConfiguration(aws.Credentials{
 		AccessKeyID:     creds.AccessKeyID,
 		SecretAccessKey: creds.SecretAccessKey,
 		SessionToken:    creds.SessionToken,
 		Source:          "environment",
-	}
-
-	cfg, err = awsutils.CreateConnectionConfiguration(InitialCreds)
+	})
 	if err != nil {
-		fmt.Println("failed to load AWS API configuration : %w", err)
-		return
-	}
-
-	SecureTokenServiceConnection = sts.NewFromConfig(cfg)
-	OrganizationsServiceConnection := organizations.NewFromConfig(cfg)
-	CurrentAccountId = awsutils.GetCurrentAccountId(SecureTokenServiceConnection)
-
-	fmt.Println("Processing Organization: " + *orgPrefix)
-	fmt.Println()
-
-	var finalCfg aws.Config
-
-	if awsutils.IsManagementAccount(OrganizationsServiceConnection, CurrentAccountId) {
-		fmt.Println(CurrentAccountId + " IS Organization Management Account")
-		fmt.Println("Will use initial credentials: " + InitialCreds.AccessKeyID)
-		finalCfg = cfg
-	} else {
-		fmt.Println(CurrentAccountId + " NOT Organization Management Account")
-		fmt.Println("Need a Role inside the Organization Management Account")
-		ManagementRoleArn := "arn:aws:iam::" + ManagementAccountId + ":role/otc/hts-ccoe-mocb-alt-contact-manager"
-		ManagementSessionName := *orgPrefix + "-alt-contact-manager"
-		fmt.Println("Attempting to switch into Role: " + ManagementRoleArn)
-
-		ManagementAssumedCreds, err := awsutils.AssumeRole(SecureTokenServiceConnection, ManagementRoleArn, ManagementSessionName)
-		if err != nil {
-			fmt.Println("failed to assume role: %w", err)
-			return
-		}
-		fmt.Println("Assumed Management role credentials:", *ManagementAssumedCreds.AccessKeyId)
-
-		ManagementAwsCreds := aws.Credentials{
-			AccessKeyID:     *ManagementAssumedCreds.AccessKeyId,
-			SecretAccessKey: *ManagementAssumedCreds.SecretAccessKey,
-			SessionToken:    *ManagementAssumedCreds.SessionToken,
-			Source:          "AssumeRole",
-		}
-
-		ManagementCfg, err := awsutils.CreateConnectionConfiguration(ManagementAwsCreds)
-		if err != nil {
-			fmt.Printf("failed to load AWS API configuration with assumed role in Management account: %v\n", err)
-			return
-		}
-		finalCfg = ManagementCfg
-	}
-
-	OrganizationsServiceConnection = organizations.NewFromConfig(finalCfg)
-
-	accounts, err := awsutils.GetAllAccountsInOrganization(OrganizationsServiceConnection)
-	if err != nil {
-		fmt.Printf("failed to get the accounts in the organization: %v\n", err)
-		return
+		return aws.Config{}, fmt.Errorf("failed to load AWS API configuration: %w", err)
 	}
 
-	fmt.Println("Accounts in the organization:")
-	for _, account := range accounts {
-		fmt.Println(*account.Name + " - " + *account.Id)
-	}
-	fmt.Println()
-
-	// Create Account service connection with the final configuration
-	AccountServiceConnection := account.NewFromConfig(finalCfg)
-
-	// Set alternate contacts for each account in the organization
-	for _, acct := range accounts {
-		accountId := *acct.Id
-		fmt.Println("Processing account: " + accountId)
-
-		// Set Security Contact
-		if ContactConfig.SecurityEmail != "" {
-			err = SetAlternateContactIfNotExists(AccountServiceConnection, accountId, accountTypes.AlternateContactTypeSecurity,
-				ContactConfig.SecurityName, ContactConfig.SecurityTitle, ContactConfig.SecurityEmail, ContactConfig.SecurityPhone, *overwrite)
-			if err != nil {
-				fmt.Printf("failed to set security contact for account %s: %v\n", accountId, err)
-			}
-		}
-
-		// Set Billing Contact
-		if ContactConfig.BillingEmail != "" {
-			err = SetAlternateContactIfNotExists(AccountServiceConnection, accountId, accountTypes.AlternateContactTypeBilling,
-				ContactConfig.BillingName, ContactConfig.BillingTitle, ContactConfig.BillingEmail, ContactConfig.BillingPhone, *overwrite)
-			if err != nil {
-				fmt.Printf("failed to set billing contact for account %s: %v\n", accountId, err)
-			}
-		}
-
-		// Set Operations Contact
-		if ContactConfig.OperationsEmail != "" {
-			err = SetAlternateContactIfNotExists(AccountServiceConnection, accountId, accountTypes.AlternateContactTypeOperations,
-				ContactConfig.OperationsName, ContactConfig.OperationsTitle, ContactConfig.OperationsEmail, ContactConfig.OperationsPhone, *overwrite)
-			if err != nil {
-				fmt.Printf("failed to set operations contact for account %s: %v\n", accountId, err)
-			}
-		}
-
-		fmt.Println()
+	stsClient := sts.NewFromConfig(cfg)
+	currentAccountId := awsutils.GetCurrentAccountId(stsClient)
+	if awsutils.IsManagementAccount(organizations.NewFromConfig(cfg), currentAccountId) {
+		return cfg, nil
 	}
 
-	// Reinitialize the AWS configuration with the initial credentials
-	cfg, err = awsutils.CreateConnectionConfiguration(InitialCreds)
+	roleArn := "arn:aws:iam::" + managementAccountId + ":role/otc/hts-ccoe-mocb-alt-contact-manager"
+	assumedCreds, err := awsutils.AssumeRole(stsClient, roleArn, orgPrefix+"-alt-contact-manager")
 	if err != nil {
-		fmt.Printf("failed to reload AWS API configuration: %v\n", err)
-		return
+		return aws.Config{}, fmt.Errorf("failed to assume role %s: %w", roleArn, err)
+	}
+	cfg, err = awsutils.CreateConnectionConfiguration(aws.Credentials{
+		AccessKeyID:     *assumedCreds.AccessKeyId,
+		SecretAccessKey: *assumedCreds.SecretAccessKey,
+		SessionToken:    *assumedCreds.SessionToken,
+		Source:          "AssumeRole",
+	})
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS API configuration with assumed role in management account: %w", err)
 	}
-
-	SecureTokenServiceConnection = sts.NewFromConfig(cfg)
-	CurrentAccountId = awsutils.GetCurrentAccountId(SecureTokenServiceConnection)
-	fmt.Println("Switched back to initial credentials in " + CurrentAccountId)
+	return cfg, nil
 }
 
-// SetContactsForAllOrganizations sets contacts for all organizations in the config file
-func SetContactsForAllOrganizations(contactConfigFile *string, overwrite *bool) {
-	ConfigPath := config.GetConfigPath()
-	fmt.Println("Working in Config Path: " + ConfigPath)
+// DeleteContactsFromOrganization deletes the given contact types (comma-separated) from every
+// account in an organization
+func DeleteContactsFromOrganization(orgPrefix string, contactTypes string, options ContactWriteOptions) (*ContactRunSummary, error) {
+	started := time.Now()
+	logger := slog.Default()
 
-	//Read the Contact Config Json File
-	ContactJson, err := os.ReadFile(ConfigPath + *contactConfigFile)
+	typesToDelete, err := ParseContactTypes(contactTypes)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	fmt.Println("Successfully opened " + ConfigPath + *contactConfigFile)
-
-	var ContactConfig types.AlternateContactConfig
-	json.NewDecoder(bytes.NewReader(ContactJson)).Decode(&ContactConfig)
-
-	//Read the Org Json File
-	OrgJson, err := os.ReadFile(ConfigPath + "OrgConfig.json")
+	orgConfig, err := LoadOrgConfig()
 	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Successfully opened " + ConfigPath + "OrgConfig.json")
-
-	var OrgConfig []types.Organization
-	json.NewDecoder(bytes.NewReader(OrgJson)).Decode(&OrgConfig)
-
-	fmt.Printf("Found %d organizations in config file\n", len(OrgConfig))
-	fmt.Println()
-
-	// Process each organization
-	for i, org := range OrgConfig {
-		fmt.Printf("Processing organization %d of %d: %s (prefix: %s)\n", i+1, len(OrgConfig), org.FriendlyName, org.Prefix)
-
-		// Call the existing single organization function
-		orgPrefix := org.Prefix
-		SetContactsForSingleOrganization(contactConfigFile, &orgPrefix, overwrite)
-
-		fmt.Printf("Completed processing organization: %s\n", org.FriendlyName)
-		fmt.Println("=" + strings.Repeat("=", 50))
-		fmt.Println()
+		return nil, err
 	}
-
-	fmt.Printf("Successfully processed all %d organizations\n", len(OrgConfig))
-}
-
-// DeleteContactsFromOrganization deletes contacts from an organization
-func DeleteContactsFromOrganization(orgPrefix *string, contactTypes *string) {
-	ConfigPath := config.GetConfigPath()
-	fmt.Println("Working in Config Path: " + ConfigPath)
-
-	//Read the Org Json File
-	OrgJson, err := os.ReadFile(ConfigPath + "OrgConfig.json")
+	orgs, err := selectOrganizations(orgConfig, orgPrefix)
 	if err != nil {
-		panic(err)
-	}
-	fmt.Println("Successfully opened " + ConfigPath + "OrgConfig.json")
-
-	var OrgConfig []types.Organization
-	json.NewDecoder(bytes.NewReader(OrgJson)).Decode(&OrgConfig)
-
-	ManagementAccountId, err := awsutils.GetManagementAccountIdByPrefix(*orgPrefix, OrgConfig)
-	if err != nil {
-		fmt.Printf("failed to get management account ID: %v\n", err)
-		return
+		return nil, err
 	}
-	fmt.Printf("Management Account ID for prefix %s: %s\n", *orgPrefix, ManagementAccountId)
 
-	var SecureTokenServiceConnection *sts.Client
-	var CurrentAccountId string
-
-	// Load the default AWS configuration
-	cfg, err := awsconfig.LoadDefaultConfig(context.TODO())
+	hash, err := checkpointHash(struct {
+		Organization string
+		ContactTypes []accountTypes.AlternateContactType
+	}{orgPrefix, typesToDelete})
 	if err != nil {
-		fmt.Printf("failed to load AWS configuration: %v\n", err)
-		return
+		return nil, err
 	}
-
-	// Use the default credentials from the role this ECS task is running as
-	creds, err := cfg.Credentials.Retrieve(context.TODO())
+	checkpoint, err := OpenContactCheckpoint(options.CheckpointFile, ContactActionDelete, hash, options.Resume)
 	if err != nil {
-		fmt.Printf("failed to retrieve AWS credentials: %v\n", err)
-		return
+		return nil, err
 	}
 
-	fmt.Println("Using credentials from the role this ECS task is running as")
-	fmt.Printf("Access Key ID: %s\n", creds.AccessKeyID)
+	run := newContactRun(options, checkpoint, logger)
+	defer run.stop()
 
-	InitialCreds := aws.Credentials{
-		AccessKeyID:     creds.AccessKeyID,
-		SecretAccessKey: creds.SecretAccessKey,
-		SessionToken:    creds.SessionToken,
-		Source:          "environment",
-	}
-
-	cfg, err = awsutils.CreateConnectionConfiguration(InitialCreds)
+	tasks, results := run.organizationTasks(orgs, orgConfig, types.AlternateContactConfig{})
+	processed, err := run.runAccounts(ContactActionDelete, tasks, deleteAccountContacts(typesToDelete))
 	if err != nil {
-		fmt.Println("failed to load AWS API configuration : %w", err)
-		return
-	}
-
-	OrganizationsServiceConnection := organizations.NewFromConfig(cfg)
-	SecureTokenServiceConnection = sts.NewFromConfig(cfg)
-	CurrentAccountId = awsutils.GetCurrentAccountId(SecureTokenServiceConnection)
-
-	if awsutils.IsManagementAccount(OrganizationsServiceConnection, CurrentAccountId) {
-		fmt.Println(CurrentAccountId + " IS Organization Management Account")
-		fmt.Println("Will use initial credentials: " + InitialCreds.AccessKeyID)
-	} else {
-		fmt.Println(CurrentAccountId + " NOT Organization Management Account")
-		fmt.Println("Need a Role inside the Organization Management Account")
-		roleArn := "arn:aws:iam::" + ManagementAccountId + ":role/otc/hts-ccoe-mocb-alt-contact-manager"
-		sessionName := *orgPrefix + "-alt-contact-manager"
-		fmt.Println("Attempting to switch into Role: " + roleArn)
-
-		AssumedCreds, err := awsutils.AssumeRole(SecureTokenServiceConnection, roleArn, sessionName)
-		if err != nil {
-			fmt.Println("failed to assume role: %w", err)
-			return
-		}
-		fmt.Println("Assumed role credentials:", *AssumedCreds.AccessKeyId)
-
-		// Transform *ststypes.Credentials returned by AssumeRole function into aws.Credentials
-		// required by CreateConnectionConfiguration function
-		awsCreds := aws.Credentials{
-			AccessKeyID:     *AssumedCreds.AccessKeyId,
-			SecretAccessKey: *AssumedCreds.SecretAccessKey,
-			SessionToken:    *AssumedCreds.SessionToken,
-			Source:          "AssumeRole",
-		}
-
-		cfg, err = awsutils.CreateConnectionConfiguration(awsCreds)
-		if err != nil {
-			fmt.Println("failed to assume role: %w", err)
-			return
-		}
-
-		OrganizationsServiceConnection = organizations.NewFromConfig(cfg)
-
-		//Check the Contacts to Delete Exist
-		accounts, err := awsutils.GetAllAccountsInOrganization(OrganizationsServiceConnection)
-		if err != nil {
-			fmt.Printf("failed to get the accounts in the organization: %v\n", err)
-			return
-		}
-
-		fmt.Println("Accounts in the organization:")
-		for _, account := range accounts {
-			fmt.Println(*account.Name + " - " + *account.Id)
-		}
-		fmt.Println()
-
-		// Split the input into a slice of strings
-		contactTypesList := strings.Split(*contactTypes, ",")
-
-		// Create Account service connection
-		AccountServiceConnection := account.NewFromConfig(cfg)
-
-		// Check if the account has the contacts to delete
-		for _, acct := range accounts {
-			accountId := *acct.Id
-
-			for _, contactTypeStr := range contactTypesList {
-				contactTypeStr = strings.TrimSpace(contactTypeStr)
-				var contactType accountTypes.AlternateContactType
-
-				switch strings.ToLower(contactTypeStr) {
-				case "security":
-					contactType = accountTypes.AlternateContactTypeSecurity
-				case "billing":
-					contactType = accountTypes.AlternateContactTypeBilling
-				case "operations":
-					contactType = accountTypes.AlternateContactTypeOperations
-				default:
-					fmt.Printf("Invalid contact type: %s\n", contactTypeStr)
-					continue
-				}
-
-				contactExists, err := CheckIfContactExists(AccountServiceConnection, accountId, contactType)
-				if err != nil {
-					fmt.Printf("failed to check if contact exists for account: %v\n", err)
-					return
-				}
-
-				if contactExists {
-					err = DeleteAlternateContact(AccountServiceConnection, accountId, contactType)
-					if err != nil {
-						fmt.Printf("failed to remove contact from account: %v\n", err)
-						return
-					}
-					fmt.Printf("Removed contact "+contactTypeStr+" from the account: %s\n", accountId)
-				} else {
-					fmt.Printf("Contact "+contactTypeStr+" does not exist for the account: %s\n", accountId)
-				}
-			}
-		}
+		return nil, err
 	}
+	return run.finish(ContactActionDelete, append(results, processed...), started), nil
 }
 
 // CredentialManager interface for dependency injection
@@ -0,0 +1,315 @@
+package contacts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	awsutils "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/config"
+)
+
+// Alternate contact audit outcomes
+const (
+	AuditMatching  = "matching"  // Contact matches the contact config
+	AuditDivergent = "divergent" // Contact exists but differs from the contact config
+	AuditMissing   = "missing"   // Contact is not set on the account
+	AuditError     = "error"     // Contact could not be read
+)
+
+// Audit report formats
+const (
+	AuditFormatJSON = "json"
+	AuditFormatCSV  = "csv"
+)
+
+// Audit exit codes, so CI can tell drift from an incomplete audit
+const (
+	AuditExitCompliant = 0
+	AuditExitDrift     = 1
+	AuditExitError     = 2
+)
+
+// ContactValues are the fields of an alternate contact
+type ContactValues struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// ContactAuditEntry records the audit of one contact type on one account
+type ContactAuditEntry struct {
+	Organization    string         `json:"organization"`
+	AccountID       string         `json:"account_id,omitempty"`
+	AccountName     string         `json:"account_name,omitempty"`
+	ContactType     string         `json:"contact_type,omitempty"`
+	Source          string         `json:"source,omitempty"` // Contact config entry the expected contact came from
+	Status          string         `json:"status"`
+	DivergentFields []string       `json:"divergent_fields,omitempty"`
+	Expected        *ContactValues `json:"expected,omitempty"`
+	Actual          *ContactValues `json:"actual,omitempty"`
+	Error           string         `json:"error,omitempty"`
+}
+
+// ContactAuditReport is the result of auditing alternate contacts across organizations
+type ContactAuditReport struct {
+	GeneratedAt       time.Time           `json:"generated_at"`
+	ContactConfigFile string              `json:"contact_config_file"`
+	Counts            map[string]int      `json:"counts"`
+	Entries           []ContactAuditEntry `json:"entries"`
+}
+
+// NewContactAuditReport starts an audit report against a contact config file
+func NewContactAuditReport(contactConfigFile string) *ContactAuditReport {
+	return &ContactAuditReport{
+		GeneratedAt:       time.Now().UTC(),
+		ContactConfigFile: contactConfigFile,
+		Counts:            make(map[string]int),
+	}
+}
+
+// Add records audit entries
+func (r *ContactAuditReport) Add(entries ...ContactAuditEntry) {
+	for _, entry := range entries {
+		r.Entries = append(r.Entries, entry)
+		r.Counts[entry.Status]++
+	}
+}
+
+// ExitCode returns AuditExitError if anything could not be audited, AuditExitDrift if any
+// contact is missing or divergent, and AuditExitCompliant otherwise
+func (r *ContactAuditReport) ExitCode() int {
+	switch {
+	case r.Counts[AuditError] > 0:
+		return AuditExitError
+	case r.Counts[AuditMissing] > 0 || r.Counts[AuditDivergent] > 0:
+		return AuditExitDrift
+	default:
+		return AuditExitCompliant
+	}
+}
+
+// DivergentFields lists the fields in which an actual contact differs from the expected one.
+// Emails compare case-insensitively and phone numbers ignore punctuation and spacing.
+func DivergentFields(expected, actual ContactValues) []string {
+	var fields []string
+	if strings.TrimSpace(expected.Name) != strings.TrimSpace(actual.Name) {
+		fields = append(fields, "name")
+	}
+	if strings.TrimSpace(expected.Title) != strings.TrimSpace(actual.Title) {
+		fields = append(fields, "title")
+	}
+	if !strings.EqualFold(strings.TrimSpace(expected.Email), strings.TrimSpace(actual.Email)) {
+		fields = append(fields, "email")
+	}
+	if normalizePhone(expected.Phone) != normalizePhone(actual.Phone) {
+		fields = append(fields, "phone")
+	}
+	return fields
+}
+
+// normalizePhone keeps the digits and a leading plus of a phone number
+func normalizePhone(phone string) string {
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		if (r >= '0' && r <= '9') || (r == '+' && i == 0) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// AuditAccount compares an account's alternate contacts with the ones resolved for it
+func AuditAccount(client AlternateContactReader, orgPrefix string, acct organizationsTypes.Account, expected map[accountTypes.AlternateContactType]ResolvedContact) []ContactAuditEntry {
+	var entries []ContactAuditEntry
+	for _, contactType := range alternateContactTypes {
+		resolved, ok := expected[contactType]
+		if !ok {
+			continue
+		}
+		want := resolved.ContactValues
+
+		entry := ContactAuditEntry{
+			Organization: orgPrefix,
+			AccountID:    aws.ToString(acct.Id),
+			AccountName:  aws.ToString(acct.Name),
+			ContactType:  strings.ToLower(string(contactType)),
+			Source:       resolved.Source,
+			Expected:     &want,
+		}
+
+		contact, err := GetAlternateContact(client, entry.AccountID, contactType)
+		switch {
+		case err != nil:
+			entry.Status = AuditError
+			entry.Error = err.Error()
+		case contact == nil:
+			entry.Status = AuditMissing
+		default:
+			actual := contactValues(contact)
+			entry.Actual = &actual
+			entry.DivergentFields = DivergentFields(want, *entry.Actual)
+			entry.Status = AuditMatching
+			if len(entry.DivergentFields) > 0 {
+				entry.Status = AuditDivergent
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// AuditContactsForAllOrganizations reads the alternate contacts of every active account in every
+// organization in OrgConfig.json (or only the one with orgPrefix) and compares them with the
+// contacts resolved for it from the contact config. Nothing is written to the accounts.
+func AuditContactsForAllOrganizations(contactConfigFile string, orgPrefix string) (*ContactAuditReport, error) {
+	contactConfig, err := LoadContactConfig(contactConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidateAlternateContactConfig(contactConfig); err != nil {
+		return nil, fmt.Errorf("invalid contact config %s: %w", contactConfigFile, err)
+	}
+	if len(GlobalContacts(contactConfig)) == 0 && len(contactConfig.Overrides) == 0 {
+		return nil, fmt.Errorf("contact config %s sets no contacts to audit", contactConfigFile)
+	}
+
+	orgConfig, err := LoadOrgConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	report := NewContactAuditReport(contactConfigFile)
+	audited := 0
+	for _, org := range orgConfig {
+		if orgPrefix != "" && org.Prefix != orgPrefix {
+			continue
+		}
+		audited++
+		fmt.Printf("Auditing organization: %s (prefix: %s)\n", org.FriendlyName, org.Prefix)
+
+		cfg, err := ManagementAccountConfig(org.Prefix, orgConfig)
+		if err != nil {
+			fmt.Printf("failed to connect to organization %s: %v\n", org.Prefix, err)
+			report.Add(ContactAuditEntry{Organization: org.Prefix, Status: AuditError, Error: err.Error()})
+			continue
+		}
+
+		accounts, err := awsutils.GetAllAccountsInOrganization(organizations.NewFromConfig(cfg))
+		if err != nil {
+			fmt.Printf("failed to get the accounts in organization %s: %v\n", org.Prefix, err)
+			report.Add(ContactAuditEntry{Organization: org.Prefix, Status: AuditError, Error: err.Error()})
+			continue
+		}
+
+		accountClient := account.NewFromConfig(cfg)
+		resolver := NewContactResolver(contactConfig, org.Prefix, organizations.NewFromConfig(cfg))
+		for _, acct := range accounts {
+			// Suspended accounts cannot be read or fixed
+			if acct.Status != organizationsTypes.AccountStatusActive {
+				fmt.Printf("Skipping %s account: %s - %s\n", strings.ToLower(string(acct.Status)), aws.ToString(acct.Name), aws.ToString(acct.Id))
+				continue
+			}
+			expected, err := resolver.Resolve(aws.ToString(acct.Id))
+			if err != nil {
+				fmt.Printf("failed to resolve contacts for account %s: %v\n", aws.ToString(acct.Id), err)
+				report.Add(ContactAuditEntry{Organization: org.Prefix, AccountID: aws.ToString(acct.Id), AccountName: aws.ToString(acct.Name), Status: AuditError, Error: err.Error()})
+				continue
+			}
+			report.Add(AuditAccount(accountClient, org.Prefix, acct, expected)...)
+		}
+		fmt.Printf("Audited %d accounts in organization: %s\n", len(accounts), org.FriendlyName)
+	}
+
+	if orgPrefix != "" && audited == 0 {
+		return nil, fmt.Errorf("organization prefix %s not found in OrgConfig.json", orgPrefix)
+	}
+	return report, nil
+}
+
+// ContactAuditFileName names an audit report by the time it was generated
+func ContactAuditFileName(generatedAt time.Time, format string) string {
+	return fmt.Sprintf("alt-contact-audit-%s.%s", generatedAt.UTC().Format("20060102T150405Z"), format)
+}
+
+// WriteContactAuditReport writes the report as JSON or CSV. An empty path writes it to the config
+// path under its generated name. The path written is returned.
+func WriteContactAuditReport(report *ContactAuditReport, path string, format string) (string, error) {
+	if format != AuditFormatJSON && format != AuditFormatCSV {
+		return "", fmt.Errorf("unsupported audit report format %q (expected json or csv)", format)
+	}
+	if path == "" {
+		path = config.GetConfigPath() + ContactAuditFileName(report.GeneratedAt, format)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create audit report: %w", err)
+	}
+	defer file.Close()
+
+	if format == AuditFormatCSV {
+		err = writeContactAuditCSV(file, report)
+	} else {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write audit report: %w", err)
+	}
+	return path, file.Close()
+}
+
+// writeContactAuditCSV writes one row per audit entry, ordered by organization, account and type
+func writeContactAuditCSV(w io.Writer, report *ContactAuditReport) error {
+	entries := append([]ContactAuditEntry(nil), report.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Organization != entries[j].Organization {
+			return entries[i].Organization < entries[j].Organization
+		}
+		return entries[i].AccountID < entries[j].AccountID
+	})
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{
+		"organization", "account_id", "account_name", "contact_type", "source", "status", "divergent_fields",
+		"expected_name", "expected_title", "expected_email", "expected_phone",
+		"actual_name", "actual_title", "actual_email", "actual_phone", "error",
+	})
+	for _, entry := range entries {
+		var expected, actual ContactValues
+		if entry.Expected != nil {
+			expected = *entry.Expected
+		}
+		if entry.Actual != nil {
+			actual = *entry.Actual
+		}
+		writer.Write([]string{
+			entry.Organization, entry.AccountID, entry.AccountName, entry.ContactType, entry.Source, entry.Status,
+			strings.Join(entry.DivergentFields, ";"),
+			expected.Name, expected.Title, expected.Email, expected.Phone,
+			actual.Name, actual.Title, actual.Email, actual.Phone, entry.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,130 @@
+package contacts
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// fakeContactReader serves alternate contacts by type; a missing type is not found
+type fakeContactReader struct {
+	contacts map[accountTypes.AlternateContactType]*accountTypes.AlternateContact
+	err      error
+}
+
+func (f *fakeContactReader) GetAlternateContact(ctx context.Context, params *account.GetAlternateContactInput, optFns ...func(*account.Options)) (*account.GetAlternateContactOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	contact, ok := f.contacts[params.AlternateContactType]
+	if !ok {
+		return nil, &accountTypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &account.GetAlternateContactOutput{AlternateContact: contact}, nil
+}
+
+func TestDivergentFields(t *testing.T) {
+	expected := ContactValues{Name: "Security Team", Title: "SecOps", Email: "security@example.com", Phone: "+1 (555) 010-0000"}
+
+	if fields := DivergentFields(expected, ContactValues{Name: "Security Team ", Title: "SecOps", Email: "Security@Example.com", Phone: "+15550100000"}); len(fields) != 0 {
+		t.Errorf("Expected case and formatting differences to match, got %v", fields)
+	}
+	fields := DivergentFields(expected, ContactValues{Name: "Jane Doe", Title: "SecOps", Email: "jane@example.com", Phone: "+1 555 010 0000"})
+	if want := []string{"name", "email"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("DivergentFields = %v, want %v", fields, want)
+	}
+}
+
+func TestAuditAccount(t *testing.T) {
+	expected := GlobalContacts(types.AlternateContactConfig{
+		SecurityName: "Security Team", SecurityTitle: "SecOps", SecurityEmail: "security@example.com", SecurityPhone: "+15550100000",
+		BillingName: "Billing Team", BillingTitle: "Finance", BillingEmail: "billing@example.com", BillingPhone: "+15550100001",
+		OperationsName: "Ops Team", OperationsTitle: "Ops", OperationsEmail: "ops@example.com", OperationsPhone: "+15550100002",
+	})
+	acct := organizationsTypes.Account{Id: aws.String("123456789012"), Name: aws.String("hts-prod")}
+	reader := &fakeContactReader{contacts: map[accountTypes.AlternateContactType]*accountTypes.AlternateContact{
+		accountTypes.AlternateContactTypeSecurity: {Name: aws.String("Security Team"), Title: aws.String("SecOps"), EmailAddress: aws.String("security@example.com"), PhoneNumber: aws.String("+1 555 010 0000")},
+		accountTypes.AlternateContactTypeBilling:  {Name: aws.String("Billing Team"), Title: aws.String("Finance"), EmailAddress: aws.String("old-billing@example.com"), PhoneNumber: aws.String("+15550100001")},
+	}}
+
+	report := NewContactAuditReport("ContactConfig.json")
+	report.Add(AuditAccount(reader, "hts", acct, expected)...)
+
+	var statuses []string
+	for _, entry := range report.Entries {
+		statuses = append(statuses, entry.ContactType+":"+entry.Status)
+	}
+	if want := []string{"security:matching", "billing:divergent", "operations:missing"}; !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("Statuses = %v, want %v", statuses, want)
+	}
+	if report.Entries[0].Source != GlobalContactSource {
+		t.Errorf("Expected the global source, got %q", report.Entries[0].Source)
+	}
+	if fields := report.Entries[1].DivergentFields; !reflect.DeepEqual(fields, []string{"email"}) {
+		t.Errorf("Expected billing email to diverge, got %v", fields)
+	}
+	if code := report.ExitCode(); code != AuditExitDrift {
+		t.Errorf("ExitCode = %d, want %d", code, AuditExitDrift)
+	}
+
+	report.Add(AuditAccount(&fakeContactReader{err: errors.New("access denied")}, "hts", acct, expected)...)
+	if report.Counts[AuditError] != 3 || report.ExitCode() != AuditExitError {
+		t.Errorf("Expected read failures to be errors, got counts %v", report.Counts)
+	}
+}
+
+func TestContactAuditReportExitCodeCompliant(t *testing.T) {
+	report := NewContactAuditReport("ContactConfig.json")
+	report.Add(ContactAuditEntry{Organization: "hts", AccountID: "123456789012", ContactType: "security", Status: AuditMatching})
+	if code := report.ExitCode(); code != AuditExitCompliant {
+		t.Errorf("ExitCode = %d, want %d", code, AuditExitCompliant)
+	}
+}
+
+func TestWriteContactAuditReportCSV(t *testing.T) {
+	report := NewContactAuditReport("ContactConfig.json")
+	report.Add(
+		ContactAuditEntry{Organization: "hts", AccountID: "222222222222", ContactType: "billing", Source: GlobalContactSource, Status: AuditMissing, Expected: &ContactValues{Email: "billing@example.com"}},
+		ContactAuditEntry{Organization: "hts", AccountID: "111111111111", ContactType: "security", Source: "override:security-team", Status: AuditDivergent, DivergentFields: []string{"name", "phone"},
+			Expected: &ContactValues{Name: "Security Team"}, Actual: &ContactValues{Name: "Jane Doe"}},
+	)
+
+	path, err := WriteContactAuditReport(report, filepath.Join(t.TempDir(), "audit.csv"), AuditFormatCSV)
+	if err != nil {
+		t.Fatalf("WriteContactAuditReport failed: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open report: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d rows", len(rows))
+	}
+	if rows[1][1] != "111111111111" || rows[1][4] != "override:security-team" || rows[1][6] != "name;phone" || rows[1][11] != "Jane Doe" {
+		t.Errorf("Unexpected first row: %v", rows[1])
+	}
+	if rows[2][5] != AuditMissing || rows[2][9] != "billing@example.com" {
+		t.Errorf("Unexpected second row: %v", rows[2])
+	}
+
+	if _, err := WriteContactAuditReport(report, filepath.Join(t.TempDir(), "audit.xml"), "xml"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,371 @@
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/concurrent"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ContactBackupFileName names a backup by the action it was taken before and the time it was taken
+func ContactBackupFileName(action string, takenAt time.Time) string {
+	return fmt.Sprintf("alt-contact-backup-%s-%s.json", action, takenAt.UTC().Format("20060102-150405"))
+}
+
+// backupAccounts snapshots all three contact types of the accounts about to be changed and writes
+// the backup locally and, with a backup location, to S3. If any account cannot be read or the
+// backup cannot be stored, an error is returned and nothing should be changed.
+func (r *contactRun) backupAccounts(action string, tasks []accountTask) error {
+	takenAt := time.Now().UTC()
+	backup := &types.AlternateContactBackup{}
+	backup.BackupMetadata.Timestamp = takenAt.Format(time.RFC3339)
+	backup.BackupMetadata.Tool = "ccoe-customer-contact-manager"
+	backup.BackupMetadata.Action = action
+
+	byKey := make(map[string]accountTask)
+	var keys []string
+	for _, task := range tasks {
+		key := checkpointKey(task.org, aws.ToString(task.account.Id))
+		byKey[key] = task
+		keys = append(keys, key)
+	}
+
+	r.logger.Info("backing up alternate contacts", "accounts", len(keys))
+	poolResults := concurrent.ProcessCustomersConcurrently(keys, nil, func(key string) (interface{}, error) {
+		return r.snapshotAccount(byKey[key])
+	}, r.options.MaxConcurrency)
+
+	var errs []error
+	for _, result := range poolResults {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.CustomerCode, result.Error))
+			continue
+		}
+		backup.Accounts = append(backup.Accounts, result.Data.(types.AlternateContactAccountBackup))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to back up %d accounts, nothing was changed: %w", len(errs), errors.Join(errs...))
+	}
+	sort.Slice(backup.Accounts, func(i, j int) bool {
+		if backup.Accounts[i].Organization != backup.Accounts[j].Organization {
+			return backup.Accounts[i].Organization < backup.Accounts[j].Organization
+		}
+		return backup.Accounts[i].AccountID < backup.Accounts[j].AccountID
+	})
+
+	path, err := WriteContactBackup(backup, filepath.Join(r.backupDir, ContactBackupFileName(action, takenAt)))
+	if err != nil {
+		return fmt.Errorf("nothing was changed: %w", err)
+	}
+	r.backupFile = path
+	r.logger.Info("alternate contacts backed up", "file", path, "accounts", len(backup.Accounts))
+
+	if r.options.BackupLocation != "" {
+		uri, err := UploadContactBackup(context.Background(), path, r.options.BackupLocation)
+		if err != nil {
+			return fmt.Errorf("nothing was changed: %w", err)
+		}
+		r.backupURI = uri
+		r.logger.Info("alternate contact backup uploaded", "uri", uri)
+	}
+	return nil
+}
+
+// snapshotAccount reads all three contact types of an account
+func (r *contactRun) snapshotAccount(task accountTask) (types.AlternateContactAccountBackup, error) {
+	accountId := aws.ToString(task.account.Id)
+	snapshot := types.AlternateContactAccountBackup{
+		Organization: task.org,
+		AccountID:    accountId,
+		AccountName:  aws.ToString(task.account.Name),
+	}
+	for _, contactType := range alternateContactTypes {
+		var current *accountTypes.AlternateContact
+		err := r.call(func() error {
+			var err error
+			current, err = GetAlternateContact(task.client, accountId, contactType)
+			return err
+		})
+		if err != nil {
+			return snapshot, fmt.Errorf("%s contact: %w", strings.ToLower(string(contactType)), err)
+		}
+		if current != nil {
+			details := types.AlternateContactDetails(contactValues(current))
+			setBackupContact(&snapshot, contactType, &details)
+		}
+	}
+	return snapshot, nil
+}
+
+// backupContact returns the contact a backup holds for a type, nil if it was not set
+func backupContact(snapshot types.AlternateContactAccountBackup, contactType accountTypes.AlternateContactType) *types.AlternateContactDetails {
+	switch contactType {
+	case accountTypes.AlternateContactTypeSecurity:
+		return snapshot.Security
+	case accountTypes.AlternateContactTypeBilling:
+		return snapshot.Billing
+	case accountTypes.AlternateContactTypeOperations:
+		return snapshot.Operations
+	}
+	return nil
+}
+
+func setBackupContact(snapshot *types.AlternateContactAccountBackup, contactType accountTypes.AlternateContactType, details *types.AlternateContactDetails) {
+	switch contactType {
+	case accountTypes.AlternateContactTypeSecurity:
+		snapshot.Security = details
+	case accountTypes.AlternateContactTypeBilling:
+		snapshot.Billing = details
+	case accountTypes.AlternateContactTypeOperations:
+		snapshot.Operations = details
+	}
+}
+
+// WriteContactBackup writes a backup to a local file and returns its path
+func WriteContactBackup(backup *types.AlternateContactBackup, path string) (string, error) {
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal alternate contact backup: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create backup directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write alternate contact backup: %w", err)
+	}
+	return path, nil
+}
+
+// UploadContactBackup uploads a backup file to s3://bucket/prefix under its file name and returns
+// the object URI
+func UploadContactBackup(ctx context.Context, path string, location string) (string, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, "s3://"), "/")
+	if !strings.HasPrefix(location, "s3://") || bucket == "" {
+		return "", fmt.Errorf("invalid backup location %q (expected s3://bucket/prefix)", location)
+	}
+
+	key := filepath.Base(path)
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read alternate contact backup: %w", err)
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	_, err = s3.NewFromConfig(awsCfg).PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload alternate contact backup: %w", err)
+	}
+	return fmt.Sprintf("s3://%s/%s", bucket, key), nil
+}
+
+// LoadContactBackup reads a backup written by WriteContactBackup
+func LoadContactBackup(path string) (*types.AlternateContactBackup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alternate contact backup: %w", err)
+	}
+	var backup types.AlternateContactBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, fmt.Errorf("failed to parse alternate contact backup %s: %w", path, err)
+	}
+	if len(backup.Accounts) == 0 {
+		return nil, fmt.Errorf("alternate contact backup %s has no accounts", path)
+	}
+	return &backup, nil
+}
+
+// RestoreContacts puts back the contacts recorded in a backup, limited to one organization when
+// orgPrefix is set. Contacts the backup records as not set are deleted. A dry run reports what
+// would change without changing anything.
+func RestoreContacts(backupFile string, orgPrefix string, options ContactWriteOptions) (*ContactRunSummary, error) {
+	started := time.Now()
+	logger := slog.Default()
+
+	backup, err := LoadContactBackup(backupFile)
+	if err != nil {
+		return nil, err
+	}
+	orgConfig, err := LoadOrgConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]types.AlternateContactAccountBackup)
+	byOrg := make(map[string][]types.AlternateContactAccountBackup)
+	var orgs []string
+	for _, snapshot := range backup.Accounts {
+		if orgPrefix != "" && snapshot.Organization != orgPrefix {
+			continue
+		}
+		if _, ok := byOrg[snapshot.Organization]; !ok {
+			orgs = append(orgs, snapshot.Organization)
+		}
+		byOrg[snapshot.Organization] = append(byOrg[snapshot.Organization], snapshot)
+		snapshots[checkpointKey(snapshot.Organization, snapshot.AccountID)] = snapshot
+	}
+	if len(orgs) == 0 {
+		return nil, fmt.Errorf("alternate contact backup %s has no accounts in organization %s", backupFile, orgPrefix)
+	}
+
+	// Dry runs change nothing, so have nothing to resume
+	var checkpoint *ContactCheckpoint
+	if !options.DryRun {
+		hash, err := checkpointHash(struct {
+			Organization string
+			Backup       *types.AlternateContactBackup
+		}{orgPrefix, backup})
+		if err != nil {
+			return nil, err
+		}
+		checkpoint, err = OpenContactCheckpoint(options.CheckpointFile, ContactActionRestore, hash, options.Resume)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	run := newContactRun(options, checkpoint, logger)
+	defer run.stop()
+
+	var tasks []accountTask
+	var results []AccountResult
+	for _, org := range orgs {
+		logger.Info("connecting to organization", "organization", org)
+		cfg, err := ManagementAccountConfig(org, orgConfig)
+		if err != nil {
+			results = append(results, run.organizationFailed(org, err))
+			continue
+		}
+		client := account.NewFromConfig(cfg)
+		for _, snapshot := range byOrg[org] {
+			tasks = append(tasks, accountTask{
+				org:     org,
+				account: organizationsTypes.Account{Id: aws.String(snapshot.AccountID), Name: aws.String(snapshot.AccountName)},
+				client:  client,
+			})
+		}
+	}
+
+	processed, err := run.runAccounts(ContactActionRestore, tasks, restoreAccountContacts(snapshots))
+	if err != nil {
+		return nil, err
+	}
+	return run.finish(ContactActionRestore, append(results, processed...), started), nil
+}
+
+// restoreAccountContacts returns an operation putting back the contacts of an account's snapshot
+func restoreAccountContacts(snapshots map[string]types.AlternateContactAccountBackup) accountOperation {
+	return func(r *contactRun, task accountTask) (map[string]string, error) {
+		accountId := aws.ToString(task.account.Id)
+		snapshot := snapshots[checkpointKey(task.org, accountId)]
+		outcomes := make(map[string]string)
+		var errs []error
+		for _, contactType := range alternateContactTypes {
+			name := strings.ToLower(string(contactType))
+			outcome, err := r.restoreContact(task.client, accountId, contactType, backupContact(snapshot, contactType))
+			if err != nil {
+				outcomes[name] = ContactFailed
+				errs = append(errs, fmt.Errorf("%s contact: %w", name, err))
+				continue
+			}
+			outcomes[name] = outcome
+		}
+		return outcomes, errors.Join(errs...)
+	}
+}
+
+// restoreContact makes one contact match its backup, deleting it if the backup has none. The
+// outcome of an update lists the fields it changes.
+func (r *contactRun) restoreContact(client AlternateContactWriter, accountId string, contactType accountTypes.AlternateContactType, want *types.AlternateContactDetails) (string, error) {
+	var current *accountTypes.AlternateContact
+	err := r.call(func() error {
+		var err error
+		current, err = GetAlternateContact(client, accountId, contactType)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var outcome string
+	var change func() error
+	switch {
+	case want == nil && current == nil:
+		return ContactAbsent, nil
+	case want == nil:
+		outcome = ContactDeleted
+		change = func() error { return DeleteAlternateContact(client, accountId, contactType) }
+	default:
+		wantValues := ContactValues(*want)
+		outcome = ContactAdded
+		if current != nil {
+			currentValues := contactValues(current)
+			fields := DivergentFields(wantValues, currentValues)
+			if len(fields) == 0 {
+				return ContactUnchanged, nil
+			}
+			outcome = ContactUpdated + " (" + describeChanges(fields, currentValues, wantValues) + ")"
+		}
+		change = func() error {
+			return SetAlternateContact(client, accountId, contactType, want.Name, want.Title, want.Email, want.Phone)
+		}
+	}
+
+	if r.options.DryRun {
+		return "would be " + outcome, nil
+	}
+	if err := r.call(change); err != nil {
+		return "", err
+	}
+	return outcome, nil
+}
+
+// describeChanges lists the old and new values of the given fields
+func describeChanges(fields []string, from, to ContactValues) string {
+	value := func(v ContactValues, field string) string {
+		switch field {
+		case "name":
+			return v.Name
+		case "title":
+			return v.Title
+		case "email":
+			return v.Email
+		}
+		return v.Phone
+	}
+	var changes []string
+	for _, field := range fields {
+		changes = append(changes, fmt.Sprintf("%s: %q → %q", field, value(from, field), value(to, field)))
+	}
+	return strings.Join(changes, ", ")
+}
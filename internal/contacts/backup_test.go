@@ -0,0 +1,125 @@
+package contacts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestRunAccountsBacksUpBeforeChanges(t *testing.T) {
+	contactConfig := types.AlternateContactConfig{SecurityName: "Security Team", SecurityEmail: "security@example.com"}
+	client := newFakeContactWriter()
+	client.set("111111111111", accountTypes.AlternateContactTypeBilling, ContactValues{Name: "Billing", Email: "billing@example.com"})
+	client.set("222222222222", accountTypes.AlternateContactTypeSecurity, ContactValues{Name: "Jane Doe", Email: "jane@example.com"})
+
+	run := newTestContactRun(t, ContactWriteOptions{Overwrite: true}, nil)
+	run.backupDir = t.TempDir()
+	results, err := run.runAccounts(ContactActionSet, testAccountTasks(client, contactConfig, "111111111111", "222222222222"), setAccountContacts)
+	if err != nil {
+		t.Fatalf("runAccounts failed: %v", err)
+	}
+	summary := run.finish(ContactActionSet, results, time.Now())
+	if summary.Failed() != 0 || summary.BackupFile == "" {
+		t.Fatalf("Expected a clean run with a backup, got %+v", summary)
+	}
+
+	backup, err := LoadContactBackup(summary.BackupFile)
+	if err != nil {
+		t.Fatalf("LoadContactBackup failed: %v", err)
+	}
+	if backup.BackupMetadata.Action != ContactActionSet || len(backup.Accounts) != 2 {
+		t.Fatalf("Unexpected backup: %+v", backup)
+	}
+	want := []types.AlternateContactAccountBackup{
+		{Organization: "hts", AccountID: "111111111111", AccountName: "acct-111111111111", Billing: &types.AlternateContactDetails{Name: "Billing", Email: "billing@example.com"}},
+		{Organization: "hts", AccountID: "222222222222", AccountName: "acct-222222222222", Security: &types.AlternateContactDetails{Name: "Jane Doe", Email: "jane@example.com"}},
+	}
+	if !reflect.DeepEqual(backup.Accounts, want) {
+		t.Errorf("Backup accounts = %+v, want the contacts before the run %+v", backup.Accounts, want)
+	}
+}
+
+func TestRunAccountsBackupFailureChangesNothing(t *testing.T) {
+	contactConfig := types.AlternateContactConfig{SecurityName: "Security Team", SecurityEmail: "security@example.com"}
+	client := newFakeContactWriter()
+	client.failing["222222222222"] = true
+
+	run := newTestContactRun(t, ContactWriteOptions{}, nil)
+	run.backupDir = t.TempDir()
+	if _, err := run.runAccounts(ContactActionSet, testAccountTasks(client, contactConfig, "111111111111", "222222222222"), setAccountContacts); err == nil {
+		t.Fatal("Expected an error when an account cannot be backed up")
+	}
+	if client.puts != 0 || run.backupFile != "" {
+		t.Errorf("Expected no writes and no backup, got %d writes and %q", client.puts, run.backupFile)
+	}
+}
+
+func TestRestoreAccountContacts(t *testing.T) {
+	snapshot := types.AlternateContactAccountBackup{
+		Organization: "hts",
+		AccountID:    "111111111111",
+		Security:     &types.AlternateContactDetails{Name: "Security Team", Email: "security@example.com"},
+		Billing:      &types.AlternateContactDetails{Name: "Billing", Email: "billing@example.com"},
+	}
+	snapshots := map[string]types.AlternateContactAccountBackup{checkpointKey("hts", "111111111111"): snapshot}
+
+	newClient := func() *fakeContactWriter {
+		client := newFakeContactWriter()
+		client.set("111111111111", accountTypes.AlternateContactTypeSecurity, ContactValues{Name: "Security Team", Email: "new-security@example.com"})
+		client.set("111111111111", accountTypes.AlternateContactTypeOperations, ContactValues{Name: "Ops", Email: "ops@example.com"})
+		return client
+	}
+
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   map[string]string
+	}{
+		{"restore", false, map[string]string{
+			"security":   `updated (email: "new-security@example.com" → "security@example.com")`,
+			"billing":    ContactAdded,
+			"operations": ContactDeleted,
+		}},
+		{"dry run", true, map[string]string{
+			"security":   `would be updated (email: "new-security@example.com" → "security@example.com")`,
+			"billing":    "would be " + ContactAdded,
+			"operations": "would be " + ContactDeleted,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient()
+			run := newTestContactRun(t, ContactWriteOptions{DryRun: tt.dryRun}, nil)
+			run.backupDir = t.TempDir()
+			results, err := run.runAccounts(ContactActionRestore, testAccountTasks(client, types.AlternateContactConfig{}, "111111111111"), restoreAccountContacts(snapshots))
+			if err != nil {
+				t.Fatalf("runAccounts failed: %v", err)
+			}
+			if !reflect.DeepEqual(results[0].Contacts, tt.want) {
+				t.Errorf("Contacts = %v, want %v", results[0].Contacts, tt.want)
+			}
+
+			if tt.dryRun {
+				if client.puts != 0 || len(client.contacts["111111111111"]) != 2 || run.backupFile != "" {
+					t.Errorf("Expected a dry run to change nothing and take no backup, got %d writes, backup %q", client.puts, run.backupFile)
+				}
+				return
+			}
+			if run.backupFile == "" {
+				t.Error("Expected a backup before restoring")
+			}
+			restored, err := run.snapshotAccount(testAccountTasks(client, types.AlternateContactConfig{}, "111111111111")[0])
+			if err != nil {
+				t.Fatalf("snapshotAccount failed: %v", err)
+			}
+			if restored.Security.Email != "security@example.com" || restored.Billing == nil || restored.Operations != nil {
+				t.Errorf("Expected the account to match the backup, got %+v", restored)
+			}
+		})
+	}
+}
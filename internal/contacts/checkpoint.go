@@ -0,0 +1,159 @@
+package contacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/config"
+)
+
+// DefaultCheckpointFile is where alternate contact runs record progress unless told otherwise
+const DefaultCheckpointFile = "alt-contact-checkpoint.json"
+
+// ContactCheckpoint records the accounts an alternate contact run has finished, so a failed run can
+// resume without redoing them. It is written after every account. A nil checkpoint, as used by dry
+// runs, records nothing.
+type ContactCheckpoint struct {
+	Action     string                   `json:"action"`      // set or delete
+	ConfigHash string                   `json:"config_hash"` // Hash of the contacts and options the run writes
+	StartedAt  time.Time                `json:"started_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	Accounts   map[string]AccountResult `json:"accounts"` // By checkpointKey
+
+	path string
+	mu   sync.Mutex
+}
+
+// checkpointKey identifies an account across organizations
+func checkpointKey(orgPrefix, accountId string) string {
+	return orgPrefix + "/" + accountId
+}
+
+// checkpointHash fingerprints what a run writes, so a checkpoint is only resumed by the same run
+func checkpointHash(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash run settings: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// OpenContactCheckpoint starts a checkpoint at path, or, when resuming, loads the one there. A
+// checkpoint written by a different action or configuration is not resumed, as the accounts it
+// finished may have been given different contacts.
+func OpenContactCheckpoint(path, action, configHash string, resume bool) (*ContactCheckpoint, error) {
+	if path == "" {
+		path = config.GetConfigPath() + DefaultCheckpointFile
+	}
+
+	if resume {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// Nothing to resume, start afresh
+		case err != nil:
+			return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+		default:
+			var checkpoint ContactCheckpoint
+			if err := json.Unmarshal(data, &checkpoint); err != nil {
+				return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+			}
+			if checkpoint.Action != action || checkpoint.ConfigHash != configHash {
+				return nil, fmt.Errorf("checkpoint %s was written by a different %s run or contact config; remove it to start afresh", path, checkpoint.Action)
+			}
+			if checkpoint.Accounts == nil {
+				checkpoint.Accounts = make(map[string]AccountResult)
+			}
+			checkpoint.path = path
+			return &checkpoint, nil
+		}
+	}
+
+	now := time.Now().UTC()
+	return &ContactCheckpoint{
+		Action:     action,
+		ConfigHash: configHash,
+		StartedAt:  now,
+		UpdatedAt:  now,
+		Accounts:   make(map[string]AccountResult),
+		path:       path,
+	}, nil
+}
+
+// Path returns the file the checkpoint is written to
+func (c *ContactCheckpoint) Path() string {
+	if c == nil {
+		return ""
+	}
+	return c.path
+}
+
+// Result returns the recorded outcome of an account
+func (c *ContactCheckpoint) Result(orgPrefix, accountId string) (AccountResult, bool) {
+	if c == nil {
+		return AccountResult{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.Accounts[checkpointKey(orgPrefix, accountId)]
+	return result, ok
+}
+
+// Finished reports whether an earlier run succeeded for the account
+func (c *ContactCheckpoint) Finished(orgPrefix, accountId string) (AccountResult, bool) {
+	result, ok := c.Result(orgPrefix, accountId)
+	return result, ok && result.Status == AccountSucceeded
+}
+
+// Record stores an account's outcome and writes the checkpoint
+func (c *ContactCheckpoint) Record(result AccountResult) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Accounts[checkpointKey(result.Organization, result.AccountID)] = result
+	c.UpdatedAt = time.Now().UTC()
+	return c.save()
+}
+
+// Remove deletes the checkpoint file once a run has nothing left to resume
+func (c *ContactCheckpoint) Remove() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// save writes the checkpoint through a temporary file, so an interrupted write never leaves a
+// truncated checkpoint behind
+func (c *ContactCheckpoint) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if dir := filepath.Dir(c.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create checkpoint directory: %w", err)
+		}
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
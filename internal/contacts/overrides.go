@@ -0,0 +1,283 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// GlobalContactSource is the source of contacts from the top-level fields of the contact config.
+// Contacts from an override have the source "override:<name>".
+const GlobalContactSource = "global"
+
+// maxOUDepth bounds the walk up the OU tree; Organizations allows five levels below the root
+const maxOUDepth = 10
+
+// alternateContactTypes are the contact types managed, in the order they are set and reported
+var alternateContactTypes = []accountTypes.AlternateContactType{
+	accountTypes.AlternateContactTypeSecurity,
+	accountTypes.AlternateContactTypeBilling,
+	accountTypes.AlternateContactTypeOperations,
+}
+
+// ResolvedContact is the contact an account should have and the config entry it came from
+type ResolvedContact struct {
+	ContactValues
+	Source string
+}
+
+// AccountPlacement is where an account sits in its organization, as far as overrides select on it
+type AccountPlacement struct {
+	Organization string // Organization prefix from OrgConfig.json
+	AccountID    string
+	OUPath       []string          // OU IDs from below the root down to the account's OU
+	Tags         map[string]string // Account tags
+}
+
+// overrideRank orders matching overrides by how specifically they select an account
+type overrideRank struct {
+	scope   int // 3 account, 2 OU, 1 organization, 0 tags only
+	ouDepth int
+	tags    int
+}
+
+func (r overrideRank) moreSpecificThan(other overrideRank) bool {
+	if r.scope != other.scope {
+		return r.scope > other.scope
+	}
+	if r.ouDepth != other.ouDepth {
+		return r.ouDepth > other.ouDepth
+	}
+	return r.tags > other.tags
+}
+
+// GlobalContacts returns the contacts set by the top-level fields of the contact config, by type.
+// Types without an email are not set.
+func GlobalContacts(contactConfig types.AlternateContactConfig) map[accountTypes.AlternateContactType]ResolvedContact {
+	contacts := make(map[accountTypes.AlternateContactType]ResolvedContact)
+	if contactConfig.SecurityEmail != "" {
+		contacts[accountTypes.AlternateContactTypeSecurity] = ResolvedContact{ContactValues{contactConfig.SecurityName, contactConfig.SecurityTitle, contactConfig.SecurityEmail, contactConfig.SecurityPhone}, GlobalContactSource}
+	}
+	if contactConfig.BillingEmail != "" {
+		contacts[accountTypes.AlternateContactTypeBilling] = ResolvedContact{ContactValues{contactConfig.BillingName, contactConfig.BillingTitle, contactConfig.BillingEmail, contactConfig.BillingPhone}, GlobalContactSource}
+	}
+	if contactConfig.OperationsEmail != "" {
+		contacts[accountTypes.AlternateContactTypeOperations] = ResolvedContact{ContactValues{contactConfig.OperationsName, contactConfig.OperationsTitle, contactConfig.OperationsEmail, contactConfig.OperationsPhone}, GlobalContactSource}
+	}
+	return contacts
+}
+
+// ResolveContacts returns the contacts an account should have: for each type, the one from the
+// most specific matching override, or the global one
+func ResolveContacts(contactConfig types.AlternateContactConfig, placement AccountPlacement) map[accountTypes.AlternateContactType]ResolvedContact {
+	resolved := GlobalContacts(contactConfig)
+	ranks := make(map[accountTypes.AlternateContactType]overrideRank)
+
+	for _, override := range contactConfig.Overrides {
+		rank, ok := matchOverride(override, placement)
+		if !ok {
+			continue
+		}
+		for _, contactType := range alternateContactTypes {
+			details := overrideContact(override, contactType)
+			if details == nil {
+				continue
+			}
+			// Ties go to the override listed first
+			if current, set := ranks[contactType]; set && !rank.moreSpecificThan(current) {
+				continue
+			}
+			ranks[contactType] = rank
+			resolved[contactType] = ResolvedContact{ContactValues(*details), "override:" + override.Name}
+		}
+	}
+	return resolved
+}
+
+// matchOverride reports whether all of an override's selectors match the account, and how specifically
+func matchOverride(override types.AlternateContactOverride, placement AccountPlacement) (overrideRank, bool) {
+	rank := overrideRank{tags: len(override.Tags)}
+
+	if override.Organization != "" {
+		if override.Organization != placement.Organization {
+			return rank, false
+		}
+		rank.scope = 1
+	}
+	if override.OrganizationalUnit != "" {
+		index := slices.Index(placement.OUPath, override.OrganizationalUnit)
+		if index < 0 {
+			return rank, false
+		}
+		rank.scope = 2
+		rank.ouDepth = index + 1
+	}
+	if override.AccountID != "" {
+		if override.AccountID != placement.AccountID {
+			return rank, false
+		}
+		rank.scope = 3
+	}
+	for key, value := range override.Tags {
+		if actual, ok := placement.Tags[key]; !ok || actual != value {
+			return rank, false
+		}
+	}
+	return rank, true
+}
+
+// overrideContact returns the contact an override sets for a type, if any
+func overrideContact(override types.AlternateContactOverride, contactType accountTypes.AlternateContactType) *types.AlternateContactDetails {
+	switch contactType {
+	case accountTypes.AlternateContactTypeSecurity:
+		return override.Security
+	case accountTypes.AlternateContactTypeBilling:
+		return override.Billing
+	case accountTypes.AlternateContactTypeOperations:
+		return override.Operations
+	}
+	return nil
+}
+
+// OrganizationsReader reads where accounts sit in an organization; *organizations.Client satisfies it
+type OrganizationsReader interface {
+	ListParents(ctx context.Context, params *organizations.ListParentsInput, optFns ...func(*organizations.Options)) (*organizations.ListParentsOutput, error)
+	ListTagsForResource(ctx context.Context, params *organizations.ListTagsForResourceInput, optFns ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error)
+}
+
+// ContactResolver resolves the contacts of the accounts in one organization. OUs and tags are only
+// looked up when an override for the organization selects on them, and OU parents are cached
+// across accounts. It is safe for concurrent use.
+type ContactResolver struct {
+	contactConfig types.AlternateContactConfig
+	orgPrefix     string
+	client        OrganizationsReader
+	needOUs       bool
+	needTags      bool
+	parents       map[string]organizationsTypes.Parent
+	mu            sync.Mutex
+
+	// call wraps each Organizations request, e.g. to wait for a run's rate limiter and retry
+	// throttling; requests are made directly when it is nil
+	call func(operation func() error) error
+}
+
+// NewContactResolver creates a resolver for the accounts of an organization
+func NewContactResolver(contactConfig types.AlternateContactConfig, orgPrefix string, client OrganizationsReader) *ContactResolver {
+	r := &ContactResolver{
+		contactConfig: contactConfig,
+		orgPrefix:     orgPrefix,
+		client:        client,
+		parents:       make(map[string]organizationsTypes.Parent),
+	}
+	for _, override := range contactConfig.Overrides {
+		if override.Organization != "" && override.Organization != orgPrefix {
+			continue
+		}
+		r.needOUs = r.needOUs || override.OrganizationalUnit != ""
+		r.needTags = r.needTags || len(override.Tags) > 0
+	}
+	return r
+}
+
+// Resolve returns the contacts an account should have
+func (r *ContactResolver) Resolve(accountId string) (map[accountTypes.AlternateContactType]ResolvedContact, error) {
+	placement, err := r.Placement(accountId)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveContacts(r.contactConfig, placement), nil
+}
+
+// Placement looks up the OUs and tags of an account, as far as the overrides need them
+func (r *ContactResolver) Placement(accountId string) (AccountPlacement, error) {
+	placement := AccountPlacement{Organization: r.orgPrefix, AccountID: accountId}
+
+	if r.needOUs {
+		path, err := r.ouPath(accountId)
+		if err != nil {
+			return placement, err
+		}
+		placement.OUPath = path
+	}
+
+	if r.needTags {
+		placement.Tags = make(map[string]string)
+		paginator := organizations.NewListTagsForResourcePaginator(r.client, &organizations.ListTagsForResourceInput{ResourceId: aws.String(accountId)})
+		for paginator.HasMorePages() {
+			var page *organizations.ListTagsForResourceOutput
+			err := r.invoke(func() error {
+				var err error
+				page, err = paginator.NextPage(context.Background())
+				return err
+			})
+			if err != nil {
+				return placement, fmt.Errorf("failed to list tags for account %s: %w", accountId, err)
+			}
+			for _, tag := range page.Tags {
+				placement.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		}
+	}
+	return placement, nil
+}
+
+// ouPath walks up from an account to the root, returning the OU IDs from the top down
+func (r *ContactResolver) ouPath(accountId string) ([]string, error) {
+	var path []string
+	child := accountId
+	for range maxOUDepth {
+		parent, err := r.parent(child)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Type == organizationsTypes.ParentTypeRoot {
+			return path, nil
+		}
+		child = aws.ToString(parent.Id)
+		path = append([]string{child}, path...)
+	}
+	return nil, fmt.Errorf("account %s is nested deeper than %d OUs", accountId, maxOUDepth)
+}
+
+// parent returns the parent of an account or OU
+func (r *ContactResolver) parent(childId string) (organizationsTypes.Parent, error) {
+	r.mu.Lock()
+	parent, ok := r.parents[childId]
+	r.mu.Unlock()
+	if ok {
+		return parent, nil
+	}
+	var result *organizations.ListParentsOutput
+	err := r.invoke(func() error {
+		var err error
+		result, err = r.client.ListParents(context.Background(), &organizations.ListParentsInput{ChildId: aws.String(childId)})
+		return err
+	})
+	if err != nil {
+		return organizationsTypes.Parent{}, fmt.Errorf("failed to get parent of %s: %w", childId, err)
+	}
+	if len(result.Parents) == 0 {
+		return organizationsTypes.Parent{}, fmt.Errorf("%s has no parent", childId)
+	}
+	r.mu.Lock()
+	r.parents[childId] = result.Parents[0]
+	r.mu.Unlock()
+	return result.Parents[0], nil
+}
+
+// invoke makes one Organizations request through call, if set
+func (r *ContactResolver) invoke(operation func() error) error {
+	if r.call == nil {
+		return operation()
+	}
+	return r.call(operation)
+}
@@ -0,0 +1,157 @@
+package contacts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// fakeOrganizations serves parents and tags, counting ListParents calls
+type fakeOrganizations struct {
+	parents     map[string]organizationsTypes.Parent
+	tags        map[string][]organizationsTypes.Tag
+	parentCalls int
+}
+
+func (f *fakeOrganizations) ListParents(ctx context.Context, params *organizations.ListParentsInput, optFns ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+	f.parentCalls++
+	return &organizations.ListParentsOutput{Parents: []organizationsTypes.Parent{f.parents[aws.ToString(params.ChildId)]}}, nil
+}
+
+func (f *fakeOrganizations) ListTagsForResource(ctx context.Context, params *organizations.ListTagsForResourceInput, optFns ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error) {
+	return &organizations.ListTagsForResourceOutput{Tags: f.tags[aws.ToString(params.ResourceId)]}, nil
+}
+
+func contactDetails(email string) *types.AlternateContactDetails {
+	return &types.AlternateContactDetails{Name: "Team", Title: "Owner", Email: email, Phone: "+15550100000"}
+}
+
+func resolvedSources(resolved map[accountTypes.AlternateContactType]ResolvedContact) map[string]string {
+	sources := make(map[string]string)
+	for contactType, contact := range resolved {
+		sources[string(contactType)] = contact.Source + " " + contact.Email
+	}
+	return sources
+}
+
+func TestResolveContacts(t *testing.T) {
+	contactConfig := types.AlternateContactConfig{
+		SecurityName: "Security", SecurityEmail: "security@example.com",
+		BillingName: "Billing", BillingEmail: "billing@example.com",
+		Overrides: []types.AlternateContactOverride{
+			{Name: "prod-org", Organization: "hts-prod", Billing: contactDetails("prod-billing@example.com")},
+			{Name: "workloads-ou", OrganizationalUnit: "ou-root-workloads", Billing: contactDetails("workloads-billing@example.com")},
+			{Name: "payments-ou", OrganizationalUnit: "ou-root-payments", Billing: contactDetails("payments-billing@example.com")},
+			{Name: "pci-tag", Tags: map[string]string{"compliance": "pci"}, Security: contactDetails("pci-security@example.com")},
+			{Name: "pci-payments", Organization: "hts-prod", Tags: map[string]string{"compliance": "pci"}, Security: contactDetails("pci-payments@example.com")},
+			{Name: "ledger-account", AccountID: "111111111111", Security: contactDetails("ledger-security@example.com")},
+			{Name: "prod-org-duplicate", Organization: "hts-prod", Billing: contactDetails("ignored@example.com")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		placement AccountPlacement
+		want      map[string]string
+	}{
+		{"global", AccountPlacement{Organization: "hts-dev", AccountID: "999999999999"}, map[string]string{
+			"SECURITY": "global security@example.com",
+			"BILLING":  "global billing@example.com",
+		}},
+		{"organization wins over global, first listed wins ties", AccountPlacement{Organization: "hts-prod", AccountID: "999999999999"}, map[string]string{
+			"SECURITY": "global security@example.com",
+			"BILLING":  "override:prod-org prod-billing@example.com",
+		}},
+		{"deeper OU wins", AccountPlacement{Organization: "hts-prod", AccountID: "222222222222", OUPath: []string{"ou-root-workloads", "ou-root-payments"}}, map[string]string{
+			"SECURITY": "global security@example.com",
+			"BILLING":  "override:payments-ou payments-billing@example.com",
+		}},
+		{"organization with tags wins over tags only", AccountPlacement{Organization: "hts-prod", AccountID: "222222222222", Tags: map[string]string{"compliance": "pci"}}, map[string]string{
+			"SECURITY": "override:pci-payments pci-payments@example.com",
+			"BILLING":  "override:prod-org prod-billing@example.com",
+		}},
+		{"tags only", AccountPlacement{Organization: "hts-dev", AccountID: "222222222222", Tags: map[string]string{"compliance": "pci"}}, map[string]string{
+			"SECURITY": "override:pci-tag pci-security@example.com",
+			"BILLING":  "global billing@example.com",
+		}},
+		{"account wins", AccountPlacement{Organization: "hts-prod", AccountID: "111111111111", OUPath: []string{"ou-root-workloads"}, Tags: map[string]string{"compliance": "pci"}}, map[string]string{
+			"SECURITY": "override:ledger-account ledger-security@example.com",
+			"BILLING":  "override:workloads-ou workloads-billing@example.com",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolvedSources(ResolveContacts(contactConfig, tt.placement)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveContacts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContactResolverPlacement(t *testing.T) {
+	client := &fakeOrganizations{
+		parents: map[string]organizationsTypes.Parent{
+			"111111111111":      {Id: aws.String("ou-root-payments"), Type: organizationsTypes.ParentTypeOrganizationalUnit},
+			"222222222222":      {Id: aws.String("ou-root-payments"), Type: organizationsTypes.ParentTypeOrganizationalUnit},
+			"ou-root-payments":  {Id: aws.String("ou-root-workloads"), Type: organizationsTypes.ParentTypeOrganizationalUnit},
+			"ou-root-workloads": {Id: aws.String("r-root"), Type: organizationsTypes.ParentTypeRoot},
+		},
+		tags: map[string][]organizationsTypes.Tag{
+			"111111111111": {{Key: aws.String("compliance"), Value: aws.String("pci")}},
+		},
+	}
+	contactConfig := types.AlternateContactConfig{Overrides: []types.AlternateContactOverride{
+		{Name: "payments-ou", OrganizationalUnit: "ou-root-payments", Billing: contactDetails("payments-billing@example.com")},
+		{Name: "pci-tag", Tags: map[string]string{"compliance": "pci"}, Security: contactDetails("pci-security@example.com")},
+	}}
+	resolver := NewContactResolver(contactConfig, "hts-prod", client)
+
+	placement, err := resolver.Placement("111111111111")
+	if err != nil {
+		t.Fatalf("Placement failed: %v", err)
+	}
+	want := AccountPlacement{
+		Organization: "hts-prod",
+		AccountID:    "111111111111",
+		OUPath:       []string{"ou-root-workloads", "ou-root-payments"},
+		Tags:         map[string]string{"compliance": "pci"},
+	}
+	if !reflect.DeepEqual(placement, want) {
+		t.Errorf("Placement = %+v, want %+v", placement, want)
+	}
+
+	resolved, err := resolver.Resolve("222222222222")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := resolvedSources(resolved); !reflect.DeepEqual(got, map[string]string{"BILLING": "override:payments-ou payments-billing@example.com"}) {
+		t.Errorf("Resolve = %v", got)
+	}
+	// The second account only needs its own parent; the OUs above are cached
+	if client.parentCalls != 4 {
+		t.Errorf("Expected 4 ListParents calls, got %d", client.parentCalls)
+	}
+}
+
+func TestContactResolverSkipsUnneededLookups(t *testing.T) {
+	client := &fakeOrganizations{}
+	contactConfig := types.AlternateContactConfig{Overrides: []types.AlternateContactOverride{
+		{Name: "other-org-ou", Organization: "hts-dev", OrganizationalUnit: "ou-root-payments", Billing: contactDetails("payments-billing@example.com")},
+	}}
+
+	placement, err := NewContactResolver(contactConfig, "hts-prod", client).Placement("111111111111")
+	if err != nil {
+		t.Fatalf("Placement failed: %v", err)
+	}
+	if client.parentCalls != 0 || placement.OUPath != nil || placement.Tags != nil {
+		t.Errorf("Expected no lookups for overrides of other organizations, got %+v after %d calls", placement, client.parentCalls)
+	}
+}
@@ -0,0 +1,532 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	awsutils "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/concurrent"
+	"ccoe-customer-contact-manager/internal/config"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Alternate contact run actions, as recorded in checkpoints
+const (
+	ContactActionSet     = "set"
+	ContactActionDelete  = "delete"
+	ContactActionRestore = "restore"
+)
+
+// Account outcomes of an alternate contact run
+const (
+	AccountSucceeded = "succeeded"
+	AccountFailed    = "failed"
+	AccountResumed   = "resumed" // Finished by an earlier run, per the checkpoint
+)
+
+// Contact outcomes of an alternate contact run
+const (
+	ContactAdded     = "added"
+	ContactUpdated   = "updated"
+	ContactUnchanged = "unchanged" // Already matches the resolved contact
+	ContactKept      = "kept"      // Differs from the resolved contact, but overwrite is off
+	ContactDeleted   = "deleted"
+	ContactAbsent    = "absent" // Nothing to delete
+	ContactFailed    = "failed"
+)
+
+// Defaults for alternate contact writes
+const (
+	DefaultContactMaxConcurrency    = 10
+	DefaultContactRequestsPerSecond = 5
+)
+
+// ContactWriteOptions controls how alternate contacts are written
+type ContactWriteOptions struct {
+	Overwrite         bool   // Replace existing contacts that differ from the resolved ones
+	MaxConcurrency    int    // Accounts processed at once (default: DefaultContactMaxConcurrency)
+	RequestsPerSecond int    // Account and Organizations API calls per second, shared by all workers (default: DefaultContactRequestsPerSecond)
+	CheckpointFile    string // Progress file (default: DefaultCheckpointFile in the config path)
+	Resume            bool   // Skip the accounts the checkpoint records as finished
+	BackupLocation    string // s3://bucket/prefix the pre-change backup is also uploaded to
+	DryRun            bool   // Report what a restore would change without changing it
+}
+
+// AccountResult is the outcome of one account in an alternate contact run. An organization that
+// could not be reached has one result without an account ID.
+type AccountResult struct {
+	Organization string            `json:"organization"`
+	AccountID    string            `json:"account_id"`
+	AccountName  string            `json:"account_name,omitempty"`
+	Status       string            `json:"status"`
+	Contacts     map[string]string `json:"contacts,omitempty"` // Outcome by contact type
+	Error        string            `json:"error,omitempty"`
+	FinishedAt   time.Time         `json:"finished_at"`
+}
+
+// ContactRunSummary is the per-account outcome of an alternate contact run
+type ContactRunSummary struct {
+	Action         string
+	DryRun         bool
+	BackupFile     string // Contacts as they were before the run
+	BackupURI      string
+	CheckpointFile string
+	Results        []AccountResult
+	Counts         map[string]int
+	Duration       time.Duration
+}
+
+// Failed returns the number of accounts (or organizations) that failed
+func (s *ContactRunSummary) Failed() int {
+	return s.Counts[AccountFailed]
+}
+
+// AlternateContactWriter reads, writes and deletes alternate contacts; *account.Client satisfies it
+type AlternateContactWriter interface {
+	AlternateContactReader
+	PutAlternateContact(ctx context.Context, params *account.PutAlternateContactInput, optFns ...func(*account.Options)) (*account.PutAlternateContactOutput, error)
+	DeleteAlternateContact(ctx context.Context, params *account.DeleteAlternateContactInput, optFns ...func(*account.Options)) (*account.DeleteAlternateContactOutput, error)
+}
+
+// accountTask is one account of a run, with the clients of its organization
+type accountTask struct {
+	org      string
+	account  organizationsTypes.Account
+	client   AlternateContactWriter
+	resolver *ContactResolver
+}
+
+// accountOperation does a run's work on one account, returning the outcome of each contact type
+type accountOperation func(run *contactRun, task accountTask) (map[string]string, error)
+
+// contactRun is the state shared by the workers of a run
+type contactRun struct {
+	options    ContactWriteOptions
+	checkpoint *ContactCheckpoint
+	limiter    *types.RateLimiter
+	retry      awsutils.RetryConfig
+	logger     *slog.Logger
+
+	backupDir  string // Where the pre-change backup is written
+	backupFile string
+	backupURI  string
+}
+
+// newContactRun applies option defaults and starts the shared rate limiter; call stop when done
+func newContactRun(options ContactWriteOptions, checkpoint *ContactCheckpoint, logger *slog.Logger) *contactRun {
+	if options.MaxConcurrency <= 0 {
+		options.MaxConcurrency = DefaultContactMaxConcurrency
+	}
+	if options.RequestsPerSecond <= 0 {
+		options.RequestsPerSecond = DefaultContactRequestsPerSecond
+	}
+	return &contactRun{
+		options:    options,
+		checkpoint: checkpoint,
+		limiter:    awsutils.NewRateLimiter(options.RequestsPerSecond),
+		retry:      awsutils.DefaultRetryConfig(),
+		logger:     logger,
+		backupDir:  config.GetConfigPath(),
+	}
+}
+
+func (r *contactRun) stop() {
+	r.limiter.Stop()
+}
+
+// call waits for the shared rate limiter before each attempt, and retries throttling and
+// transient errors with backoff
+func (r *contactRun) call(operation func() error) error {
+	return awsutils.RetryWithBackoff(context.Background(), func() error {
+		r.limiter.Wait()
+		return operation()
+	}, r.retry, r.logger)
+}
+
+// record stores a result in the checkpoint; failing to save progress is logged, not fatal, as it
+// only costs a resumed run some repeated work
+func (r *contactRun) record(result AccountResult) {
+	result.FinishedAt = time.Now().UTC()
+	if err := r.checkpoint.Record(result); err != nil {
+		r.logger.Warn("failed to write checkpoint", "error", err)
+	}
+}
+
+// runAccounts backs up the contacts of the accounts still to be processed, then processes every
+// account. Nothing is changed if the backup fails. Dry runs change nothing, so take no backup.
+func (r *contactRun) runAccounts(action string, tasks []accountTask, operation accountOperation) ([]AccountResult, error) {
+	if !r.options.DryRun {
+		var pending []accountTask
+		for _, task := range tasks {
+			if _, ok := r.checkpoint.Finished(task.org, aws.ToString(task.account.Id)); r.options.Resume && ok {
+				continue
+			}
+			pending = append(pending, task)
+		}
+		if len(pending) > 0 {
+			if err := r.backupAccounts(action, pending); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r.processAccounts(tasks, operation), nil
+}
+
+// processAccounts runs the operation on every account in the worker pool and returns one result per
+// account, including the ones resumed from the checkpoint
+func (r *contactRun) processAccounts(tasks []accountTask, operation accountOperation) []AccountResult {
+	var results []AccountResult
+	pending := make(map[string]accountTask)
+	var keys []string
+	names := make(map[string]string)
+
+	for _, task := range tasks {
+		accountId := aws.ToString(task.account.Id)
+		if previous, ok := r.checkpoint.Finished(task.org, accountId); r.options.Resume && ok {
+			previous.Status = AccountResumed
+			results = append(results, previous)
+			continue
+		}
+		key := checkpointKey(task.org, accountId)
+		pending[key] = task
+		keys = append(keys, key)
+		names[key] = aws.ToString(task.account.Name)
+	}
+	if len(results) > 0 {
+		r.logger.Info("resuming from checkpoint", "finished_accounts", len(results), "remaining_accounts", len(keys))
+	}
+
+	poolResults := concurrent.ProcessCustomersConcurrently(keys, names, func(key string) (interface{}, error) {
+		task := pending[key]
+		result := AccountResult{
+			Organization: task.org,
+			AccountID:    aws.ToString(task.account.Id),
+			AccountName:  aws.ToString(task.account.Name),
+			Status:       AccountSucceeded,
+		}
+
+		contacts, err := operation(r, task)
+		result.Contacts = contacts
+		if err != nil {
+			result.Status = AccountFailed
+			result.Error = err.Error()
+			r.logger.Error("account failed", "organization", result.Organization, "account_id", result.AccountID, "contacts", contacts, "error", err)
+		} else {
+			r.logger.Info("account processed", "organization", result.Organization, "account_id", result.AccountID, "contacts", contacts)
+		}
+		r.record(result)
+		return result, err
+	}, r.options.MaxConcurrency)
+
+	for _, poolResult := range poolResults {
+		if result, ok := poolResult.Data.(AccountResult); ok {
+			results = append(results, result)
+			continue
+		}
+		// Failed or panicked operations return no data, so take their result from the checkpoint
+		task := pending[poolResult.CustomerCode]
+		result := AccountResult{
+			Organization: task.org,
+			AccountID:    aws.ToString(task.account.Id),
+			AccountName:  aws.ToString(task.account.Name),
+			Status:       AccountFailed,
+		}
+		if recorded, ok := r.checkpoint.Result(task.org, result.AccountID); ok {
+			result = recorded
+		} else if poolResult.Error != nil {
+			result.Error = poolResult.Error.Error()
+			r.record(result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// organizationTasks connects to each organization and lists its active accounts. Organizations
+// that cannot be reached are returned as failed results.
+func (r *contactRun) organizationTasks(orgs []types.Organization, orgConfig []types.Organization, contactConfig types.AlternateContactConfig) ([]accountTask, []AccountResult) {
+	var tasks []accountTask
+	var failed []AccountResult
+	for _, org := range orgs {
+		r.logger.Info("connecting to organization", "organization", org.Prefix, "name", org.FriendlyName)
+
+		cfg, err := ManagementAccountConfig(org.Prefix, orgConfig)
+		if err != nil {
+			failed = append(failed, r.organizationFailed(org.Prefix, err))
+			continue
+		}
+		orgClient := organizations.NewFromConfig(cfg)
+
+		var accounts []organizationsTypes.Account
+		err = r.call(func() error {
+			var err error
+			accounts, err = awsutils.GetAllAccountsInOrganization(orgClient)
+			return err
+		})
+		if err != nil {
+			failed = append(failed, r.organizationFailed(org.Prefix, err))
+			continue
+		}
+
+		accountClient := account.NewFromConfig(cfg)
+		// Each OU and tag lookup waits for the shared limiter, like every other request of the run
+		resolver := NewContactResolver(contactConfig, org.Prefix, orgClient)
+		resolver.call = r.call
+		active := 0
+		for _, acct := range accounts {
+			// Suspended accounts cannot be changed
+			if acct.Status != organizationsTypes.AccountStatusActive {
+				r.logger.Info("skipping inactive account", "organization", org.Prefix, "account_id", aws.ToString(acct.Id), "status", acct.Status)
+				continue
+			}
+			tasks = append(tasks, accountTask{org: org.Prefix, account: acct, client: accountClient, resolver: resolver})
+			active++
+		}
+		r.logger.Info("listed organization accounts", "organization", org.Prefix, "accounts", len(accounts), "active", active)
+	}
+	return tasks, failed
+}
+
+// organizationFailed records an organization that could not be reached
+func (r *contactRun) organizationFailed(orgPrefix string, err error) AccountResult {
+	r.logger.Error("failed to connect to organization", "organization", orgPrefix, "error", err)
+	result := AccountResult{Organization: orgPrefix, Status: AccountFailed, Error: err.Error()}
+	r.record(result)
+	return result
+}
+
+// finish summarizes the run and removes the checkpoint if nothing is left to resume
+func (r *contactRun) finish(action string, results []AccountResult, started time.Time) *ContactRunSummary {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Organization != results[j].Organization {
+			return results[i].Organization < results[j].Organization
+		}
+		return results[i].AccountID < results[j].AccountID
+	})
+
+	summary := &ContactRunSummary{
+		Action:         action,
+		DryRun:         r.options.DryRun,
+		BackupFile:     r.backupFile,
+		BackupURI:      r.backupURI,
+		CheckpointFile: r.checkpoint.Path(),
+		Results:        results,
+		Counts:         make(map[string]int),
+		Duration:       time.Since(started),
+	}
+	for _, result := range results {
+		summary.Counts[result.Status]++
+	}
+
+	if summary.Failed() == 0 {
+		if err := r.checkpoint.Remove(); err != nil {
+			r.logger.Warn("failed to remove checkpoint", "error", err)
+		}
+		summary.CheckpointFile = ""
+	}
+	return summary
+}
+
+// setAccountContacts sets the contacts resolved for an account
+func setAccountContacts(r *contactRun, task accountTask) (map[string]string, error) {
+	accountId := aws.ToString(task.account.Id)
+
+	resolved, err := task.resolver.Resolve(accountId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve contacts: %w", err)
+	}
+
+	outcomes := make(map[string]string)
+	var errs []error
+	for _, contactType := range alternateContactTypes {
+		contact, ok := resolved[contactType]
+		if !ok {
+			continue
+		}
+		name := strings.ToLower(string(contactType))
+		outcome, err := r.applyContact(task.client, accountId, contactType, contact.ContactValues)
+		if err != nil {
+			outcomes[name] = ContactFailed
+			errs = append(errs, fmt.Errorf("%s contact: %w", name, err))
+			continue
+		}
+		if contact.Source != GlobalContactSource {
+			outcome += " (" + contact.Source + ")"
+		}
+		outcomes[name] = outcome
+	}
+	return outcomes, errors.Join(errs...)
+}
+
+// applyContact sets one contact unless the account already has it, or has a different one and
+// overwrite is off
+func (r *contactRun) applyContact(client AlternateContactWriter, accountId string, contactType accountTypes.AlternateContactType, want ContactValues) (string, error) {
+	var current *accountTypes.AlternateContact
+	err := r.call(func() error {
+		var err error
+		current, err = GetAlternateContact(client, accountId, contactType)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	outcome := ContactAdded
+	if current != nil {
+		if len(DivergentFields(want, contactValues(current))) == 0 {
+			return ContactUnchanged, nil
+		}
+		if !r.options.Overwrite {
+			return ContactKept, nil
+		}
+		outcome = ContactUpdated
+	}
+
+	err = r.call(func() error {
+		return SetAlternateContact(client, accountId, contactType, want.Name, want.Title, want.Email, want.Phone)
+	})
+	if err != nil {
+		return "", err
+	}
+	return outcome, nil
+}
+
+// deleteAccountContacts returns an operation deleting the given contact types
+func deleteAccountContacts(contactTypes []accountTypes.AlternateContactType) accountOperation {
+	return func(r *contactRun, task accountTask) (map[string]string, error) {
+		accountId := aws.ToString(task.account.Id)
+		outcomes := make(map[string]string)
+		var errs []error
+		for _, contactType := range contactTypes {
+			name := strings.ToLower(string(contactType))
+
+			var current *accountTypes.AlternateContact
+			err := r.call(func() error {
+				var err error
+				current, err = GetAlternateContact(task.client, accountId, contactType)
+				return err
+			})
+			if err == nil && current == nil {
+				outcomes[name] = ContactAbsent
+				continue
+			}
+			if err == nil {
+				err = r.call(func() error {
+					return DeleteAlternateContact(task.client, accountId, contactType)
+				})
+			}
+			if err != nil {
+				outcomes[name] = ContactFailed
+				errs = append(errs, fmt.Errorf("%s contact: %w", name, err))
+				continue
+			}
+			outcomes[name] = ContactDeleted
+		}
+		return outcomes, errors.Join(errs...)
+	}
+}
+
+// ParseContactTypes parses a comma-separated list of contact types (security, billing, operations)
+func ParseContactTypes(list string) ([]accountTypes.AlternateContactType, error) {
+	var contactTypes []accountTypes.AlternateContactType
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		contactType := accountTypes.AlternateContactType(strings.ToUpper(name))
+		found := false
+		for _, known := range alternateContactTypes {
+			found = found || known == contactType
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid contact type: %s (use security, billing or operations)", name)
+		}
+		contactTypes = append(contactTypes, contactType)
+	}
+	if len(contactTypes) == 0 {
+		return nil, fmt.Errorf("no contact types specified")
+	}
+	return contactTypes, nil
+}
+
+// contactValues returns the fields of an alternate contact read from an account
+func contactValues(contact *accountTypes.AlternateContact) ContactValues {
+	return ContactValues{
+		Name:  aws.ToString(contact.Name),
+		Title: aws.ToString(contact.Title),
+		Email: aws.ToString(contact.EmailAddress),
+		Phone: aws.ToString(contact.PhoneNumber),
+	}
+}
+
+// DisplayContactRunSummary prints each account's outcome and the totals of a run
+func DisplayContactRunSummary(summary *ContactRunSummary) {
+	fmt.Println()
+	fmt.Printf("=" + strings.Repeat("=", 70) + "\n")
+	title := strings.ToUpper(summary.Action)
+	if summary.DryRun {
+		title += " (DRY RUN)"
+	}
+	fmt.Printf("📊 ALTERNATE CONTACT %s SUMMARY\n", title)
+	fmt.Printf("=" + strings.Repeat("=", 70) + "\n")
+
+	for _, result := range summary.Results {
+		status := "✅"
+		switch result.Status {
+		case AccountFailed:
+			status = "❌"
+		case AccountResumed:
+			status = "⏭️ "
+		}
+
+		label := result.Organization
+		if result.AccountID != "" {
+			label += " " + result.AccountID
+		}
+		if result.AccountName != "" {
+			label += " (" + result.AccountName + ")"
+		}
+
+		var contacts []string
+		for _, contactType := range alternateContactTypes {
+			name := strings.ToLower(string(contactType))
+			if outcome, ok := result.Contacts[name]; ok {
+				contacts = append(contacts, name+": "+outcome)
+			}
+		}
+		fmt.Printf("%s %s: %s %s\n", status, label, result.Status, strings.Join(contacts, ", "))
+		if result.Error != "" {
+			fmt.Printf("   Error: %s\n", result.Error)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Total accounts: %d\n", len(summary.Results))
+	fmt.Printf("✅ Succeeded: %d\n", summary.Counts[AccountSucceeded])
+	fmt.Printf("❌ Failed: %d\n", summary.Counts[AccountFailed])
+	fmt.Printf("⏭️  Resumed from checkpoint: %d\n", summary.Counts[AccountResumed])
+	fmt.Printf("⏱️  Total time: %.2fs\n", summary.Duration.Seconds())
+	if summary.BackupFile != "" {
+		fmt.Printf("\n💾 Contacts before the %s backed up to %s\n", summary.Action, summary.BackupFile)
+		if summary.BackupURI != "" {
+			fmt.Printf("   Uploaded to %s\n", summary.BackupURI)
+		}
+		fmt.Printf("   Undo with --action restore --backup-file %s\n", summary.BackupFile)
+	}
+	if summary.CheckpointFile != "" {
+		fmt.Printf("\nProgress saved to %s; rerun with --resume to retry the failed accounts\n", summary.CheckpointFile)
+	}
+	fmt.Printf("=" + strings.Repeat("=", 70) + "\n")
+}
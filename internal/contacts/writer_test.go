@@ -0,0 +1,239 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/account"
+	accountTypes "github.com/aws/aws-sdk-go-v2/service/account/types"
+	organizationsTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/smithy-go"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// fakeContactWriter stores alternate contacts by account and type. Accounts in failing reject
+// every call; throttles is the number of calls throttled before any succeed.
+type fakeContactWriter struct {
+	mu        sync.Mutex
+	contacts  map[string]map[accountTypes.AlternateContactType]*accountTypes.AlternateContact
+	failing   map[string]bool
+	throttles int
+	puts      int
+}
+
+func newFakeContactWriter() *fakeContactWriter {
+	return &fakeContactWriter{
+		contacts: make(map[string]map[accountTypes.AlternateContactType]*accountTypes.AlternateContact),
+		failing:  make(map[string]bool),
+	}
+}
+
+func (f *fakeContactWriter) check(accountId *string) error {
+	if f.throttles > 0 {
+		f.throttles--
+		return &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	}
+	if f.failing[aws.ToString(accountId)] {
+		return &smithy.GenericAPIError{Code: "AccessDeniedException", Message: "not authorized"}
+	}
+	return nil
+}
+
+func (f *fakeContactWriter) GetAlternateContact(ctx context.Context, params *account.GetAlternateContactInput, optFns ...func(*account.Options)) (*account.GetAlternateContactOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check(params.AccountId); err != nil {
+		return nil, err
+	}
+	contact, ok := f.contacts[aws.ToString(params.AccountId)][params.AlternateContactType]
+	if !ok {
+		return nil, &accountTypes.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &account.GetAlternateContactOutput{AlternateContact: contact}, nil
+}
+
+func (f *fakeContactWriter) PutAlternateContact(ctx context.Context, params *account.PutAlternateContactInput, optFns ...func(*account.Options)) (*account.PutAlternateContactOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check(params.AccountId); err != nil {
+		return nil, err
+	}
+	f.puts++
+	f.set(aws.ToString(params.AccountId), params.AlternateContactType, ContactValues{
+		Name: aws.ToString(params.Name), Title: aws.ToString(params.Title), Email: aws.ToString(params.EmailAddress), Phone: aws.ToString(params.PhoneNumber),
+	})
+	return &account.PutAlternateContactOutput{}, nil
+}
+
+func (f *fakeContactWriter) DeleteAlternateContact(ctx context.Context, params *account.DeleteAlternateContactInput, optFns ...func(*account.Options)) (*account.DeleteAlternateContactOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.check(params.AccountId); err != nil {
+		return nil, err
+	}
+	delete(f.contacts[aws.ToString(params.AccountId)], params.AlternateContactType)
+	return &account.DeleteAlternateContactOutput{}, nil
+}
+
+func (f *fakeContactWriter) set(accountId string, contactType accountTypes.AlternateContactType, values ContactValues) {
+	if f.contacts[accountId] == nil {
+		f.contacts[accountId] = make(map[accountTypes.AlternateContactType]*accountTypes.AlternateContact)
+	}
+	f.contacts[accountId][contactType] = &accountTypes.AlternateContact{
+		Name: aws.String(values.Name), Title: aws.String(values.Title), EmailAddress: aws.String(values.Email), PhoneNumber: aws.String(values.Phone),
+	}
+}
+
+// newTestContactRun starts a run with fast retries and a quiet logger
+func newTestContactRun(t *testing.T, options ContactWriteOptions, checkpoint *ContactCheckpoint) *contactRun {
+	options.RequestsPerSecond = 1000
+	run := newContactRun(options, checkpoint, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	run.retry.InitialDelay = time.Millisecond
+	run.retry.MaxDelay = time.Millisecond
+	t.Cleanup(run.stop)
+	return run
+}
+
+func testAccountTasks(client AlternateContactWriter, contactConfig types.AlternateContactConfig, accountIds ...string) []accountTask {
+	var tasks []accountTask
+	resolver := NewContactResolver(contactConfig, "hts", nil)
+	for _, id := range accountIds {
+		tasks = append(tasks, accountTask{org: "hts", account: organizationsTypes.Account{Id: aws.String(id), Name: aws.String("acct-" + id)}, client: client, resolver: resolver})
+	}
+	return tasks
+}
+
+func TestApplyContact(t *testing.T) {
+	want := ContactValues{Name: "Security Team", Title: "SecOps", Email: "security@example.com", Phone: "+15550100000"}
+	client := newFakeContactWriter()
+	client.set("111111111111", accountTypes.AlternateContactTypeSecurity, ContactValues{Name: "Security Team", Title: "SecOps", Email: "SECURITY@example.com", Phone: "+1 555 010 0000"})
+	client.set("222222222222", accountTypes.AlternateContactTypeSecurity, ContactValues{Name: "Jane Doe", Email: "jane@example.com"})
+
+	tests := []struct {
+		name      string
+		accountId string
+		overwrite bool
+		want      string
+	}{
+		{"missing", "333333333333", false, ContactAdded},
+		{"matching", "111111111111", true, ContactUnchanged},
+		{"different without overwrite", "222222222222", false, ContactKept},
+		{"different with overwrite", "222222222222", true, ContactUpdated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := newTestContactRun(t, ContactWriteOptions{Overwrite: tt.overwrite}, nil)
+			outcome, err := run.applyContact(client, tt.accountId, accountTypes.AlternateContactTypeSecurity, want)
+			if err != nil {
+				t.Fatalf("applyContact failed: %v", err)
+			}
+			if outcome != tt.want {
+				t.Errorf("outcome = %s, want %s", outcome, tt.want)
+			}
+		})
+	}
+	if client.puts != 2 {
+		t.Errorf("Expected 2 writes, got %d", client.puts)
+	}
+}
+
+func TestContactRunRetriesThrottling(t *testing.T) {
+	client := newFakeContactWriter()
+	client.throttles = 2
+
+	run := newTestContactRun(t, ContactWriteOptions{}, nil)
+	outcome, err := run.applyContact(client, "111111111111", accountTypes.AlternateContactTypeBilling, ContactValues{Email: "billing@example.com"})
+	if err != nil || outcome != ContactAdded {
+		t.Fatalf("Expected the contact to be added after throttling, got %s, %v", outcome, err)
+	}
+}
+
+func TestProcessAccountsCheckpointAndResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	contactConfig := types.AlternateContactConfig{SecurityName: "Security Team", SecurityEmail: "security@example.com"}
+	client := newFakeContactWriter()
+	client.failing["222222222222"] = true
+	tasks := testAccountTasks(client, contactConfig, "111111111111", "222222222222")
+
+	checkpoint, err := OpenContactCheckpoint(path, ContactActionSet, "hash", false)
+	if err != nil {
+		t.Fatalf("OpenContactCheckpoint failed: %v", err)
+	}
+	run := newTestContactRun(t, ContactWriteOptions{MaxConcurrency: 2}, checkpoint)
+	summary := run.finish(ContactActionSet, run.processAccounts(tasks, setAccountContacts), time.Now())
+
+	if summary.Counts[AccountSucceeded] != 1 || summary.Failed() != 1 {
+		t.Fatalf("Unexpected counts: %v", summary.Counts)
+	}
+	if got := summary.Results[0].Contacts; !reflect.DeepEqual(got, map[string]string{"security": ContactAdded}) {
+		t.Errorf("Contacts = %v", got)
+	}
+	if summary.Results[1].Status != AccountFailed || summary.Results[1].Error == "" {
+		t.Errorf("Expected the second account to fail, got %+v", summary.Results[1])
+	}
+	if summary.CheckpointFile != path {
+		t.Fatalf("Expected the checkpoint to be kept, got %q", summary.CheckpointFile)
+	}
+
+	// A different run does not resume the checkpoint
+	if _, err := OpenContactCheckpoint(path, ContactActionSet, "other", true); err == nil {
+		t.Error("Expected an error resuming a checkpoint with a different hash")
+	}
+
+	client.failing["222222222222"] = false
+	checkpoint, err = OpenContactCheckpoint(path, ContactActionSet, "hash", true)
+	if err != nil {
+		t.Fatalf("OpenContactCheckpoint failed: %v", err)
+	}
+	run = newTestContactRun(t, ContactWriteOptions{Resume: true}, checkpoint)
+	summary = run.finish(ContactActionSet, run.processAccounts(tasks, setAccountContacts), time.Now())
+
+	var statuses []string
+	for _, result := range summary.Results {
+		statuses = append(statuses, result.Status)
+	}
+	if want := []string{AccountResumed, AccountSucceeded}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Statuses = %v, want %v", statuses, want)
+	}
+	if client.puts != 2 {
+		t.Errorf("Expected each account written once, got %d writes", client.puts)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) || summary.CheckpointFile != "" {
+		t.Errorf("Expected the checkpoint to be removed after a clean run, got %v", err)
+	}
+}
+
+func TestDeleteAccountContacts(t *testing.T) {
+	client := newFakeContactWriter()
+	client.set("111111111111", accountTypes.AlternateContactTypeBilling, ContactValues{Email: "billing@example.com"})
+
+	contactTypes, err := ParseContactTypes("billing, operations")
+	if err != nil {
+		t.Fatalf("ParseContactTypes failed: %v", err)
+	}
+	checkpoint, _ := OpenContactCheckpoint(filepath.Join(t.TempDir(), "checkpoint.json"), ContactActionDelete, "hash", false)
+	run := newTestContactRun(t, ContactWriteOptions{}, checkpoint)
+
+	results := run.processAccounts(testAccountTasks(client, types.AlternateContactConfig{}, "111111111111"), deleteAccountContacts(contactTypes))
+	if want := map[string]string{"billing": ContactDeleted, "operations": ContactAbsent}; !reflect.DeepEqual(results[0].Contacts, want) {
+		t.Errorf("Contacts = %v, want %v", results[0].Contacts, want)
+	}
+
+	if _, err := ParseContactTypes("security,finance"); err == nil {
+		t.Error("Expected an error for an unknown contact type")
+	}
+	if _, err := ParseContactTypes(""); err == nil {
+		t.Error("Expected an error for no contact types")
+	}
+}
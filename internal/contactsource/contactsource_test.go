@@ -0,0 +1,204 @@
+package contactsource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func groupsOf(data *aws.IdentityCenterData, email string) []string {
+	for _, membership := range data.Memberships {
+		if membership.Email == email {
+			return membership.Groups
+		}
+	}
+	return nil
+}
+
+func TestSCIMSourceRetrieve(t *testing.T) {
+	inactive := false
+	users := []scimUser{
+		{ID: "1", UserName: "jane@example.com", Emails: []scimMultiValue{{Value: "jane.work@example.com", Primary: true}, {Value: "jane@example.com"}}, PhoneNumbers: []scimMultiValue{{Value: "+15555550100"}}},
+		{ID: "2", UserName: "bob@example.com", Active: &inactive, Groups: []scimMultiValue{{Value: "g9", Display: "okta-only"}}},
+		{ID: "3", UserName: "svc-account"},
+	}
+	users[0].Name.GivenName, users[0].Name.FamilyName = "Jane", "Doe"
+	groups := []scimGroup{
+		{ID: "g1", DisplayName: "ccoe-cloud-hts-prod-admin", Members: []scimMultiValue{{Value: "1"}, {Value: "2"}}},
+		{ID: "g2", DisplayName: "ccoe-cloud-hts-prod-security", Members: []scimMultiValue{{Value: "1"}}},
+	}
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		start, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+
+		// One resource per page to exercise paging
+		switch r.URL.Path {
+		case "/scim/v2/Users":
+			page := scimListResponse[scimUser]{TotalResults: len(users), StartIndex: start}
+			if start <= len(users) {
+				page.Resources = users[start-1 : start]
+			}
+			json.NewEncoder(w).Encode(page)
+		case "/scim/v2/Groups":
+			json.NewEncoder(w).Encode(scimListResponse[scimGroup]{TotalResults: len(groups), Resources: groups})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	data, err := NewSCIMSource(server.URL+"/scim/v2/", "secret").Retrieve(context.Background(), &aws.DefaultLogger{})
+	if err != nil {
+		t.Fatalf("retrieve failed: %v", err)
+	}
+
+	if authorization != "Bearer secret" {
+		t.Errorf("authorization = %q", authorization)
+	}
+	if len(data.Users) != 2 {
+		t.Fatalf("got %d users, want 2 (user without email skipped): %+v", len(data.Users), data.Users)
+	}
+
+	jane := data.Users[0]
+	if jane.Email != "jane.work@example.com" || jane.DisplayName != "Jane Doe" || jane.PhoneNumber != "+15555550100" || !jane.Active {
+		t.Errorf("unexpected user: %+v", jane)
+	}
+	if data.Users[1].Active {
+		t.Errorf("expected bob to be inactive")
+	}
+
+	if got := groupsOf(data, "jane.work@example.com"); !reflect.DeepEqual(got, []string{"ccoe-cloud-hts-prod-admin", "ccoe-cloud-hts-prod-security"}) {
+		t.Errorf("jane's groups = %v", got)
+	}
+	if got := groupsOf(data, "bob@example.com"); !reflect.DeepEqual(got, []string{"okta-only", "ccoe-cloud-hts-prod-admin"}) {
+		t.Errorf("bob's groups = %v", got)
+	}
+	if data.InstanceID != instanceID("scim", server.Listener.Addr().String()) {
+		t.Errorf("instance ID = %s", data.InstanceID)
+	}
+}
+
+func TestFileSourceCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entra-export.csv")
+	content := "\xef\xbb\xbfdisplayName,mail,groupName,accountEnabled\n" +
+		"Jane Doe,jane@example.com,ccoe-cloud-hts-prod-admin,true\n" +
+		"Jane Doe,JANE@example.com,ccoe-cloud-hts-prod-security,true\n" +
+		"Bob,bob@example.com,ccoe-cloud-hts-prod-admin,false\n" +
+		"No Mail,,ccoe-cloud-hts-prod-admin,true\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := (&FileSource{Path: path}).Retrieve(context.Background(), &aws.DefaultLogger{})
+	if err != nil {
+		t.Fatalf("retrieve failed: %v", err)
+	}
+
+	if len(data.Users) != 2 {
+		t.Fatalf("got %d users, want 2: %+v", len(data.Users), data.Users)
+	}
+	if data.Users[0].UserId != "jane@example.com" || data.Users[0].DisplayName != "Jane Doe" || data.Users[0].UserName != "jane@example.com" {
+		t.Errorf("unexpected user: %+v", data.Users[0])
+	}
+	if data.Users[1].Active {
+		t.Errorf("expected bob to be inactive")
+	}
+	if got := groupsOf(data, "jane@example.com"); !reflect.DeepEqual(got, []string{"ccoe-cloud-hts-prod-admin", "ccoe-cloud-hts-prod-security"}) {
+		t.Errorf("jane's groups = %v", got)
+	}
+	if data.InstanceID != "file-entra-export" {
+		t.Errorf("instance ID = %s", data.InstanceID)
+	}
+}
+
+func TestFileSourceJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "okta.export")
+	content := `[{"email": "jane@example.com", "given_name": "Jane", "family_name": "Doe", "groups": ["ccoe-cloud-hts-prod-admin"]}]`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := (&FileSource{Path: path, Format: "json"}).Retrieve(context.Background(), &aws.DefaultLogger{})
+	if err != nil {
+		t.Fatalf("retrieve failed: %v", err)
+	}
+	if len(data.Users) != 1 || data.Users[0].DisplayName != "Jane Doe" || !data.Users[0].Active {
+		t.Fatalf("unexpected users: %+v", data.Users)
+	}
+	if got := groupsOf(data, "jane@example.com"); !reflect.DeepEqual(got, []string{"ccoe-cloud-hts-prod-admin"}) {
+		t.Errorf("groups = %v", got)
+	}
+}
+
+func TestFileSourceCSVRequiresEmail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.csv")
+	if err := os.WriteFile(path, []byte("name,group\nJane,admins\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&FileSource{Path: path}).Retrieve(context.Background(), &aws.DefaultLogger{}); err == nil {
+		t.Error("expected an error for a CSV without an email column")
+	}
+}
+
+func TestSyncComputesDeltaFromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.csv")
+	store := &aws.FileSnapshotStore{Dir: dir}
+	ctx := context.Background()
+
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("email,group\njane@example.com,admins\n")
+	sync, err := Sync(ctx, &FileSource{Path: path}, "hts", store, false, &aws.DefaultLogger{})
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if !sync.Full || sync.Reason != "no previous snapshot" {
+		t.Fatalf("expected a full first sync, got %+v", sync)
+	}
+	if err := store.Save(ctx, sync.Snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	write("email,group\njane@example.com,admins\nbob@example.com,admins\n")
+	sync, err = Sync(ctx, &FileSource{Path: path}, "hts", store, false, &aws.DefaultLogger{})
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if sync.Full || sync.Delta == nil || sync.Delta.Count(aws.UserJoined) != 1 || len(sync.Delta.Changes) != 1 {
+		t.Fatalf("expected bob to join, got %+v", sync.Delta)
+	}
+
+	sync, err = Sync(ctx, &FileSource{Path: path}, "hts", store, true, &aws.DefaultLogger{})
+	if err != nil || !sync.Full || sync.Delta != nil {
+		t.Fatalf("expected --full-resync to skip the delta, got %+v, %v", sync, err)
+	}
+}
+
+func TestNewRequiresRoleForIdentityCenter(t *testing.T) {
+	if _, err := New(context.Background(), nil, "", 1, 1); err == nil {
+		t.Error("expected an error without an Identity Center role")
+	}
+	source, err := New(context.Background(), &types.ContactSourceConfig{Type: types.ContactSourceFile, Path: "export.csv"}, "", 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := source.(*FileSource); !ok {
+		t.Errorf("expected a file source, got %T", source)
+	}
+}
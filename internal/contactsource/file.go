@@ -0,0 +1,282 @@
+package contactsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// FileSource reads users and group memberships from a JSON or CSV export, such as an Entra ID
+// or Okta group membership export. The path can be local or s3://bucket/key.
+//
+// JSON exports are an array of users, each with its groups:
+//
+//	[{"email": "jane@example.com", "display_name": "Jane Doe", "groups": ["ccoe-cloud-hts-prod-admin"]}]
+//
+// CSV exports need an email column. A groups column holds semicolon-separated group names, and
+// a group column holds one group per row, so a plain group-member export with a row per
+// membership works as is. Rows for the same email are merged.
+type FileSource struct {
+	Path   string
+	Format string // json or csv; defaults to the file extension
+}
+
+// fileRecord is one user in a JSON export
+type fileRecord struct {
+	UserID      string   `json:"user_id"`
+	UserName    string   `json:"user_name"`
+	DisplayName string   `json:"display_name"`
+	Email       string   `json:"email"`
+	GivenName   string   `json:"given_name"`
+	FamilyName  string   `json:"family_name"`
+	PhoneNumber string   `json:"phone_number"`
+	Active      *bool    `json:"active"` // Defaults to true
+	Groups      []string `json:"groups"`
+}
+
+// csvColumns maps accepted CSV header names, lower-cased, to fileRecord fields
+var csvColumns = map[string]string{
+	"user_id":           "user_id",
+	"id":                "user_id",
+	"user_name":         "user_name",
+	"username":          "user_name",
+	"userprincipalname": "user_name",
+	"display_name":      "display_name",
+	"displayname":       "display_name",
+	"name":              "display_name",
+	"email":             "email",
+	"mail":              "email",
+	"given_name":        "given_name",
+	"givenname":         "given_name",
+	"family_name":       "family_name",
+	"surname":           "family_name",
+	"phone_number":      "phone_number",
+	"phone":             "phone_number",
+	"mobilephone":       "phone_number",
+	"active":            "active",
+	"accountenabled":    "active",
+	"groups":            "groups",
+	"group":             "groups",
+	"group_name":        "groups",
+	"groupname":         "groups",
+}
+
+// Name describes the source in logs
+func (s *FileSource) Name() string {
+	return "file (" + s.Path + ")"
+}
+
+// Retrieve reads and parses the export
+func (s *FileSource) Retrieve(ctx context.Context, logger aws.Logger) (*aws.IdentityCenterData, error) {
+	content, err := readSourceFile(ctx, s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []fileRecord
+	switch s.format() {
+	case "json":
+		if err := json.Unmarshal(content, &records); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", s.Path, err)
+		}
+	case "csv":
+		if records, err = parseCSVRecords(content); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", s.Path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported contact file format %q (expected json or csv)", s.format())
+	}
+
+	data := recordsToData(records, logger)
+	data.InstanceID = instanceID("file", strings.TrimSuffix(filepath.Base(s.Path), filepath.Ext(s.Path)))
+	return data, nil
+}
+
+func (s *FileSource) format() string {
+	if s.Format != "" {
+		return strings.ToLower(s.Format)
+	}
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(s.Path)), ".")
+}
+
+// parseCSVRecords reads a CSV export with a header row, one record per row
+func parseCSVRecords(content []byte) ([]fileRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	fields := make([]string, len(header))
+	hasEmail := false
+	for i, name := range header {
+		fields[i] = csvColumns[strings.ToLower(strings.TrimSpace(name))]
+		hasEmail = hasEmail || fields[i] == "email"
+	}
+	if !hasEmail {
+		return nil, fmt.Errorf("no email column in header: %s", strings.Join(header, ","))
+	}
+
+	var records []fileRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var record fileRecord
+		for i, value := range row {
+			if i >= len(fields) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch fields[i] {
+			case "user_id":
+				record.UserID = value
+			case "user_name":
+				record.UserName = value
+			case "display_name":
+				record.DisplayName = value
+			case "email":
+				record.Email = value
+			case "given_name":
+				record.GivenName = value
+			case "family_name":
+				record.FamilyName = value
+			case "phone_number":
+				record.PhoneNumber = value
+			case "active":
+				if value != "" {
+					active := parseActive(value)
+					record.Active = &active
+				}
+			case "groups":
+				record.Groups = append(record.Groups, strings.Split(value, ";")...)
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// parseActive treats false, 0, no, inactive, disabled and suspended as inactive
+func parseActive(value string) bool {
+	switch strings.ToLower(value) {
+	case "false", "0", "no", "inactive", "disabled", "suspended", "deprovisioned":
+		return false
+	}
+	return true
+}
+
+// recordsToData merges records by email into users and memberships. Records without an email
+// are skipped; users without an ID are keyed by their lower-cased email.
+func recordsToData(records []fileRecord, logger aws.Logger) *aws.IdentityCenterData {
+	var users []types.IdentityCenterUser
+	index := make(map[string]int)
+	groupsByUser := make(map[string][]string)
+
+	for _, record := range records {
+		email := strings.TrimSpace(record.Email)
+		if email == "" {
+			name := record.UserName
+			if name == "" {
+				name = record.DisplayName
+			}
+			logger.Printf("⚠️  Skipping contact record %q without an email address", name)
+			continue
+		}
+		key := strings.ToLower(email)
+
+		i, seen := index[key]
+		if !seen {
+			userID := record.UserID
+			if userID == "" {
+				userID = key
+			}
+			users = append(users, types.IdentityCenterUser{UserId: userID, Email: email, Active: true})
+			i = len(users) - 1
+			index[key] = i
+		}
+
+		// Later rows fill in attributes earlier rows left blank
+		user := &users[i]
+		fillBlank(&user.UserName, record.UserName)
+		fillBlank(&user.DisplayName, record.DisplayName)
+		fillBlank(&user.GivenName, record.GivenName)
+		fillBlank(&user.FamilyName, record.FamilyName)
+		fillBlank(&user.PhoneNumber, record.PhoneNumber)
+		if record.Active != nil && !*record.Active {
+			user.Active = false
+		}
+
+		for _, group := range record.Groups {
+			addGroup(groupsByUser, user.UserId, group)
+		}
+	}
+
+	for i := range users {
+		fillBlank(&users[i].UserName, users[i].Email)
+		fillBlank(&users[i].DisplayName, strings.TrimSpace(users[i].GivenName+" "+users[i].FamilyName))
+	}
+
+	return &aws.IdentityCenterData{
+		Users:       users,
+		Memberships: membershipsFor(users, groupsByUser),
+	}
+}
+
+func fillBlank(field *string, value string) {
+	if *field == "" {
+		*field = strings.TrimSpace(value)
+	}
+}
+
+// readSourceFile reads a local file or an s3://bucket/key object
+func readSourceFile(ctx context.Context, path string) ([]byte, error) {
+	if !strings.HasPrefix(path, "s3://") {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read contact file %s: %w", path, err)
+		}
+		return content, nil
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(path, "s3://"), "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("invalid contact file location %q (expected s3://bucket/key)", path)
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	output, err := s3.NewFromConfig(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download contact file %s: %w", path, err)
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
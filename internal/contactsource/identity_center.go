@@ -0,0 +1,30 @@
+package contactsource
+
+import (
+	"context"
+
+	"ccoe-customer-contact-manager/internal/aws"
+)
+
+// IdentityCenterSource reads users and group memberships from AWS IAM Identity Center by
+// assuming a role in the account that owns the instance
+type IdentityCenterSource struct {
+	RoleArn           string
+	MaxConcurrency    int
+	RequestsPerSecond int
+}
+
+// Name describes the source in logs
+func (s *IdentityCenterSource) Name() string {
+	return "identity center (" + s.RoleArn + ")"
+}
+
+// Retrieve lists every user and their group memberships
+func (s *IdentityCenterSource) Retrieve(ctx context.Context, logger aws.Logger) (*aws.IdentityCenterData, error) {
+	return aws.RetrieveIdentityCenterDataWithLogger(s.RoleArn, s.MaxConcurrency, s.RequestsPerSecond, logger)
+}
+
+// Sync lists memberships per group rather than per user when a previous snapshot exists
+func (s *IdentityCenterSource) Sync(ctx context.Context, customerCode string, store aws.SnapshotStore, fullResync bool, logger aws.Logger) (*aws.IdentityCenterSync, error) {
+	return aws.SyncIdentityCenterWithLogger(ctx, s.RoleArn, customerCode, store, fullResync, s.MaxConcurrency, s.RequestsPerSecond, logger)
+}
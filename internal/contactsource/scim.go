@@ -0,0 +1,287 @@
+package contactsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+
+	"ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// scimPageSize is the number of resources requested per page
+const scimPageSize = 100
+
+// SCIMSource reads users and groups from a SCIM 2.0 service provider, such as the SCIM API of
+// Okta or another identity provider
+type SCIMSource struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+}
+
+// NewSCIMSource creates a source for a SCIM base URL such as https://example.okta.com/scim/v2
+func NewSCIMSource(baseURL, token string) *SCIMSource {
+	return &SCIMSource{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		attempts:   3,
+		backoff:    time.Second,
+	}
+}
+
+// scimListResponse is a page of a SCIM list request
+type scimListResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	ItemsPerPage int `json:"itemsPerPage"`
+	StartIndex   int `json:"startIndex"`
+	Resources    []T `json:"Resources"`
+}
+
+// scimMultiValue is a SCIM multi-valued attribute entry (emails, phoneNumbers, groups, members)
+type scimMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display"`
+	Type    string `json:"type"`
+	Primary bool   `json:"primary"`
+}
+
+type scimUser struct {
+	ID          string `json:"id"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName"`
+	Name        struct {
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+	} `json:"name"`
+	Emails       []scimMultiValue `json:"emails"`
+	PhoneNumbers []scimMultiValue `json:"phoneNumbers"`
+	Groups       []scimMultiValue `json:"groups"`
+	Active       *bool            `json:"active"`
+}
+
+type scimGroup struct {
+	ID          string           `json:"id"`
+	DisplayName string           `json:"displayName"`
+	Members     []scimMultiValue `json:"members"`
+}
+
+// Name describes the source in logs
+func (s *SCIMSource) Name() string {
+	return "scim (" + s.host() + ")"
+}
+
+func (s *SCIMSource) host() string {
+	if parsed, err := url.Parse(s.baseURL); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return s.baseURL
+}
+
+// Retrieve lists every user and group. Memberships come from each group's members, plus the
+// groups attribute of users for providers that only report membership there.
+func (s *SCIMSource) Retrieve(ctx context.Context, logger aws.Logger) (*aws.IdentityCenterData, error) {
+	scimUsers, err := listSCIM[scimUser](ctx, s, "/Users")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SCIM users: %w", err)
+	}
+	logger.Printf("🔍 Retrieved %d SCIM users", len(scimUsers))
+
+	scimGroups, err := listSCIM[scimGroup](ctx, s, "/Groups")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SCIM groups: %w", err)
+	}
+	logger.Printf("🔍 Retrieved %d SCIM groups", len(scimGroups))
+
+	users := make([]types.IdentityCenterUser, 0, len(scimUsers))
+	groupsByUser := make(map[string][]string)
+	for _, su := range scimUsers {
+		user := convertSCIMUser(su)
+		if user.Email == "" {
+			logger.Printf("⚠️  Skipping SCIM user %s without an email address", su.UserName)
+			continue
+		}
+		users = append(users, user)
+		for _, group := range su.Groups {
+			addGroup(groupsByUser, su.ID, group.Display)
+		}
+	}
+
+	for _, group := range scimGroups {
+		for _, member := range group.Members {
+			// Members can be users or nested groups; only users appear in the user list
+			addGroup(groupsByUser, member.Value, group.DisplayName)
+		}
+	}
+
+	return &aws.IdentityCenterData{
+		Users:       users,
+		Memberships: membershipsFor(users, groupsByUser),
+		InstanceID:  instanceID("scim", s.host()),
+	}, nil
+}
+
+// convertSCIMUser maps a SCIM user to the contact model. The primary email and phone number are
+// used, or the first of each if none is primary. Users without an active attribute are active.
+func convertSCIMUser(su scimUser) types.IdentityCenterUser {
+	email := primaryValue(su.Emails)
+	if email == "" && strings.Contains(su.UserName, "@") {
+		email = su.UserName
+	}
+
+	displayName := su.DisplayName
+	if displayName == "" {
+		displayName = strings.TrimSpace(su.Name.GivenName + " " + su.Name.FamilyName)
+	}
+
+	return types.IdentityCenterUser{
+		UserId:      su.ID,
+		UserName:    su.UserName,
+		DisplayName: displayName,
+		Email:       email,
+		GivenName:   su.Name.GivenName,
+		FamilyName:  su.Name.FamilyName,
+		PhoneNumber: primaryValue(su.PhoneNumbers),
+		Active:      su.Active == nil || *su.Active,
+	}
+}
+
+// primaryValue returns the primary value of a multi-valued attribute, or the first one
+func primaryValue(values []scimMultiValue) string {
+	for _, value := range values {
+		if value.Primary && value.Value != "" {
+			return value.Value
+		}
+	}
+	for _, value := range values {
+		if value.Value != "" {
+			return value.Value
+		}
+	}
+	return ""
+}
+
+// listSCIM pages through a SCIM resource endpoint using startIndex and count
+func listSCIM[T any](ctx context.Context, s *SCIMSource, resource string) ([]T, error) {
+	var all []T
+	startIndex := 1
+
+	for {
+		query := url.Values{}
+		query.Set("startIndex", strconv.Itoa(startIndex))
+		query.Set("count", strconv.Itoa(scimPageSize))
+
+		var page scimListResponse[T]
+		if err := s.get(ctx, resource+"?"+query.Encode(), &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Resources...)
+
+		if len(page.Resources) == 0 {
+			return all, nil
+		}
+		if page.TotalResults > 0 {
+			if len(all) >= page.TotalResults {
+				return all, nil
+			}
+		} else if len(page.Resources) < scimPageSize {
+			// Some providers omit totalResults; a short page ends the list
+			return all, nil
+		}
+		startIndex += len(page.Resources)
+	}
+}
+
+// get sends a GET request, retrying network errors, throttling and server errors
+func (s *SCIMSource) get(ctx context.Context, path string, out interface{}) error {
+	var lastErr error
+	for attempt := 1; attempt <= s.attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.backoff * time.Duration(1<<uint(attempt-2))):
+			}
+		}
+
+		retryable, err := s.getOnce(ctx, path, out)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return lastErr
+}
+
+// getOnce sends a single request and reports whether a failure is worth retrying
+func (s *SCIMSource) getOnce(ctx context.Context, path string, out interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Accept", "application/scim+json, application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("scim returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return false, nil
+}
+
+// loadSCIMToken reads the bearer token from token_env, or from Parameter Store via token_parameter
+func loadSCIMToken(ctx context.Context, source *types.ContactSourceConfig) (string, error) {
+	if source.TokenEnv != "" {
+		if token := os.Getenv(source.TokenEnv); token != "" {
+			return token, nil
+		}
+		if source.TokenParameter == "" {
+			return "", fmt.Errorf("environment variable %s is not set", source.TokenEnv)
+		}
+	}
+	if source.TokenParameter == "" {
+		return "", fmt.Errorf("token_parameter or token_env is required for scim contact sources")
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	result, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           awssdk.String(source.TokenParameter),
+		WithDecryption: awssdk.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get SCIM token from SSM: %w", err)
+	}
+	return awssdk.ToString(result.Parameter.Value), nil
+}
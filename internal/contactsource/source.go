@@ -0,0 +1,141 @@
+// Package contactsource retrieves the users and group memberships that SES contacts are imported
+// from. Identity Center, SCIM 2.0 endpoints (such as Okta) and JSON/CSV group exports (such as
+// Entra ID) all produce the same users-plus-memberships model, so topic assignment works the same
+// way for every customer.
+package contactsource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ContactSource produces users and their group memberships
+type ContactSource interface {
+	// Name describes the source in logs, e.g. "scim (example.okta.com)"
+	Name() string
+	// Retrieve returns every user and their group memberships. InstanceID identifies the
+	// directory, so snapshots of different sources never mix.
+	Retrieve(ctx context.Context, logger aws.Logger) (*aws.IdentityCenterData, error)
+}
+
+// IncrementalSource is a ContactSource that can fetch less when a previous snapshot exists
+type IncrementalSource interface {
+	ContactSource
+	Sync(ctx context.Context, customerCode string, store aws.SnapshotStore, fullResync bool, logger aws.Logger) (*aws.IdentityCenterSync, error)
+}
+
+// New creates the contact source configured for a customer. Identity Center sources need the
+// role ARN to assume; the other sources ignore it.
+func New(ctx context.Context, source *types.ContactSourceConfig, identityCenterRoleArn string, maxConcurrency int, requestsPerSecond int) (ContactSource, error) {
+	switch source.GetType() {
+	case types.ContactSourceIdentityCenter:
+		if identityCenterRoleArn == "" {
+			return nil, fmt.Errorf("identity_center_role_arn is required for identity_center contact sources")
+		}
+		return &IdentityCenterSource{
+			RoleArn:           identityCenterRoleArn,
+			MaxConcurrency:    maxConcurrency,
+			RequestsPerSecond: requestsPerSecond,
+		}, nil
+
+	case types.ContactSourceSCIM:
+		token, err := loadSCIMToken(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		return NewSCIMSource(source.URL, token), nil
+
+	case types.ContactSourceFile:
+		return &FileSource{Path: source.Path, Format: source.Format}, nil
+	}
+
+	return nil, fmt.Errorf("unknown contact source type: %s", source.Type)
+}
+
+// Sync retrieves a customer's users and memberships and, when a snapshot from the last import
+// exists, the delta since then. Sources that cannot fetch incrementally are retrieved in full
+// and compared with the snapshot.
+func Sync(ctx context.Context, source ContactSource, customerCode string, store aws.SnapshotStore, fullResync bool, logger aws.Logger) (*aws.IdentityCenterSync, error) {
+	if incremental, ok := source.(IncrementalSource); ok {
+		return incremental.Sync(ctx, customerCode, store, fullResync, logger)
+	}
+
+	logger.Printf("📥 Retrieving contacts from %s", source.Name())
+
+	data, err := source.Retrieve(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve contacts from %s: %w", source.Name(), err)
+	}
+	logger.Printf("✅ Retrieved %d users and %d group memberships from %s", len(data.Users), len(data.Memberships), source.Name())
+
+	sync := &aws.IdentityCenterSync{
+		Data:     data,
+		Snapshot: aws.NewIdentityCenterSnapshot(customerCode, data, time.Now()),
+		Full:     true,
+		Reason:   "--full-resync",
+	}
+	if fullResync {
+		return sync, nil
+	}
+
+	previous, err := store.Load(ctx, customerCode, data.InstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contact snapshot: %w", err)
+	}
+	if previous == nil {
+		sync.Reason = "no previous snapshot"
+		return sync, nil
+	}
+
+	sync.Delta = aws.DiffIdentityCenterSnapshots(previous, sync.Snapshot)
+	sync.Full, sync.Reason = false, ""
+	logger.Printf("✅ Contact delta: %s", sync.Delta.Summary())
+	return sync, nil
+}
+
+// instanceID builds a file-name-safe directory identifier such as scim-example.okta.com
+func instanceID(kind, name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, name)
+	return kind + "-" + safe
+}
+
+// membershipsFor builds user-centric memberships from a user ID to group names map, with an
+// entry for every user
+func membershipsFor(users []types.IdentityCenterUser, groupsByUser map[string][]string) []types.IdentityCenterGroupMembership {
+	memberships := make([]types.IdentityCenterGroupMembership, 0, len(users))
+	for _, user := range users {
+		memberships = append(memberships, types.IdentityCenterGroupMembership{
+			UserId:      user.UserId,
+			UserName:    user.UserName,
+			DisplayName: user.DisplayName,
+			Email:       user.Email,
+			Groups:      groupsByUser[user.UserId],
+		})
+	}
+	return memberships
+}
+
+// addGroup appends a group to a user's groups unless it is already there
+func addGroup(groupsByUser map[string][]string, userID, group string) {
+	group = strings.TrimSpace(group)
+	if group == "" {
+		return
+	}
+	for _, existing := range groupsByUser[userID] {
+		if existing == group {
+			return
+		}
+	}
+	groupsByUser[userID] = append(groupsByUser[userID], group)
+}
@@ -0,0 +1,366 @@
+// Package feeds renders announcement feeds (Atom and paginated JSON) for intranet pages and bots
+package feeds
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// AllTypes is the feed name that combines every announcement type
+const AllTypes = "all"
+
+// AnnouncementTypes are the per-type feeds published for each customer
+var AnnouncementTypes = []string{"cic", "finops", "innersource", "general"}
+
+// Entry statuses
+const (
+	StatusPublished = "published"
+	StatusCancelled = "cancelled"
+)
+
+// Entry is one announcement in a feed
+type Entry struct {
+	ID               string    `json:"id"`
+	AnnouncementType string    `json:"announcement_type"`
+	Title            string    `json:"title"`
+	Summary          string    `json:"summary"`
+	Content          string    `json:"content"`
+	Status           string    `json:"status"`
+	Author           string    `json:"author,omitempty"`
+	URL              string    `json:"url,omitempty"`
+	Published        time.Time `json:"published"`
+	Updated          time.Time `json:"updated"`
+}
+
+// typeDisplayNames are the feed titles for each announcement type
+var typeDisplayNames = map[string]string{
+	AllTypes:      "",
+	"cic":         "CIC",
+	"finops":      "FinOps",
+	"innersource": "InnerSource",
+	"general":     "General",
+}
+
+// Title returns the display title of a customer feed
+func Title(customerName, feedType string) string {
+	if name := typeDisplayNames[feedType]; name != "" {
+		return fmt.Sprintf("%s %s Announcements", customerName, name)
+	}
+	return fmt.Sprintf("%s Announcements", customerName)
+}
+
+// NormalizeType maps an announcement type to one of AnnouncementTypes, defaulting to general
+func NormalizeType(announcementType string) string {
+	t := strings.ToLower(strings.TrimSpace(announcementType))
+	for _, known := range AnnouncementTypes {
+		if t == known {
+			return t
+		}
+	}
+	return "general"
+}
+
+// NewEntry builds the feed entry for an announcement. It returns false when the announcement
+// does not belong in a feed: it has not been approved, or was cancelled before approval.
+func NewEntry(announcement *types.AnnouncementMetadata, portalBaseURL string) (Entry, bool) {
+	if announcement == nil || announcement.AnnouncementID == "" {
+		return Entry{}, false
+	}
+
+	status := StatusPublished
+	switch announcement.Status {
+	case "approved", "completed":
+	case "cancelled":
+		if !wasApproved(announcement) {
+			return Entry{}, false
+		}
+		status = StatusCancelled
+	default:
+		return Entry{}, false
+	}
+
+	published := announcement.PostedDate
+	if published.IsZero() {
+		published = approvedAt(announcement)
+	}
+	if published.IsZero() {
+		published = announcement.CreatedAt
+	}
+
+	updated := announcement.ModifiedAt
+	if n := len(announcement.Modifications); n > 0 && announcement.Modifications[n-1].Timestamp.After(updated) {
+		updated = announcement.Modifications[n-1].Timestamp
+	}
+	if updated.Before(published) {
+		updated = published
+	}
+
+	author := announcement.Author
+	if author == "" {
+		author = announcement.CreatedBy
+	}
+
+	entry := Entry{
+		ID:               announcement.AnnouncementID,
+		AnnouncementType: NormalizeType(announcement.AnnouncementType),
+		Title:            announcement.Title,
+		Summary:          announcement.Summary,
+		Content:          announcement.Content,
+		Status:           status,
+		Author:           author,
+		Published:        published.UTC(),
+		Updated:          updated.UTC(),
+	}
+	if portalBaseURL != "" {
+		entry.URL = fmt.Sprintf("%s/edit-announcement.html?announcementId=%s", strings.TrimRight(portalBaseURL, "/"), announcement.AnnouncementID)
+	}
+
+	return entry, true
+}
+
+// wasApproved reports whether an announcement was approved at some point in its history
+func wasApproved(announcement *types.AnnouncementMetadata) bool {
+	return !approvedAt(announcement).IsZero() || announcement.PriorStatus == "approved"
+}
+
+// approvedAt returns the time of the first approval, or zero if never approved
+func approvedAt(announcement *types.AnnouncementMetadata) time.Time {
+	for _, mod := range announcement.Modifications {
+		if mod.ModificationType == types.ModificationTypeApproved {
+			return mod.Timestamp
+		}
+	}
+	return time.Time{}
+}
+
+// State is the full list of a customer's feed entries, stored so one announcement can be
+// updated without rescanning the archive
+type State struct {
+	CustomerCode string           `json:"customer_code"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	Entries      map[string]Entry `json:"entries"`
+}
+
+// Apply adds, updates or removes the entry for an announcement. It returns the feed types
+// whose content changed (always including AllTypes when anything changed).
+func (s *State) Apply(announcement *types.AnnouncementMetadata, portalBaseURL string) []string {
+	if s.Entries == nil {
+		s.Entries = make(map[string]Entry)
+	}
+
+	existing, exists := s.Entries[announcement.AnnouncementID]
+	entry, include := NewEntry(announcement, portalBaseURL)
+
+	switch {
+	case !include && !exists:
+		return nil
+	case !include:
+		delete(s.Entries, announcement.AnnouncementID)
+		return []string{AllTypes, existing.AnnouncementType}
+	case exists && existing == entry:
+		return nil
+	}
+
+	s.Entries[announcement.AnnouncementID] = entry
+	changed := []string{AllTypes, entry.AnnouncementType}
+	if exists && existing.AnnouncementType != entry.AnnouncementType {
+		changed = append(changed, existing.AnnouncementType)
+	}
+	return changed
+}
+
+// EntriesFor returns the entries in a feed (AllTypes or one announcement type), newest first
+func (s *State) EntriesFor(feedType string) []Entry {
+	var entries []Entry
+	for _, entry := range s.Entries {
+		if feedType == AllTypes || entry.AnnouncementType == feedType {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Published.Equal(entries[j].Published) {
+			return entries[i].Published.After(entries[j].Published)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// Page is one page of a paginated JSON index
+type Page struct {
+	CustomerCode     string    `json:"customer_code"`
+	AnnouncementType string    `json:"announcement_type"`
+	Page             int       `json:"page"`
+	TotalPages       int       `json:"total_pages"`
+	TotalEntries     int       `json:"total_entries"`
+	Updated          time.Time `json:"updated"`
+	Next             string    `json:"next,omitempty"`
+	Prev             string    `json:"prev,omitempty"`
+	Entries          []Entry   `json:"entries"`
+}
+
+// PageName returns the object name of a JSON index page: index.json, index-2.json, ...
+func PageName(page int) string {
+	if page <= 1 {
+		return "index.json"
+	}
+	return fmt.Sprintf("index-%d.json", page)
+}
+
+// Paginate splits newest-first entries into JSON index pages. An empty feed still has one page.
+func Paginate(customerCode, feedType string, entries []Entry, pageSize int, updated time.Time) []Page {
+	if pageSize <= 0 {
+		pageSize = len(entries)
+	}
+
+	totalPages := 1
+	if len(entries) > 0 && pageSize > 0 {
+		totalPages = (len(entries) + pageSize - 1) / pageSize
+	}
+
+	pages := make([]Page, 0, totalPages)
+	for i := 1; i <= totalPages; i++ {
+		start := (i - 1) * pageSize
+		end := start + pageSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		page := Page{
+			CustomerCode:     customerCode,
+			AnnouncementType: feedType,
+			Page:             i,
+			TotalPages:       totalPages,
+			TotalEntries:     len(entries),
+			Updated:          updated.UTC(),
+			Entries:          append([]Entry{}, entries[start:end]...),
+		}
+		if i < totalPages {
+			page.Next = PageName(i + 1)
+		}
+		if i > 1 {
+			page.Prev = PageName(i - 1)
+		}
+		pages = append(pages, page)
+	}
+
+	return pages
+}
+
+// MarshalPage renders a JSON index page
+func MarshalPage(page Page) ([]byte, error) {
+	return json.MarshalIndent(page, "", "  ")
+}
+
+// Atom document structures (RFC 4287)
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      atomText       `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+	Content    *atomText      `xml:"content,omitempty"`
+}
+
+// FeedID returns the stable Atom id of a customer feed
+func FeedID(customerCode, feedType string) string {
+	return fmt.Sprintf("urn:ccoe-customer-contact-manager:announcements:%s:%s", customerCode, feedType)
+}
+
+// EntryID returns the stable Atom id of an announcement
+func EntryID(announcementID string) string {
+	return fmt.Sprintf("urn:ccoe-customer-contact-manager:announcement:%s", announcementID)
+}
+
+// RenderAtom renders newest-first entries as an Atom feed. Summary is plain text; content is
+// escaped and rendered as HTML with line breaks, so announcement text can never inject markup.
+func RenderAtom(customerCode, feedType, title, selfURL string, entries []Entry, updated time.Time) ([]byte, error) {
+	for _, entry := range entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+
+	feed := atomFeed{
+		ID:      FeedID(customerCode, feedType),
+		Title:   title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: "CCOE customer contact manager"},
+	}
+	if selfURL != "" {
+		feed.Links = append(feed.Links, atomLink{Href: selfURL, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, entry := range entries {
+		entryTitle := entry.Title
+		if entry.Status == StatusCancelled {
+			entryTitle = "[Cancelled] " + entryTitle
+		}
+
+		atom := atomEntry{
+			ID:         EntryID(entry.ID),
+			Title:      atomText{Type: "text", Body: entryTitle},
+			Updated:    entry.Updated.UTC().Format(time.RFC3339),
+			Published:  entry.Published.UTC().Format(time.RFC3339),
+			Categories: []atomCategory{{Term: entry.AnnouncementType}},
+		}
+		if entry.Author != "" {
+			atom.Author = &atomPerson{Name: entry.Author}
+		}
+		if entry.URL != "" {
+			atom.Links = append(atom.Links, atomLink{Href: entry.URL, Rel: "alternate", Type: "text/html"})
+		}
+		if entry.Summary != "" {
+			atom.Summary = &atomText{Type: "text", Body: entry.Summary}
+		}
+		if entry.Content != "" {
+			atom.Content = &atomText{Type: "html", Body: strings.ReplaceAll(html.EscapeString(entry.Content), "\n", "<br>")}
+		}
+		feed.Entries = append(feed.Entries, atom)
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Atom feed: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
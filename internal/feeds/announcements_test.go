@@ -0,0 +1,111 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func announcement(id, announcementType, status string, posted time.Time, approved bool) *types.AnnouncementMetadata {
+	a := &types.AnnouncementMetadata{
+		AnnouncementID: id, AnnouncementType: announcementType, Status: status,
+		Title: "Title " + id, Summary: "Summary", Content: "Body", PostedDate: posted,
+	}
+	if approved {
+		a.Modifications = []types.ModificationEntry{{Timestamp: posted, ModificationType: types.ModificationTypeApproved}}
+	}
+	return a
+}
+
+func TestStateApply(t *testing.T) {
+	posted := time.Date(2025, 4, 10, 14, 0, 0, 0, time.UTC)
+	state := &State{}
+
+	if changed := state.Apply(announcement("CIC-1", "cic", "submitted", posted, false), ""); changed != nil {
+		t.Errorf("Expected submitted announcement to be skipped, got %v", changed)
+	}
+
+	if changed := state.Apply(announcement("CIC-1", "cic", "approved", posted, true), ""); strings.Join(changed, ",") != "all,cic" {
+		t.Errorf("Expected all and cic feeds to change, got %v", changed)
+	}
+
+	if changed := state.Apply(announcement("CIC-1", "cic", "approved", posted, true), ""); changed != nil {
+		t.Errorf("Expected reprocessing to be a no-op, got %v", changed)
+	}
+
+	state.Apply(announcement("CIC-1", "cic", "cancelled", posted, true), "")
+	if state.Entries["CIC-1"].Status != StatusCancelled {
+		t.Errorf("Expected cancelled entry to be kept as cancelled, got %+v", state.Entries["CIC-1"])
+	}
+
+	if changed := state.Apply(announcement("GEN-1", "unknown", "cancelled", posted, false), ""); changed != nil {
+		t.Errorf("Expected never-approved cancellation to be skipped, got %v", changed)
+	}
+
+	state.Apply(announcement("FIN-1", "FinOps", "completed", posted.Add(time.Hour), true), "")
+	if got := state.EntriesFor("finops"); len(got) != 1 || got[0].ID != "FIN-1" {
+		t.Errorf("Expected finops feed to contain FIN-1, got %+v", got)
+	}
+	if got := state.EntriesFor(AllTypes); len(got) != 2 || got[0].ID != "FIN-1" {
+		t.Errorf("Expected all feed newest first, got %+v", got)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	var entries []Entry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, Entry{ID: fmt.Sprintf("GEN-%d", i)})
+	}
+
+	pages := Paginate("hts", AllTypes, entries, 2, time.Now())
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(pages))
+	}
+	if pages[0].Next != "index-2.json" || pages[0].Prev != "" || pages[2].Prev != "index-2.json" || pages[2].Next != "" {
+		t.Errorf("Unexpected page links: %+v", pages)
+	}
+	if len(pages[2].Entries) != 1 || pages[2].TotalEntries != 5 {
+		t.Errorf("Unexpected last page: %+v", pages[2])
+	}
+
+	if empty := Paginate("hts", "cic", nil, 2, time.Now()); len(empty) != 1 || empty[0].Entries == nil {
+		t.Errorf("Expected one empty page with an entries array, got %+v", empty)
+	}
+}
+
+func TestRenderAtomEscapesContent(t *testing.T) {
+	posted := time.Date(2025, 4, 10, 14, 0, 0, 0, time.UTC)
+	entries := []Entry{{
+		ID: "CIC-1", AnnouncementType: "cic", Title: "Tools & <tips>", Status: StatusCancelled,
+		Summary: "Use <b>this</b>", Content: "Line one\n<script>alert(1)</script>",
+		URL: "https://portal.example.com/edit-announcement.html?announcementId=CIC-1&x=1", Published: posted, Updated: posted,
+	}}
+
+	body, err := RenderAtom("hts", "cic", Title("Hearst", "cic"), "https://feeds.example.com/hts/cic/atom.xml", entries, posted)
+	if err != nil {
+		t.Fatalf("RenderAtom failed: %v", err)
+	}
+
+	var parsed atomFeed
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		t.Fatalf("Rendered feed is not valid XML: %v\n%s", err, body)
+	}
+
+	if parsed.Title != "Hearst CIC Announcements" || parsed.ID != "urn:ccoe-customer-contact-manager:announcements:hts:cic" {
+		t.Errorf("Unexpected feed header: %+v", parsed)
+	}
+	entry := parsed.Entries[0]
+	if entry.Title.Body != "[Cancelled] Tools & <tips>" {
+		t.Errorf("Unexpected entry title: %q", entry.Title.Body)
+	}
+	if entry.Content.Type != "html" || entry.Content.Body != "Line one<br>&lt;script&gt;alert(1)&lt;/script&gt;" {
+		t.Errorf("Expected content to be HTML-escaped, got %q", entry.Content.Body)
+	}
+	if entry.ID != EntryID("CIC-1") || entry.Links[0].Href != entries[0].URL {
+		t.Errorf("Unexpected entry id or link: %+v", entry)
+	}
+}
@@ -0,0 +1,114 @@
+// Package jira posts change lifecycle comments and workflow transitions to Jira issues
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/restclient"
+)
+
+// Environment variables populated from Parameter Store by ses.LoadAllCredentialsFromSSM
+const (
+	EnvUsername = "JIRA_USERNAME"
+	EnvAPIToken = "JIRA_API_TOKEN"
+)
+
+// Authentication schemes
+const (
+	AuthBasic  = "basic"  // Jira Cloud: account email and API token
+	AuthBearer = "bearer" // Jira Server/Data Center: personal access token
+)
+
+// Transition is a workflow transition available on an issue
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+// Client talks to the Jira REST API v2, which Cloud and Server both support with plain text comments
+type Client struct {
+	rest *restclient.Client
+}
+
+// NewClient creates a client for a Jira site such as https://example.atlassian.net
+func NewClient(baseURL, authType, username, token string) *Client {
+	bearer := strings.EqualFold(authType, AuthBearer)
+	return &Client{rest: restclient.New("jira", baseURL, func(req *http.Request) {
+		if bearer {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else {
+			req.SetBasicAuth(username, token)
+		}
+	})}
+}
+
+// NewClientFromEnv creates a client using credentials loaded into the environment from Parameter Store
+func NewClientFromEnv(baseURL, authType string) (*Client, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("jira base_url is not configured")
+	}
+
+	required := []string{EnvAPIToken}
+	if !strings.EqualFold(authType, AuthBearer) {
+		required = append([]string{EnvUsername}, required...)
+	}
+	if err := restclient.RequireEnv(required...); err != nil {
+		return nil, fmt.Errorf("jira credentials: %w", err)
+	}
+
+	return NewClient(baseURL, authType, os.Getenv(EnvUsername), os.Getenv(EnvAPIToken)), nil
+}
+
+// AddComment adds a plain text comment to an issue
+func (c *Client) AddComment(ctx context.Context, issueKey, body string) error {
+	path := fmt.Sprintf("/rest/api/2/issue/%s/comment", url.PathEscape(issueKey))
+	if err := c.rest.Do(ctx, http.MethodPost, path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to comment on %s: %w", issueKey, err)
+	}
+	return nil
+}
+
+// Transitions lists the workflow transitions currently available on an issue
+func (c *Client) Transitions(ctx context.Context, issueKey string) ([]Transition, error) {
+	var response struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(issueKey))
+	if err := c.rest.Do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list transitions for %s: %w", issueKey, err)
+	}
+	return response.Transitions, nil
+}
+
+// TransitionIssue moves an issue through the named workflow transition (matched case-insensitively
+// on the transition or target status name). It returns false without error when the transition is
+// not available, which usually means the issue is already past it.
+func (c *Client) TransitionIssue(ctx context.Context, issueKey, name string) (bool, error) {
+	transitions, err := c.Transitions(ctx, issueKey)
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range transitions {
+		if !strings.EqualFold(t.Name, name) && !strings.EqualFold(t.To.Name, name) {
+			continue
+		}
+
+		body := map[string]interface{}{"transition": map[string]string{"id": t.ID}}
+		path := fmt.Sprintf("/rest/api/2/issue/%s/transitions", url.PathEscape(issueKey))
+		if err := c.rest.Do(ctx, http.MethodPost, path, body, nil); err != nil {
+			return false, fmt.Errorf("failed to transition %s via %q: %w", issueKey, name, err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
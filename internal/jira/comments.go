@@ -0,0 +1,108 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// issueKeyPattern matches Jira issue keys such as OPS-123
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// eventLabels describe each lifecycle event in a comment heading
+var eventLabels = map[string]string{
+	"submitted":   "submitted for approval",
+	"approved":    "approved",
+	"in_progress": "implementation started",
+	"completed":   "completed",
+	"failed":      "failed",
+	"rolled_back": "rolled back",
+	"cancelled":   "cancelled",
+}
+
+// EventForStatus returns the lifecycle event commented on for a change status, or "" when
+// the status is not mirrored to Jira
+func EventForStatus(status string) string {
+	if status == "completed_unconfirmed" {
+		return "completed"
+	}
+	if _, ok := eventLabels[status]; ok {
+		return status
+	}
+	return ""
+}
+
+// ResolveIssueKey normalizes a change's Jira ticket to an issue key. Browse URLs are reduced
+// to their key and bare issue numbers are prefixed with the customer's project key. It
+// returns "" when no key can be determined.
+func ResolveIssueKey(ticket, projectKey string) string {
+	ticket = strings.TrimSpace(ticket)
+	if i := strings.LastIndex(ticket, "/browse/"); i >= 0 {
+		ticket = ticket[i+len("/browse/"):]
+	}
+	ticket = strings.ToUpper(strings.Trim(ticket, "/ "))
+
+	if issueKeyPattern.MatchString(ticket) {
+		return ticket
+	}
+
+	projectKey = strings.ToUpper(strings.TrimSpace(projectKey))
+	if projectKey != "" && ticket != "" && strings.Trim(ticket, "0123456789") == "" {
+		return projectKey + "-" + ticket
+	}
+	return ""
+}
+
+// ProjectKey returns the project part of an issue key
+func ProjectKey(issueKey string) string {
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		return issueKey[:i]
+	}
+	return ""
+}
+
+// Comment builds the plain text comment posted for a lifecycle event
+func Comment(event string, metadata *types.ChangeMetadata, portalBaseURL string) string {
+	label := eventLabels[event]
+	if label == "" {
+		label = event
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Change %s %s* (CCOE Customer Contact Manager)\n", metadata.ChangeID, label)
+	fmt.Fprintf(&b, "Title: %s\n", metadata.ChangeTitle)
+	fmt.Fprintf(&b, "Status: %s\n", metadata.Status)
+	if metadata.StatusReason != "" {
+		fmt.Fprintf(&b, "Reason: %s\n", metadata.StatusReason)
+	}
+	if len(metadata.Customers) > 0 {
+		fmt.Fprintf(&b, "Customers: %s\n", strings.Join(metadata.Customers, ", "))
+	}
+	if !metadata.ImplementationStart.IsZero() {
+		fmt.Fprintf(&b, "Implementation window: %s - %s UTC",
+			metadata.ImplementationStart.UTC().Format("2006-01-02 15:04"),
+			metadata.ImplementationEnd.UTC().Format("2006-01-02 15:04"))
+		if metadata.Timezone != "" {
+			fmt.Fprintf(&b, " (%s)", metadata.Timezone)
+		}
+		b.WriteString("\n")
+	}
+
+	meeting := metadata.MeetingMetadata
+	if meeting == nil {
+		meeting = metadata.GetLatestMeetingMetadata()
+	}
+	if meeting != nil && meeting.JoinURL != "" && event != "cancelled" {
+		fmt.Fprintf(&b, "Meeting: %s\n", meeting.JoinURL)
+	}
+	if metadata.SurveyURL != "" {
+		fmt.Fprintf(&b, "Survey: %s\n", metadata.SurveyURL)
+	}
+	if portalBaseURL != "" {
+		fmt.Fprintf(&b, "Details: %s/edit-change.html?changeId=%s\n", strings.TrimRight(portalBaseURL, "/"), metadata.ChangeID)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
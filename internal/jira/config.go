@@ -0,0 +1,46 @@
+package jira
+
+import (
+	"sort"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// IssueKeyForChange resolves the issue a change's jiraTicket refers to. Bare issue numbers use
+// the project of the first of the change's customers with a project mapping.
+func IssueKeyForChange(cfg *types.JiraConfig, metadata *types.ChangeMetadata) string {
+	projectKey := ""
+	for _, customer := range metadata.Customers {
+		if project, ok := cfg.Projects[customer]; ok && project.ProjectKey != "" {
+			projectKey = project.ProjectKey
+			break
+		}
+	}
+	return ResolveIssueKey(metadata.JiraTicket, projectKey)
+}
+
+// TransitionFor returns the workflow transition to drive for an event on an issue, or "" when
+// none is configured. A customer project mapping for the issue's project overrides the global
+// transitions; mappings are checked in customer code order so the choice is deterministic.
+func TransitionFor(cfg *types.JiraConfig, issueKey, event string) string {
+	project := ProjectKey(issueKey)
+
+	codes := make([]string, 0, len(cfg.Projects))
+	for code := range cfg.Projects {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		mapping := cfg.Projects[code]
+		if !strings.EqualFold(mapping.ProjectKey, project) {
+			continue
+		}
+		if name, ok := mapping.Transitions[event]; ok {
+			return name
+		}
+	}
+
+	return cfg.Transitions[event]
+}
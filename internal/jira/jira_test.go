@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// standIn is a minimal Jira REST API serving a single issue
+type standIn struct {
+	mu          sync.Mutex
+	issue       string
+	status      string
+	comments    []string
+	authHeaders []string
+}
+
+func newStandIn(t *testing.T, issue, status string) (*standIn, *httptest.Server) {
+	t.Helper()
+	s := &standIn{issue: issue, status: status}
+	server := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authHeaders = append(s.authHeaders, r.Header.Get("Authorization"))
+
+	base := "/rest/api/2/issue/" + s.issue
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == base+"/comment":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		s.comments = append(s.comments, body["body"])
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"10000"}`))
+
+	case r.Method == http.MethodGet && r.URL.Path == base+"/transitions":
+		// Only "Start Implementation" is available from the Approved status
+		transitions := []map[string]interface{}{}
+		if s.status == "Approved" {
+			transitions = append(transitions, map[string]interface{}{"id": "31", "name": "Start Implementation", "to": map[string]string{"name": "In Progress"}})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"transitions": transitions})
+
+	case r.Method == http.MethodPost && r.URL.Path == base+"/transitions":
+		var body struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Transition.ID != "31" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.status = "In Progress"
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	jira, server := newStandIn(t, "OPS-12", "Approved")
+	client := NewClient(server.URL, "", "user@example.com", "token")
+
+	if err := client.AddComment(context.Background(), "OPS-12", "hello"); err != nil {
+		t.Fatalf("Expected comment to be added, got %v", err)
+	}
+	if len(jira.comments) != 1 || jira.comments[0] != "hello" {
+		t.Errorf("Unexpected comments: %v", jira.comments)
+	}
+	if !strings.HasPrefix(jira.authHeaders[0], "Basic ") {
+		t.Errorf("Expected basic auth by default, got %q", jira.authHeaders[0])
+	}
+
+	if err := client.AddComment(context.Background(), "OPS-99", "hello"); err == nil {
+		t.Error("Expected error for unknown issue")
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	jira, server := newStandIn(t, "OPS-12", "Approved")
+	client := NewClient(server.URL, AuthBearer, "", "pat")
+
+	// Matched on the target status name, case-insensitively
+	ok, err := client.TransitionIssue(context.Background(), "OPS-12", "in progress")
+	if err != nil || !ok {
+		t.Fatalf("Expected transition, got ok=%v err=%v", ok, err)
+	}
+	if jira.status != "In Progress" {
+		t.Errorf("Expected issue to move to In Progress, got %s", jira.status)
+	}
+	if jira.authHeaders[0] != "Bearer pat" {
+		t.Errorf("Expected bearer auth, got %q", jira.authHeaders[0])
+	}
+
+	// No longer available once the issue has moved on
+	ok, err = client.TransitionIssue(context.Background(), "OPS-12", "Start Implementation")
+	if err != nil || ok {
+		t.Errorf("Expected unavailable transition to be skipped, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestResolveIssueKey(t *testing.T) {
+	tests := []struct {
+		ticket, project, want string
+	}{
+		{"ops-12", "", "OPS-12"},
+		{"https://example.atlassian.net/browse/OPS-12", "", "OPS-12"},
+		{"12", "hts", "HTS-12"},
+		{"12", "", ""},
+		{"see ticket", "HTS", ""},
+	}
+	for _, tt := range tests {
+		if got := ResolveIssueKey(tt.ticket, tt.project); got != tt.want {
+			t.Errorf("ResolveIssueKey(%q, %q) = %q, want %q", tt.ticket, tt.project, got, tt.want)
+		}
+	}
+}
+
+func TestProjectMappings(t *testing.T) {
+	cfg := &types.JiraConfig{
+		Transitions: map[string]string{"completed": "Done"},
+		Projects: map[string]types.JiraProjectConfig{
+			"hts": {ProjectKey: "HTS", Transitions: map[string]string{"completed": "Resolve"}},
+			"cds": {ProjectKey: "CDS"},
+		},
+	}
+
+	metadata := &types.ChangeMetadata{JiraTicket: "42", Customers: []string{"cds", "hts"}}
+	if got := IssueKeyForChange(cfg, metadata); got != "CDS-42" {
+		t.Errorf("Expected first mapped customer's project, got %q", got)
+	}
+
+	if got := TransitionFor(cfg, "HTS-1", "completed"); got != "Resolve" {
+		t.Errorf("Expected project override, got %q", got)
+	}
+	if got := TransitionFor(cfg, "CDS-1", "completed"); got != "Done" {
+		t.Errorf("Expected global transition, got %q", got)
+	}
+	if got := TransitionFor(cfg, "CDS-1", "approved"); got != "" {
+		t.Errorf("Expected no transition, got %q", got)
+	}
+}
+
+func TestComment(t *testing.T) {
+	metadata := &types.ChangeMetadata{
+		ChangeID:            "CHG-1",
+		ChangeTitle:         "Upgrade RDS",
+		Status:              "completed_unconfirmed",
+		ImplementationStart: time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC),
+		ImplementationEnd:   time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC),
+		Timezone:            "America/New_York",
+		SurveyURL:           "https://form.typeform.com/to/abc",
+		Modifications: []types.ModificationEntry{{
+			ModificationType: types.ModificationTypeMeetingScheduled,
+			MeetingMetadata:  &types.MeetingMetadata{JoinURL: "https://teams.example.com/join"},
+		}},
+	}
+
+	comment := Comment(EventForStatus(metadata.Status), metadata, "https://portal.example.com")
+	for _, want := range []string{
+		"*Change CHG-1 completed*",
+		"Status: completed_unconfirmed",
+		"Implementation window: 2026-03-01 14:00 - 2026-03-01 16:00 UTC (America/New_York)",
+		"Meeting: https://teams.example.com/join",
+		"Survey: https://form.typeform.com/to/abc",
+		"Details: https://portal.example.com/edit-change.html?changeId=CHG-1",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("Expected comment to contain %q:\n%s", want, comment)
+		}
+	}
+}
@@ -26,13 +26,13 @@ func handleAnnouncementEventNew(ctx context.Context, customerCode string, s3Buck
 	log.Printf("📢 Processing announcement event for customer %s from S3: %s/%s", customerCode, s3Bucket, s3Key)
 
 	// Download announcement from S3
-	announcement, err := downloadAnnouncementFromS3(ctx, s3Bucket, s3Key, cfg.AWSRegion)
+	announcement, err := DownloadAnnouncementFromS3(ctx, s3Bucket, s3Key, cfg.AWSRegion)
 	if err != nil {
 		return fmt.Errorf("failed to download announcement from S3: %w", err)
 	}
 
 	// Validate announcement
-	if err := validateAnnouncement(announcement); err != nil {
+	if err := ValidateAnnouncement(announcement); err != nil {
 		return fmt.Errorf("invalid announcement: %w", err)
 	}
 
@@ -55,8 +55,8 @@ func handleAnnouncementEventNew(ctx context.Context, customerCode string, s3Buck
 	return processor.ProcessAnnouncement(ctx, customerCode, announcement, s3Bucket, s3Key)
 }
 
-// downloadAnnouncementFromS3 downloads and parses announcement metadata from S3
-func downloadAnnouncementFromS3(ctx context.Context, bucket, key, region string) (*types.AnnouncementMetadata, error) {
+// DownloadAnnouncementFromS3 downloads and parses announcement metadata from S3
+func DownloadAnnouncementFromS3(ctx context.Context, bucket, key, region string) (*types.AnnouncementMetadata, error) {
 	// Create S3 client
 	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
 	if err != nil {
@@ -81,6 +81,12 @@ func downloadAnnouncementFromS3(ctx context.Context, bucket, key, region string)
 		return nil, fmt.Errorf("failed to read S3 object content: %w", err)
 	}
 
+	return ParseAnnouncement(contentBytes, result.Metadata)
+}
+
+// ParseAnnouncement parses announcement metadata downloaded from S3, taking survey metadata from
+// its S3 object metadata
+func ParseAnnouncement(contentBytes []byte, s3Metadata map[string]string) (*types.AnnouncementMetadata, error) {
 	// Parse as AnnouncementMetadata
 	var announcement types.AnnouncementMetadata
 	if err := json.Unmarshal(contentBytes, &announcement); err != nil {
@@ -88,14 +94,14 @@ func downloadAnnouncementFromS3(ctx context.Context, bucket, key, region string)
 	}
 
 	// Extract survey metadata from S3 object metadata if present
-	if result.Metadata != nil {
-		if surveyID, ok := result.Metadata["survey_id"]; ok {
+	if s3Metadata != nil {
+		if surveyID, ok := s3Metadata["survey_id"]; ok {
 			announcement.SurveyID = surveyID
 		}
-		if surveyURL, ok := result.Metadata["survey_url"]; ok {
+		if surveyURL, ok := s3Metadata["survey_url"]; ok {
 			announcement.SurveyURL = surveyURL
 		}
-		if surveyCreatedAt, ok := result.Metadata["survey_created_at"]; ok {
+		if surveyCreatedAt, ok := s3Metadata["survey_created_at"]; ok {
 			announcement.SurveyCreatedAt = surveyCreatedAt
 		}
 		if announcement.SurveyID != "" {
@@ -106,8 +112,8 @@ func downloadAnnouncementFromS3(ctx context.Context, bucket, key, region string)
 	return &announcement, nil
 }
 
-// validateAnnouncement validates the announcement metadata structure
-func validateAnnouncement(announcement *types.AnnouncementMetadata) error {
+// ValidateAnnouncement validates the announcement metadata structure
+func ValidateAnnouncement(announcement *types.AnnouncementMetadata) error {
 	if announcement == nil {
 		return fmt.Errorf("announcement cannot be nil")
 	}
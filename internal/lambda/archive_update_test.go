@@ -272,8 +272,8 @@ func TestMeetingMetadataAtRootLevel(t *testing.T) {
 		t.Errorf("Expected meeting_metadata.meeting_id '%s', got '%v'", meetingMetadata.MeetingID, changeMetadata.MeetingMetadata)
 	}
 
-	if changeMetadata.JoinURL != meetingMetadata.JoinURL {
-		t.Errorf("Expected root-level join_url '%s', got '%s'", meetingMetadata.JoinURL, changeMetadata.JoinURL)
+	if changeMetadata.MeetingMetadata.JoinURL != meetingMetadata.JoinURL {
+		t.Errorf("Expected meeting_metadata.join_url '%s', got '%s'", meetingMetadata.JoinURL, changeMetadata.MeetingMetadata.JoinURL)
 	}
 
 	// Verify modification entry exists
@@ -339,13 +339,13 @@ func TestCombinedMeetingAndProcessedEntries(t *testing.T) {
 		t.Errorf("Expected customer code 'customer-a', got '%s'", changeMetadata.Modifications[1].CustomerCode)
 	}
 
-	// Verify root-level meeting fields
-	if changeMetadata.MeetingID != meetingMetadata.MeetingID {
-		t.Errorf("Expected root-level meeting_id '%s', got '%s'", meetingMetadata.MeetingID, changeMetadata.MeetingID)
+	// Verify nested meeting fields
+	if changeMetadata.MeetingMetadata.MeetingID != meetingMetadata.MeetingID {
+		t.Errorf("Expected meeting_metadata.meeting_id '%s', got '%s'", meetingMetadata.MeetingID, changeMetadata.MeetingMetadata.MeetingID)
 	}
 
-	if changeMetadata.JoinURL != meetingMetadata.JoinURL {
-		t.Errorf("Expected root-level join_url '%s', got '%s'", meetingMetadata.JoinURL, changeMetadata.JoinURL)
+	if changeMetadata.MeetingMetadata.JoinURL != meetingMetadata.JoinURL {
+		t.Errorf("Expected meeting_metadata.join_url '%s', got '%s'", meetingMetadata.JoinURL, changeMetadata.MeetingMetadata.JoinURL)
 	}
 }
 
@@ -0,0 +1,304 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// calendarFeedMaxRetries bounds retries when concurrent changes update the same customer's feed
+const calendarFeedMaxRetries = 5
+
+// CalendarFeedState is the list of events a customer's .ics feed is rendered from.
+// It is stored next to the feed so a single change can be updated without rescanning the archive.
+type CalendarFeedState struct {
+	CustomerCode string                           `json:"customer_code"`
+	UpdatedAt    time.Time                        `json:"updated_at"`
+	Events       map[string]ses.CalendarFeedEvent `json:"events"`
+}
+
+// applyChange adds, updates or removes the event for a change. It returns true when the state changed.
+// Metadata older than the stored event (a retried or out-of-order trigger) is ignored.
+func (s *CalendarFeedState) applyChange(metadata *types.ChangeMetadata, portalBaseURL string) bool {
+	if s.Events == nil {
+		s.Events = make(map[string]ses.CalendarFeedEvent)
+	}
+
+	existing, exists := s.Events[metadata.ChangeID]
+	if exists && metadata.Version < existing.Sequence {
+		log.Printf("ℹ️  Ignoring version %d of change %s: calendar feed already has version %d", metadata.Version, metadata.ChangeID, existing.Sequence)
+		return false
+	}
+	event, include := ses.NewCalendarFeedEvent(metadata, portalBaseURL)
+
+	if !include {
+		if exists {
+			delete(s.Events, metadata.ChangeID)
+			return true
+		}
+		return false
+	}
+
+	if exists && existing == event {
+		return false
+	}
+	s.Events[metadata.ChangeID] = event
+	return true
+}
+
+// render returns the .ics feed for the state
+func (s *CalendarFeedState) render(calendarName string, now time.Time) string {
+	events := make([]ses.CalendarFeedEvent, 0, len(s.Events))
+	for _, event := range s.Events {
+		events = append(events, event)
+	}
+	return ses.GenerateCalendarFeed(calendarName, events, now)
+}
+
+// calendarFeedName returns the calendar display name for a customer
+func calendarFeedName(cfg *types.Config, customerCode string) string {
+	name := customerCode
+	if info, exists := cfg.CustomerMappings[customerCode]; exists && info.CustomerName != "" {
+		name = info.CustomerName
+	}
+	return fmt.Sprintf("%s Change Calendar", name)
+}
+
+// calendarFeedBucket returns the configured feed bucket, falling back to the processing bucket
+func calendarFeedBucket(cfg *types.Config, bucket string) string {
+	if cfg.CalendarFeed != nil && cfg.CalendarFeed.Bucket != "" {
+		return cfg.CalendarFeed.Bucket
+	}
+	return bucket
+}
+
+// PublishCalendarFeedsForChange updates the calendar feed of every customer on a change
+func PublishCalendarFeedsForChange(ctx context.Context, cfg *types.Config, bucket string, metadata *types.ChangeMetadata) error {
+	var errs []string
+	for _, customerCode := range metadata.Customers {
+		if err := PublishCalendarFeedForChange(ctx, cfg, bucket, customerCode, metadata); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", customerCode, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish calendar feeds: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// PublishCalendarFeedForChange records a change's current status in a customer's calendar feed
+// and republishes the .ics file. It does nothing when calendar feeds are not enabled.
+func PublishCalendarFeedForChange(ctx context.Context, cfg *types.Config, bucket, customerCode string, metadata *types.ChangeMetadata) error {
+	if cfg.CalendarFeed == nil || !cfg.CalendarFeed.Enabled {
+		return nil
+	}
+	if metadata == nil || metadata.ChangeID == "" {
+		return fmt.Errorf("change metadata with a change ID is required")
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 manager: %w", err)
+	}
+
+	feedBucket := calendarFeedBucket(cfg, bucket)
+	stateKey := cfg.CalendarFeed.StateKey(customerCode)
+	feedKey := cfg.CalendarFeed.FeedKey(customerCode)
+
+	for attempt := 1; attempt <= calendarFeedMaxRetries; attempt++ {
+		state, etag, err := s3Manager.loadCalendarFeedState(ctx, feedBucket, stateKey)
+		if err != nil {
+			return err
+		}
+		state.CustomerCode = customerCode
+
+		if !state.applyChange(metadata, cfg.EmailConfig.PortalBaseURL) {
+			log.Printf("ℹ️  Calendar feed for %s already reflects change %s (%s)", customerCode, metadata.ChangeID, metadata.Status)
+			return nil
+		}
+
+		state.UpdatedAt = time.Now()
+		etag, err = s3Manager.saveCalendarFeedState(ctx, feedBucket, stateKey, state, etag)
+		if err != nil {
+			if IsETagMismatch(err) {
+				log.Printf("🔄 Calendar feed state for %s changed concurrently, retrying (attempt %d/%d)", customerCode, attempt, calendarFeedMaxRetries)
+				continue
+			}
+			return err
+		}
+
+		if state, err = s3Manager.publishCalendarFeed(ctx, feedBucket, stateKey, feedKey, calendarFeedName(cfg, customerCode), state, etag); err != nil {
+			return err
+		}
+
+		log.Printf("📅 Published calendar feed s3://%s/%s (%d events, change %s is %s)", feedBucket, feedKey, len(state.Events), metadata.ChangeID, metadata.Status)
+		return nil
+	}
+
+	return fmt.Errorf("calendar feed state for %s kept changing after %d attempts", customerCode, calendarFeedMaxRetries)
+}
+
+// RebuildCalendarFeed regenerates a customer's feed from a full set of changes, replacing the
+// stored state. Used to backfill feeds and to repair drift. The rendered feed is returned; when
+// dryRun is true nothing is written.
+func RebuildCalendarFeed(ctx context.Context, cfg *types.Config, bucket, customerCode string, changes []*types.ChangeMetadata, dryRun bool) (string, int, error) {
+	state := &CalendarFeedState{CustomerCode: customerCode}
+	for _, metadata := range changes {
+		if !containsCustomer(metadata.Customers, customerCode) {
+			continue
+		}
+		state.applyChange(metadata, cfg.EmailConfig.PortalBaseURL)
+	}
+
+	state.UpdatedAt = time.Now()
+	feed := state.render(calendarFeedName(cfg, customerCode), state.UpdatedAt)
+	if dryRun {
+		return feed, len(state.Events), nil
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create S3 manager: %w", err)
+	}
+
+	feedBucket := calendarFeedBucket(cfg, bucket)
+	stateKey := cfg.CalendarFeed.StateKey(customerCode)
+	_, etag, err := s3Manager.loadCalendarFeedState(ctx, feedBucket, stateKey)
+	if err != nil {
+		return "", 0, err
+	}
+	if etag, err = s3Manager.saveCalendarFeedState(ctx, feedBucket, stateKey, state, etag); err != nil {
+		return "", 0, err
+	}
+	if _, err := s3Manager.publishCalendarFeed(ctx, feedBucket, stateKey, cfg.CalendarFeed.FeedKey(customerCode), calendarFeedName(cfg, customerCode), state, etag); err != nil {
+		return "", 0, err
+	}
+
+	return feed, len(state.Events), nil
+}
+
+// publishCalendarFeed uploads the .ics rendered from a committed state and then checks that the
+// state is still the one it was rendered from. If another writer committed in the meantime its
+// upload may have been overwritten by this stale one, so the feed is re-rendered from the latest
+// state until the uploaded feed matches the stored state. The published state is returned.
+func (s *S3UpdateManager) publishCalendarFeed(ctx context.Context, bucket, stateKey, feedKey, calendarName string, state *CalendarFeedState, etag string) (*CalendarFeedState, error) {
+	for attempt := 1; attempt <= calendarFeedMaxRetries; attempt++ {
+		if err := s.putCalendarFeed(ctx, bucket, feedKey, state.render(calendarName, state.UpdatedAt)); err != nil {
+			return nil, err
+		}
+
+		latest, latestETag, err := s.loadCalendarFeedState(ctx, bucket, stateKey)
+		if err != nil {
+			return nil, err
+		}
+		if latestETag == etag {
+			return state, nil
+		}
+
+		log.Printf("🔄 Calendar feed state s3://%s/%s changed while publishing, re-rendering (attempt %d/%d)", bucket, stateKey, attempt, calendarFeedMaxRetries)
+		state, etag = latest, latestETag
+	}
+
+	return nil, fmt.Errorf("calendar feed state s3://%s/%s kept changing while publishing after %d attempts", bucket, stateKey, calendarFeedMaxRetries)
+}
+
+// containsCustomer reports whether a customer code is in the list
+func containsCustomer(customers []string, customerCode string) bool {
+	for _, code := range customers {
+		if code == customerCode {
+			return true
+		}
+	}
+	return false
+}
+
+// loadCalendarFeedState loads a feed's event list and ETag. A missing object yields an empty state and no ETag.
+func (s *S3UpdateManager) loadCalendarFeedState(ctx context.Context, bucket, key string) (*CalendarFeedState, string, error) {
+	output, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NoSuchKey") || strings.Contains(err.Error(), "NotFound") {
+			return &CalendarFeedState{}, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to load calendar feed state s3://%s/%s: %w", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read calendar feed state: %w", err)
+	}
+
+	var state CalendarFeedState
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, "", fmt.Errorf("failed to parse calendar feed state s3://%s/%s: %w", bucket, key, err)
+	}
+
+	return &state, aws.ToString(output.ETag), nil
+}
+
+// saveCalendarFeedState writes a feed's event list and returns the new ETag. With an ETag the write
+// only succeeds if the object is unchanged; with an empty ETag it only succeeds if the object does
+// not exist yet.
+func (s *S3UpdateManager) saveCalendarFeedState(ctx context.Context, bucket, key string, state *CalendarFeedState, expectedETag string) (string, error) {
+	jsonData, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal calendar feed state: %w", err)
+	}
+
+	putInput := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(jsonData),
+		ContentType: aws.String("application/json"),
+	}
+	if expectedETag != "" {
+		putInput.IfMatch = aws.String(expectedETag)
+	} else {
+		putInput.IfNoneMatch = aws.String("*")
+	}
+
+	output, err := s.s3Client.PutObject(ctx, putInput)
+	if err != nil {
+		if strings.Contains(err.Error(), "PreconditionFailed") || strings.Contains(err.Error(), "412") {
+			return "", &ETagMismatchError{
+				Bucket:       bucket,
+				Key:          key,
+				ExpectedETag: expectedETag,
+				Message:      "Calendar feed state was modified by another process (ETag mismatch)",
+				Cause:        err,
+			}
+		}
+		return "", fmt.Errorf("failed to save calendar feed state s3://%s/%s: %w", bucket, key, err)
+	}
+
+	return aws.ToString(output.ETag), nil
+}
+
+// putCalendarFeed uploads a rendered .ics feed
+func (s *S3UpdateManager) putCalendarFeed(ctx context.Context, bucket, key, feed string) error {
+	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(key),
+		Body:         strings.NewReader(feed),
+		ContentType:  aws.String("text/calendar; charset=utf-8"),
+		CacheControl: aws.String("max-age=300"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload calendar feed s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
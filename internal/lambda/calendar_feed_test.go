@@ -0,0 +1,54 @@
+package lambda
+
+import (
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func calendarFeedChange(version int, status string) *types.ChangeMetadata {
+	start := time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC)
+	return &types.ChangeMetadata{
+		ChangeID:            "CHG-1",
+		ChangeTitle:         "Patch DB",
+		Status:              status,
+		Version:             version,
+		ImplementationStart: start,
+		ImplementationEnd:   start.Add(2 * time.Hour),
+		Modifications: []types.ModificationEntry{
+			{ModificationType: types.ModificationTypeApproved, Timestamp: start.Add(-24 * time.Hour)},
+		},
+	}
+}
+
+func TestCalendarFeedStateIgnoresOlderVersions(t *testing.T) {
+	state := &CalendarFeedState{}
+	if !state.applyChange(calendarFeedChange(3, "cancelled"), "") {
+		t.Fatal("Expected version 3 to be added")
+	}
+
+	// A late trigger for the approved version must not bring the change back
+	if state.applyChange(calendarFeedChange(2, "approved"), "") {
+		t.Error("Expected version 2 to be ignored after version 3")
+	}
+	if event := state.Events["CHG-1"]; event.Sequence != 3 || event.Status != ses.ICSStatusCancelled {
+		t.Errorf("Expected the cancelled version 3 event, got %+v", event)
+	}
+
+	// Nor may an older version that is not in the feed remove the event
+	unscheduled := calendarFeedChange(2, "approved")
+	unscheduled.ImplementationStart = time.Time{}
+	if state.applyChange(unscheduled, "") {
+		t.Error("Expected an older unscheduled version to be ignored")
+	}
+	if _, exists := state.Events["CHG-1"]; !exists {
+		t.Error("Expected the event to be kept")
+	}
+
+	// Newer versions still apply
+	if !state.applyChange(calendarFeedChange(4, "approved"), "") || state.Events["CHG-1"].Sequence != 4 {
+		t.Errorf("Expected version 4 to replace the event, got %+v", state.Events["CHG-1"])
+	}
+}
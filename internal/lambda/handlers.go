@@ -24,8 +24,10 @@ import (
 	awsinternal "ccoe-customer-contact-manager/internal/aws"
 	"ccoe-customer-contact-manager/internal/config"
 	"ccoe-customer-contact-manager/internal/datetime"
+	"ccoe-customer-contact-manager/internal/servicenow"
 	"ccoe-customer-contact-manager/internal/ses"
 	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/surveys"
 	"ccoe-customer-contact-manager/internal/typeform"
 	"ccoe-customer-contact-manager/internal/types"
 )
@@ -426,10 +428,12 @@ func ExtractCustomerCodeAndChangeIDFromS3Key(s3Key string) (string, string, erro
 // hasBeenProcessedForCurrentStatus checks if a change has already been processed for its current status
 // This prevents duplicate processing when triggers are created multiple times for the same status
 func hasBeenProcessedForCurrentStatus(metadata *types.ChangeMetadata) bool {
-	// For cancelled and completed statuses, check if there's already a "processed" entry
+	// For implementation and final statuses, check if there's already a "processed" entry
 	// after the status change modification
-	if metadata.Status != "cancelled" && metadata.Status != "completed" {
-		return false // Only check for final states
+	switch metadata.Status {
+	case "cancelled", "completed", types.ModificationTypeInProgress, types.ModificationTypeFailed, types.ModificationTypeRolledBack:
+	default:
+		return false // Only check for implementation and final states
 	}
 
 	// Find the most recent status change modification
@@ -437,7 +441,10 @@ func hasBeenProcessedForCurrentStatus(metadata *types.ChangeMetadata) bool {
 	for i := len(metadata.Modifications) - 1; i >= 0; i-- {
 		mod := metadata.Modifications[i]
 		if mod.ModificationType == "cancelled" ||
-			mod.ModificationType == "completed" {
+			mod.ModificationType == "completed" ||
+			mod.ModificationType == types.ModificationTypeInProgress ||
+			mod.ModificationType == types.ModificationTypeFailed ||
+			mod.ModificationType == types.ModificationTypeRolledBack {
 			statusChangeIndex = i
 			break
 		}
@@ -502,6 +509,13 @@ func ProcessTransientTrigger(ctx context.Context, bucketName, triggerKey, custom
 		return fmt.Errorf("trigger already processed: change already processed for status %s", metadata.Status)
 	}
 
+	// Step 2.6: Mirror the transition to the referenced ServiceNow change request (non-fatal)
+	if !strings.HasPrefix(metadata.ObjectType, "announcement_") {
+		if err := SyncServiceNowForChange(ctx, cfg, bucketName, metadata); err != nil {
+			log.Printf("⚠️  Failed to sync change %s to ServiceNow: %v", changeID, err)
+		}
+	}
+
 	// Step 3: Process the change (send emails, schedule meetings, etc.)
 	var processingErr error
 	if strings.HasPrefix(metadata.ObjectType, "announcement_") {
@@ -533,6 +547,16 @@ func ProcessTransientTrigger(ctx context.Context, bucketName, triggerKey, custom
 		}
 
 		log.Printf("✅ Successfully updated archive with processing results")
+
+		// Step 4.5: Refresh this customer's calendar feed (non-fatal)
+		if err := PublishCalendarFeedForChange(ctx, cfg, bucketName, customerCode, metadata); err != nil {
+			log.Printf("⚠️  Failed to publish calendar feed for customer %s: %v", customerCode, err)
+		}
+
+		// Step 4.6: Comment on the referenced Jira issue (non-fatal, runs after emails are sent)
+		if err := SyncJiraForChange(ctx, cfg, bucketName, metadata); err != nil {
+			log.Printf("⚠️  Failed to sync change %s to Jira: %v", changeID, err)
+		}
 	} else {
 		log.Printf("✅ Skipping archive update for announcement (handled by AnnouncementProcessor)")
 	}
@@ -665,10 +689,48 @@ func DownloadMetadataFromS3(ctx context.Context, bucket, key, region string) (*t
 		return nil, fmt.Errorf("failed to read S3 object content: %w", err)
 	}
 
+	return ParseMetadata(contentBytes, result.Metadata)
+}
+
+// LoadArchiveObject downloads an archive object once with the given client and parses it as a
+// change or, when its object_type starts with "announcement_", as an announcement
+func LoadArchiveObject(ctx context.Context, s3Client *s3.Client, bucket, key string) (*types.ChangeMetadata, *types.AnnouncementMetadata, error) {
+	result, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download S3 object: %w", err)
+	}
+	defer result.Body.Close()
+
+	contentBytes, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read S3 object content: %w", err)
+	}
+
+	metadata, err := ParseMetadata(contentBytes, result.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(metadata.ObjectType, "announcement_") {
+		return metadata, nil, nil
+	}
+
+	announcement, err := ParseAnnouncement(contentBytes, result.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, announcement, nil
+}
+
+// ParseMetadata parses a change object downloaded from S3, taking the request type from its S3
+// object metadata. Announcements are returned with only their ObjectType set.
+func ParseMetadata(contentBytes []byte, s3Metadata map[string]string) (*types.ChangeMetadata, error) {
 	// Extract request type from S3 object metadata if available
 	var requestTypeFromS3 string
-	if result.Metadata != nil {
-		if reqType, exists := result.Metadata["request-type"]; exists {
+	if s3Metadata != nil {
+		if reqType, exists := s3Metadata["request-type"]; exists {
 			requestTypeFromS3 = reqType
 		}
 	}
@@ -757,6 +819,7 @@ func ProcessChangeRequest(ctx context.Context, customerCode string, metadata *ty
 		"implementationEnd":    metadata.ImplementationEnd.Format(time.RFC3339),
 		"timezone":             metadata.Timezone,
 		"status":               metadata.Status,
+		"statusReason":         metadata.StatusReason,
 		"version":              metadata.Version,
 		"createdAt":            metadata.CreatedAt,
 		"createdBy":            metadata.CreatedBy,
@@ -783,13 +846,17 @@ func ProcessChangeRequest(ctx context.Context, customerCode string, metadata *ty
 	// Send appropriate notification based on request type
 	switch requestType {
 	case "approval_request":
-		err := SendApprovalRequestEmail(ctx, customerCode, changeDetails, cfg)
+		err := dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			return SendApprovalRequestEmail(ctx, customerCode, changeDetails, cfg)
+		})
 		if err != nil {
 			log.Printf("ERROR: Failed to send approval request email for customer %s: %v", customerCode, err)
 		}
 
 	case "approved_announcement":
-		err := SendApprovedAnnouncementEmail(ctx, customerCode, changeDetails, cfg)
+		err := dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			return SendApprovedAnnouncementEmail(ctx, customerCode, changeDetails, cfg)
+		})
 		if err != nil {
 			log.Printf("ERROR: Failed to send approved announcement email for customer %s: %v", customerCode, err)
 		}
@@ -810,12 +877,16 @@ func ProcessChangeRequest(ctx context.Context, customerCode string, metadata *ty
 		}
 
 		// Now send the completion email (which will include the survey link if survey was created successfully)
-		err = SendChangeCompleteEmail(ctx, customerCode, changeDetails, cfg, s3Bucket, s3Key)
+		err = dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			return SendChangeCompleteEmail(ctx, customerCode, changeDetails, cfg, s3Bucket, s3Key)
+		})
 		if err != nil {
 			log.Printf("ERROR: Failed to send change complete email for customer %s: %v", customerCode, err)
 		}
 	case "change_cancelled":
-		err := SendChangeCancelledEmail(ctx, customerCode, changeDetails, cfg)
+		err := dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			return SendChangeCancelledEmail(ctx, customerCode, changeDetails, cfg)
+		})
 		if err != nil {
 			log.Printf("ERROR: Failed to send change cancelled email for customer %s: %v", customerCode, err)
 		}
@@ -825,6 +896,31 @@ func ProcessChangeRequest(ctx context.Context, customerCode string, metadata *ty
 		if err != nil {
 			log.Printf("ERROR: Failed to cancel meeting for change %s: %v", metadata.ChangeID, err)
 		}
+	case "change_in_progress":
+		err := dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			return SendChangeInProgressEmail(ctx, customerCode, changeDetails, cfg)
+		})
+		if err != nil {
+			log.Printf("ERROR: Failed to send implementation started email for customer %s: %v", customerCode, err)
+		}
+	case "change_failed", "change_rolled_back":
+		// Failed and rolled back changes still collect feedback, so create the survey
+		// FIRST (same as completion) so the survey URL is available for the email
+		err := CreateSurveyForCompletedChange(ctx, metadata, cfg, s3Bucket, s3Key)
+		if err != nil {
+			log.Printf("ERROR: Failed to create survey for change %s: %v", metadata.ChangeID, err)
+			// Don't fail the entire workflow if survey creation fails
+		}
+
+		err = dispatchChangeNotification(ctx, customerCode, metadata, cfg, func(ctx context.Context) error {
+			if requestType == "change_failed" {
+				return SendChangeFailedEmail(ctx, customerCode, changeDetails, cfg, s3Bucket, s3Key)
+			}
+			return SendChangeRolledBackEmail(ctx, customerCode, changeDetails, cfg, s3Bucket, s3Key)
+		})
+		if err != nil {
+			log.Printf("ERROR: Failed to send %s email for customer %s: %v", requestType, customerCode, err)
+		}
 	default:
 		log.Printf("WARNING: Unknown event type '%s' - ignoring", requestType)
 		return nil
@@ -871,6 +967,12 @@ func DetermineRequestTypeFromStatus(status string) string {
 		return "change_complete"
 	case "cancelled":
 		return "change_cancelled"
+	case "in_progress":
+		return "change_in_progress"
+	case "failed":
+		return "change_failed"
+	case "rolled_back":
+		return "change_rolled_back"
 	default:
 		log.Printf("⚠️  Unknown status: %s", status)
 		return "unknown"
@@ -923,6 +1025,13 @@ func ScheduleMultiCustomerMeetingIfNeeded(ctx context.Context, metadata *types.C
 	}
 
 	log.Printf("✅ Successfully scheduled meeting for change %s: ID=%s", metadata.ChangeID, meetingMetadata.MeetingID)
+
+	// Note the meeting on the ServiceNow change request (non-fatal)
+	withMeeting := *metadata
+	withMeeting.MeetingMetadata = meetingMetadata
+	if err := SyncServiceNowTransition(ctx, cfg, s3Bucket, &withMeeting, servicenow.TransitionMeetingScheduled, "meeting:"+meetingMetadata.MeetingID); err != nil {
+		log.Printf("⚠️  Failed to sync meeting for change %s to ServiceNow: %v", metadata.ChangeID, err)
+	}
 	return nil
 }
 
@@ -1113,17 +1222,17 @@ func extractEventTypeAndSubtype(objectType string) (string, string) {
 	return "general", "general"
 }
 
-// generateSurveyURLAndQRCode generates a Typeform survey URL with hidden parameters and QR code
-func generateSurveyURLAndQRCode(metadata *types.ChangeMetadata, cfg *types.Config) (string, string) {
+// generateSurveyURLAndQRCode generates a Typeform survey URL with hidden parameters and QR code.
+// user_login is added per recipient when the email is sent.
+func generateSurveyURLAndQRCode(metadata *types.ChangeMetadata, cfg *types.Config, customerCode string) (string, string) {
 	// Check if survey URL exists in metadata
 	if metadata.SurveyURL == "" {
 		log.Printf("⚠️  No survey URL found in metadata for change %s", metadata.ChangeID)
 		return "", ""
 	}
 
-	// Get customer code (use first customer if multiple)
-	customerCode := ""
-	if len(metadata.Customers) > 0 {
+	// Use the customer being emailed, falling back to the first customer
+	if customerCode == "" && len(metadata.Customers) > 0 {
 		customerCode = metadata.Customers[0]
 	}
 
@@ -1333,6 +1442,7 @@ func createChangeMetadataFromChangeDetails(changeDetails map[string]interface{})
 		ImplementationEnd:   parseTimeString(getString("implementationEnd")),
 		Timezone:            getString("timezone"),
 		Status:              getString("status"),
+		StatusReason:        getString("statusReason"),
 		Version:             1, // Default version
 		CreatedAt:           parseTimeString(getString("createdAt")),
 		CreatedBy:           getString("createdBy"),
@@ -1581,6 +1691,12 @@ func StartLambdaMode() {
 	log.Printf("CCOE Customer Contact Manager Lambda v%s (commit: %s, built: %s)",
 		getVersion(), getGitCommit(), getBuildTime())
 
+	// The same binary is deployed as the scheduled overdue sweeper
+	if os.Getenv("LAMBDA_MODE") == "overdue-sweeper" {
+		lambda.Start(OverdueSweeperHandler)
+		return
+	}
+
 	lambda.Start(Handler)
 }
 
@@ -1672,7 +1788,7 @@ func SendApprovalRequestEmail(ctx context.Context, customerCode string, changeDe
 	metadata := createChangeMetadataFromChangeDetails(changeDetails)
 
 	// Send approval request email using new template system
-	err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, metadata, cfg, "approval_request")
+	_, err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, customerCode, metadata, cfg, "approval_request")
 	if err != nil {
 		log.Printf("❌ Failed to send approval request email: %v", err)
 		return fmt.Errorf("failed to send approval request email: %w", err)
@@ -1734,7 +1850,7 @@ func SendApprovedAnnouncementEmail(ctx context.Context, customerCode string, cha
 	}
 
 	// Send approved announcement email using new template system
-	err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, metadata, cfg, "approved")
+	_, err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, customerCode, metadata, cfg, "approved")
 	if err != nil {
 		log.Printf("❌ Failed to send approved announcement email: %v", err)
 		return fmt.Errorf("failed to send approved announcement email: %w", err)
@@ -1794,7 +1910,8 @@ func SendChangeCompleteEmail(ctx context.Context, customerCode string, changeDet
 	}
 
 	// Send change complete email using new template system
-	err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, metadata, cfg, "completed")
+	recipients, err := sendChangeEmailWithTemplate(ctx, sesClient, topicName, customerCode, metadata, cfg, "completed")
+	recordSurveyDistribution(ctx, cfg, s3Bucket, customerCode, topicName, metadata, recipients)
 	if err != nil {
 		log.Printf("❌ Failed to send change complete email: %v", err)
 		return fmt.Errorf("failed to send change complete email: %w", err)
@@ -1883,7 +2000,7 @@ func SendChangeCancelledEmail(ctx context.Context, customerCode string, changeDe
 	log.Printf("📧 Sending change cancelled notification email for change %s to topic %s", changeID, topicName)
 
 	// Send change cancelled email using new template system
-	err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, metadata, cfg, "cancelled")
+	_, err = sendChangeEmailWithTemplate(ctx, sesClient, topicName, customerCode, metadata, cfg, "cancelled")
 	if err != nil {
 		log.Printf("❌ Failed to send change cancelled email: %v", err)
 		return fmt.Errorf("failed to send change cancelled email: %w", err)
@@ -1900,6 +2017,91 @@ func SendChangeCancelledEmail(ctx context.Context, customerCode string, changeDe
 	return nil
 }
 
+// SendChangeInProgressEmail sends the implementation started notification email for a change
+func SendChangeInProgressEmail(ctx context.Context, customerCode string, changeDetails map[string]interface{}, cfg *types.Config) error {
+	log.Printf("Sending implementation started notification email for customer %s", customerCode)
+
+	metadata := createChangeMetadataFromChangeDetails(changeDetails)
+	return sendImplementationUpdateEmail(ctx, customerCode, metadata, cfg, "", "in_progress")
+}
+
+// SendChangeFailedEmail sends the implementation failed notification email for a change
+func SendChangeFailedEmail(ctx context.Context, customerCode string, changeDetails map[string]interface{}, cfg *types.Config, s3Bucket, s3Key string) error {
+	log.Printf("Sending implementation failed notification email for customer %s", customerCode)
+
+	metadata := loadChangeMetadataWithSurvey(ctx, changeDetails, cfg, s3Bucket, s3Key)
+	return sendImplementationUpdateEmail(ctx, customerCode, metadata, cfg, s3Bucket, "failed")
+}
+
+// SendChangeRolledBackEmail sends the rolled back notification email for a change
+func SendChangeRolledBackEmail(ctx context.Context, customerCode string, changeDetails map[string]interface{}, cfg *types.Config, s3Bucket, s3Key string) error {
+	log.Printf("Sending rolled back notification email for customer %s", customerCode)
+
+	metadata := loadChangeMetadataWithSurvey(ctx, changeDetails, cfg, s3Bucket, s3Key)
+	return sendImplementationUpdateEmail(ctx, customerCode, metadata, cfg, s3Bucket, "rolled_back")
+}
+
+// loadChangeMetadataWithSurvey loads the change from S3 so survey metadata created earlier in the
+// same invocation is available, falling back to changeDetails if the object cannot be read
+func loadChangeMetadataWithSurvey(ctx context.Context, changeDetails map[string]interface{}, cfg *types.Config, s3Bucket, s3Key string) *types.ChangeMetadata {
+	s3UpdateManager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		log.Printf("⚠️  Failed to create S3 update manager, falling back to changeDetails: %v", err)
+		return createChangeMetadataFromChangeDetails(changeDetails)
+	}
+
+	metadata, err := s3UpdateManager.LoadChangeObjectFromS3(ctx, s3Bucket, s3Key)
+	if err != nil {
+		log.Printf("⚠️  Failed to load metadata from S3, falling back to changeDetails: %v", err)
+		return createChangeMetadataFromChangeDetails(changeDetails)
+	}
+
+	return metadata
+}
+
+// sendImplementationUpdateEmail sends an implementation lifecycle notification to the aws-announce topic.
+// Survey recipients of failed and rolled back notifications are recorded in s3Bucket.
+func sendImplementationUpdateEmail(ctx context.Context, customerCode string, metadata *types.ChangeMetadata, cfg *types.Config, s3Bucket, notificationType string) error {
+	// Create credential manager to assume customer role
+	credentialManager, err := awsinternal.NewCredentialManager(cfg.AWSRegion, cfg.CustomerMappings)
+	if err != nil {
+		return fmt.Errorf("failed to create credential manager: %w", err)
+	}
+
+	// Get customer-specific AWS config (assumes SES role)
+	customerConfig, err := credentialManager.GetCustomerConfig(customerCode)
+	if err != nil {
+		return fmt.Errorf("failed to get customer config for %s: %w", customerCode, err)
+	}
+
+	// Create SES client with assumed role credentials
+	sesClient := sesv2.NewFromConfig(customerConfig)
+
+	// Implementation updates only happen after approval, so they go to the broader audience
+	topicName := "aws-announce"
+
+	log.Printf("📧 Sending %s notification email for change %s", notificationType, metadata.ChangeID)
+
+	recipients, err := sendChangeEmailWithTemplate(ctx, sesClient, topicName, customerCode, metadata, cfg, notificationType)
+	if notificationType != "in_progress" {
+		recordSurveyDistribution(ctx, cfg, s3Bucket, customerCode, topicName, metadata, recipients)
+	}
+	if err != nil {
+		log.Printf("❌ Failed to send %s email: %v", notificationType, err)
+		return fmt.Errorf("failed to send %s email: %w", notificationType, err)
+	}
+
+	// Get topic subscriber count for logging
+	subscriberCount, err := getTopicSubscriberCount(sesClient, topicName)
+	if err != nil {
+		log.Printf("⚠️  Could not get subscriber count: %v", err)
+		subscriberCount = "unknown"
+	}
+
+	log.Printf("✅ %s notification email sent to %s members of topic %s", notificationType, subscriberCount, topicName)
+	return nil
+}
+
 // generateApprovalRequestHTML generates HTML content for approval request emails
 func generateApprovalRequestHTML(metadata *types.ChangeMetadata) string {
 	// Use centralized timezone formatting function
@@ -3586,23 +3788,24 @@ func HandleMeetingCancellationFailure(ctx context.Context, changeMetadata *types
 		changeMetadata.ChangeID, meetingID, err, time.Now().Format(time.RFC3339))
 }
 
-// sendChangeEmailWithTemplate sends change notification emails using the new template system
-func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, topicName string, metadata *types.ChangeMetadata, cfg *types.Config, notificationType string) error {
+// sendChangeEmailWithTemplate sends change notification emails using the new template system and
+// returns the addresses it was sent to. Survey links are personalised with each recipient's user_login.
+func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, topicName, customerCode string, metadata *types.ChangeMetadata, cfg *types.Config, notificationType string) ([]string, error) {
 	// Get account contact list
 	accountListName, err := ses.GetAccountContactList(sesClient)
 	if err != nil {
-		return fmt.Errorf("failed to get account contact list: %w", err)
+		return nil, fmt.Errorf("failed to get account contact list: %w", err)
 	}
 
 	// Get all contacts that should receive emails for this topic
 	subscribedContacts, err := getSubscribedContactsForTopic(sesClient, accountListName, topicName)
 	if err != nil {
-		return fmt.Errorf("failed to get subscribed contacts for topic '%s': %w", topicName, err)
+		return nil, fmt.Errorf("failed to get subscribed contacts for topic '%s': %w", topicName, err)
 	}
 
 	if len(subscribedContacts) == 0 {
 		log.Printf("⚠️  No contacts are subscribed to topic '%s'", topicName)
-		return nil
+		return nil, nil
 	}
 
 	// Initialize template registry with email config
@@ -3611,10 +3814,11 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 	// Prepare template data based on notification type
 	var template templates.EmailTemplate
 	var templateErr error
+	var surveyURL string
 
 	switch notificationType {
 	case "approval_request":
-		// Get customer code from metadata (use first customer if multiple)
+		// Approval links use the first customer, matching the approvals page
 		customerCode := ""
 		if len(metadata.Customers) > 0 {
 			customerCode = metadata.Customers[0]
@@ -3660,7 +3864,8 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 
 	case "completed":
 		// Generate survey URL with hidden parameters
-		surveyURL, qrCode := generateSurveyURLAndQRCode(metadata, cfg)
+		var qrCode string
+		surveyURL, qrCode = generateSurveyURLAndQRCode(metadata, cfg, customerCode)
 
 		data := templates.CompletionData{
 			BaseTemplateData: templates.BaseTemplateData{
@@ -3703,12 +3908,44 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 		}
 		template, templateErr = registry.GetTemplate("change", templates.NotificationCancelled, data)
 
+	case "in_progress", "failed", "rolled_back":
+		data := templates.ImplementationUpdateData{
+			BaseTemplateData: templates.BaseTemplateData{
+				EventID:       metadata.ChangeID,
+				EventType:     "change",
+				Category:      "change",
+				Status:        metadata.Status,
+				Title:         metadata.ChangeTitle,
+				Summary:       metadata.ChangeReason,
+				Content:       metadata.ImplementationPlan,
+				SenderAddress: cfg.EmailConfig.SenderAddress,
+				Timestamp:     time.Now(),
+				Attachments:   extractAttachments(metadata),
+			},
+			UpdatedBy:      metadata.ModifiedBy,
+			UpdatedByEmail: "", // Not available in current metadata
+			UpdatedAt:      metadata.ModifiedAt,
+			Reason:         metadata.StatusReason,
+			RollbackPlan:   metadata.RollbackPlan,
+			WindowStart:    metadata.ImplementationStart,
+			WindowEnd:      metadata.ImplementationEnd,
+			WindowTimezone: metadata.Timezone,
+		}
+
+		// Failed and rolled back changes include the feedback survey
+		if notificationType != "in_progress" {
+			surveyURL, data.SurveyQRCode = generateSurveyURLAndQRCode(metadata, cfg, customerCode)
+			data.SurveyURL = surveyURL
+		}
+
+		template, templateErr = registry.GetTemplate("change", templates.NotificationType(notificationType), data)
+
 	default:
-		return fmt.Errorf("unknown notification type: %s", notificationType)
+		return nil, fmt.Errorf("unknown notification type: %s", notificationType)
 	}
 
 	if templateErr != nil {
-		return fmt.Errorf("failed to generate template: %w", templateErr)
+		return nil, fmt.Errorf("failed to generate template: %w", templateErr)
 	}
 
 	log.Printf("📧 Sending %s notification to topic '%s' (%d subscribers)", notificationType, topicName, len(subscribedContacts))
@@ -3744,6 +3981,7 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 	successCount := 0
 	errorCount := 0
 	skippedCount := 0
+	var sentTo []string
 
 	// Send to each subscribed contact
 	for _, contact := range subscribedContacts {
@@ -3756,6 +3994,13 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 
 		sendInput.Destination.ToAddresses = []string{*contact.EmailAddress}
 
+		// Personalise the survey link so the response can be matched to the recipient
+		if surveyURL != "" {
+			personalURL := surveys.PersonalizeSurveyURL(surveyURL, *contact.EmailAddress)
+			sendInput.Content.Simple.Body.Html.Data = aws.String(strings.ReplaceAll(template.HTMLBody, surveyURL, personalURL))
+			sendInput.Content.Simple.Body.Text.Data = aws.String(strings.ReplaceAll(template.TextBody, surveyURL, personalURL))
+		}
+
 		_, err := sesClient.SendEmail(ctx, sendInput)
 		if err != nil {
 			log.Printf("   ❌ Failed to send to %s: %v", *contact.EmailAddress, err)
@@ -3763,6 +4008,7 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 		} else {
 			log.Printf("   ✅ Sent to %s", *contact.EmailAddress)
 			successCount++
+			sentTo = append(sentTo, *contact.EmailAddress)
 		}
 	}
 
@@ -3773,10 +4019,43 @@ func sendChangeEmailWithTemplate(ctx context.Context, sesClient *sesv2.Client, t
 	}
 
 	if errorCount > 0 {
-		return fmt.Errorf("failed to send email to %d recipients", errorCount)
+		return sentTo, fmt.Errorf("failed to send email to %d recipients", errorCount)
 	}
 
-	return nil
+	return sentTo, nil
+}
+
+// recordSurveyDistribution records who was sent a change's survey, and on which topic, so
+// non-respondents can be reminded. Failures are logged rather than failing the notification.
+func recordSurveyDistribution(ctx context.Context, cfg *types.Config, s3Bucket, customerCode, topicName string, metadata *types.ChangeMetadata, recipients []string) {
+	if s3Bucket == "" || metadata.SurveyID == "" || len(recipients) == 0 {
+		return
+	}
+
+	surveyURL, _ := generateSurveyURLAndQRCode(metadata, cfg, customerCode)
+	distribution := surveys.Distribution{
+		ObjectID:     metadata.ChangeID,
+		CustomerCode: customerCode,
+		FormID:       metadata.SurveyID,
+		SurveyURL:    surveyURL,
+		Title:        metadata.ChangeTitle,
+		Summary:      metadata.ChangeReason,
+		Topic:        topicName,
+		Recipients:   recipients,
+		SentAt:       time.Now().UTC(),
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		log.Printf("⚠️  Failed to record survey recipients for %s: %v", metadata.ChangeID, err)
+		return
+	}
+	s3Client := s3.NewFromConfig(awsCfg)
+	if err := surveys.RecordDistribution(ctx, s3Client, s3Bucket, distribution); err != nil {
+		log.Printf("⚠️  Failed to record survey recipients for %s: %v", metadata.ChangeID, err)
+		return
+	}
+	log.Printf("📋 Recorded %d survey recipients for %s (%s)", len(recipients), metadata.ChangeID, customerCode)
 }
 
 // extractAttachments extracts attachment URLs from metadata
@@ -0,0 +1,190 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/archive"
+	"ccoe-customer-contact-manager/internal/jira"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ExternalSystemJira identifies Jira in external modification entries
+const ExternalSystemJira = "jira"
+
+// Jira sync states recorded in external_sync entries. Failed attempts record the furthest
+// step reached (empty or commented) together with the error.
+const (
+	JiraSyncClaimed      = "claimed"
+	JiraSyncCommented    = "commented"
+	JiraSyncTransitioned = "transitioned"
+)
+
+// JiraRetryResult summarizes a retry pass over failed Jira syncs
+type JiraRetryResult struct {
+	Scanned   int
+	Retried   int
+	Succeeded int
+	Failed    int
+}
+
+// SyncJiraForChange comments on the Jira issue referenced by a change's jiraTicket for its
+// current status and drives the configured workflow transition. The event is claimed with an
+// external_sync entry on the archived change so it is posted once across customers, and the
+// outcome is recorded so failures can be retried by the scheduled sweep.
+func SyncJiraForChange(ctx context.Context, cfg *types.Config, bucket string, metadata *types.ChangeMetadata) error {
+	if !cfg.Jira.IsEnabled() || strings.TrimSpace(metadata.JiraTicket) == "" {
+		return nil
+	}
+
+	event := jira.EventForStatus(metadata.Status)
+	if event == "" {
+		return nil
+	}
+
+	issueKey := jira.IssueKeyForChange(cfg.Jira, metadata)
+	if issueKey == "" {
+		return fmt.Errorf("cannot determine Jira issue for ticket %q on change %s (no project mapping for its customers)", metadata.JiraTicket, metadata.ChangeID)
+	}
+
+	client, err := jira.NewClientFromEnv(cfg.Jira.BaseURL, cfg.Jira.AuthType)
+	if err != nil {
+		return err
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	archiveKey := fmt.Sprintf("archive/%s.json", metadata.ChangeID)
+	userID := NewModificationManager().BackendUserID
+	ref := &types.ExternalReference{
+		System: ExternalSystemJira,
+		Ticket: issueKey,
+		Event:  event,
+		State:  JiraSyncClaimed,
+		Key:    lifecycleSyncKey(event, metadata),
+	}
+
+	// The claim reloads the archive, which also picks up the meeting and survey created
+	// while processing this transition
+	latest := metadata
+	claimed, err := s3Manager.appendExternalEntry(ctx, bucket, archiveKey, types.ModificationTypeExternalSync, userID, ref, func(existing *types.ChangeMetadata) bool {
+		latest = existing
+		return hasExternalSync(existing, ref)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record Jira sync for change %s: %w", metadata.ChangeID, err)
+	}
+	if !claimed {
+		log.Printf("⏭️  Jira %s for change %s already posted to %s", event, metadata.ChangeID, issueKey)
+		return nil
+	}
+
+	return deliverJiraEvent(ctx, client, s3Manager, cfg, bucket, archiveKey, userID, ref, latest, false)
+}
+
+// RetryFailedJiraSyncs retries Jira events whose last attempt failed, up to the configured number
+// of attempts. Events for a status the change has since left are not retried.
+func RetryFailedJiraSyncs(ctx context.Context, cfg *types.Config, bucket string) (*JiraRetryResult, error) {
+	if !cfg.Jira.IsEnabled() {
+		return nil, fmt.Errorf("jira is not enabled in the configuration")
+	}
+
+	client, err := jira.NewClientFromEnv(cfg.Jira.BaseURL, cfg.Jira.AuthType)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Manager.s3Client, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := cfg.Jira.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = types.DefaultJiraMaxAttempts
+	}
+
+	result := &JiraRetryResult{}
+	userID := NewModificationManager().BackendUserID
+
+	for _, object := range objects {
+		key := object.Key
+		metadata, err := s3Manager.LoadChangeObjectFromS3(ctx, bucket, key)
+		if err != nil {
+			log.Printf("⚠️  Skipping %s: %v", key, err)
+			continue
+		}
+		result.Scanned++
+
+		for _, failed := range pendingJiraRetries(metadata, maxAttempts) {
+			result.Retried++
+			ref := &types.ExternalReference{System: ExternalSystemJira, Ticket: failed.Ticket, Event: failed.Event, Key: failed.Key}
+			if err := deliverJiraEvent(ctx, client, s3Manager, cfg, bucket, key, userID, ref, metadata, failed.State == JiraSyncCommented); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Succeeded++
+		}
+	}
+
+	return result, nil
+}
+
+// deliverJiraEvent posts the comment and transition for an event and records the outcome as an
+// external_sync entry. When commented is set the comment already went out and only the
+// transition is attempted.
+func deliverJiraEvent(ctx context.Context, client *jira.Client, s3Manager *S3UpdateManager, cfg *types.Config, bucket, archiveKey, userID string, ref *types.ExternalReference, metadata *types.ChangeMetadata, commented bool) error {
+	outcome := *ref
+	outcome.State = ""
+
+	var err error
+	if !commented {
+		err = client.AddComment(ctx, ref.Ticket, jira.Comment(ref.Event, metadata, cfg.EmailConfig.PortalBaseURL))
+	}
+	if err == nil {
+		outcome.State = JiraSyncCommented
+		if name := jira.TransitionFor(cfg.Jira, ref.Ticket, ref.Event); name != "" {
+			var transitioned bool
+			transitioned, err = client.TransitionIssue(ctx, ref.Ticket, name)
+			if transitioned {
+				outcome.State = JiraSyncTransitioned
+			} else if err == nil {
+				log.Printf("ℹ️  Jira transition %q is not available on %s, leaving its status unchanged", name, ref.Ticket)
+			}
+		}
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+
+	if _, recordErr := s3Manager.appendExternalEntry(ctx, bucket, archiveKey, types.ModificationTypeExternalSync, userID, &outcome, func(*types.ChangeMetadata) bool {
+		return false
+	}); recordErr != nil {
+		log.Printf("⚠️  Failed to record Jira sync outcome for change %s: %v", metadata.ChangeID, recordErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to sync %s for change %s to Jira %s: %w", ref.Event, metadata.ChangeID, ref.Ticket, err)
+	}
+	log.Printf("✅ Synced %s for change %s to Jira %s (%s)", ref.Event, metadata.ChangeID, ref.Ticket, outcome.State)
+	return nil
+}
+
+// pendingJiraRetries returns the latest entry of each Jira event that failed fewer than
+// maxAttempts times and still matches the change's current status
+func pendingJiraRetries(metadata *types.ChangeMetadata, maxAttempts int) []types.ExternalReference {
+	current := jira.EventForStatus(metadata.Status)
+	return pendingExternalRetries(metadata, ExternalSystemJira, maxAttempts, func(ref types.ExternalReference) bool {
+		return ref.Event == current
+	})
+}
@@ -0,0 +1,228 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/jira"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// jiraTestUserID is the backend role that records sync outcomes
+const jiraTestUserID = "arn:aws:iam::123456789012:role/backend-lambda-role"
+
+func jiraEntry(key, event, state, errMsg string) types.ModificationEntry {
+	return types.ModificationEntry{
+		ModificationType: types.ModificationTypeExternalSync,
+		External: &types.ExternalReference{
+			System: ExternalSystemJira,
+			Ticket: "OPS-12",
+			Event:  event,
+			State:  state,
+			Key:    key,
+			Error:  errMsg,
+		},
+	}
+}
+
+// fakeJira records the comments and transitions posted to one issue
+type fakeJira struct {
+	mu          sync.Mutex
+	comments    []string
+	transitions []string
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/OPS-12/comment":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		f.comments = append(f.comments, body["body"])
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/OPS-12/transitions":
+		w.Write([]byte(`{"transitions":[{"id":"11","name":"Approve","to":{"name":"Approved"}},{"id":"21","name":"Start Implementation","to":{"name":"In Progress"}}]}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/OPS-12/transitions":
+		var body struct {
+			Transition struct {
+				ID string `json:"id"`
+			} `json:"transition"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		f.transitions = append(f.transitions, body.Transition.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// fakeS3 stores objects in memory and honours If-Match on PUT
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	version int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	etag := fmt.Sprintf(`"v%d"`, f.version)
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write(data)
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); match != "" && match != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+		f.version++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, f.version))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// outcomes returns the Jira external_sync entries recorded on the stored change
+func (f *fakeS3) outcomes(t *testing.T, path string) []types.ExternalReference {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var metadata types.ChangeMetadata
+	if err := json.Unmarshal(f.objects[path], &metadata); err != nil {
+		t.Fatalf("Failed to decode stored change: %v", err)
+	}
+	var refs []types.ExternalReference
+	for _, mod := range metadata.Modifications {
+		if mod.External != nil && mod.External.System == ExternalSystemJira {
+			refs = append(refs, *mod.External)
+		}
+	}
+	return refs
+}
+
+func newJiraDeliveryFixture(t *testing.T) (*fakeJira, *fakeS3, *jira.Client, *S3UpdateManager, *types.ChangeMetadata) {
+	t.Helper()
+
+	fj := &fakeJira{}
+	jiraServer := httptest.NewServer(fj)
+	t.Cleanup(jiraServer.Close)
+
+	metadata := &types.ChangeMetadata{ChangeID: "CHG-1", ChangeTitle: "Rotate certificates", Status: "in_progress", JiraTicket: "OPS-12"}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("Failed to encode change: %v", err)
+	}
+	fs := &fakeS3{objects: map[string][]byte{"/bucket/archive/CHG-1.json": data}}
+	s3Server := httptest.NewServer(fs)
+	t.Cleanup(s3Server.Close)
+
+	s3Manager := &S3UpdateManager{s3Client: s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(s3Server.URL),
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	})}
+
+	return fj, fs, jira.NewClient(jiraServer.URL, "basic", "bot@example.com", "token"), s3Manager, metadata
+}
+
+func TestDeliverJiraEventTransitionsByName(t *testing.T) {
+	fj, fs, client, s3Manager, metadata := newJiraDeliveryFixture(t)
+	cfg := &types.Config{Jira: &types.JiraConfig{
+		Transitions: map[string]string{"in_progress": "start implementation"},
+	}}
+	ref := &types.ExternalReference{System: ExternalSystemJira, Ticket: "OPS-12", Event: "in_progress", State: JiraSyncClaimed, Key: "in_progress:v1"}
+
+	if err := deliverJiraEvent(context.Background(), client, s3Manager, cfg, "bucket", "archive/CHG-1.json", jiraTestUserID, ref, metadata, false); err != nil {
+		t.Fatalf("Expected delivery to succeed, got %v", err)
+	}
+
+	if len(fj.comments) != 1 || !strings.Contains(fj.comments[0], "CHG-1") {
+		t.Errorf("Expected one comment about the change, got %q", fj.comments)
+	}
+	// The configured name matches the transition case-insensitively, not its position or ID
+	if len(fj.transitions) != 1 || fj.transitions[0] != "21" {
+		t.Errorf("Expected the Start Implementation transition (21), got %v", fj.transitions)
+	}
+
+	outcomes := fs.outcomes(t, "/bucket/archive/CHG-1.json")
+	if len(outcomes) != 1 || outcomes[0].State != JiraSyncTransitioned || outcomes[0].Error != "" {
+		t.Errorf("Expected a transitioned outcome, got %+v", outcomes)
+	}
+}
+
+func TestDeliverJiraEventResumesAfterComment(t *testing.T) {
+	fj, fs, client, s3Manager, metadata := newJiraDeliveryFixture(t)
+	cfg := &types.Config{Jira: &types.JiraConfig{
+		Transitions: map[string]string{"in_progress": "Start Implementation"},
+		// The project override is matched on the issue key's project
+		Projects: map[string]types.JiraProjectConfig{"acme": {ProjectKey: "OPS", Transitions: map[string]string{"in_progress": "In Progress"}}},
+	}}
+
+	// The sweeper resumes an event whose comment was posted but whose transition failed
+	metadata.Modifications = []types.ModificationEntry{jiraEntry("in_progress:v1", "in_progress", JiraSyncCommented, "jira returned status 500")}
+	pending := pendingJiraRetries(metadata, 3)
+	if len(pending) != 1 || pending[0].State != JiraSyncCommented {
+		t.Fatalf("Expected the commented event to be pending, got %+v", pending)
+	}
+
+	failed := pending[0]
+	ref := &types.ExternalReference{System: ExternalSystemJira, Ticket: failed.Ticket, Event: failed.Event, Key: failed.Key}
+	if err := deliverJiraEvent(context.Background(), client, s3Manager, cfg, "bucket", "archive/CHG-1.json", jiraTestUserID, ref, metadata, failed.State == JiraSyncCommented); err != nil {
+		t.Fatalf("Expected delivery to succeed, got %v", err)
+	}
+
+	if len(fj.comments) != 0 {
+		t.Errorf("Expected the comment not to be posted again, got %q", fj.comments)
+	}
+	if len(fj.transitions) != 1 || fj.transitions[0] != "21" {
+		t.Errorf("Expected the transition to the In Progress status (21), got %v", fj.transitions)
+	}
+
+	outcomes := fs.outcomes(t, "/bucket/archive/CHG-1.json")
+	if len(outcomes) != 1 || outcomes[0].State != JiraSyncTransitioned || outcomes[0].Error != "" {
+		t.Errorf("Expected a transitioned outcome, got %+v", outcomes)
+	}
+}
+
+func TestDeliverJiraEventWithoutAvailableTransition(t *testing.T) {
+	fj, fs, client, s3Manager, metadata := newJiraDeliveryFixture(t)
+	cfg := &types.Config{Jira: &types.JiraConfig{
+		Transitions: map[string]string{"in_progress": "Done"},
+	}}
+	ref := &types.ExternalReference{System: ExternalSystemJira, Ticket: "OPS-12", Event: "in_progress", State: JiraSyncClaimed, Key: "in_progress:v1"}
+
+	if err := deliverJiraEvent(context.Background(), client, s3Manager, cfg, "bucket", "archive/CHG-1.json", jiraTestUserID, ref, metadata, false); err != nil {
+		t.Fatalf("Expected an unavailable transition not to fail delivery, got %v", err)
+	}
+
+	if len(fj.comments) != 1 || len(fj.transitions) != 0 {
+		t.Errorf("Expected a comment and no transition, got %d comments and %v", len(fj.comments), fj.transitions)
+	}
+	outcomes := fs.outcomes(t, "/bucket/archive/CHG-1.json")
+	if len(outcomes) != 1 || outcomes[0].State != JiraSyncCommented || outcomes[0].Error != "" {
+		t.Errorf("Expected a commented outcome, got %+v", outcomes)
+	}
+}
@@ -242,7 +242,7 @@ func TestMeetingSchedulerIdempotency(t *testing.T) {
 		// Test the meeting creation logic (without actual API calls)
 		meetingMetadata, err := scheduler.createGraphMeeting(context.Background(), changeMetadata)
 		if err != nil {
-			t.Errorf("Failed to create meeting metadata: %v", err)
+			t.Fatalf("Failed to create meeting metadata: %v", err)
 		}
 
 		if meetingMetadata.MeetingID == "" {
@@ -0,0 +1,55 @@
+package lambda
+
+import (
+	"context"
+	"log"
+
+	"ccoe-customer-contact-manager/internal/notify"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// changeNotificationTopic returns the SES topic a change lifecycle email is sent to. Chat
+// channels mirror topics, so this must match the topic chosen by the Send*Email functions.
+func changeNotificationTopic(metadata *types.ChangeMetadata) string {
+	switch metadata.Status {
+	case "submitted":
+		return "aws-approval"
+	case "cancelled":
+		if metadata.ApprovedAt != nil || metadata.ApprovedBy != "" || metadata.PriorStatus == "approved" {
+			return "aws-announce"
+		}
+		for _, mod := range metadata.Modifications {
+			if mod.ModificationType == types.ModificationTypeApproved {
+				return "aws-announce"
+			}
+		}
+		return "aws-approval"
+	default:
+		return "aws-announce"
+	}
+}
+
+// dispatchChangeNotification sends a change email and mirrors it to the topic's chat channels
+func dispatchChangeNotification(ctx context.Context, customerCode string, metadata *types.ChangeMetadata, cfg *types.Config, sendEmail func(ctx context.Context) error) error {
+	customer := cfg.CustomerMappings[customerCode]
+	if customer.CustomerCode == "" {
+		customer.CustomerCode = customerCode
+	}
+
+	topic := changeNotificationTopic(metadata)
+	event := notify.ChangeEvent(metadata, customer, topic, cfg.EmailConfig.PortalBaseURL)
+
+	notifiers := []notify.Notifier{&notify.EmailNotifier{Send: func(ctx context.Context, _ notify.Event) error {
+		return sendEmail(ctx)
+	}}}
+	notifiers = append(notifiers, notify.ChannelNotifiers(customer.NotificationChannels, topic)...)
+
+	failures := notify.NewDispatcher(notifiers...).Dispatch(ctx, event)
+	for name, err := range failures {
+		if name != notify.ChannelEmail {
+			log.Printf("⚠️  Failed to notify %s for change %s (customer %s): %v", name, metadata.ChangeID, customerCode, err)
+		}
+	}
+
+	return failures[notify.ChannelEmail]
+}
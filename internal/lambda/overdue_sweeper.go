@@ -0,0 +1,452 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"ccoe-customer-contact-manager/internal/archive"
+	awsinternal "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/config"
+	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// OverdueAction is the action the sweeper takes for a single overdue change
+type OverdueAction string
+
+const (
+	OverdueActionNone         OverdueAction = "none"
+	OverdueActionReminder     OverdueAction = "reminder"
+	OverdueActionEscalation   OverdueAction = "escalation"
+	OverdueActionAutoComplete OverdueAction = "auto_complete"
+)
+
+// OverdueSweepItem records what happened to a single change during a sweep
+type OverdueSweepItem struct {
+	ChangeID  string
+	Key       string
+	Action    OverdueAction
+	OverdueBy time.Duration
+	Err       error
+}
+
+// OverdueSweepResult summarizes a sweep over the archive
+type OverdueSweepResult struct {
+	Scanned       int
+	Overdue       int
+	Reminders     int
+	Escalations   int
+	AutoCompleted int
+	Errors        int
+	Items         []OverdueSweepItem
+}
+
+// OverdueSweeperHandler is the Lambda entry point for the scheduled overdue change sweep
+func OverdueSweeperHandler(ctx context.Context, event events.CloudWatchEvent) error {
+	configFile := os.Getenv("CONFIG_FILE")
+	if configFile == "" {
+		configFile = "config.json"
+	}
+
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	if err := config.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("configuration validation failed: %v", err)
+	}
+
+	if cfg.S3Config.BucketName == "" {
+		return fmt.Errorf("s3_config.bucket_name is required for the overdue sweeper")
+	}
+
+	log.Printf("⏰ Starting overdue change sweep (event %s)", event.ID)
+
+	result, err := SweepOverdueChanges(ctx, cfg, cfg.S3Config.BucketName, time.Now(), false)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("📊 Overdue Sweep Summary: %d scanned, %d overdue, %d reminders, %d escalations, %d auto-completed, %d errors",
+		result.Scanned, result.Overdue, result.Reminders, result.Escalations, result.AutoCompleted, result.Errors)
+
+	// The scheduled sweep also pulls ServiceNow approvals for submitted changes when configured
+	if cfg.ServiceNow.IsEnabled() && cfg.ServiceNow.PullApprovals {
+		pullServiceNowApprovalsOnSweep(ctx, cfg)
+	}
+
+	// ...and retries ServiceNow and Jira syncs that failed when the change was processed
+	if cfg.ServiceNow.IsEnabled() {
+		retryServiceNowSyncsOnSweep(ctx, cfg)
+	}
+	if cfg.Jira.IsEnabled() {
+		retryJiraSyncsOnSweep(ctx, cfg)
+	}
+
+	// ...and reminds survey non-respondents, closing forms past their deadline
+	if cfg.SurveyReminders.IsEnabled() {
+		sendSurveyRemindersOnSweep(ctx, cfg)
+	}
+
+	return nil
+}
+
+// pullServiceNowApprovalsOnSweep runs the ServiceNow approval pull, logging rather than failing the sweep
+func pullServiceNowApprovalsOnSweep(ctx context.Context, cfg *types.Config) {
+	if err := ses.LoadServiceNowCredentialsFromSSM(ctx); err != nil {
+		log.Printf("⚠️  Skipping ServiceNow approval pull: %v", err)
+		return
+	}
+
+	approvals, err := PullServiceNowApprovals(ctx, cfg, cfg.S3Config.BucketName, false)
+	if err != nil {
+		log.Printf("⚠️  ServiceNow approval pull failed: %v", err)
+		return
+	}
+	log.Printf("📊 ServiceNow Approval Summary: %d checked, %d recorded, %d errors", approvals.Checked, approvals.Recorded, approvals.Errors)
+}
+
+// retryServiceNowSyncsOnSweep retries failed ServiceNow syncs, logging rather than failing the sweep
+func retryServiceNowSyncsOnSweep(ctx context.Context, cfg *types.Config) {
+	if err := ses.LoadServiceNowCredentialsFromSSM(ctx); err != nil {
+		log.Printf("⚠️  Skipping ServiceNow retries: %v", err)
+		return
+	}
+
+	retries, err := RetryFailedServiceNowSyncs(ctx, cfg, cfg.S3Config.BucketName)
+	if err != nil {
+		log.Printf("⚠️  ServiceNow retry pass failed: %v", err)
+		return
+	}
+	log.Printf("📊 ServiceNow Retry Summary: %d retried, %d succeeded, %d failed", retries.Retried, retries.Succeeded, retries.Failed)
+}
+
+// retryJiraSyncsOnSweep retries failed Jira syncs, logging rather than failing the sweep
+func retryJiraSyncsOnSweep(ctx context.Context, cfg *types.Config) {
+	if err := ses.LoadJiraCredentialsFromSSM(ctx); err != nil {
+		log.Printf("⚠️  Skipping Jira retries: %v", err)
+		return
+	}
+
+	retries, err := RetryFailedJiraSyncs(ctx, cfg, cfg.S3Config.BucketName)
+	if err != nil {
+		log.Printf("⚠️  Jira retry pass failed: %v", err)
+		return
+	}
+	log.Printf("📊 Jira Retry Summary: %d retried, %d succeeded, %d failed", retries.Retried, retries.Succeeded, retries.Failed)
+}
+
+// SweepOverdueChanges scans archive/ for approved changes past their implementation end and
+// sends reminders, escalations, or auto-completes them according to the sweeper configuration
+func SweepOverdueChanges(ctx context.Context, cfg *types.Config, bucket string, now time.Time, dryRun bool) (*OverdueSweepResult, error) {
+	sweeperCfg := cfg.OverdueSweeper.WithDefaults()
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Manager.s3Client, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OverdueSweepResult{}
+
+	for _, object := range objects {
+		key := object.Key
+		metadata, err := s3Manager.LoadChangeObjectFromS3(ctx, bucket, key)
+		if err != nil {
+			log.Printf("⚠️  Skipping %s: %v", key, err)
+			result.Errors++
+			continue
+		}
+		result.Scanned++
+
+		// Announcements have no implementation window
+		if strings.HasPrefix(metadata.ObjectType, "announcement_") {
+			continue
+		}
+
+		action := determineOverdueAction(metadata, sweeperCfg, now)
+		if action == OverdueActionNone {
+			continue
+		}
+
+		item := OverdueSweepItem{
+			ChangeID:  metadata.ChangeID,
+			Key:       key,
+			Action:    action,
+			OverdueBy: now.Sub(metadata.ImplementationEnd),
+		}
+		result.Overdue++
+
+		if dryRun {
+			log.Printf("🔍 DRY RUN: Would take action %s for change %s (overdue by %s)", action, metadata.ChangeID, item.OverdueBy.Round(time.Minute))
+		} else {
+			item.Err = applyOverdueAction(ctx, s3Manager, cfg, sweeperCfg, bucket, key, metadata, action, now)
+		}
+
+		if item.Err != nil {
+			log.Printf("❌ Failed to apply %s for change %s: %v", action, metadata.ChangeID, item.Err)
+			result.Errors++
+		} else {
+			switch action {
+			case OverdueActionReminder:
+				result.Reminders++
+			case OverdueActionEscalation:
+				result.Escalations++
+			case OverdueActionAutoComplete:
+				result.AutoCompleted++
+			}
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// determineOverdueAction decides what the sweeper should do with a change. Overdue modification
+// entries recorded after the current implementation end make the sweep idempotent, while
+// rescheduling a change (moving its end time forward) starts the reminder cycle again.
+func determineOverdueAction(metadata *types.ChangeMetadata, sweeperCfg types.OverdueSweeperConfig, now time.Time) OverdueAction {
+	if metadata.Status != "approved" || metadata.ImplementationEnd.IsZero() {
+		return OverdueActionNone
+	}
+
+	overdueBy := now.Sub(metadata.ImplementationEnd)
+	if overdueBy < time.Duration(sweeperCfg.GracePeriodHours)*time.Hour {
+		return OverdueActionNone
+	}
+
+	if sweeperCfg.AutoComplete && overdueBy >= time.Duration(sweeperCfg.AutoCompleteHours)*time.Hour {
+		return OverdueActionAutoComplete
+	}
+
+	escalated := hasOverdueEntrySince(metadata, types.ModificationTypeOverdueEscalated)
+	if overdueBy >= time.Duration(sweeperCfg.EscalationHours)*time.Hour && !escalated {
+		return OverdueActionEscalation
+	}
+
+	if !escalated && !hasOverdueEntrySince(metadata, types.ModificationTypeOverdueReminder) {
+		return OverdueActionReminder
+	}
+
+	return OverdueActionNone
+}
+
+// hasOverdueEntrySince reports whether a modification of the given type was recorded after the implementation end
+func hasOverdueEntrySince(metadata *types.ChangeMetadata, modificationType string) bool {
+	for _, mod := range metadata.Modifications {
+		if mod.ModificationType == modificationType && mod.Timestamp.After(metadata.ImplementationEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyOverdueAction sends the reminder for an overdue change and records the result in the archive
+func applyOverdueAction(ctx context.Context, s3Manager *S3UpdateManager, cfg *types.Config, sweeperCfg types.OverdueSweeperConfig, bucket, key string, metadata *types.ChangeMetadata, action OverdueAction, now time.Time) error {
+	modManager := NewModificationManager()
+
+	switch action {
+	case OverdueActionReminder, OverdueActionEscalation:
+		escalated := action == OverdueActionEscalation
+		if err := sendOverdueReminderEmail(ctx, cfg, sweeperCfg, metadata, escalated, now); err != nil {
+			return err
+		}
+
+		modificationType := types.ModificationTypeOverdueReminder
+		if escalated {
+			modificationType = types.ModificationTypeOverdueEscalated
+		}
+
+		entry, err := types.NewModificationEntry(modificationType, modManager.BackendUserID)
+		if err != nil {
+			return fmt.Errorf("failed to create %s entry: %w", modificationType, err)
+		}
+
+		return s3Manager.UpdateChangeObjectWithModificationOptimistic(ctx, bucket, key, entry, 3)
+
+	case OverdueActionAutoComplete:
+		return autoCompleteOverdueChange(ctx, s3Manager, cfg, bucket, key, modManager.BackendUserID)
+	}
+
+	return nil
+}
+
+// autoCompleteOverdueChange marks an overdue change as completed_unconfirmed. The object is
+// reloaded with its ETag so a concurrent status change by a user always wins.
+func autoCompleteOverdueChange(ctx context.Context, s3Manager *S3UpdateManager, cfg *types.Config, bucket, key, userID string) error {
+	metadata, etag, err := s3Manager.LoadChangeObjectFromS3WithETag(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	if metadata.Status != "approved" {
+		log.Printf("⏭️  Change %s is now %s, skipping auto-completion", metadata.ChangeID, metadata.Status)
+		return nil
+	}
+
+	entry, err := types.NewModificationEntry(types.ModificationTypeAutoCompleted, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create %s entry: %w", types.ModificationTypeAutoCompleted, err)
+	}
+
+	if err := metadata.AddModificationEntry(entry); err != nil {
+		return fmt.Errorf("failed to add modification entry: %w", err)
+	}
+
+	metadata.PriorStatus = metadata.Status
+	metadata.Status = types.ChangeStatusCompletedUnconfirmed
+	metadata.StatusReason = "Automatically marked complete after the implementation window passed without confirmation"
+	metadata.ModifiedAt = entry.Timestamp
+	metadata.ModifiedBy = userID
+
+	if err := s3Manager.UpdateChangeObjectInS3WithETag(ctx, bucket, key, metadata, etag); err != nil {
+		if IsETagMismatch(err) {
+			log.Printf("⏭️  Change %s was modified concurrently, leaving it for the next sweep", metadata.ChangeID)
+			return nil
+		}
+		return fmt.Errorf("failed to save auto-completed change: %w", err)
+	}
+
+	log.Printf("✅ Marked change %s as %s", metadata.ChangeID, types.ChangeStatusCompletedUnconfirmed)
+
+	if err := PublishCalendarFeedsForChange(ctx, cfg, bucket, metadata); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	if err := SyncServiceNowForChange(ctx, cfg, bucket, metadata); err != nil {
+		log.Printf("⚠️  Failed to sync change %s to ServiceNow: %v", metadata.ChangeID, err)
+	}
+	if err := SyncJiraForChange(ctx, cfg, bucket, metadata); err != nil {
+		log.Printf("⚠️  Failed to sync change %s to Jira: %v", metadata.ChangeID, err)
+	}
+	return nil
+}
+
+// overdueReminderRecipients returns the change creator and submitter, plus escalation recipients when escalating
+func overdueReminderRecipients(metadata *types.ChangeMetadata, sweeperCfg types.OverdueSweeperConfig, escalated bool) []string {
+	candidates := []string{metadata.CreatedBy, metadata.SubmittedBy}
+	if escalated {
+		candidates = append(candidates, sweeperCfg.EscalationRecipients...)
+	}
+
+	seen := make(map[string]bool)
+	var recipients []string
+	for _, candidate := range candidates {
+		email := strings.ToLower(strings.TrimSpace(candidate))
+		if !strings.Contains(email, "@") || seen[email] {
+			continue
+		}
+		seen[email] = true
+		recipients = append(recipients, email)
+	}
+
+	return recipients
+}
+
+// sendOverdueReminderEmail sends the overdue reminder directly to the change owners using the SES
+// account of the first configured customer on the change
+func sendOverdueReminderEmail(ctx context.Context, cfg *types.Config, sweeperCfg types.OverdueSweeperConfig, metadata *types.ChangeMetadata, escalated bool, now time.Time) error {
+	var customerCode string
+	var customerInfo types.CustomerAccountInfo
+	for _, code := range metadata.Customers {
+		if info, exists := cfg.CustomerMappings[code]; exists {
+			customerCode, customerInfo = code, info
+			break
+		}
+	}
+	if customerCode == "" {
+		return fmt.Errorf("change %s has no configured customers", metadata.ChangeID)
+	}
+
+	recipients, skipped := customerInfo.FilterRecipients(overdueReminderRecipients(metadata, sweeperCfg, escalated))
+	if skipped > 0 {
+		log.Printf("   ⏭️  Skipped %d recipients (not on restricted recipient list)", skipped)
+	}
+	if len(recipients) == 0 {
+		log.Printf("⚠️  No recipients for overdue reminder on change %s (createdBy=%q, submittedBy=%q)",
+			metadata.ChangeID, metadata.CreatedBy, metadata.SubmittedBy)
+		return nil
+	}
+
+	credentialManager, err := awsinternal.NewCredentialManager(cfg.AWSRegion, cfg.CustomerMappings)
+	if err != nil {
+		return fmt.Errorf("failed to create credential manager: %w", err)
+	}
+
+	customerConfig, err := credentialManager.GetCustomerConfig(customerCode)
+	if err != nil {
+		return fmt.Errorf("failed to get customer config for %s: %w", customerCode, err)
+	}
+
+	sesClient := sesv2.NewFromConfig(customerConfig)
+
+	data := templates.OverdueReminderData{
+		BaseTemplateData: templates.BaseTemplateData{
+			EventID:   metadata.ChangeID,
+			EventType: "change",
+			Category:  "change",
+			Status:    metadata.Status,
+			Title:     metadata.ChangeTitle,
+			Summary:   metadata.ChangeReason,
+			Timestamp: now,
+		},
+		ImplementationEnd: metadata.ImplementationEnd,
+		Timezone:          metadata.Timezone,
+		OverdueBy:         now.Sub(metadata.ImplementationEnd),
+		Escalated:         escalated,
+	}
+	if sweeperCfg.AutoComplete {
+		data.AutoCompleteAt = metadata.ImplementationEnd.Add(time.Duration(sweeperCfg.AutoCompleteHours) * time.Hour)
+	}
+
+	template := templates.BuildOverdueReminder(data, cfg.EmailConfig)
+
+	// Reminders are transactional and go straight to the owners, so no list management options
+	sendInput := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(cfg.EmailConfig.SenderAddress),
+		Destination: &sesv2Types.Destination{
+			ToAddresses: recipients,
+		},
+		Content: &sesv2Types.EmailContent{
+			Simple: &sesv2Types.Message{
+				Subject: &sesv2Types.Content{
+					Data: aws.String(template.Subject),
+				},
+				Body: &sesv2Types.Body{
+					Html: &sesv2Types.Content{
+						Data: aws.String(template.HTMLBody),
+					},
+					Text: &sesv2Types.Content{
+						Data: aws.String(template.TextBody),
+					},
+				},
+			},
+		},
+	}
+
+	if _, err := sesClient.SendEmail(ctx, sendInput); err != nil {
+		return fmt.Errorf("failed to send overdue reminder: %w", err)
+	}
+
+	kind := "reminder"
+	if escalated {
+		kind = "escalation"
+	}
+	log.Printf("✅ Sent overdue %s for change %s to %s", kind, metadata.ChangeID, strings.Join(recipients, ", "))
+	return nil
+}
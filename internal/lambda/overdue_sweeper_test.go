@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestDetermineOverdueAction(t *testing.T) {
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	sweeperCfg := (&types.OverdueSweeperConfig{}).WithDefaults()
+	autoCfg := (&types.OverdueSweeperConfig{AutoComplete: true}).WithDefaults()
+
+	entry := func(modificationType string, at time.Time) types.ModificationEntry {
+		return types.ModificationEntry{Timestamp: at, UserID: "backend", ModificationType: modificationType}
+	}
+
+	tests := []struct {
+		name          string
+		status        string
+		overdueBy     time.Duration
+		modifications func(end time.Time) []types.ModificationEntry
+		cfg           types.OverdueSweeperConfig
+		expected      OverdueAction
+	}{
+		{"not approved", "completed", 100 * time.Hour, nil, sweeperCfg, OverdueActionNone},
+		{"within grace period", "approved", 12 * time.Hour, nil, sweeperCfg, OverdueActionNone},
+		{"first reminder", "approved", 30 * time.Hour, nil, sweeperCfg, OverdueActionReminder},
+		{"reminder already sent", "approved", 30 * time.Hour, func(end time.Time) []types.ModificationEntry {
+			return []types.ModificationEntry{entry(types.ModificationTypeOverdueReminder, end.Add(25*time.Hour))}
+		}, sweeperCfg, OverdueActionNone},
+		{"escalation due", "approved", 80 * time.Hour, func(end time.Time) []types.ModificationEntry {
+			return []types.ModificationEntry{entry(types.ModificationTypeOverdueReminder, end.Add(25*time.Hour))}
+		}, sweeperCfg, OverdueActionEscalation},
+		{"already escalated", "approved", 100 * time.Hour, func(end time.Time) []types.ModificationEntry {
+			return []types.ModificationEntry{entry(types.ModificationTypeOverdueEscalated, end.Add(73*time.Hour))}
+		}, sweeperCfg, OverdueActionNone},
+		{"reminder before reschedule is ignored", "approved", 30 * time.Hour, func(end time.Time) []types.ModificationEntry {
+			return []types.ModificationEntry{entry(types.ModificationTypeOverdueReminder, end.Add(-48*time.Hour))}
+		}, sweeperCfg, OverdueActionReminder},
+		{"auto-complete disabled", "approved", 200 * time.Hour, func(end time.Time) []types.ModificationEntry {
+			return []types.ModificationEntry{entry(types.ModificationTypeOverdueEscalated, end.Add(73*time.Hour))}
+		}, sweeperCfg, OverdueActionNone},
+		{"auto-complete enabled", "approved", 200 * time.Hour, nil, autoCfg, OverdueActionAutoComplete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end := now.Add(-tt.overdueBy)
+			metadata := &types.ChangeMetadata{
+				ChangeID:          "CHG-1",
+				Status:            tt.status,
+				ImplementationEnd: end,
+			}
+			if tt.modifications != nil {
+				metadata.Modifications = tt.modifications(end)
+			}
+
+			if got := determineOverdueAction(metadata, tt.cfg, now); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOverdueReminderRecipients(t *testing.T) {
+	metadata := &types.ChangeMetadata{
+		CreatedBy:   "Owner@example.com",
+		SubmittedBy: "owner@example.com",
+	}
+	sweeperCfg := types.OverdueSweeperConfig{EscalationRecipients: []string{"manager@example.com", "not-an-email"}}
+
+	if got := overdueReminderRecipients(metadata, sweeperCfg, false); !reflect.DeepEqual(got, []string{"owner@example.com"}) {
+		t.Errorf("Unexpected reminder recipients: %v", got)
+	}
+
+	expected := []string{"owner@example.com", "manager@example.com"}
+	if got := overdueReminderRecipients(metadata, sweeperCfg, true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected escalation recipients %v, got %v", expected, got)
+	}
+}
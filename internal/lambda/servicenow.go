@@ -0,0 +1,385 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/archive"
+	"ccoe-customer-contact-manager/internal/servicenow"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ExternalSystemServiceNow identifies ServiceNow in external modification entries
+const ExternalSystemServiceNow = "servicenow"
+
+// ServiceNowApprovalItem records the approval state pulled for a single change
+type ServiceNowApprovalItem struct {
+	ChangeID string
+	Ticket   string
+	Approval string
+	Recorded bool
+	Err      error
+}
+
+// ServiceNowRetryResult summarizes a retry pass over failed ServiceNow syncs
+type ServiceNowRetryResult struct {
+	Scanned   int
+	Retried   int
+	Succeeded int
+	Failed    int
+}
+
+// ServiceNowApprovalResult summarizes an approval pull over the archive
+type ServiceNowApprovalResult struct {
+	Scanned  int
+	Checked  int
+	Recorded int
+	Errors   int
+	Items    []ServiceNowApprovalItem
+}
+
+// SyncServiceNowForChange pushes the transition for a change's current status to the
+// ServiceNow change request referenced by its snowTicket. Statuses that are not mirrored
+// to ServiceNow are ignored.
+func SyncServiceNowForChange(ctx context.Context, cfg *types.Config, bucket string, metadata *types.ChangeMetadata) error {
+	transition := servicenow.TransitionForStatus(metadata.Status)
+	if transition == "" {
+		return nil
+	}
+
+	return SyncServiceNowTransition(ctx, cfg, bucket, metadata, transition, lifecycleSyncKey(transition, metadata))
+}
+
+// lifecycleSyncKey identifies a status transition for external sync. Every customer's trigger
+// carries the same version and modification time, so the key lets exactly one of them sync it.
+func lifecycleSyncKey(event string, metadata *types.ChangeMetadata) string {
+	return fmt.Sprintf("%s:v%d:%s", event, metadata.Version, metadata.ModifiedAt.UTC().Format(time.RFC3339Nano))
+}
+
+// SyncServiceNowTransition updates the referenced change request's state and adds a work note.
+// The transition is first claimed with an external_sync modification entry on the archived
+// change so concurrent per-customer processing syncs it only once. A failed call is recorded
+// so the scheduled sweep can retry it.
+func SyncServiceNowTransition(ctx context.Context, cfg *types.Config, bucket string, metadata *types.ChangeMetadata, transition, key string) error {
+	ticket := strings.TrimSpace(metadata.SnowTicket)
+	if !cfg.ServiceNow.IsEnabled() || ticket == "" {
+		return nil
+	}
+	if !servicenow.IsChangeNumber(ticket) {
+		return fmt.Errorf("change %s has invalid ServiceNow ticket %q (expected e.g. CHG0012345)", metadata.ChangeID, ticket)
+	}
+
+	client, err := servicenow.NewClientFromEnv(cfg.ServiceNow.InstanceURL)
+	if err != nil {
+		return err
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	state := servicenow.StateFor(transition, cfg.ServiceNow.States)
+	ref := &types.ExternalReference{
+		System: ExternalSystemServiceNow,
+		Ticket: ticket,
+		Event:  transition,
+		State:  state,
+		Key:    key,
+	}
+
+	archiveKey := fmt.Sprintf("archive/%s.json", metadata.ChangeID)
+	userID := NewModificationManager().BackendUserID
+
+	claimed, err := s3Manager.appendExternalEntry(ctx, bucket, archiveKey, types.ModificationTypeExternalSync, userID, ref, func(existing *types.ChangeMetadata) bool {
+		return hasExternalSync(existing, ref)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record ServiceNow sync for change %s: %w", metadata.ChangeID, err)
+	}
+	if !claimed {
+		log.Printf("⏭️  ServiceNow %s for change %s already synced to %s", transition, metadata.ChangeID, ticket)
+		return nil
+	}
+
+	record, err := deliverServiceNowTransition(ctx, client, s3Manager, cfg, bucket, archiveKey, userID, ref, metadata, false)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ServiceNow.PullApprovals && metadata.Status == "submitted" {
+		if _, err := recordServiceNowApproval(ctx, s3Manager, bucket, archiveKey, userID, ticket, record.Approval); err != nil {
+			log.Printf("⚠️  Failed to record ServiceNow approval for change %s: %v", metadata.ChangeID, err)
+		}
+	}
+
+	return nil
+}
+
+// RetryFailedServiceNowSyncs retries ServiceNow transitions whose last attempt failed, up to the
+// configured number of attempts. Transitions for a status the change has since left are not retried.
+func RetryFailedServiceNowSyncs(ctx context.Context, cfg *types.Config, bucket string) (*ServiceNowRetryResult, error) {
+	if !cfg.ServiceNow.IsEnabled() {
+		return nil, fmt.Errorf("servicenow is not enabled in the configuration")
+	}
+
+	client, err := servicenow.NewClientFromEnv(cfg.ServiceNow.InstanceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Manager.s3Client, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := cfg.ServiceNow.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = types.DefaultServiceNowMaxAttempts
+	}
+
+	result := &ServiceNowRetryResult{}
+	userID := NewModificationManager().BackendUserID
+
+	for _, object := range objects {
+		key := object.Key
+		metadata, err := s3Manager.LoadChangeObjectFromS3(ctx, bucket, key)
+		if err != nil {
+			log.Printf("⚠️  Skipping %s: %v", key, err)
+			continue
+		}
+		result.Scanned++
+
+		for _, failed := range pendingServiceNowRetries(metadata, maxAttempts) {
+			result.Retried++
+			ref := &types.ExternalReference{System: ExternalSystemServiceNow, Ticket: failed.Ticket, Event: failed.Event, State: failed.State, Key: failed.Key}
+			if _, err := deliverServiceNowTransition(ctx, client, s3Manager, cfg, bucket, key, userID, ref, metadata, true); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Succeeded++
+		}
+	}
+
+	return result, nil
+}
+
+// deliverServiceNowTransition pushes a claimed transition to ServiceNow. A failure is recorded as
+// an external_sync entry carrying the error; a successful retry records a clean entry so the
+// transition is no longer pending.
+func deliverServiceNowTransition(ctx context.Context, client *servicenow.Client, s3Manager *S3UpdateManager, cfg *types.Config, bucket, archiveKey, userID string, ref *types.ExternalReference, metadata *types.ChangeMetadata, retry bool) (*servicenow.ChangeRequest, error) {
+	record, err := client.SyncTransition(ctx, ref.Ticket, ref.State, servicenow.WorkNote(ref.Event, metadata, cfg.EmailConfig.PortalBaseURL))
+
+	if err != nil || retry {
+		outcome := *ref
+		if err != nil {
+			outcome.Error = err.Error()
+		}
+		if _, recordErr := s3Manager.appendExternalEntry(ctx, bucket, archiveKey, types.ModificationTypeExternalSync, userID, &outcome, func(*types.ChangeMetadata) bool {
+			return false
+		}); recordErr != nil {
+			log.Printf("⚠️  Failed to record ServiceNow sync outcome for change %s: %v", metadata.ChangeID, recordErr)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync %s for change %s to ServiceNow %s: %w", ref.Event, metadata.ChangeID, ref.Ticket, err)
+	}
+	log.Printf("✅ Synced %s for change %s to ServiceNow %s (state %s)", ref.Event, metadata.ChangeID, ref.Ticket, record.State)
+	return record, nil
+}
+
+// pendingServiceNowRetries returns the latest entry of each ServiceNow transition that failed fewer
+// than maxAttempts times and still applies to the change. Meeting notes apply while the change is
+// approved; status transitions only while the change is still in that status.
+func pendingServiceNowRetries(metadata *types.ChangeMetadata, maxAttempts int) []types.ExternalReference {
+	current := servicenow.TransitionForStatus(metadata.Status)
+	return pendingExternalRetries(metadata, ExternalSystemServiceNow, maxAttempts, func(ref types.ExternalReference) bool {
+		return ref.Event == current || (ref.Event == servicenow.TransitionMeetingScheduled && metadata.Status == "approved")
+	})
+}
+
+// PullServiceNowApprovals records the ServiceNow approval state of every submitted change
+// with a snowTicket as an external_approval modification entry whenever it changes
+func PullServiceNowApprovals(ctx context.Context, cfg *types.Config, bucket string, dryRun bool) (*ServiceNowApprovalResult, error) {
+	if !cfg.ServiceNow.IsEnabled() {
+		return nil, fmt.Errorf("servicenow is not enabled in the configuration")
+	}
+
+	client, err := servicenow.NewClientFromEnv(cfg.ServiceNow.InstanceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s3Manager, err := NewS3UpdateManager(cfg.AWSRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 update manager: %w", err)
+	}
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Manager.s3Client, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServiceNowApprovalResult{}
+	userID := NewModificationManager().BackendUserID
+
+	for _, object := range objects {
+		key := object.Key
+		metadata, err := s3Manager.LoadChangeObjectFromS3(ctx, bucket, key)
+		if err != nil {
+			log.Printf("⚠️  Skipping %s: %v", key, err)
+			result.Errors++
+			continue
+		}
+		result.Scanned++
+
+		ticket := strings.TrimSpace(metadata.SnowTicket)
+		if strings.HasPrefix(metadata.ObjectType, "announcement_") || metadata.Status != "submitted" || ticket == "" {
+			continue
+		}
+		result.Checked++
+
+		item := ServiceNowApprovalItem{ChangeID: metadata.ChangeID, Ticket: ticket}
+
+		record, err := client.GetChangeRequest(ctx, ticket)
+		if err != nil {
+			item.Err = err
+		} else {
+			item.Approval = record.Approval
+			if dryRun {
+				item.Recorded = serviceNowApprovalChanged(metadata, ticket, record.Approval)
+			} else {
+				item.Recorded, item.Err = recordServiceNowApproval(ctx, s3Manager, bucket, key, userID, ticket, record.Approval)
+			}
+		}
+
+		if item.Err != nil {
+			log.Printf("❌ Failed to pull ServiceNow approval for change %s: %v", metadata.ChangeID, item.Err)
+			result.Errors++
+		} else if item.Recorded {
+			result.Recorded++
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// recordServiceNowApproval appends an external_approval entry when the approval state differs
+// from the last one recorded for the ticket
+func recordServiceNowApproval(ctx context.Context, s3Manager *S3UpdateManager, bucket, key, userID, ticket, approval string) (bool, error) {
+	if approval == "" {
+		return false, nil
+	}
+
+	ref := &types.ExternalReference{System: ExternalSystemServiceNow, Ticket: ticket, State: approval}
+	return s3Manager.appendExternalEntry(ctx, bucket, key, types.ModificationTypeExternalApproval, userID, ref, func(existing *types.ChangeMetadata) bool {
+		return !serviceNowApprovalChanged(existing, ticket, approval)
+	})
+}
+
+// hasExternalSync reports whether a sync with the same system, ticket and key was already claimed
+func hasExternalSync(metadata *types.ChangeMetadata, ref *types.ExternalReference) bool {
+	for _, mod := range metadata.Modifications {
+		if mod.ModificationType != types.ModificationTypeExternalSync || mod.External == nil {
+			continue
+		}
+		if mod.External.System == ref.System && mod.External.Ticket == ref.Ticket && mod.External.Key == ref.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingExternalRetries returns the latest external_sync entry of each event synced to system
+// whose last attempt failed, that failed fewer than maxAttempts times and that still applies to
+// the change. Entries are returned in the order the events were first synced.
+func pendingExternalRetries(metadata *types.ChangeMetadata, system string, maxAttempts int, applies func(types.ExternalReference) bool) []types.ExternalReference {
+	var order []string
+	latest := make(map[string]types.ExternalReference)
+	failures := make(map[string]int)
+
+	for _, mod := range metadata.Modifications {
+		if mod.ModificationType != types.ModificationTypeExternalSync || mod.External == nil || mod.External.System != system {
+			continue
+		}
+		ref := *mod.External
+		if _, seen := latest[ref.Key]; !seen {
+			order = append(order, ref.Key)
+		}
+		latest[ref.Key] = ref
+		if ref.Error != "" {
+			failures[ref.Key]++
+		}
+	}
+
+	var pending []types.ExternalReference
+	for _, key := range order {
+		ref := latest[key]
+		if ref.Error == "" || failures[key] >= maxAttempts || !applies(ref) {
+			continue
+		}
+		pending = append(pending, ref)
+	}
+	return pending
+}
+
+// serviceNowApprovalChanged reports whether an approval state differs from the latest one recorded for the ticket
+func serviceNowApprovalChanged(metadata *types.ChangeMetadata, ticket, approval string) bool {
+	for i := len(metadata.Modifications) - 1; i >= 0; i-- {
+		mod := metadata.Modifications[i]
+		if mod.ModificationType == types.ModificationTypeExternalApproval && mod.External != nil &&
+			mod.External.System == ExternalSystemServiceNow && mod.External.Ticket == ticket {
+			return mod.External.State != approval
+		}
+	}
+	return true
+}
+
+// appendExternalEntry adds an external modification entry to a change object with ETag locking.
+// skip is evaluated against each freshly loaded object; it returns false without writing when skip
+// reports the entry is not needed.
+func (s *S3UpdateManager) appendExternalEntry(ctx context.Context, bucket, key, modificationType, userID string, ref *types.ExternalReference, skip func(*types.ChangeMetadata) bool) (bool, error) {
+	const maxAttempts = 5
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		metadata, etag, err := s.LoadChangeObjectFromS3WithETag(ctx, bucket, key)
+		if err != nil {
+			return false, err
+		}
+
+		if skip(metadata) {
+			return false, nil
+		}
+
+		entry, err := types.NewExternalEntry(modificationType, userID, ref)
+		if err != nil {
+			return false, err
+		}
+		if err := metadata.AddModificationEntry(entry); err != nil {
+			return false, fmt.Errorf("failed to add modification entry: %w", err)
+		}
+
+		err = s.UpdateChangeObjectInS3WithETag(ctx, bucket, key, metadata, etag)
+		if err == nil {
+			return true, nil
+		}
+		if !IsETagMismatch(err) {
+			return false, err
+		}
+
+		log.Printf("⚠️  Change %s modified concurrently, retrying %s entry (attempt %d/%d)", metadata.ChangeID, modificationType, attempt, maxAttempts)
+		time.Sleep(time.Duration(100<<uint(attempt-1)) * time.Millisecond)
+	}
+
+	return false, fmt.Errorf("failed to add %s entry to %s after %d attempts due to concurrent modifications", modificationType, key, maxAttempts)
+}
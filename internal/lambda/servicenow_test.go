@@ -0,0 +1,54 @@
+package lambda
+
+import (
+	"testing"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func serviceNowEntry(key, event, errMsg string) types.ModificationEntry {
+	return types.ModificationEntry{
+		ModificationType: types.ModificationTypeExternalSync,
+		External: &types.ExternalReference{
+			System: ExternalSystemServiceNow,
+			Ticket: "CHG0012345",
+			Event:  event,
+			State:  "-2",
+			Key:    key,
+			Error:  errMsg,
+		},
+	}
+}
+
+func TestPendingServiceNowRetries(t *testing.T) {
+	metadata := &types.ChangeMetadata{
+		Status: "approved",
+		Modifications: []types.ModificationEntry{
+			// Submitted transition failed but the change has moved on
+			serviceNowEntry("submitted:v1", "submitted", ""),
+			serviceNowEntry("submitted:v1", "submitted", "servicenow returned status 503"),
+			// Approved transition failed
+			serviceNowEntry("approved:v2", "approved", ""),
+			serviceNowEntry("approved:v2", "approved", "servicenow returned status 500"),
+			// Meeting note failed while the change is approved
+			serviceNowEntry("meeting:m1", "meeting_scheduled", ""),
+			serviceNowEntry("meeting:m1", "meeting_scheduled", "servicenow returned status 500"),
+		},
+	}
+
+	pending := pendingServiceNowRetries(metadata, 3)
+	if len(pending) != 2 || pending[0].Key != "approved:v2" || pending[1].Key != "meeting:m1" {
+		t.Fatalf("Expected the approved transition and meeting note to be retried, got %+v", pending)
+	}
+
+	// Exhausted attempts are not retried
+	if pending := pendingServiceNowRetries(metadata, 1); len(pending) != 0 {
+		t.Errorf("Expected no retries after max attempts, got %+v", pending)
+	}
+
+	// A later success clears the failure
+	metadata.Modifications = append(metadata.Modifications, serviceNowEntry("approved:v2", "approved", ""), serviceNowEntry("meeting:m1", "meeting_scheduled", ""))
+	if pending := pendingServiceNowRetries(metadata, 3); len(pending) != 0 {
+		t.Errorf("Expected no retries after success, got %+v", pending)
+	}
+}
@@ -0,0 +1,325 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	awsinternal "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/surveys"
+	"ccoe-customer-contact-manager/internal/typeform"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// surveyReminderDefaultTopic is the topic survey emails go to, used for distribution records
+// written before the topic was stored
+const surveyReminderDefaultTopic = "aws-announce"
+
+// SurveyReminderItem records what happened to one survey distribution during a sweep
+type SurveyReminderItem struct {
+	ObjectID     string
+	CustomerCode string
+	FormID       string
+	Action       surveys.ReminderAction
+	Pending      int // Recipients without a response
+	Sent         int // Reminders sent (or that would be sent in a dry run)
+	Err          error
+}
+
+// SurveyReminderResult summarizes a survey reminder sweep
+type SurveyReminderResult struct {
+	Scanned   int
+	Reminded  int // Surveys that had reminders sent
+	Reminders int // Individual reminder emails
+	Closed    int
+	Errors    int
+	Items     []SurveyReminderItem
+}
+
+// sendSurveyRemindersOnSweep runs the survey reminder pass, logging rather than failing the sweep
+func sendSurveyRemindersOnSweep(ctx context.Context, cfg *types.Config) {
+	// Reminders still go out without the token; only closing forms needs it
+	if err := ses.LoadTypeformAPITokenFromSSM(ctx); err != nil {
+		log.Printf("⚠️  Typeform API token not loaded, forms cannot be closed: %v", err)
+	}
+
+	result, err := SweepSurveyReminders(ctx, cfg, cfg.S3Config.BucketName, time.Now(), false)
+	if err != nil {
+		log.Printf("⚠️  Survey reminder pass failed: %v", err)
+		return
+	}
+	log.Printf("📊 Survey Reminder Summary: %d scanned, %d surveys reminded, %d reminders, %d closed, %d errors",
+		result.Scanned, result.Reminded, result.Reminders, result.Closed, result.Errors)
+}
+
+// SweepSurveyReminders sends one reminder to the recipients of each change survey who have not
+// responded once reminder_after_days has passed, and closes the Typeform form once
+// close_after_days has passed. Recipients come from surveys/recipients/ and responses from
+// surveys/results/.
+func SweepSurveyReminders(ctx context.Context, cfg *types.Config, bucket string, now time.Time, dryRun bool) (*SurveyReminderResult, error) {
+	remindAfter := cfg.SurveyReminders.GetReminderAfter()
+	closeAfter := cfg.SurveyReminders.GetCloseAfter()
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	s3Client := s3.NewFromConfig(awsCfg)
+
+	distributions, err := surveys.LoadDistributions(ctx, s3Client, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SurveyReminderResult{}
+	var responses []surveys.Response
+	responsesLoaded := false
+	var formClient *typeform.Client
+
+	for _, distribution := range distributions {
+		result.Scanned++
+
+		action := distribution.NextAction(now, remindAfter, closeAfter)
+		if action == surveys.ReminderActionNone {
+			continue
+		}
+
+		item := SurveyReminderItem{
+			ObjectID:     distribution.ObjectID,
+			CustomerCode: distribution.CustomerCode,
+			FormID:       distribution.FormID,
+			Action:       action,
+		}
+
+		switch action {
+		case surveys.ReminderActionRemind:
+			// Responses are loaded once, and only when a reminder is due
+			if !responsesLoaded {
+				payloads, err := surveys.LoadResults(ctx, s3Client, bucket, "")
+				if err != nil {
+					return nil, err
+				}
+				for _, payload := range payloads {
+					responses = append(responses, surveys.ParseResponse(payload))
+				}
+				responsesLoaded = true
+			}
+
+			pending := distribution.NonRespondents(responses)
+			item.Pending = len(pending)
+			if dryRun {
+				item.Sent = len(pending)
+				log.Printf("🔍 DRY RUN: Would remind %d of %d recipients of the survey for %s (%s)",
+					len(pending), len(distribution.Recipients), distribution.ObjectID, distribution.CustomerCode)
+				break
+			}
+
+			reminded, err := sendSurveyReminders(ctx, cfg, distribution, pending, distribution.SentAt.Add(closeAfter), now)
+			item.Sent, item.Err = len(reminded), err
+			if err != nil && len(reminded) == 0 {
+				// Nobody was reminded, so the next sweep tries again
+				break
+			}
+
+			remindedAt := now.UTC()
+			if err := updateSweptDistribution(ctx, s3Client, bucket, distribution, func(current *surveys.Distribution) {
+				current.RemindedAt = &remindedAt
+				current.Reminded = append(current.Reminded, reminded...)
+			}); err != nil && item.Err == nil {
+				item.Err = err
+			}
+
+		case surveys.ReminderActionClose:
+			if dryRun {
+				log.Printf("🔍 DRY RUN: Would close survey form %s for %s (%s)", distribution.FormID, distribution.ObjectID, distribution.CustomerCode)
+				break
+			}
+
+			if formClient == nil {
+				formClient, err = typeform.NewClient(slog.Default())
+				if err != nil {
+					item.Err = fmt.Errorf("failed to create Typeform client: %w", err)
+					break
+				}
+			}
+			if item.Err = formClient.CloseForm(ctx, distribution.FormID); item.Err != nil {
+				break
+			}
+
+			closedAt := now.UTC()
+			item.Err = updateSweptDistribution(ctx, s3Client, bucket, distribution, func(current *surveys.Distribution) {
+				current.ClosedAt = &closedAt
+			})
+			log.Printf("🔒 Closed survey form %s for %s (%s)", distribution.FormID, distribution.ObjectID, distribution.CustomerCode)
+		}
+
+		if item.Err != nil {
+			log.Printf("❌ Failed to %s survey for %s (%s): %v", action, distribution.ObjectID, distribution.CustomerCode, item.Err)
+			result.Errors++
+		}
+		switch {
+		case action == surveys.ReminderActionRemind && item.Sent > 0:
+			result.Reminded++
+			result.Reminders += item.Sent
+		case action == surveys.ReminderActionClose && item.Err == nil:
+			result.Closed++
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// sendSurveyReminders emails each pending recipient their personalised survey link and returns
+// the recipients that were reminded
+func sendSurveyReminders(ctx context.Context, cfg *types.Config, distribution *surveys.Distribution, pending []string, closesAt, now time.Time) ([]string, error) {
+	customerInfo, exists := cfg.CustomerMappings[distribution.CustomerCode]
+	if !exists {
+		return nil, fmt.Errorf("customer %s is not configured", distribution.CustomerCode)
+	}
+
+	// Recipients can be removed from restricted_recipients after the completion email
+	recipients, skipped := customerInfo.FilterRecipients(pending)
+	if skipped > 0 {
+		log.Printf("   ⏭️  Skipped %d recipients (not on restricted recipient list)", skipped)
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	credentialManager, err := awsinternal.NewCredentialManager(cfg.AWSRegion, cfg.CustomerMappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential manager: %w", err)
+	}
+
+	customerConfig, err := credentialManager.GetCustomerConfig(distribution.CustomerCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer config for %s: %w", distribution.CustomerCode, err)
+	}
+
+	sesClient := sesv2.NewFromConfig(customerConfig)
+
+	// Recipients can also unsubscribe from the topic after the completion email
+	topicName := distribution.Topic
+	if topicName == "" {
+		topicName = surveyReminderDefaultTopic
+	}
+	accountListName, err := ses.GetAccountContactList(sesClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account contact list: %w", err)
+	}
+	recipients, err = filterTopicSubscribers(sesClient, accountListName, topicName, recipients)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+
+	var reminded []string
+	errorCount := 0
+	for _, recipient := range recipients {
+		template := templates.BuildSurveyReminder(templates.SurveyReminderData{
+			BaseTemplateData: templates.BaseTemplateData{
+				EventID:   distribution.ObjectID,
+				EventType: "change",
+				Category:  "change",
+				Status:    "completed",
+				Title:     distribution.Title,
+				Summary:   distribution.Summary,
+				Timestamp: now,
+			},
+			SurveyURL: surveys.PersonalizeSurveyURL(distribution.SurveyURL, recipient),
+			ClosesAt:  closesAt,
+		}, cfg.EmailConfig)
+
+		// Sent on the survey email's topic, so reminders carry the same unsubscribe link
+		sendInput := &sesv2.SendEmailInput{
+			FromEmailAddress: aws.String(cfg.EmailConfig.SenderAddress),
+			Destination: &sesv2Types.Destination{
+				ToAddresses: []string{recipient},
+			},
+			Content: &sesv2Types.EmailContent{
+				Simple: &sesv2Types.Message{
+					Subject: &sesv2Types.Content{
+						Data: aws.String(template.Subject),
+					},
+					Body: &sesv2Types.Body{
+						Html: &sesv2Types.Content{
+							Data: aws.String(template.HTMLBody),
+						},
+						Text: &sesv2Types.Content{
+							Data: aws.String(template.TextBody),
+						},
+					},
+				},
+			},
+			ListManagementOptions: &sesv2Types.ListManagementOptions{
+				ContactListName: aws.String(accountListName),
+				TopicName:       aws.String(topicName),
+			},
+		}
+
+		if _, err := sesClient.SendEmail(ctx, sendInput); err != nil {
+			log.Printf("   ❌ Failed to send survey reminder to %s: %v", recipient, err)
+			errorCount++
+			continue
+		}
+		log.Printf("   ✅ Sent survey reminder for %s to %s", distribution.ObjectID, recipient)
+		reminded = append(reminded, recipient)
+	}
+
+	if errorCount > 0 {
+		return reminded, fmt.Errorf("failed to send survey reminder to %d recipients", errorCount)
+	}
+	return reminded, nil
+}
+
+// filterTopicSubscribers keeps the recipients that are still subscribed to a topic
+func filterTopicSubscribers(sesClient *sesv2.Client, listName, topicName string, recipients []string) ([]string, error) {
+	contacts, err := getSubscribedContactsForTopic(sesClient, listName, topicName)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed := make(map[string]bool, len(contacts))
+	for _, contact := range contacts {
+		subscribed[strings.ToLower(aws.ToString(contact.EmailAddress))] = true
+	}
+
+	var kept []string
+	for _, recipient := range recipients {
+		if subscribed[strings.ToLower(recipient)] {
+			kept = append(kept, recipient)
+		}
+	}
+	if skipped := len(recipients) - len(kept); skipped > 0 {
+		log.Printf("   ⏭️  Skipped %d recipients (no longer subscribed to topic %s)", skipped, topicName)
+	}
+	return kept, nil
+}
+
+// updateSweptDistribution applies a sweep result to the stored distribution with ETag locking, so
+// recipients added while the sweep ran are kept. A record that has since moved to a new form is
+// left alone.
+func updateSweptDistribution(ctx context.Context, s3Client *s3.Client, bucket string, swept *surveys.Distribution, apply func(*surveys.Distribution)) error {
+	return surveys.UpdateDistribution(ctx, s3Client, bucket, swept.CustomerCode, swept.ObjectID, func(current *surveys.Distribution) *surveys.Distribution {
+		if current == nil || current.FormID != swept.FormID {
+			return nil
+		}
+		apply(current)
+		return current
+	})
+}
@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// NotificationTypeForStatus maps an object status to the lifecycle notification it triggers
+func NotificationTypeForStatus(status string) templates.NotificationType {
+	if status == "submitted" {
+		return templates.NotificationApprovalRequest
+	}
+	return templates.NotificationType(status)
+}
+
+// ChangeEvent builds the event for a change lifecycle notification
+func ChangeEvent(metadata *types.ChangeMetadata, customer types.CustomerAccountInfo, topic, portalBaseURL string) Event {
+	event := Event{
+		Type:         NotificationTypeForStatus(metadata.Status),
+		Category:     templates.CategoryChange,
+		CustomerCode: customer.CustomerCode,
+		CustomerName: customer.CustomerName,
+		Topic:        topic,
+		ObjectID:     metadata.ChangeID,
+		Title:        metadata.ChangeTitle,
+		Summary:      metadata.ChangeReason,
+		Status:       metadata.Status,
+		StatusReason: metadata.StatusReason,
+	}
+
+	if portalBaseURL != "" {
+		event.URL = fmt.Sprintf("%s/edit-change.html?changeId=%s", strings.TrimRight(portalBaseURL, "/"), metadata.ChangeID)
+	}
+
+	if !metadata.ImplementationStart.IsZero() {
+		window := metadata.ImplementationStart.UTC().Format("2006-01-02 15:04 MST")
+		if !metadata.ImplementationEnd.IsZero() {
+			window += " – " + metadata.ImplementationEnd.UTC().Format("2006-01-02 15:04 MST")
+		}
+		event.Facts = append(event.Facts, Fact{Name: "Implementation window", Value: window})
+	}
+	event.Facts = append(event.Facts,
+		Fact{Name: "Customer impact", Value: metadata.CustomerImpact},
+		Fact{Name: "ServiceNow", Value: metadata.SnowTicket},
+		Fact{Name: "Jira", Value: metadata.JiraTicket},
+	)
+	if metadata.MeetingMetadata != nil {
+		event.Facts = append(event.Facts, Fact{Name: "Meeting", Value: metadata.MeetingMetadata.JoinURL})
+	}
+
+	return event
+}
+
+// AnnouncementEvent builds the event for an announcement lifecycle notification
+func AnnouncementEvent(announcement *types.AnnouncementMetadata, customer types.CustomerAccountInfo, topic, portalBaseURL string) Event {
+	event := Event{
+		Type:         NotificationTypeForStatus(announcement.Status),
+		Category:     templates.CategoryType(strings.ToLower(announcement.AnnouncementType)),
+		CustomerCode: customer.CustomerCode,
+		CustomerName: customer.CustomerName,
+		Topic:        topic,
+		ObjectID:     announcement.AnnouncementID,
+		Title:        announcement.Title,
+		Summary:      announcement.Summary,
+		Status:       announcement.Status,
+	}
+
+	if portalBaseURL != "" {
+		event.URL = fmt.Sprintf("%s/edit-announcement.html?announcementId=%s", strings.TrimRight(portalBaseURL, "/"), announcement.AnnouncementID)
+	}
+
+	if announcement.MeetingMetadata != nil {
+		event.Facts = append(event.Facts, Fact{Name: "Meeting", Value: announcement.MeetingMetadata.JoinURL})
+	}
+	event.Facts = append(event.Facts, Fact{Name: "Survey", Value: announcement.SurveyURL})
+
+	return event
+}
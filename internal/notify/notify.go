@@ -0,0 +1,175 @@
+// Package notify fans change and announcement lifecycle events out to email and chat channels.
+// Email remains the channel of record: callers log chat failures and return only the email
+// error, so configuring chat channels never changes how a failed notification is handled.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Channel types accepted in notification_channels configuration
+const (
+	ChannelEmail = "email"
+	ChannelSlack = "slack"
+	ChannelTeams = "teams"
+)
+
+// Fact is a labelled value shown with a notification (ticket numbers, schedule, ...)
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Event is a channel-agnostic lifecycle notification. Each notifier renders it in its own format.
+type Event struct {
+	Type         templates.NotificationType
+	Category     templates.CategoryType
+	CustomerCode string
+	CustomerName string
+	Topic        string // SES topic the email for this event goes to
+	ObjectID     string
+	Title        string
+	Summary      string
+	Status       string
+	StatusReason string
+	URL          string
+	Facts        []Fact
+}
+
+// eventLabels describe each notification type in a headline
+var eventLabels = map[templates.NotificationType]string{
+	templates.NotificationApprovalRequest: "approval requested",
+	templates.NotificationApproved:        "approved",
+	templates.NotificationCompleted:       "completed",
+	templates.NotificationCancelled:       "cancelled",
+	templates.NotificationInProgress:      "implementation started",
+	templates.NotificationFailed:          "failed",
+	templates.NotificationRolledBack:      "rolled back",
+}
+
+// Headline returns a one-line description such as "🟢 Change approved"
+func (e Event) Headline() string {
+	kind := "Announcement"
+	if e.Category == templates.CategoryChange {
+		kind = "Change"
+	}
+
+	label := eventLabels[e.Type]
+	if label == "" {
+		label = string(e.Type)
+	}
+
+	return fmt.Sprintf("%s %s %s", templates.GetEmojiForNotification(e.Type, e.Category), kind, label)
+}
+
+// Notifier delivers an event to one channel
+type Notifier interface {
+	// Name identifies the channel in logs and dispatch results
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// EmailNotifier adapts an existing SES send function to the Notifier interface. It does not
+// retry: topic sends are not idempotent per recipient, so a retry could duplicate emails.
+type EmailNotifier struct {
+	Send func(ctx context.Context, event Event) error
+}
+
+// Name implements Notifier
+func (n *EmailNotifier) Name() string { return ChannelEmail }
+
+// Notify implements Notifier
+func (n *EmailNotifier) Notify(ctx context.Context, event Event) error {
+	return n.Send(ctx, event)
+}
+
+// Dispatcher fans an event out to several notifiers. Each notifier runs concurrently and a
+// failure or panic in one channel never affects the others. Notifiers bound their own time
+// (webhook notifiers use HTTP timeouts; email must not be cut off mid-send).
+type Dispatcher struct {
+	Notifiers []Notifier
+}
+
+// NewDispatcher creates a dispatcher for the given notifiers
+func NewDispatcher(notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{Notifiers: notifiers}
+}
+
+// Dispatch delivers the event to every notifier and returns the failures keyed by notifier name
+func (d *Dispatcher) Dispatch(ctx context.Context, event Event) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failures := make(map[string]error)
+
+	for _, notifier := range d.Notifiers {
+		wg.Add(1)
+		go func(notifier Notifier) {
+			defer wg.Done()
+
+			err := notifySafely(ctx, notifier, event)
+			if err == nil {
+				log.Printf("✅ Delivered %s notification for %s via %s", event.Type, event.ObjectID, notifier.Name())
+				return
+			}
+
+			mu.Lock()
+			failures[notifier.Name()] = err
+			mu.Unlock()
+		}(notifier)
+	}
+
+	wg.Wait()
+	return failures
+}
+
+// notifySafely calls a notifier, converting panics to errors
+func notifySafely(ctx context.Context, notifier Notifier, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s notifier panicked: %v", notifier.Name(), r)
+		}
+	}()
+
+	return notifier.Notify(ctx, event)
+}
+
+// ChannelNotifiers builds the chat notifiers configured for a customer that mirror the given
+// SES topic. Channels without topics mirror every topic; invalid channels are logged and skipped.
+func ChannelNotifiers(channels []types.NotificationChannel, topic string) []Notifier {
+	var notifiers []Notifier
+	for i, channel := range channels {
+		if !channel.MirrorsTopic(topic) {
+			continue
+		}
+
+		// Prefix with the type so a channel can never be mistaken for the email notifier
+		name := fmt.Sprintf("%s:%s", strings.ToLower(channel.Type), channel.Name)
+		if channel.Name == "" {
+			name = fmt.Sprintf("%s:%d", strings.ToLower(channel.Type), i+1)
+		}
+
+		if channel.WebhookURLParameter == "" {
+			log.Printf("⚠️  Skipping notification channel %s: webhook_url_parameter is empty", name)
+			continue
+		}
+
+		// The URL is resolved when the notification is posted, so it never sits in configuration
+		client := newParameterWebhookClient(channel.WebhookURLParameter)
+		switch strings.ToLower(channel.Type) {
+		case ChannelSlack:
+			notifiers = append(notifiers, &SlackNotifier{name: name, client: client})
+		case ChannelTeams:
+			notifiers = append(notifiers, &TeamsNotifier{name: name, client: client})
+		default:
+			log.Printf("⚠️  Skipping notification channel %s: unsupported type %q", name, channel.Type)
+		}
+	}
+	return notifiers
+}
@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func testEvent() Event {
+	return Event{
+		Type:         templates.NotificationApproved,
+		Category:     templates.CategoryChange,
+		CustomerCode: "hts",
+		CustomerName: "Hearst Television",
+		Topic:        "aws-announce",
+		ObjectID:     "CHG-1",
+		Title:        "Upgrade <RDS> & caches",
+		Summary:      "Security patch",
+		URL:          "https://portal.example.com/edit-change.html?changeId=CHG-1",
+		Facts:        []Fact{{Name: "ServiceNow", Value: "CHG0012345"}, {Name: "Jira", Value: ""}},
+	}
+}
+
+// webhookServer records request bodies and answers with the given status codes in order
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, *int32, *[]map[string]interface{}) {
+	t.Helper()
+	var calls int32
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+
+		status := http.StatusOK
+		if int(n) <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func TestSlackNotifier(t *testing.T) {
+	server, calls, bodies := webhookServer(t, http.StatusInternalServerError)
+
+	notifier := NewSlackNotifier("slack:ops", server.URL)
+	notifier.client.backoff = time.Millisecond
+
+	if err := notifier.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", *calls)
+	}
+
+	payload, _ := json.Marshal((*bodies)[1])
+	blocks := (*bodies)[1]["blocks"].([]interface{})
+	section := blocks[1].(map[string]interface{})["text"].(map[string]interface{})["text"].(string)
+	if section != "*<https://portal.example.com/edit-change.html?changeId=CHG-1|Upgrade &lt;RDS&gt; &amp; caches>*\nSecurity patch" {
+		t.Errorf("Unexpected Slack section text: %q", section)
+	}
+	if !strings.Contains(string(payload), "CHG0012345") {
+		t.Errorf("Expected Slack payload to contain the ServiceNow ticket: %s", payload)
+	}
+	if strings.Contains(string(payload), "*Jira*") {
+		t.Errorf("Expected empty facts to be omitted: %s", payload)
+	}
+}
+
+func TestTeamsNotifierDoesNotRetryClientErrors(t *testing.T) {
+	server, calls, bodies := webhookServer(t, http.StatusBadRequest)
+
+	notifier := NewTeamsNotifier("teams:ops", server.URL)
+	notifier.client.backoff = time.Millisecond
+
+	if err := notifier.Notify(context.Background(), testEvent()); err == nil {
+		t.Fatal("Expected error for 400 response")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected a single attempt for a client error, got %d", *calls)
+	}
+
+	attachments := (*bodies)[0]["attachments"].([]interface{})
+	attachment := attachments[0].(map[string]interface{})
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("Unexpected attachment: %v", attachment)
+	}
+	card := attachment["content"].(map[string]interface{})
+	if card["type"] != "AdaptiveCard" || card["actions"] == nil {
+		t.Errorf("Expected an Adaptive Card with a portal action, got %v", card)
+	}
+}
+
+type fakeNotifier struct {
+	name  string
+	err   error
+	panic bool
+	calls int32
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Notify(ctx context.Context, event Event) error {
+	atomic.AddInt32(&f.calls, 1)
+	if f.panic {
+		panic("boom")
+	}
+	return f.err
+}
+
+func TestDispatcherIsolatesFailures(t *testing.T) {
+	email := &fakeNotifier{name: ChannelEmail}
+	failing := &fakeNotifier{name: "slack:ops", err: errors.New("webhook returned status 404")}
+	panicking := &fakeNotifier{name: "teams:ops", panic: true}
+
+	failures := NewDispatcher(email, failing, panicking).Dispatch(context.Background(), testEvent())
+
+	if email.calls != 1 || failing.calls != 1 || panicking.calls != 1 {
+		t.Errorf("Expected every notifier to be called once")
+	}
+	if failures[ChannelEmail] != nil {
+		t.Errorf("Expected email to succeed, got %v", failures[ChannelEmail])
+	}
+	if failures["slack:ops"] == nil || failures["teams:ops"] == nil || !strings.Contains(failures["teams:ops"].Error(), "panicked") {
+		t.Errorf("Unexpected failures: %v", failures)
+	}
+}
+
+func TestChannelNotifiersFiltersByTopic(t *testing.T) {
+	channels := []types.NotificationChannel{
+		{Name: "changes", Type: "slack", WebhookURLParameter: "/hts/slack/changes", Topics: []string{"aws-announce"}},
+		{Type: "Teams", WebhookURLParameter: "/hts/teams/all"},
+		{Name: "finops", Type: "slack", WebhookURLParameter: "/hts/slack/finops", Topics: []string{"finops-announce"}},
+		{Name: "broken", Type: "pager", WebhookURLParameter: "/hts/pager"},
+		{Name: "empty", Type: "slack"},
+	}
+
+	var names []string
+	for _, n := range ChannelNotifiers(channels, "aws-announce") {
+		names = append(names, n.Name())
+	}
+	if strings.Join(names, ",") != "slack:changes,teams:2" {
+		t.Errorf("Unexpected notifiers: %v", names)
+	}
+}
+
+func TestChannelNotifierResolvesWebhookURLAtSendTime(t *testing.T) {
+	server, calls, _ := webhookServer(t)
+
+	var resolved []string
+	original := ResolveWebhookURL
+	ResolveWebhookURL = func(ctx context.Context, parameter string) (string, error) {
+		resolved = append(resolved, parameter)
+		if parameter != "/hts/slack/changes" {
+			return "", errors.New("parameter not found")
+		}
+		return server.URL, nil
+	}
+	t.Cleanup(func() { ResolveWebhookURL = original })
+
+	notifiers := ChannelNotifiers([]types.NotificationChannel{
+		{Name: "changes", Type: "slack", WebhookURLParameter: "/hts/slack/changes"},
+		{Name: "missing", Type: "teams", WebhookURLParameter: "/hts/teams/missing"},
+	}, "aws-announce")
+	if len(resolved) != 0 {
+		t.Fatalf("Expected no lookups before sending, got %v", resolved)
+	}
+
+	if err := notifiers[0].Notify(context.Background(), testEvent()); err != nil || *calls != 1 {
+		t.Errorf("Expected the resolved webhook to be posted once, got %d calls (err %v)", *calls, err)
+	}
+	if err := notifiers[1].Notify(context.Background(), testEvent()); err == nil || !strings.Contains(err.Error(), "/hts/teams/missing") {
+		t.Errorf("Expected a resolution error naming the parameter, got %v", err)
+	}
+}
+
+func TestHeadline(t *testing.T) {
+	event := testEvent()
+	if got := event.Headline(); got != templates.EmojiApprovedChange+" Change approved" {
+		t.Errorf("Unexpected headline %q", got)
+	}
+
+	event.Category = templates.CategoryFinOps
+	event.Type = templates.NotificationCancelled
+	if got := event.Headline(); got != templates.EmojiCancelled+" Announcement cancelled" {
+		t.Errorf("Unexpected headline %q", got)
+	}
+}
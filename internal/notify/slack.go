@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SlackNotifier posts Block Kit messages to a Slack incoming webhook
+type SlackNotifier struct {
+	name   string
+	client webhookClient
+}
+
+// NewSlackNotifier creates a notifier for a Slack incoming webhook URL
+func NewSlackNotifier(name, webhookURL string) *SlackNotifier {
+	return &SlackNotifier{name: name, client: newWebhookClient(webhookURL)}
+}
+
+// Name implements Notifier
+func (n *SlackNotifier) Name() string { return n.name }
+
+// Notify implements Notifier
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	if err := n.client.post(ctx, BuildSlackMessage(event)); err != nil {
+		return fmt.Errorf("slack channel %s: %w", n.name, err)
+	}
+	return nil
+}
+
+// BuildSlackMessage renders an event as a Slack Block Kit message with a plain text fallback
+func BuildSlackMessage(event Event) map[string]interface{} {
+	headline := event.Headline()
+
+	title := escapeSlack(event.Title)
+	if event.URL != "" {
+		title = fmt.Sprintf("<%s|%s>", event.URL, title)
+	}
+	text := "*" + title + "*"
+	if event.Summary != "" {
+		text += "\n" + escapeSlack(event.Summary)
+	}
+	if event.StatusReason != "" {
+		text += "\n>" + escapeSlack(event.StatusReason)
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": headline, "emoji": true},
+		},
+		{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": text},
+		},
+	}
+
+	// Slack allows at most 10 fields per section
+	var fields []map[string]interface{}
+	for _, fact := range event.Facts {
+		if fact.Value == "" || len(fields) == 10 {
+			continue
+		}
+		fields = append(fields, map[string]interface{}{
+			"type": "mrkdwn",
+			"text": fmt.Sprintf("*%s*\n%s", escapeSlack(fact.Name), escapeSlack(fact.Value)),
+		})
+	}
+	if len(fields) > 0 {
+		blocks = append(blocks, map[string]interface{}{"type": "section", "fields": fields})
+	}
+
+	customer := event.CustomerName
+	if customer == "" {
+		customer = event.CustomerCode
+	}
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []map[string]interface{}{
+			{"type": "mrkdwn", "text": escapeSlack(fmt.Sprintf("%s · %s · sent by the CCOE customer contact manager", customer, event.ObjectID))},
+		},
+	})
+
+	return map[string]interface{}{
+		"text":   fmt.Sprintf("%s: %s", headline, escapeSlack(event.Title)),
+		"blocks": blocks,
+	}
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences in mrkdwn text
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// webhookURLCacheTTL bounds how long a resolved webhook URL is reused, so a rotated URL is
+// picked up by warm Lambda containers
+const webhookURLCacheTTL = 5 * time.Minute
+
+// ResolveWebhookURL reads a channel's incoming webhook URL from Parameter Store. Tests replace it.
+var ResolveWebhookURL = resolveWebhookURLFromSSM
+
+// cachedWebhookURL is a resolved webhook URL and when it was loaded
+type cachedWebhookURL struct {
+	url      string
+	loadedAt time.Time
+}
+
+var (
+	webhookURLCacheMu sync.Mutex
+	webhookURLCache   = make(map[string]cachedWebhookURL)
+)
+
+// resolveWebhookURLFromSSM loads a SecureString parameter holding a webhook URL, caching it
+func resolveWebhookURLFromSSM(ctx context.Context, parameter string) (string, error) {
+	webhookURLCacheMu.Lock()
+	cached, ok := webhookURLCache[parameter]
+	webhookURLCacheMu.Unlock()
+	if ok && time.Since(cached.loadedAt) < webhookURLCacheTTL {
+		return cached.url, nil
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	result, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(parameter),
+		WithDecryption: aws.Bool(true), // Important for SecureString parameters
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := aws.ToString(result.Parameter.Value)
+	if url == "" {
+		return "", fmt.Errorf("parameter %s is empty", parameter)
+	}
+
+	webhookURLCacheMu.Lock()
+	webhookURLCache[parameter] = cachedWebhookURL{url: url, loadedAt: time.Now()}
+	webhookURLCacheMu.Unlock()
+	return url, nil
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// TeamsNotifier posts Adaptive Cards to a Microsoft Teams incoming webhook (or Workflows webhook)
+type TeamsNotifier struct {
+	name   string
+	client webhookClient
+}
+
+// NewTeamsNotifier creates a notifier for a Teams incoming webhook URL
+func NewTeamsNotifier(name, webhookURL string) *TeamsNotifier {
+	return &TeamsNotifier{name: name, client: newWebhookClient(webhookURL)}
+}
+
+// Name implements Notifier
+func (n *TeamsNotifier) Name() string { return n.name }
+
+// Notify implements Notifier
+func (n *TeamsNotifier) Notify(ctx context.Context, event Event) error {
+	if err := n.client.post(ctx, BuildTeamsMessage(event)); err != nil {
+		return fmt.Errorf("teams channel %s: %w", n.name, err)
+	}
+	return nil
+}
+
+// BuildTeamsMessage renders an event as a message carrying an Adaptive Card. Adaptive Card
+// TextBlocks treat text as plain text with limited markdown, so values are passed through as-is.
+func BuildTeamsMessage(event Event) map[string]interface{} {
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": event.Headline(), "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": event.Title, "weight": "Bolder", "wrap": true},
+	}
+	if event.Summary != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": event.Summary, "wrap": true})
+	}
+	if event.StatusReason != "" {
+		body = append(body, map[string]interface{}{"type": "TextBlock", "text": event.StatusReason, "wrap": true, "isSubtle": true})
+	}
+
+	customer := event.CustomerName
+	if customer == "" {
+		customer = event.CustomerCode
+	}
+	facts := []map[string]string{
+		{"title": "Customer", "value": customer},
+		{"title": "ID", "value": event.ObjectID},
+	}
+	for _, fact := range event.Facts {
+		if fact.Value != "" {
+			facts = append(facts, map[string]string{"title": fact.Name, "value": fact.Value})
+		}
+	}
+	body = append(body, map[string]interface{}{"type": "FactSet", "facts": facts})
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if event.URL != "" {
+		card["actions"] = []map[string]interface{}{
+			{"type": "Action.OpenUrl", "title": "Open in portal", "url": event.URL},
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
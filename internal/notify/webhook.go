@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook retry settings
+const (
+	DefaultWebhookAttempts = 3
+	defaultWebhookBackoff  = time.Second
+	maxWebhookRetryAfter   = 10 * time.Second
+)
+
+// webhookClient posts JSON payloads to an incoming webhook, retrying rate limits,
+// server errors and network failures with exponential backoff
+type webhookClient struct {
+	url        string
+	parameter  string // Parameter Store name resolved to the URL at send time when url is empty
+	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+}
+
+func newWebhookClient(url string) webhookClient {
+	return webhookClient{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		attempts:   DefaultWebhookAttempts,
+		backoff:    defaultWebhookBackoff,
+	}
+}
+
+// newParameterWebhookClient creates a client whose URL is read from Parameter Store when posting
+func newParameterWebhookClient(parameter string) webhookClient {
+	client := newWebhookClient("")
+	client.parameter = parameter
+	return client
+}
+
+// post sends the payload, returning the last error once all attempts are used
+func (c webhookClient) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	if c.url == "" {
+		if c.url, err = ResolveWebhookURL(ctx, c.parameter); err != nil {
+			return fmt.Errorf("failed to resolve webhook URL from %s: %w", c.parameter, err)
+		}
+	}
+
+	var lastErr error
+	delay := c.backoff
+	for attempt := 1; attempt <= c.attempts; attempt++ {
+		retryAfter, err := c.postOnce(ctx, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if retryAfter < 0 || attempt == c.attempts {
+			break
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (gave up: %v)", lastErr, ctx.Err())
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+
+	return lastErr
+}
+
+// postOnce performs one request. The returned duration is negative when the error is not
+// retryable, zero for a default backoff, or the server's Retry-After.
+func (c webhookClient) postOnce(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return 0, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if seconds, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && seconds > 0 {
+			retryAfter := time.Duration(seconds) * time.Second
+			if retryAfter > maxWebhookRetryAfter {
+				retryAfter = maxWebhookRetryAfter
+			}
+			return retryAfter, err
+		}
+		return 0, err
+	case resp.StatusCode >= 500:
+		return 0, err
+	default:
+		return -1, err
+	}
+}
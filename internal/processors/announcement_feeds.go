@@ -0,0 +1,204 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/feeds"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// announcementFeedMaxRetries bounds retries when concurrent announcements update the same customer's feeds
+const announcementFeedMaxRetries = 5
+
+// errFeedStateConflict is returned when the feed state was written by another process
+var errFeedStateConflict = errors.New("announcement feed state was modified concurrently")
+
+// PublishAnnouncementFeeds records an announcement in the customer's feed state and republishes
+// the Atom feed and JSON index for "all" and the announcement's type. Layout under the prefix:
+//
+//	{customer}/entries.json                 full entry list (ETag locked)
+//	{customer}/{all|type}/atom.xml          newest page_size entries
+//	{customer}/{all|type}/index.json        page 1, then index-2.json, ...
+//
+// It does nothing when announcement feeds are not enabled.
+func (p *AnnouncementProcessor) PublishAnnouncementFeeds(ctx context.Context, customerCode string, announcement *types.AnnouncementMetadata, s3Bucket string) error {
+	if p.Config == nil || p.Config.AnnouncementFeed == nil || !p.Config.AnnouncementFeed.Enabled {
+		return nil
+	}
+
+	feedCfg := p.Config.AnnouncementFeed.WithDefaults()
+	bucket := feedCfg.Bucket
+	if bucket == "" {
+		bucket = s3Bucket
+	}
+	customerPrefix := path.Join(feedCfg.Prefix, customerCode)
+	stateKey := path.Join(customerPrefix, "entries.json")
+
+	for attempt := 1; attempt <= announcementFeedMaxRetries; attempt++ {
+		state, etag, err := p.loadAnnouncementFeedState(ctx, bucket, stateKey)
+		if err != nil {
+			return err
+		}
+		state.CustomerCode = customerCode
+
+		changed := state.Apply(announcement, p.Config.EmailConfig.PortalBaseURL)
+		if len(changed) == 0 {
+			log.Printf("ℹ️  Announcement feeds for %s already reflect %s (%s)", customerCode, announcement.AnnouncementID, announcement.Status)
+			return nil
+		}
+
+		now := time.Now()
+		state.UpdatedAt = now
+		if err := p.saveAnnouncementFeedState(ctx, bucket, stateKey, state, etag); err != nil {
+			if errors.Is(err, errFeedStateConflict) {
+				log.Printf("🔄 Announcement feed state for %s changed concurrently, retrying (attempt %d/%d)", customerCode, attempt, announcementFeedMaxRetries)
+				continue
+			}
+			return err
+		}
+
+		customerName := customerCode
+		if info, exists := p.Config.CustomerMappings[customerCode]; exists && info.CustomerName != "" {
+			customerName = info.CustomerName
+		}
+
+		published := make(map[string]bool)
+		for _, feedType := range changed {
+			if published[feedType] {
+				continue
+			}
+			published[feedType] = true
+
+			if err := p.writeAnnouncementFeed(ctx, bucket, customerPrefix, customerCode, customerName, feedType, state, feedCfg, now); err != nil {
+				return err
+			}
+		}
+
+		log.Printf("📰 Published announcement feeds for %s under s3://%s/%s (%d entries)", customerCode, bucket, customerPrefix, len(state.Entries))
+		return nil
+	}
+
+	return fmt.Errorf("announcement feed state for %s kept changing after %d attempts", customerCode, announcementFeedMaxRetries)
+}
+
+// writeAnnouncementFeed renders and uploads one feed's Atom document and JSON index pages
+func (p *AnnouncementProcessor) writeAnnouncementFeed(ctx context.Context, bucket, customerPrefix, customerCode, customerName, feedType string, state *feeds.State, feedCfg types.AnnouncementFeedConfig, now time.Time) error {
+	feedPrefix := path.Join(customerPrefix, feedType)
+	entries := state.EntriesFor(feedType)
+
+	pages := feeds.Paginate(customerCode, feedType, entries, feedCfg.PageSize, now)
+	for _, page := range pages {
+		body, err := feeds.MarshalPage(page)
+		if err != nil {
+			return fmt.Errorf("failed to render %s page %d: %w", feedPrefix, page.Page, err)
+		}
+		if err := p.putFeedObject(ctx, bucket, path.Join(feedPrefix, feeds.PageName(page.Page)), body, "application/json"); err != nil {
+			return err
+		}
+	}
+
+	// A removal can shorten the index by one page; drop the page that is no longer linked
+	staleKey := path.Join(feedPrefix, feeds.PageName(len(pages)+1))
+	if _, err := p.S3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(bucket), Key: aws.String(staleKey)}); err != nil {
+		log.Printf("⚠️  Failed to remove stale feed page %s: %v", staleKey, err)
+	}
+
+	atomEntries := entries
+	if len(atomEntries) > feedCfg.PageSize {
+		atomEntries = atomEntries[:feedCfg.PageSize]
+	}
+
+	selfURL := ""
+	if feedCfg.PublicBaseURL != "" {
+		selfURL = strings.TrimRight(feedCfg.PublicBaseURL, "/") + "/" + path.Join(customerCode, feedType, "atom.xml")
+	}
+
+	atom, err := feeds.RenderAtom(customerCode, feedType, feeds.Title(customerName, feedType), selfURL, atomEntries, now)
+	if err != nil {
+		return err
+	}
+	return p.putFeedObject(ctx, bucket, path.Join(feedPrefix, "atom.xml"), atom, "application/atom+xml; charset=utf-8")
+}
+
+// loadAnnouncementFeedState loads a customer's feed entries and ETag. A missing object yields an empty state and no ETag.
+func (p *AnnouncementProcessor) loadAnnouncementFeedState(ctx context.Context, bucket, key string) (*feeds.State, string, error) {
+	output, err := p.S3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NoSuchKey") || strings.Contains(err.Error(), "NotFound") {
+			return &feeds.State{}, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to load announcement feed state s3://%s/%s: %w", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read announcement feed state: %w", err)
+	}
+
+	var state feeds.State
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, "", fmt.Errorf("failed to parse announcement feed state s3://%s/%s: %w", bucket, key, err)
+	}
+
+	return &state, aws.ToString(output.ETag), nil
+}
+
+// saveAnnouncementFeedState writes a customer's feed entries. With an ETag the write only succeeds
+// if the object is unchanged; with an empty ETag it only succeeds if the object does not exist yet.
+func (p *AnnouncementProcessor) saveAnnouncementFeedState(ctx context.Context, bucket, key string, state *feeds.State, expectedETag string) error {
+	body, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal announcement feed state: %w", err)
+	}
+
+	putInput := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	}
+	if expectedETag != "" {
+		putInput.IfMatch = aws.String(expectedETag)
+	} else {
+		putInput.IfNoneMatch = aws.String("*")
+	}
+
+	if _, err := p.S3Client.PutObject(ctx, putInput); err != nil {
+		if strings.Contains(err.Error(), "PreconditionFailed") || strings.Contains(err.Error(), "412") {
+			return fmt.Errorf("%w: %v", errFeedStateConflict, err)
+		}
+		return fmt.Errorf("failed to save announcement feed state s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
+
+// putFeedObject uploads a rendered feed document
+func (p *AnnouncementProcessor) putFeedObject(ctx context.Context, bucket, key string, body []byte, contentType string) error {
+	_, err := p.S3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(body),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String("max-age=300"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload feed s3://%s/%s: %w", bucket, key, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package processors
+
+import (
+	"context"
+	"log"
+
+	"ccoe-customer-contact-manager/internal/notify"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// announcementNotificationTopic returns the SES topic an announcement email is sent to.
+// Chat channels mirror topics, so this must match sendEmailWithNewTemplates.
+func (p *AnnouncementProcessor) announcementNotificationTopic(customerCode string, announcement *types.AnnouncementMetadata) string {
+	if announcement.Status == "submitted" {
+		return "announce-approval"
+	}
+	return p.getTopicNameForAnnouncementType(customerCode, announcement.AnnouncementType)
+}
+
+// dispatchNotification sends an announcement email and posts it to the customer's chat channels
+func (p *AnnouncementProcessor) dispatchNotification(ctx context.Context, customerCode string, announcement *types.AnnouncementMetadata, sendEmail func(ctx context.Context) error) error {
+	customer := p.Config.CustomerMappings[customerCode]
+	if customer.CustomerCode == "" {
+		customer.CustomerCode = customerCode
+	}
+
+	topic := p.announcementNotificationTopic(customerCode, announcement)
+	event := notify.AnnouncementEvent(announcement, customer, topic, p.Config.EmailConfig.PortalBaseURL)
+
+	notifiers := []notify.Notifier{&notify.EmailNotifier{Send: func(ctx context.Context, _ notify.Event) error {
+		return sendEmail(ctx)
+	}}}
+	notifiers = append(notifiers, notify.ChannelNotifiers(customer.NotificationChannels, topic)...)
+
+	failures := notify.NewDispatcher(notifiers...).Dispatch(ctx, event)
+	for name, err := range failures {
+		if name != notify.ChannelEmail {
+			log.Printf("⚠️  Failed to notify %s for announcement %s (customer %s): %v", name, announcement.AnnouncementID, customerCode, err)
+		}
+	}
+
+	return failures[notify.ChannelEmail]
+}
@@ -21,16 +21,18 @@ import (
 	awsinternal "ccoe-customer-contact-manager/internal/aws"
 	"ccoe-customer-contact-manager/internal/ses"
 	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/sms"
 	"ccoe-customer-contact-manager/internal/typeform"
 	"ccoe-customer-contact-manager/internal/types"
 )
 
 // AnnouncementProcessor handles announcement-specific processing logic
 type AnnouncementProcessor struct {
-	S3Client   *s3.Client
-	SESClient  *sesv2.Client
-	GraphToken string
-	Config     *types.Config
+	S3Client    *s3.Client
+	SESClient   *sesv2.Client
+	GraphToken  string
+	Config      *types.Config
+	SMSProvider sms.Provider // Optional: defaults to SNS when urgent SMS is enabled
 }
 
 // NewAnnouncementProcessor creates a new announcement processor with required clients
@@ -48,26 +50,38 @@ func (p *AnnouncementProcessor) ProcessAnnouncement(ctx context.Context, custome
 	log.Printf("📢 Processing announcement for customer %s: %s (type: %s, status: %s)",
 		customerCode, announcement.AnnouncementID, announcement.AnnouncementType, announcement.Status)
 
+	var err error
 	switch announcement.Status {
 	case "submitted":
 		return p.handleSubmitted(ctx, customerCode, announcement)
 	case "approved":
-		return p.handleApproved(ctx, customerCode, announcement, s3Bucket, s3Key)
+		err = p.handleApproved(ctx, customerCode, announcement, s3Bucket, s3Key)
 	case "cancelled":
-		return p.handleCancelled(ctx, customerCode, announcement, s3Bucket, s3Key)
+		err = p.handleCancelled(ctx, customerCode, announcement, s3Bucket, s3Key)
 	case "completed":
-		return p.handleCompleted(ctx, customerCode, announcement, s3Bucket, s3Key)
+		err = p.handleCompleted(ctx, customerCode, announcement, s3Bucket, s3Key)
 	default:
 		log.Printf("⏭️  Skipping announcement %s - status is '%s' (not submitted/approved/cancelled/completed)",
 			announcement.AnnouncementID, announcement.Status)
 		return nil
 	}
+	if err != nil {
+		return err
+	}
+
+	// Refresh the customer's Atom feeds and JSON indexes (non-fatal)
+	if feedErr := p.PublishAnnouncementFeeds(ctx, customerCode, announcement, s3Bucket); feedErr != nil {
+		log.Printf("⚠️  Failed to publish announcement feeds for customer %s: %v", customerCode, feedErr)
+	}
+	return nil
 }
 
 // handleSubmitted processes a submitted announcement (sends approval request)
 func (p *AnnouncementProcessor) handleSubmitted(ctx context.Context, customerCode string, announcement *types.AnnouncementMetadata) error {
 	log.Printf("📧 Sending approval request for announcement %s", announcement.AnnouncementID)
-	return p.sendApprovalRequest(ctx, customerCode, announcement)
+	return p.dispatchNotification(ctx, customerCode, announcement, func(ctx context.Context) error {
+		return p.sendApprovalRequest(ctx, customerCode, announcement)
+	})
 }
 
 // handleApproved processes an approved announcement (schedules meeting if needed, sends emails)
@@ -90,7 +104,9 @@ func (p *AnnouncementProcessor) handleApproved(ctx context.Context, customerCode
 
 	// Send announcement emails
 	log.Printf("📧 Sending announcement emails for %s", announcement.AnnouncementID)
-	err := p.sendAnnouncementEmails(ctx, customerCode, announcement)
+	err := p.dispatchNotification(ctx, customerCode, announcement, func(ctx context.Context) error {
+		return p.sendAnnouncementEmails(ctx, customerCode, announcement)
+	})
 	if err != nil {
 		// Check if error is due to no subscribers (not a real error)
 		if strings.Contains(err.Error(), "no subscribers found") {
@@ -101,6 +117,11 @@ func (p *AnnouncementProcessor) handleApproved(ctx context.Context, customerCode
 		}
 	}
 
+	// Text opted-in customers' subscribers about urgent announcements (non-fatal)
+	if err := p.sendUrgentSMS(ctx, customerCode, announcement, s3Bucket); err != nil {
+		log.Printf("⚠️  Failed to send urgent SMS for announcement %s (customer %s): %v", announcement.AnnouncementID, customerCode, err)
+	}
+
 	log.Printf("✅ Announcement processing completed for customer %s: %s", customerCode, announcement.AnnouncementID)
 	return nil
 }
@@ -135,7 +156,9 @@ func (p *AnnouncementProcessor) handleCancelled(ctx context.Context, customerCod
 	// Only send cancellation email if announcement was previously approved
 	if wasApproved {
 		log.Printf("📧 Sending cancellation email for announcement %s (was previously approved)", announcement.AnnouncementID)
-		err := p.sendCancellationEmail(ctx, customerCode, announcement)
+		err := p.dispatchNotification(ctx, customerCode, announcement, func(ctx context.Context) error {
+			return p.sendCancellationEmail(ctx, customerCode, announcement)
+		})
 		if err != nil {
 			// Check if error is due to no subscribers (not a real error)
 			if strings.Contains(err.Error(), "no subscribers found") {
@@ -173,7 +196,9 @@ func (p *AnnouncementProcessor) handleCompleted(ctx context.Context, customerCod
 
 	// Send completion email (which will include the survey link if survey was created successfully)
 	log.Printf("📧 Sending completion email for announcement %s", announcement.AnnouncementID)
-	err = p.sendCompletionEmail(ctx, customerCode, announcement)
+	err = p.dispatchNotification(ctx, customerCode, announcement, func(ctx context.Context) error {
+		return p.sendCompletionEmail(ctx, customerCode, announcement)
+	})
 	if err != nil {
 		// Check if error is due to no subscribers (not a real error)
 		if strings.Contains(err.Error(), "no subscribers found") {
@@ -0,0 +1,283 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+
+	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/sms"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// errSMSObjectConflict is returned when a conditional write to an SMS log or usage object loses a race
+var errSMSObjectConflict = errors.New("sms object was modified concurrently")
+
+// smsUsage counts the messages sent for a customer in one hour
+type smsUsage struct {
+	Count int `json:"count"`
+}
+
+// sendUrgentSMS texts topic subscribers that have a phone number about an urgent announcement.
+// Customers opt in with urgent_sms. The delivery log doubles as an idempotency claim, so a
+// reprocessed announcement never texts anyone twice.
+func (p *AnnouncementProcessor) sendUrgentSMS(ctx context.Context, customerCode string, announcement *types.AnnouncementMetadata, s3Bucket string) error {
+	if !announcement.IsUrgent() {
+		return nil
+	}
+	if !p.Config.SMS.IsEnabled() {
+		log.Printf("ℹ️  Announcement %s is urgent but SMS is not enabled - sending email only", announcement.AnnouncementID)
+		return nil
+	}
+
+	customer := p.Config.CustomerMappings[customerCode]
+	if !customer.UrgentSMS {
+		log.Printf("ℹ️  Customer %s has not opted in to urgent SMS - skipping SMS for announcement %s", customerCode, announcement.AnnouncementID)
+		return nil
+	}
+
+	provider, err := p.smsProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	topic := p.getTopicNameForAnnouncementType(customerCode, announcement.AnnouncementType)
+	url := ""
+	if base := p.Config.EmailConfig.PortalBaseURL; base != "" {
+		url = fmt.Sprintf("%s/edit-announcement.html?announcementId=%s", strings.TrimRight(base, "/"), announcement.AnnouncementID)
+	}
+
+	deliveryLog := &sms.DeliveryLog{
+		AnnouncementID: announcement.AnnouncementID,
+		CustomerCode:   customerCode,
+		Provider:       provider.Name(),
+		Topic:          topic,
+		Message:        sms.BuildMessage(customer.CustomerName, announcement.Title, announcement.Summary, url),
+		StartedAt:      time.Now().UTC(),
+		Deliveries:     []sms.Delivery{},
+	}
+
+	prefix := p.Config.SMS.GetLogPrefix()
+	logKey := path.Join(prefix, customerCode, announcement.AnnouncementID+".json")
+
+	// Recipients are resolved before claiming the delivery log so a lookup failure leaves the
+	// announcement unclaimed and a retry can still send it
+	recipients, err := p.getSMSRecipients(ctx, customerCode, topic)
+	if err != nil {
+		return err
+	}
+
+	etag, err := p.putSMSObject(ctx, s3Bucket, logKey, deliveryLog, "")
+	if errors.Is(err, errSMSObjectConflict) {
+		log.Printf("⏭️  Urgent SMS for announcement %s already sent to customer %s", announcement.AnnouncementID, customerCode)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim SMS delivery log: %w", err)
+	}
+
+	allowed := 0
+	if len(recipients) > 0 {
+		allowed, err = p.reserveSMSQuota(ctx, s3Bucket, path.Join(prefix, customerCode, "usage"), len(recipients))
+		if err != nil {
+			log.Printf("⚠️  Failed to reserve SMS quota for customer %s, sending nothing: %v", customerCode, err)
+		}
+	}
+
+	deliveryLog.Complete(sms.Send(ctx, provider, recipients, deliveryLog.Message, allowed))
+	if _, err := p.putSMSObject(ctx, s3Bucket, logKey, deliveryLog, etag); err != nil {
+		log.Printf("⚠️  Failed to write SMS delivery log s3://%s/%s: %v", s3Bucket, logKey, err)
+	}
+
+	log.Printf("📱 Urgent SMS for announcement %s (customer %s): %d sent, %d failed, %d rate limited",
+		announcement.AnnouncementID, customerCode, deliveryLog.Sent, deliveryLog.Failed, deliveryLog.RateLimited)
+
+	if deliveryLog.Failed > 0 && deliveryLog.Sent == 0 {
+		return fmt.Errorf("failed to send SMS to all %d recipients", deliveryLog.Failed)
+	}
+	return nil
+}
+
+// smsProvider returns the configured provider, defaulting to SNS in the configured region
+func (p *AnnouncementProcessor) smsProvider(ctx context.Context) (sms.Provider, error) {
+	if p.SMSProvider != nil {
+		return p.SMSProvider, nil
+	}
+
+	region := p.Config.SMS.Region
+	if region == "" {
+		region = p.Config.AWSRegion
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config for SNS: %w", err)
+	}
+
+	p.SMSProvider = sms.NewSNSProvider(sns.NewFromConfig(awsCfg), p.Config.SMS.SenderID, p.Config.SMS.OriginationNumber)
+	return p.SMSProvider, nil
+}
+
+// getSMSRecipients returns the topic subscribers allowed by restricted_recipients that have a
+// valid phone number, one per number. Phone numbers live in contact AttributesData, which
+// ListContacts does not return, so each subscriber is looked up.
+func (p *AnnouncementProcessor) getSMSRecipients(ctx context.Context, customerCode, topic string) ([]sms.Recipient, error) {
+	sesClient, err := p.getCustomerSESClient(ctx, customerCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create customer SES client: %w", err)
+	}
+
+	listName, err := ses.GetAccountContactList(sesClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account contact list: %w", err)
+	}
+
+	contacts, err := p.getSubscribedContactsForTopic(sesClient, listName, topic)
+	if err != nil {
+		if strings.Contains(err.Error(), "doesn't contain Topic") || strings.Contains(err.Error(), "NotFoundException") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var emails []string
+	for _, contact := range contacts {
+		if contact.EmailAddress != nil {
+			emails = append(emails, *contact.EmailAddress)
+		}
+	}
+	if customer, ok := p.Config.CustomerMappings[customerCode]; ok && len(customer.RestrictedRecipients) > 0 {
+		emails, _ = customer.FilterRecipients(emails)
+	}
+
+	var recipients []sms.Recipient
+	seen := make(map[string]bool)
+	for _, email := range emails {
+		contact, err := sesClient.GetContact(ctx, &sesv2.GetContactInput{
+			ContactListName: aws.String(listName),
+			EmailAddress:    aws.String(email),
+		})
+		if err != nil {
+			log.Printf("⚠️  Failed to get contact %s for SMS: %v", email, err)
+			continue
+		}
+
+		phone := ses.ContactPhoneNumber(aws.ToString(contact.AttributesData))
+		if phone == "" {
+			continue
+		}
+		normalized, err := sms.NormalizePhoneNumber(phone)
+		if err != nil {
+			log.Printf("⚠️  Skipping SMS to %s: %v", email, err)
+			continue
+		}
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		recipients = append(recipients, sms.Recipient{Email: email, PhoneNumber: normalized})
+	}
+
+	log.Printf("📱 %d of %d subscribers to topic '%s' have a phone number for SMS", len(recipients), len(emails), topic)
+	return recipients, nil
+}
+
+// reserveSMSQuota takes up to requested messages from the customer's hourly allowance and the
+// per-announcement cap. The hourly counter is updated with ETag locking so concurrent
+// announcements cannot exceed it.
+func (p *AnnouncementProcessor) reserveSMSQuota(ctx context.Context, bucket, usagePrefix string, requested int) (int, error) {
+	const maxAttempts = 5
+	key := path.Join(usagePrefix, time.Now().UTC().Format("2006-01-02T15")+".json")
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		usage, etag, err := p.loadSMSUsage(ctx, bucket, key)
+		if err != nil {
+			return 0, err
+		}
+
+		allowed := sms.Allowance(requested, p.Config.SMS.GetMaxPerAnnouncement(), usage.Count, p.Config.SMS.GetMaxPerHour())
+		if allowed == 0 {
+			return 0, nil
+		}
+
+		usage.Count += allowed
+		_, err = p.putSMSObject(ctx, bucket, key, usage, etag)
+		if err == nil {
+			return allowed, nil
+		}
+		if !errors.Is(err, errSMSObjectConflict) {
+			return 0, err
+		}
+		time.Sleep(time.Duration(100<<uint(attempt-1)) * time.Millisecond)
+	}
+
+	return 0, fmt.Errorf("failed to update SMS usage %s after %d attempts due to concurrent modifications", key, maxAttempts)
+}
+
+// loadSMSUsage loads an hourly usage counter and its ETag. A missing object yields zero usage and no ETag.
+func (p *AnnouncementProcessor) loadSMSUsage(ctx context.Context, bucket, key string) (*smsUsage, string, error) {
+	output, err := p.S3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NoSuchKey") || strings.Contains(err.Error(), "NotFound") {
+			return &smsUsage{}, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to load SMS usage s3://%s/%s: %w", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read SMS usage: %w", err)
+	}
+
+	var usage smsUsage
+	if err := json.Unmarshal(body, &usage); err != nil {
+		return nil, "", fmt.Errorf("failed to parse SMS usage s3://%s/%s: %w", bucket, key, err)
+	}
+	return &usage, aws.ToString(output.ETag), nil
+}
+
+// putSMSObject writes a JSON object and returns its new ETag. With an ETag the write only succeeds
+// if the object is unchanged; with an empty ETag it only succeeds if the object does not exist yet.
+func (p *AnnouncementProcessor) putSMSObject(ctx context.Context, bucket, key string, value interface{}, expectedETag string) (string, error) {
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", key, err)
+	}
+
+	putInput := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	}
+	if expectedETag != "" {
+		putInput.IfMatch = aws.String(expectedETag)
+	} else {
+		putInput.IfNoneMatch = aws.String("*")
+	}
+
+	output, err := p.S3Client.PutObject(ctx, putInput)
+	if err != nil {
+		if strings.Contains(err.Error(), "PreconditionFailed") || strings.Contains(err.Error(), "412") {
+			return "", fmt.Errorf("%w: %v", errSMSObjectConflict, err)
+		}
+		return "", fmt.Errorf("failed to write s3://%s/%s: %w", bucket, key, err)
+	}
+	return aws.ToString(output.ETag), nil
+}
@@ -0,0 +1,438 @@
+// Package reports aggregates archived changes, announcements and survey results into
+// per-customer reports for business reviews
+package reports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/surveys"
+	"ccoe-customer-contact-manager/internal/typeform"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Period is a reporting period [Start, End)
+type Period struct {
+	Label string
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether t falls within the period
+func (p Period) Contains(t time.Time) bool {
+	return !t.IsZero() && !t.Before(p.Start) && t.Before(p.End)
+}
+
+// ParsePeriod parses a period such as "2025", "2025-Q1" or "2025-03" (UTC)
+func ParsePeriod(value string) (Period, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	if year, quarter, ok := strings.Cut(value, "-Q"); ok {
+		y, err := strconv.Atoi(year)
+		q, qErr := strconv.Atoi(quarter)
+		if err != nil || qErr != nil || q < 1 || q > 4 {
+			return Period{}, fmt.Errorf("invalid quarter %q (expected e.g. 2025-Q1)", value)
+		}
+		start := time.Date(y, time.Month((q-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+		return Period{Label: fmt.Sprintf("%d-Q%d", y, q), Start: start, End: start.AddDate(0, 3, 0)}, nil
+	}
+
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return Period{Label: t.Format("January 2006"), Start: t, End: t.AddDate(0, 1, 0)}, nil
+	}
+
+	if t, err := time.Parse("2006", value); err == nil {
+		return Period{Label: t.Format("2006"), Start: t, End: t.AddDate(1, 0, 0)}, nil
+	}
+
+	return Period{}, fmt.Errorf("invalid period %q (expected YYYY, YYYY-QN or YYYY-MM)", value)
+}
+
+// Cancellation describes a cancelled change
+type Cancellation struct {
+	ChangeID    string
+	Title       string
+	CancelledAt time.Time
+	Reason      string
+}
+
+// Meeting describes a meeting held for a change or announcement
+type Meeting struct {
+	ObjectID string
+	Subject  string
+	Start    time.Time
+}
+
+// CustomerReport is the aggregated report for a customer and period
+type CustomerReport struct {
+	CustomerCode string
+	CustomerName string
+	Period       Period
+	GeneratedAt  time.Time
+
+	TotalChanges        int
+	ChangesByStatus     map[string]int
+	CompletedChanges    int
+	CompletedOnTime     int
+	UnconfirmedChanges  int // Auto-completed after their window without owner confirmation
+	Cancellations       []Cancellation
+	ApprovalDurations   []time.Duration
+	Meetings            []Meeting
+	TotalAnnouncements  int
+	AnnouncementsByType map[string]int
+	Surveys             *surveys.Stats // Survey analytics over the customer's responses in the period
+	LatestComment       string
+}
+
+// BuildCustomerReport aggregates the given objects for a customer and period. Objects for
+// other customers or outside the period are ignored, so callers can pass the whole archive.
+func BuildCustomerReport(customerCode, customerName string, period Period, changes []*types.ChangeMetadata, announcements []*types.AnnouncementMetadata, payloads []typeform.WebhookPayload, now time.Time) *CustomerReport {
+	report := &CustomerReport{
+		CustomerCode:        customerCode,
+		CustomerName:        customerName,
+		Period:              period,
+		GeneratedAt:         now,
+		ChangesByStatus:     make(map[string]int),
+		AnnouncementsByType: make(map[string]int),
+	}
+
+	for _, change := range changes {
+		if !hasCustomer(change.Customers, customerCode) || !period.Contains(changeEventTime(change)) {
+			continue
+		}
+		report.addChange(change, now)
+	}
+
+	for _, announcement := range announcements {
+		if !hasCustomer(announcement.Customers, customerCode) || !period.Contains(announcementEventTime(announcement)) {
+			continue
+		}
+		report.addAnnouncement(announcement, now)
+	}
+
+	var responses []surveys.Response
+	var latestCommentAt time.Time
+	for _, payload := range payloads {
+		response := surveys.ParseResponse(payload)
+		if response.CustomerCode != customerCode || !period.Contains(response.SubmittedAt) {
+			continue
+		}
+		responses = append(responses, response)
+		if n := len(response.Comments); n > 0 && !response.SubmittedAt.Before(latestCommentAt) {
+			report.LatestComment, latestCommentAt = response.Comments[n-1], response.SubmittedAt
+		}
+	}
+	report.Surveys = surveys.Analyze(responses, nil, surveys.Filter{}, surveys.Options{}, now).Overall
+
+	sort.Slice(report.Cancellations, func(i, j int) bool {
+		return report.Cancellations[i].CancelledAt.Before(report.Cancellations[j].CancelledAt)
+	})
+	sort.Slice(report.Meetings, func(i, j int) bool { return report.Meetings[i].Start.Before(report.Meetings[j].Start) })
+
+	return report
+}
+
+// addChange folds a single change into the report
+func (r *CustomerReport) addChange(change *types.ChangeMetadata, now time.Time) {
+	r.TotalChanges++
+	r.ChangesByStatus[change.Status]++
+
+	switch change.Status {
+	case "completed":
+		r.CompletedChanges++
+		completedAt := lastModificationTime(change.Modifications, "completed")
+		if !completedAt.IsZero() && !change.ImplementationEnd.IsZero() && !completedAt.After(change.ImplementationEnd) {
+			r.CompletedOnTime++
+		}
+	case types.ChangeStatusCompletedUnconfirmed:
+		r.UnconfirmedChanges++
+	case "cancelled":
+		reason := cancellationReason(change)
+		if reason == "" {
+			reason = "No reason given"
+		}
+		r.Cancellations = append(r.Cancellations, Cancellation{
+			ChangeID:    change.ChangeID,
+			Title:       change.ChangeTitle,
+			CancelledAt: lastModificationTime(change.Modifications, "cancelled"),
+			Reason:      reason,
+		})
+	}
+
+	if d, ok := timeToApproval(change); ok {
+		r.ApprovalDurations = append(r.ApprovalDurations, d)
+	}
+
+	if change.Status != "cancelled" {
+		r.addMeeting(change.ChangeID, change.MeetingMetadata, now)
+	}
+}
+
+// addAnnouncement folds a single announcement into the report
+func (r *CustomerReport) addAnnouncement(announcement *types.AnnouncementMetadata, now time.Time) {
+	r.TotalAnnouncements++
+	announcementType := announcement.AnnouncementType
+	if announcementType == "" {
+		announcementType = strings.TrimPrefix(announcement.ObjectType, "announcement_")
+	}
+	r.AnnouncementsByType[announcementType]++
+
+	if announcement.Status != "cancelled" {
+		r.addMeeting(announcement.AnnouncementID, announcement.MeetingMetadata, now)
+	}
+}
+
+// addMeeting records a meeting that has already taken place
+func (r *CustomerReport) addMeeting(objectID string, meeting *types.MeetingMetadata, now time.Time) {
+	if meeting == nil || meeting.StartTime == "" {
+		return
+	}
+	start, err := time.Parse(time.RFC3339, meeting.StartTime)
+	if err != nil || start.After(now) {
+		return
+	}
+	r.Meetings = append(r.Meetings, Meeting{ObjectID: objectID, Subject: meeting.Subject, Start: start})
+}
+
+// OnTimeRate returns the share of completed changes completed before their window ended
+func (r *CustomerReport) OnTimeRate() float64 {
+	if r.CompletedChanges == 0 {
+		return 0
+	}
+	return float64(r.CompletedOnTime) / float64(r.CompletedChanges) * 100
+}
+
+// MedianTimeToApproval returns the median submitted-to-approved duration
+func (r *CustomerReport) MedianTimeToApproval() time.Duration {
+	if len(r.ApprovalDurations) == 0 {
+		return 0
+	}
+	durations := append([]time.Duration(nil), r.ApprovalDurations...)
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
+
+// TemplateData converts the report into the shared report email layout
+func (r *CustomerReport) TemplateData() templates.ReportData {
+	name := r.CustomerName
+	if name == "" {
+		name = r.CustomerCode
+	}
+
+	data := templates.ReportData{
+		Title:       fmt.Sprintf("%s Change & Announcement Report", name),
+		Subtitle:    r.Period.Label,
+		GeneratedAt: r.GeneratedAt,
+		Metrics: []templates.ReportMetric{
+			{Label: "Changes", Value: strconv.Itoa(r.TotalChanges)},
+			{Label: "On-time completion", Value: formatPercent(r.OnTimeRate(), r.CompletedChanges)},
+			{Label: "Completed (unconfirmed)", Value: strconv.Itoa(r.UnconfirmedChanges)},
+			{Label: "Median time to approval", Value: formatDuration(r.MedianTimeToApproval(), len(r.ApprovalDurations))},
+			{Label: "Announcements", Value: strconv.Itoa(r.TotalAnnouncements)},
+			{Label: "Meetings held", Value: strconv.Itoa(len(r.Meetings))},
+			{Label: "Survey NPS", Value: formatStat(r.Surveys.NPS, "%+.0f")},
+		},
+		Sections: r.sections(),
+	}
+
+	return data
+}
+
+// sections returns the tabular sections shared by the HTML and CSV outputs
+func (r *CustomerReport) sections() []templates.ReportSection {
+	statusSection := templates.ReportSection{Title: "Changes by Status", Columns: []string{"Status", "Count"}, Empty: "No changes in this period"}
+	for _, status := range sortedKeys(r.ChangesByStatus) {
+		statusSection.Rows = append(statusSection.Rows, []string{status, strconv.Itoa(r.ChangesByStatus[status])})
+	}
+
+	cancellationSection := templates.ReportSection{Title: "Cancellations", Columns: []string{"Change", "Title", "Cancelled", "Reason"}, Empty: "No cancelled changes"}
+	for _, c := range r.Cancellations {
+		cancellationSection.Rows = append(cancellationSection.Rows, []string{c.ChangeID, c.Title, formatDate(c.CancelledAt), c.Reason})
+	}
+
+	meetingSection := templates.ReportSection{Title: "Meetings Held", Columns: []string{"Date", "Subject", "Object"}, Empty: "No meetings held"}
+	for _, m := range r.Meetings {
+		meetingSection.Rows = append(meetingSection.Rows, []string{formatDate(m.Start), m.Subject, m.ObjectID})
+	}
+
+	announcementSection := templates.ReportSection{Title: "Announcements by Type", Columns: []string{"Type", "Count"}, Empty: "No announcements in this period"}
+	for _, t := range sortedKeys(r.AnnouncementsByType) {
+		announcementSection.Rows = append(announcementSection.Rows, []string{t, strconv.Itoa(r.AnnouncementsByType[t])})
+	}
+
+	surveySection := templates.ReportSection{Title: "Survey Results", Columns: []string{"Metric", "Value"}, Empty: "No survey responses"}
+	if r.Surveys.Responses > 0 {
+		surveySection.Rows = [][]string{
+			{"Responses", strconv.Itoa(r.Surveys.Responses)},
+			{"Net promoter score", formatStat(r.Surveys.NPS, "%+.0f")},
+			{"Average score (0-10)", formatStat(r.Surveys.AverageScore, "%.1f")},
+			{"Satisfaction (CSAT)", formatStat(r.Surveys.CSAT, "%.0f%%")},
+		}
+		if r.LatestComment != "" {
+			surveySection.Rows = append(surveySection.Rows, []string{"Latest comment", r.LatestComment})
+		}
+	}
+
+	return []templates.ReportSection{statusSection, cancellationSection, meetingSection, announcementSection, surveySection}
+}
+
+// WriteCSV writes the report as CSV rows of section, key and value columns
+func (r *CustomerReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"customer", "period", "section", "columns", "values"}); err != nil {
+		return err
+	}
+
+	data := r.TemplateData()
+	for _, metric := range data.Metrics {
+		if err := writer.Write([]string{r.CustomerCode, r.Period.Label, "Summary", metric.Label, metric.Value}); err != nil {
+			return err
+		}
+	}
+
+	for _, section := range data.Sections {
+		for _, row := range section.Rows {
+			if err := writer.Write([]string{r.CustomerCode, r.Period.Label, section.Title, strings.Join(section.Columns, "|"), strings.Join(row, "|")}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// changeEventTime returns the time a change is attributed to a period
+func changeEventTime(change *types.ChangeMetadata) time.Time {
+	if !change.ImplementationStart.IsZero() {
+		return change.ImplementationStart
+	}
+	return change.CreatedAt
+}
+
+// announcementEventTime returns the time an announcement is attributed to a period
+func announcementEventTime(announcement *types.AnnouncementMetadata) time.Time {
+	if !announcement.PostedDate.IsZero() {
+		return announcement.PostedDate
+	}
+	return announcement.CreatedAt
+}
+
+// timeToApproval measures submitted-to-approved from the modifications, falling back to the legacy timestamps
+func timeToApproval(change *types.ChangeMetadata) (time.Duration, bool) {
+	submitted := firstModificationTime(change.Modifications, types.ModificationTypeSubmitted)
+	approved := firstModificationTime(change.Modifications, types.ModificationTypeApproved)
+
+	if submitted.IsZero() && change.SubmittedAt != nil {
+		submitted = *change.SubmittedAt
+	}
+	if approved.IsZero() && change.ApprovedAt != nil {
+		approved = *change.ApprovedAt
+	}
+
+	if submitted.IsZero() || approved.IsZero() || approved.Before(submitted) {
+		return 0, false
+	}
+	return approved.Sub(submitted), true
+}
+
+// firstModificationTime returns the timestamp of the first modification of a type
+func firstModificationTime(modifications []types.ModificationEntry, modificationType string) time.Time {
+	var first time.Time
+	for _, mod := range modifications {
+		if mod.ModificationType == modificationType && (first.IsZero() || mod.Timestamp.Before(first)) {
+			first = mod.Timestamp
+		}
+	}
+	return first
+}
+
+// cancellationReason returns the reason recorded with the cancellation, preferring the change's
+// status reason and falling back to the last cancelled modification entry
+func cancellationReason(change *types.ChangeMetadata) string {
+	if reason := strings.TrimSpace(change.StatusReason); reason != "" {
+		return reason
+	}
+	for i := len(change.Modifications) - 1; i >= 0; i-- {
+		if mod := change.Modifications[i]; mod.ModificationType == "cancelled" {
+			return strings.TrimSpace(mod.Reason)
+		}
+	}
+	return ""
+}
+
+// lastModificationTime returns the timestamp of the last modification of a type
+func lastModificationTime(modifications []types.ModificationEntry, modificationType string) time.Time {
+	var last time.Time
+	for _, mod := range modifications {
+		if mod.ModificationType == modificationType && mod.Timestamp.After(last) {
+			last = mod.Timestamp
+		}
+	}
+	return last
+}
+
+// hasCustomer reports whether the customer code is in the list
+func hasCustomer(customers []string, customerCode string) bool {
+	for _, c := range customers {
+		if c == customerCode {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns map keys in sorted order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatPercent formats a percentage, or "n/a" when there is no data
+func formatPercent(value float64, samples int) string {
+	if samples == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", value)
+}
+
+// formatStat formats a survey metric, or "n/a" when it had no samples
+func formatStat(value *float64, format string) string {
+	if value == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf(format, *value)
+}
+
+// formatDuration formats a duration in days and hours, or "n/a" when there is no data
+func formatDuration(d time.Duration, samples int) string {
+	if samples == 0 {
+		return "n/a"
+	}
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%.1fh", d.Hours())
+	}
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+// formatDate formats a date for report tables
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02")
+}
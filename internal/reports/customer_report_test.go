@@ -0,0 +1,138 @@
+package reports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/typeform"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectError   bool
+	}{
+		{"2025-Q2", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025-q4", time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025-03", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2025-Q5", time.Time{}, time.Time{}, true},
+		{"last quarter", time.Time{}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			period, err := ParsePeriod(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !period.Start.Equal(tt.expectedStart) || !period.End.Equal(tt.expectedEnd) {
+				t.Errorf("Expected [%s, %s), got [%s, %s)", tt.expectedStart, tt.expectedEnd, period.Start, period.End)
+			}
+		})
+	}
+}
+
+func TestBuildCustomerReport(t *testing.T) {
+	period, _ := ParsePeriod("2025-Q1")
+	now := time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC)
+	at := func(day int, hour int) time.Time { return time.Date(2025, 2, day, hour, 0, 0, 0, time.UTC) }
+	mod := func(modificationType string, ts time.Time) types.ModificationEntry {
+		return types.ModificationEntry{Timestamp: ts, UserID: "user", ModificationType: modificationType}
+	}
+
+	changes := []*types.ChangeMetadata{
+		{
+			ChangeID: "CHG-1", ChangeTitle: "On time", Status: "completed", Customers: []string{"hts"},
+			ImplementationStart: at(10, 14), ImplementationEnd: at(10, 16),
+			Modifications:   []types.ModificationEntry{mod("submitted", at(1, 9)), mod("approved", at(2, 9)), mod("completed", at(10, 15))},
+			MeetingMetadata: &types.MeetingMetadata{Subject: "Change Implementation: On time", StartTime: at(10, 14).Format(time.RFC3339)},
+		},
+		{
+			ChangeID: "CHG-2", ChangeTitle: "Late", Status: "completed", Customers: []string{"hts", "cds"},
+			ImplementationStart: at(12, 14), ImplementationEnd: at(12, 16),
+			Modifications: []types.ModificationEntry{mod("submitted", at(3, 9)), mod("approved", at(7, 9)), mod("completed", at(14, 9))},
+		},
+		{
+			ChangeID: "CHG-3", ChangeTitle: "Not needed", Status: "cancelled", Customers: []string{"hts"},
+			ImplementationStart: at(20, 14),
+			Modifications:       []types.ModificationEntry{{Timestamp: at(18, 9), UserID: "user", ModificationType: "cancelled", Reason: "Vendor fixed upstream"}},
+			MeetingMetadata:     &types.MeetingMetadata{Subject: "Cancelled meeting", StartTime: at(20, 14).Format(time.RFC3339)},
+		},
+		{ChangeID: "CHG-4", Status: "approved", Customers: []string{"cds"}, ImplementationStart: at(5, 0)},
+		{ChangeID: "CHG-5", Status: "approved", Customers: []string{"hts"}, ImplementationStart: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{ChangeID: "CHG-6", Status: "completed_unconfirmed", Customers: []string{"hts"}, ImplementationStart: at(25, 14), ImplementationEnd: at(25, 16),
+			Modifications: []types.ModificationEntry{mod("completed_unconfirmed", at(27, 16))}},
+	}
+
+	announcements := []*types.AnnouncementMetadata{
+		{AnnouncementID: "FIN-1", ObjectType: "announcement_finops", AnnouncementType: "finops", Customers: []string{"hts"}, Status: "completed", PostedDate: at(8, 0)},
+		{AnnouncementID: "CIC-1", ObjectType: "announcement_cic", Customers: []string{"hts"}, Status: "approved", PostedDate: at(9, 0)},
+	}
+
+	score := func(n int) *int { return &n }
+	yes := true
+	surveys := []typeform.WebhookPayload{
+		{FormResponse: typeform.FormResponse{SubmittedAt: "2025-02-11T10:00:00Z", Hidden: map[string]string{"customer_code": "hts"},
+			Answers: []typeform.Answer{{Type: "boolean", Boolean: &yes}, {Type: "number", Number: score(10)}}}},
+		{FormResponse: typeform.FormResponse{SubmittedAt: "2025-02-13T10:00:00Z", Hidden: map[string]string{"customer_code": "hts"},
+			Answers: []typeform.Answer{{Type: "number", Number: score(4)}}}},
+		{FormResponse: typeform.FormResponse{SubmittedAt: "2025-02-13T10:00:00Z", Hidden: map[string]string{"customer_code": "cds"},
+			Answers: []typeform.Answer{{Type: "number", Number: score(0)}}}},
+	}
+
+	report := BuildCustomerReport("hts", "Hearst Television", period, changes, announcements, surveys, now)
+
+	if report.TotalChanges != 4 {
+		t.Errorf("Expected 4 changes in period for hts, got %d", report.TotalChanges)
+	}
+	if report.ChangesByStatus["completed"] != 2 || report.ChangesByStatus["cancelled"] != 1 {
+		t.Errorf("Unexpected status counts: %v", report.ChangesByStatus)
+	}
+	if report.UnconfirmedChanges != 1 {
+		t.Errorf("Expected 1 unconfirmed completion, got %d", report.UnconfirmedChanges)
+	}
+	if report.OnTimeRate() != 50 {
+		t.Errorf("Expected 50%% on-time rate, got %.1f", report.OnTimeRate())
+	}
+	if got := report.MedianTimeToApproval(); got != 60*time.Hour {
+		t.Errorf("Expected median time to approval of 60h, got %s", got)
+	}
+	if len(report.Cancellations) != 1 || report.Cancellations[0].Reason != "Vendor fixed upstream" {
+		t.Errorf("Unexpected cancellations: %+v", report.Cancellations)
+	}
+	if len(report.Meetings) != 1 || report.Meetings[0].ObjectID != "CHG-1" {
+		t.Errorf("Expected only the held meeting for CHG-1, got %+v", report.Meetings)
+	}
+	if report.AnnouncementsByType["finops"] != 1 || report.AnnouncementsByType["cic"] != 1 {
+		t.Errorf("Unexpected announcement counts: %v", report.AnnouncementsByType)
+	}
+	if report.Surveys.Responses != 2 || report.Surveys.NPS == nil || *report.Surveys.NPS != 0 || report.Surveys.Satisfied != 1 {
+		t.Errorf("Unexpected survey stats: %+v", report.Surveys)
+	}
+
+	email := templates.BuildReport(report.TemplateData())
+	if !strings.Contains(email.Subject, "Hearst Television") || !strings.Contains(email.HTMLBody, "Vendor fixed upstream") {
+		t.Errorf("Expected rendered report to include customer name and cancellation reason")
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "hts,2025-Q1,Summary,On-time completion,50%") || !strings.Contains(buf.String(), "Summary,Completed (unconfirmed),1") {
+		t.Errorf("Unexpected CSV output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,45 @@
+package reports
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/archive"
+	"ccoe-customer-contact-manager/internal/surveys"
+	"ccoe-customer-contact-manager/internal/typeform"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// SurveyResultsPrefix is where the Typeform webhook stores survey responses
+const SurveyResultsPrefix = surveys.ResultsPrefix
+
+// LoadArchive loads every listed archive object, returning changes and announcements.
+// Objects that fail to load are logged and skipped.
+func LoadArchive(ctx context.Context, objects []archive.ObjectInfo, loader archive.Loader) ([]*types.ChangeMetadata, []*types.AnnouncementMetadata) {
+	var changes []*types.ChangeMetadata
+	var announcements []*types.AnnouncementMetadata
+
+	for _, obj := range objects {
+		metadata, announcement, err := loader.Load(ctx, obj.Key)
+		if err != nil {
+			log.Printf("⚠️  Skipping %s: %v", obj.Key, err)
+			continue
+		}
+
+		if announcement != nil {
+			announcements = append(announcements, announcement)
+			continue
+		}
+
+		changes = append(changes, metadata)
+	}
+
+	return changes, announcements
+}
+
+// LoadSurveyResults loads the stored webhook payloads for a customer
+func LoadSurveyResults(ctx context.Context, s3Client *s3.Client, bucket, customerCode string) ([]typeform.WebhookPayload, error) {
+	return surveys.LoadResults(ctx, s3Client, bucket, customerCode)
+}
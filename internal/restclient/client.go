@@ -0,0 +1,125 @@
+// Package restclient sends JSON requests to the REST APIs of external ticketing systems such as
+// Jira and ServiceNow, retrying network errors, throttling and server errors
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Client sends requests to one REST API
+type Client struct {
+	// System names the API in error messages, e.g. "jira"
+	System  string
+	BaseURL string
+	// Authorize adds credentials to each request
+	Authorize  func(req *http.Request)
+	HTTPClient *http.Client
+	Attempts   int
+	Backoff    time.Duration // Doubled after each failed attempt
+}
+
+// New creates a client with a 15 second timeout and three attempts
+func New(system, baseURL string, authorize func(req *http.Request)) *Client {
+	return &Client{
+		System:     system,
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Authorize:  authorize,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+		Attempts:   3,
+		Backoff:    time.Second,
+	}
+}
+
+// RequireEnv checks that credentials were loaded into the environment from Parameter Store
+func RequireEnv(names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if os.Getenv(name) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s must be loaded from Parameter Store", strings.Join(missing, " and "))
+	}
+	return nil
+}
+
+// Do sends a request with body encoded as JSON and decodes a non-empty response into out.
+// Network errors, 429 and 5xx responses are retried with exponential backoff.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= c.Attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.Backoff * time.Duration(1<<uint(attempt-2))):
+			}
+		}
+
+		retryable, err := c.doOnce(ctx, method, path, payload, out)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return lastErr
+}
+
+// doOnce sends a single request and reports whether a failure is worth retrying
+func (c *Client) doOnce(ctx context.Context, method, path string, payload []byte, out interface{}) (bool, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return false, err
+	}
+	if c.Authorize != nil {
+		c.Authorize(req)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("%s returned status %d: %s", c.System, resp.StatusCode, strings.TrimSpace(string(data)))
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return false, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,71 @@
+package restclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesThrottlingAndServerErrors(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(statuses[calls])
+		calls++
+		w.Write([]byte(`{"id":"7"}`))
+	}))
+	defer server.Close()
+
+	client := New("jira", server.URL+"/", func(req *http.Request) { req.Header.Set("Authorization", "Bearer pat") })
+	client.Backoff = time.Millisecond
+
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := client.Do(context.Background(), http.MethodPost, "/issue", map[string]string{"a": "b"}, &out); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if calls != 3 || out.ID != "7" {
+		t.Errorf("Expected 3 calls and a decoded response, got %d calls and %+v", calls, out)
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("bad transition"))
+	}))
+	defer server.Close()
+
+	client := New("servicenow", server.URL, nil)
+	client.Backoff = time.Millisecond
+
+	err := client.Do(context.Background(), http.MethodGet, "/table", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "servicenow returned status 400: bad transition") {
+		t.Errorf("Expected the 400 error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+}
+
+func TestRequireEnv(t *testing.T) {
+	t.Setenv("RESTCLIENT_USER", "user")
+	t.Setenv("RESTCLIENT_TOKEN", "")
+
+	if err := RequireEnv("RESTCLIENT_USER"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := RequireEnv("RESTCLIENT_USER", "RESTCLIENT_TOKEN"); err == nil || !strings.Contains(err.Error(), "RESTCLIENT_TOKEN must be loaded") {
+		t.Errorf("Expected the missing token to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Package servicenow keeps ServiceNow change requests in step with the change lifecycle
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+
+	"ccoe-customer-contact-manager/internal/restclient"
+)
+
+// Environment variables populated from Parameter Store by ses.LoadAllCredentialsFromSSM
+const (
+	EnvUsername = "SERVICENOW_USERNAME"
+	EnvPassword = "SERVICENOW_PASSWORD"
+)
+
+// changeRequestTable is the Table API path for change requests
+const changeRequestTable = "/api/now/table/change_request"
+
+// changeNumberPattern matches change request numbers such as CHG0012345
+var changeNumberPattern = regexp.MustCompile(`^CHG\d+$`)
+
+// IsChangeNumber reports whether a ticket is a change request number. Only such numbers are
+// put into encoded queries, so a snowTicket cannot add query conditions of its own.
+func IsChangeNumber(number string) bool {
+	return changeNumberPattern.MatchString(number)
+}
+
+// ChangeRequest is the subset of a ServiceNow change_request record used by the sync
+type ChangeRequest struct {
+	SysID    string `json:"sys_id"`
+	Number   string `json:"number"`
+	State    string `json:"state"`
+	Approval string `json:"approval"`
+}
+
+// Client talks to the ServiceNow Table API using basic authentication
+type Client struct {
+	rest *restclient.Client
+}
+
+// NewClient creates a client for a ServiceNow instance such as https://example.service-now.com
+func NewClient(instanceURL, username, password string) *Client {
+	return &Client{rest: restclient.New("servicenow", instanceURL, func(req *http.Request) {
+		req.SetBasicAuth(username, password)
+	})}
+}
+
+// NewClientFromEnv creates a client using credentials loaded into the environment from Parameter Store
+func NewClientFromEnv(instanceURL string) (*Client, error) {
+	if instanceURL == "" {
+		return nil, fmt.Errorf("servicenow instance_url is not configured")
+	}
+	if err := restclient.RequireEnv(EnvUsername, EnvPassword); err != nil {
+		return nil, err
+	}
+
+	return NewClient(instanceURL, os.Getenv(EnvUsername), os.Getenv(EnvPassword)), nil
+}
+
+// GetChangeRequest looks up a change request by its number (e.g. CHG0012345)
+func (c *Client) GetChangeRequest(ctx context.Context, number string) (*ChangeRequest, error) {
+	if !IsChangeNumber(number) {
+		return nil, fmt.Errorf("invalid change request number %q (expected e.g. CHG0012345)", number)
+	}
+
+	query := url.Values{}
+	query.Set("sysparm_query", "number="+number)
+	query.Set("sysparm_limit", "1")
+	query.Set("sysparm_fields", "sys_id,number,state,approval")
+
+	var response struct {
+		Result []ChangeRequest `json:"result"`
+	}
+	if err := c.rest.Do(ctx, http.MethodGet, changeRequestTable+"?"+query.Encode(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to look up change request %s: %w", number, err)
+	}
+
+	if len(response.Result) == 0 {
+		return nil, fmt.Errorf("change request %s not found", number)
+	}
+	return &response.Result[0], nil
+}
+
+// UpdateChangeRequest patches fields on a change request and returns the updated record
+func (c *Client) UpdateChangeRequest(ctx context.Context, sysID string, fields map[string]string) (*ChangeRequest, error) {
+	var response struct {
+		Result ChangeRequest `json:"result"`
+	}
+	if err := c.rest.Do(ctx, http.MethodPatch, changeRequestTable+"/"+url.PathEscape(sysID), fields, &response); err != nil {
+		return nil, fmt.Errorf("failed to update change request %s: %w", sysID, err)
+	}
+	return &response.Result, nil
+}
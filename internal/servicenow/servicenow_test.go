@@ -0,0 +1,190 @@
+package servicenow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// standIn is a minimal ServiceNow Table API for change_request records
+type standIn struct {
+	mu        sync.Mutex
+	records   map[string]*ChangeRequest // keyed by sys_id
+	patches   []map[string]string
+	lastAuthz string
+}
+
+func newStandIn(t *testing.T, records ...ChangeRequest) (*standIn, *httptest.Server) {
+	t.Helper()
+	s := &standIn{records: make(map[string]*ChangeRequest)}
+	for i := range records {
+		s.records[records[i].SysID] = &records[i]
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(server.Close)
+	return s, server
+}
+
+func (s *standIn) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); ok {
+		s.lastAuthz = user + ":" + pass
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == changeRequestTable:
+		number := strings.TrimPrefix(r.URL.Query().Get("sysparm_query"), "number=")
+		result := []ChangeRequest{}
+		for _, record := range s.records {
+			if record.Number == number {
+				result = append(result, *record)
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": result})
+
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, changeRequestTable+"/"):
+		record := s.records[strings.TrimPrefix(r.URL.Path, changeRequestTable+"/")]
+		if record == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var fields map[string]string
+		json.NewDecoder(r.Body).Decode(&fields)
+		s.patches = append(s.patches, fields)
+		if state, ok := fields["state"]; ok {
+			record.State = state
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": record})
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func testClient(url string) *Client {
+	return NewClient(url+"/", "integration", "secret")
+}
+
+func TestSyncTransitionUpdatesStateAndWorkNotes(t *testing.T) {
+	snow, server := newStandIn(t, ChangeRequest{SysID: "abc123", Number: "CHG0012345", State: "-3", Approval: "requested"})
+	client := testClient(server.URL)
+
+	record, err := client.SyncTransition(context.Background(), "CHG0012345", StateFor(TransitionApproved, nil), "approved note")
+	if err != nil {
+		t.Fatalf("SyncTransition failed: %v", err)
+	}
+
+	if record.State != "-2" {
+		t.Errorf("Expected state -2, got %s", record.State)
+	}
+	if snow.lastAuthz != "integration:secret" {
+		t.Errorf("Expected basic auth credentials, got %q", snow.lastAuthz)
+	}
+	if len(snow.patches) != 1 || snow.patches[0]["work_notes"] != "approved note" {
+		t.Errorf("Unexpected patches: %v", snow.patches)
+	}
+}
+
+func TestSyncTransitionSkipsUnchangedState(t *testing.T) {
+	snow, server := newStandIn(t, ChangeRequest{SysID: "abc123", Number: "CHG0012345", State: "-2"})
+	client := testClient(server.URL)
+
+	// Meeting notes never change state, and an unchanged state is not re-sent
+	for _, state := range []string{StateFor(TransitionMeetingScheduled, nil), "-2"} {
+		if _, err := client.SyncTransition(context.Background(), "CHG0012345", state, "note"); err != nil {
+			t.Fatalf("SyncTransition failed: %v", err)
+		}
+	}
+
+	for _, patch := range snow.patches {
+		if _, ok := patch["state"]; ok {
+			t.Errorf("Expected work note only, got %v", patch)
+		}
+	}
+}
+
+func TestGetChangeRequest(t *testing.T) {
+	_, server := newStandIn(t, ChangeRequest{SysID: "abc123", Number: "CHG0012345", Approval: "approved"})
+	client := testClient(server.URL)
+
+	record, err := client.GetChangeRequest(context.Background(), "CHG0012345")
+	if err != nil {
+		t.Fatalf("Expected the change request, got %v", err)
+	}
+	if record.Approval != "approved" {
+		t.Errorf("Expected approval state, got %q", record.Approval)
+	}
+
+	if _, err := client.GetChangeRequest(context.Background(), "CHG0099999"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestGetChangeRequestRejectsInvalidNumbers(t *testing.T) {
+	_, server := newStandIn(t, ChangeRequest{SysID: "abc123", Number: "CHG0012345"})
+	client := testClient(server.URL)
+
+	for _, number := range []string{"CHG0012345^ORnumberISNOTEMPTY", "INC0012345", "chg0012345", ""} {
+		if _, err := client.GetChangeRequest(context.Background(), number); err == nil || !strings.Contains(err.Error(), "invalid change request number") {
+			t.Errorf("Expected %q to be rejected, got %v", number, err)
+		}
+	}
+}
+
+func TestStateFor(t *testing.T) {
+	configured := map[string]string{TransitionCompleted: "3", TransitionCancelled: ""}
+
+	if got := StateFor(TransitionCompleted, configured); got != "3" {
+		t.Errorf("Expected configured state, got %q", got)
+	}
+	if got := StateFor(TransitionCancelled, configured); got != "" {
+		t.Errorf("Expected empty configured state to disable the update, got %q", got)
+	}
+	if got := StateFor(TransitionSubmitted, configured); got != "-3" {
+		t.Errorf("Expected default state, got %q", got)
+	}
+	if got := TransitionForStatus("completed_unconfirmed"); got != TransitionCompleted {
+		t.Errorf("Expected completed transition, got %q", got)
+	}
+	if got := TransitionForStatus("in_progress"); got != "" {
+		t.Errorf("Expected in_progress not to be synced, got %q", got)
+	}
+}
+
+func TestWorkNote(t *testing.T) {
+	metadata := &types.ChangeMetadata{
+		ChangeID:            "CHG-1",
+		ChangeTitle:         "Upgrade RDS",
+		Customers:           []string{"hts", "htsnonprod"},
+		ImplementationStart: time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC),
+		ImplementationEnd:   time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC),
+		ApprovedBy:          "approver@example.com",
+		MeetingMetadata:     &types.MeetingMetadata{JoinURL: "https://teams.example.com/join"},
+	}
+
+	note := WorkNote(TransitionApproved, metadata, "https://portal.example.com/")
+	for _, want := range []string{
+		"Customer change CHG-1 approved by approver@example.com.",
+		"Customers: hts, htsnonprod",
+		"Implementation window: 2026-03-01 14:00 - 2026-03-01 16:00 UTC",
+		"Details: https://portal.example.com/edit-change.html?changeId=CHG-1",
+	} {
+		if !strings.Contains(note, want) {
+			t.Errorf("Expected work note to contain %q:\n%s", want, note)
+		}
+	}
+
+	if note := WorkNote(TransitionMeetingScheduled, metadata, ""); !strings.Contains(note, "Join: https://teams.example.com/join") {
+		t.Errorf("Expected meeting join URL in work note:\n%s", note)
+	}
+}
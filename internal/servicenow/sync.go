@@ -0,0 +1,121 @@
+package servicenow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Lifecycle transitions pushed to ServiceNow
+const (
+	TransitionSubmitted        = "submitted"
+	TransitionApproved         = "approved"
+	TransitionMeetingScheduled = "meeting_scheduled"
+	TransitionCompleted        = "completed"
+	TransitionCancelled        = "cancelled"
+)
+
+// DefaultStates maps transitions to the out-of-the-box change_request state values
+// (Authorize, Scheduled, Review, Canceled). A transition without a state only adds a work note.
+var DefaultStates = map[string]string{
+	TransitionSubmitted: "-3",
+	TransitionApproved:  "-2",
+	TransitionCompleted: "0",
+	TransitionCancelled: "4",
+}
+
+// TransitionForStatus returns the transition synced for a change status, or "" when the
+// status is not mirrored to ServiceNow
+func TransitionForStatus(status string) string {
+	switch status {
+	case "submitted":
+		return TransitionSubmitted
+	case "approved":
+		return TransitionApproved
+	case "completed", "completed_unconfirmed":
+		return TransitionCompleted
+	case "cancelled":
+		return TransitionCancelled
+	}
+	return ""
+}
+
+// StateFor returns the state value for a transition. Configured states override the defaults;
+// an empty configured value disables the state change for that transition.
+func StateFor(transition string, configured map[string]string) string {
+	if state, ok := configured[transition]; ok {
+		return state
+	}
+	return DefaultStates[transition]
+}
+
+// WorkNote builds the work note added to the change request for a transition
+func WorkNote(transition string, metadata *types.ChangeMetadata, portalBaseURL string) string {
+	var b strings.Builder
+
+	switch transition {
+	case TransitionSubmitted:
+		fmt.Fprintf(&b, "Customer change %s submitted for approval.\n", metadata.ChangeID)
+	case TransitionApproved:
+		fmt.Fprintf(&b, "Customer change %s approved", metadata.ChangeID)
+		if metadata.ApprovedBy != "" {
+			fmt.Fprintf(&b, " by %s", metadata.ApprovedBy)
+		}
+		b.WriteString(".\n")
+	case TransitionMeetingScheduled:
+		fmt.Fprintf(&b, "Implementation meeting scheduled for customer change %s.\n", metadata.ChangeID)
+		if metadata.MeetingMetadata != nil {
+			if metadata.MeetingMetadata.StartTime != "" {
+				fmt.Fprintf(&b, "Meeting start: %s\n", metadata.MeetingMetadata.StartTime)
+			}
+			if metadata.MeetingMetadata.JoinURL != "" {
+				fmt.Fprintf(&b, "Join: %s\n", metadata.MeetingMetadata.JoinURL)
+			}
+		}
+	case TransitionCompleted:
+		fmt.Fprintf(&b, "Customer change %s completed.\n", metadata.ChangeID)
+		if metadata.Status == "completed_unconfirmed" {
+			b.WriteString("Completion was not confirmed; the change was closed automatically after its window passed.\n")
+		}
+	case TransitionCancelled:
+		fmt.Fprintf(&b, "Customer change %s cancelled.\n", metadata.ChangeID)
+	default:
+		fmt.Fprintf(&b, "Customer change %s: %s.\n", metadata.ChangeID, transition)
+	}
+
+	fmt.Fprintf(&b, "Title: %s\n", metadata.ChangeTitle)
+	if len(metadata.Customers) > 0 {
+		fmt.Fprintf(&b, "Customers: %s\n", strings.Join(metadata.Customers, ", "))
+	}
+	if !metadata.ImplementationStart.IsZero() {
+		fmt.Fprintf(&b, "Implementation window: %s - %s UTC\n",
+			metadata.ImplementationStart.UTC().Format("2006-01-02 15:04"),
+			metadata.ImplementationEnd.UTC().Format("2006-01-02 15:04"))
+	}
+	if metadata.StatusReason != "" && transition != TransitionMeetingScheduled {
+		fmt.Fprintf(&b, "Reason: %s\n", metadata.StatusReason)
+	}
+	if portalBaseURL != "" {
+		fmt.Fprintf(&b, "Details: %s/edit-change.html?changeId=%s\n", strings.TrimRight(portalBaseURL, "/"), metadata.ChangeID)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// SyncTransition updates the change request identified by its number with the state for the
+// transition (if any) and a work note, returning the updated record
+func (c *Client) SyncTransition(ctx context.Context, number, state, note string) (*ChangeRequest, error) {
+	record, err := c.GetChangeRequest(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{"work_notes": note}
+	if state != "" && state != record.State {
+		fields["state"] = state
+	}
+
+	return c.UpdateChangeRequest(ctx, record.SysID, fields)
+}
@@ -0,0 +1,225 @@
+package ses
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ccoe-customer-contact-manager/internal/datetime"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// ICS event status values (RFC 5545 section 3.8.1.11)
+const (
+	ICSStatusConfirmed = "CONFIRMED"
+	ICSStatusCancelled = "CANCELLED"
+)
+
+// calendarFeedUIDDomain is the right-hand side of every feed event UID
+const calendarFeedUIDDomain = "ccoe-customer-contact-manager"
+
+// CalendarFeedEvent is a single change window in a customer's calendar feed
+type CalendarFeedEvent struct {
+	UID          string    `json:"uid"`
+	ChangeID     string    `json:"change_id"`
+	Summary      string    `json:"summary"`
+	Description  string    `json:"description"`
+	URL          string    `json:"url,omitempty"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Status       string    `json:"status"`
+	ChangeStatus string    `json:"change_status"`
+	Sequence     int       `json:"sequence"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// CalendarFeedUID returns the stable UID for a change so calendar clients update
+// the same event as the change moves through its lifecycle
+func CalendarFeedUID(changeID string) string {
+	return fmt.Sprintf("%s@%s", changeID, calendarFeedUIDDomain)
+}
+
+// NewCalendarFeedEvent builds the feed event for a change. It returns false when the
+// change does not belong in a feed: it has not been approved, or has no window.
+// Cancelled changes are included (as CANCELLED) only if they were approved first.
+func NewCalendarFeedEvent(metadata *types.ChangeMetadata, portalBaseURL string) (CalendarFeedEvent, bool) {
+	if metadata == nil || metadata.ChangeID == "" || metadata.ImplementationStart.IsZero() {
+		return CalendarFeedEvent{}, false
+	}
+
+	status := ICSStatusConfirmed
+	switch metadata.Status {
+	case "approved", "in_progress", "completed", "completed_unconfirmed", "failed", "rolled_back":
+	case "cancelled":
+		if !wasApproved(metadata) {
+			return CalendarFeedEvent{}, false
+		}
+		status = ICSStatusCancelled
+	default:
+		return CalendarFeedEvent{}, false
+	}
+
+	end := metadata.ImplementationEnd
+	if end.IsZero() || end.Before(metadata.ImplementationStart) {
+		end = metadata.ImplementationStart.Add(time.Hour)
+	}
+
+	lastModified := metadata.ModifiedAt
+	if n := len(metadata.Modifications); n > 0 && metadata.Modifications[n-1].Timestamp.After(lastModified) {
+		lastModified = metadata.Modifications[n-1].Timestamp
+	}
+
+	event := CalendarFeedEvent{
+		UID:          CalendarFeedUID(metadata.ChangeID),
+		ChangeID:     metadata.ChangeID,
+		Summary:      fmt.Sprintf("Change: %s", metadata.ChangeTitle),
+		Description:  calendarFeedDescription(metadata),
+		Start:        metadata.ImplementationStart,
+		End:          end,
+		Status:       status,
+		ChangeStatus: metadata.Status,
+		Sequence:     metadata.Version,
+		LastModified: lastModified,
+	}
+	if portalBaseURL != "" {
+		event.URL = fmt.Sprintf("%s/edit-change.html?changeId=%s", strings.TrimRight(portalBaseURL, "/"), metadata.ChangeID)
+	}
+
+	return event, true
+}
+
+// wasApproved reports whether a change was approved at some point in its history
+func wasApproved(metadata *types.ChangeMetadata) bool {
+	if metadata.ApprovedAt != nil || metadata.PriorStatus == "approved" {
+		return true
+	}
+	for _, mod := range metadata.Modifications {
+		if mod.ModificationType == types.ModificationTypeApproved {
+			return true
+		}
+	}
+	return false
+}
+
+// calendarFeedDescription builds the plain text event description for a change
+func calendarFeedDescription(metadata *types.ChangeMetadata) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Change ID: %s\n", metadata.ChangeID))
+	sb.WriteString(fmt.Sprintf("Status: %s\n", metadata.Status))
+	if metadata.StatusReason != "" {
+		sb.WriteString(fmt.Sprintf("Reason: %s\n", metadata.StatusReason))
+	}
+	if metadata.SnowTicket != "" {
+		sb.WriteString(fmt.Sprintf("ServiceNow: %s\n", metadata.SnowTicket))
+	}
+	if metadata.JiraTicket != "" {
+		sb.WriteString(fmt.Sprintf("Jira: %s\n", metadata.JiraTicket))
+	}
+	if metadata.ChangeReason != "" {
+		sb.WriteString(fmt.Sprintf("\n%s\n", metadata.ChangeReason))
+	}
+	if metadata.CustomerImpact != "" {
+		sb.WriteString(fmt.Sprintf("\nCustomer impact: %s\n", metadata.CustomerImpact))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// GenerateCalendarFeed renders a subscribable iCalendar feed (METHOD:PUBLISH) containing
+// the given events, ordered by start time. Lines are CRLF terminated and folded at 75 octets.
+func GenerateCalendarFeed(calendarName string, events []CalendarFeedEvent, now time.Time) string {
+	dtManager := datetime.New(nil)
+	toICS := func(t time.Time) string { return dtManager.Format(t).ToICS() }
+
+	sorted := make([]CalendarFeedEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Start.Equal(sorted[j].Start) {
+			return sorted[i].Start.Before(sorted[j].Start)
+		}
+		return sorted[i].UID < sorted[j].UID
+	})
+
+	var sb strings.Builder
+	writeLine := func(line string) {
+		sb.WriteString(foldICSLine(line))
+		sb.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//AWS Contact Manager//Change Calendar//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICSText(calendarName))
+	writeLine("X-PUBLISHED-TTL:PT1H")
+	writeLine("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+
+	for _, event := range sorted {
+		dtStamp := event.LastModified
+		if dtStamp.IsZero() {
+			dtStamp = now
+		}
+
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + event.UID)
+		writeLine("DTSTAMP:" + toICS(dtStamp))
+		writeLine("DTSTART:" + toICS(event.Start))
+		writeLine("DTEND:" + toICS(event.End))
+		writeLine("SUMMARY:" + escapeICSText(event.Summary))
+		if event.Description != "" {
+			writeLine("DESCRIPTION:" + escapeICSText(event.Description))
+		}
+		if event.URL != "" {
+			writeLine("URL:" + event.URL)
+		}
+		writeLine("STATUS:" + event.Status)
+		writeLine(fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		if !event.LastModified.IsZero() {
+			writeLine("LAST-MODIFIED:" + toICS(event.LastModified))
+		}
+		writeLine("TRANSP:TRANSPARENT")
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+	return sb.String()
+}
+
+// escapeICSText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeICSText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// foldICSLine folds a content line longer than 75 octets (RFC 5545 section 3.1),
+// never splitting a multi-byte UTF-8 character
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var sb strings.Builder
+	width := 0
+	lineLimit := limit
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > lineLimit {
+			sb.WriteString("\r\n ")
+			width = 0
+			lineLimit = limit - 1 // continuation lines start with a space
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	return sb.String()
+}
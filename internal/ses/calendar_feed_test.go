@@ -0,0 +1,92 @@
+package ses
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestNewCalendarFeedEvent(t *testing.T) {
+	start := time.Date(2025, 4, 10, 14, 0, 0, 0, time.UTC)
+	approvedAt := start.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name           string
+		metadata       types.ChangeMetadata
+		expectIncluded bool
+		expectedStatus string
+	}{
+		{"approved", types.ChangeMetadata{ChangeID: "CHG-1", Status: "approved", ImplementationStart: start}, true, ICSStatusConfirmed},
+		{"completed", types.ChangeMetadata{ChangeID: "CHG-1", Status: "completed", ImplementationStart: start}, true, ICSStatusConfirmed},
+		{"submitted", types.ChangeMetadata{ChangeID: "CHG-1", Status: "submitted", ImplementationStart: start}, false, ""},
+		{"cancelled after approval", types.ChangeMetadata{ChangeID: "CHG-1", Status: "cancelled", ImplementationStart: start, ApprovedAt: &approvedAt}, true, ICSStatusCancelled},
+		{"cancelled before approval", types.ChangeMetadata{ChangeID: "CHG-1", Status: "cancelled", ImplementationStart: start}, false, ""},
+		{"no window", types.ChangeMetadata{ChangeID: "CHG-1", Status: "approved"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, included := NewCalendarFeedEvent(&tt.metadata, "https://portal.example.com")
+			if included != tt.expectIncluded {
+				t.Fatalf("Expected included=%v, got %v", tt.expectIncluded, included)
+			}
+			if !included {
+				return
+			}
+			if event.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, event.Status)
+			}
+			if event.UID != "CHG-1@ccoe-customer-contact-manager" {
+				t.Errorf("Expected stable UID, got %s", event.UID)
+			}
+			if !event.End.Equal(start.Add(time.Hour)) {
+				t.Errorf("Expected missing end to default to one hour, got %s", event.End)
+			}
+		})
+	}
+}
+
+func TestGenerateCalendarFeed(t *testing.T) {
+	start := time.Date(2025, 4, 10, 14, 0, 0, 0, time.UTC)
+	events := []CalendarFeedEvent{
+		{UID: CalendarFeedUID("CHG-2"), Summary: "Change: Later", Start: start.Add(24 * time.Hour), End: start.Add(25 * time.Hour), Status: ICSStatusCancelled, Sequence: 3},
+		{
+			UID: CalendarFeedUID("CHG-1"), Summary: "Change: Patch DB, cache; and proxy", Start: start, End: start.Add(2 * time.Hour),
+			Description: "Line one\nLine two with a back\\slash and " + strings.Repeat("long text ", 10),
+			Status:      ICSStatusConfirmed, Sequence: 1,
+		},
+	}
+
+	feed := GenerateCalendarFeed("HTS Change Calendar", events, start)
+
+	for _, line := range strings.Split(strings.TrimSuffix(feed, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line exceeds 75 octets: %q", line)
+		}
+		if strings.Contains(line, "\n") {
+			t.Errorf("Line contains a bare newline: %q", line)
+		}
+	}
+
+	expected := []string{
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:HTS Change Calendar",
+		"UID:CHG-1@ccoe-customer-contact-manager",
+		"DTSTART:20250410T140000Z",
+		`SUMMARY:Change: Patch DB\, cache\; and proxy`,
+		`DESCRIPTION:Line one\nLine two with a back\\slash`,
+		"STATUS:CANCELLED",
+		"SEQUENCE:3",
+	}
+	for _, want := range expected {
+		if !strings.Contains(feed, want) {
+			t.Errorf("Expected feed to contain %q:\n%s", want, feed)
+		}
+	}
+
+	if strings.Index(feed, "CHG-1@") > strings.Index(feed, "CHG-2@") {
+		t.Error("Expected events ordered by start time")
+	}
+}
@@ -0,0 +1,287 @@
+package ses
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	awsic "ccoe-customer-contact-manager/internal/aws"
+	internalconfig "ccoe-customer-contact-manager/internal/config"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// loadContactImportConfig reads the SES config file, checks its topic rules and builds the contact
+// import configuration
+func loadContactImportConfig() (types.ContactImportConfig, error) {
+	sesJson, err := os.ReadFile(GetConfigPath() + GetSESConfigFilePath())
+	if err != nil {
+		return types.ContactImportConfig{}, fmt.Errorf("error reading SES config file: %v", err)
+	}
+
+	var sesConfig types.SESConfig
+	if err := json.Unmarshal(sesJson, &sesConfig); err != nil {
+		return types.ContactImportConfig{}, fmt.Errorf("error parsing SES config: %v", err)
+	}
+
+	if err := ValidateTopicRules(sesConfig.TopicRules); err != nil {
+		return types.ContactImportConfig{}, err
+	}
+
+	return BuildContactImportConfigFromSES(sesConfig), nil
+}
+
+// customerGroupPatterns returns the customer's group patterns, if there is a customer
+func customerGroupPatterns(customer *types.CustomerAccountInfo) []types.GroupPattern {
+	if customer == nil {
+		return nil
+	}
+	return customer.GroupPatterns
+}
+
+// isRestrictedRecipient reports whether the customer's restricted_recipients list keeps an email
+// address out of the contact list
+func isRestrictedRecipient(customer *types.CustomerAccountInfo, email string) bool {
+	return customer != nil && !customer.IsRecipientAllowed(email)
+}
+
+// ImportAWSContactDeltaWithLogger applies an Identity Center delta to the account contact list:
+// joined users are added, users who left are removed, deactivated users are removed when active
+// users are required, users whose groups changed are subscribed to any newly implied topics, and
+// updated users get their phone number refreshed. Topics are never removed, so subscriptions users
+// manage themselves are kept, as in the full import. Users not on the customer's
+// restricted_recipients list are left alone. Each change applied is recorded in report, which may
+// be nil.
+func ImportAWSContactDeltaWithLogger(sesClient *sesv2.Client, delta *awsic.IdentityCenterDelta, customer *types.CustomerAccountInfo, dryRun bool, logger Logger, report *ContactImportReport) error {
+	logger.Printf("🔍 Importing Identity Center changes: %s", delta.Summary())
+	report.SetMode("delta")
+	groupPatterns := customerGroupPatterns(customer)
+
+	config, err := loadContactImportConfig()
+	if err != nil {
+		return err
+	}
+	if err := internalconfig.ValidateGroupPatterns(groupPatterns); err != nil {
+		return err
+	}
+	config.GroupPatterns = groupPatterns
+
+	// Only the changed users' groups are known here; the full import reports on every group
+	var memberships []types.IdentityCenterGroupMembership
+	for _, change := range delta.Changes {
+		if change.Type != awsic.UserLeft {
+			memberships = append(memberships, *deltaMembership(change))
+		}
+	}
+	reportUnmatchedGroups(logger, FindUnmatchedGroups(memberships, groupPatterns))
+
+	if dryRun {
+		for _, change := range delta.Changes {
+			entry, ok := deltaReportEntry(change, config, customer)
+			if ok {
+				report.Record(entry)
+			}
+			logger.Printf("🔍 DRY RUN: %s %s → topics: %v", change.Type, change.User.Email, DetermineUserTopics(change.User, deltaMembership(change), config))
+		}
+		return nil
+	}
+
+	accountListName, err := GetAccountContactList(sesClient)
+	if err != nil {
+		return fmt.Errorf("failed to get account contact list: %w", err)
+	}
+	logger.Printf("📋 Using SES contact list: %s", accountListName)
+
+	// Use 1 request per second for contact operations, as in the full import
+	rateLimiter := NewRateLimiter(1)
+	defer rateLimiter.Stop()
+
+	// Removals and role topic opt-outs wait for a backup of the list
+	backup := &offboardingBackup{sesClient: sesClient, listName: accountListName, logger: logger}
+
+	applied := 0
+	errorCount := 0
+	for _, change := range delta.Changes {
+		entry, ok := deltaReportEntry(change, config, customer)
+		if !ok {
+			continue
+		}
+		if entry.Action == ContactSkipped {
+			report.Record(entry)
+			continue
+		}
+
+		email := change.User.Email
+		rateLimiter.Wait()
+
+		switch change.Type {
+		case awsic.UserJoined:
+			err = AddContactToListWithPhone(sesClient, accountListName, email, entry.NewTopics, change.User.PhoneNumber)
+			if isAlreadyExistsError(err) {
+				err = nil
+				entry = ContactImportEntry{Email: email, UserName: entry.UserName, Action: ContactSkipped, Reason: ReasonAlreadyExists}
+			}
+
+		case awsic.UserLeft, awsic.UserDeactivated:
+			if err = backup.ensure(); err != nil {
+				break
+			}
+			err = RemoveContactFromList(sesClient, accountListName, email)
+			if isNotFound(err) {
+				err = nil
+				entry = ContactImportEntry{Email: email, UserName: entry.UserName, Action: ContactSkipped, Reason: ReasonNotFound}
+			} else if err == nil {
+				logger.Printf("🗑️  Offboarded %s (%s)", email, entry.Reason)
+			}
+
+		case awsic.UserGroupsChanged:
+			if len(entry.AddedTopics) > 0 {
+				err = AddContactTopics(sesClient, accountListName, email, entry.AddedTopics)
+			}
+			if isNotFound(err) {
+				// Missing from the list, e.g. removed by hand; add them back with all their topics
+				rateLimiter.Wait()
+				err = AddContactToListWithPhone(sesClient, accountListName, email, entry.NewTopics, change.User.PhoneNumber)
+				entry.Action = ContactAdded
+				entry.OldTopics = nil
+				entry.AddedTopics = entry.NewTopics
+				entry.RemovedTopics = nil
+			}
+			if err == nil && len(entry.RemovedTopics) > 0 {
+				if err = backup.ensure(); err == nil {
+					rateLimiter.Wait()
+					err = OptOutContactTopics(sesClient, accountListName, email, entry.RemovedTopics)
+				}
+				if err == nil {
+					logger.Printf("✂️  Opted %s out of role topics %v (%s)", email, entry.RemovedTopics, ReasonRoleTopicsLost)
+				}
+			}
+
+		case awsic.UserUpdated:
+			var updated bool
+			updated, err = SetContactPhoneNumber(sesClient, accountListName, email, change.User.PhoneNumber, PhoneSourceIdentityCenter)
+			if err == nil && !updated {
+				continue
+			}
+		}
+
+		if err != nil {
+			logger.Printf("   ❌ Failed to apply %s for %s: %v", change.Type, email, err)
+			entry.Action = ContactFailed
+			entry.Error = err.Error()
+			report.Record(entry)
+			errorCount++
+			continue
+		}
+		report.Record(entry)
+		applied++
+	}
+
+	logger.Printf("📊 Delta import: %d applied, %d errors", applied, errorCount)
+
+	if errorCount > 0 {
+		return fmt.Errorf("failed to apply %d contact changes", errorCount)
+	}
+	return nil
+}
+
+// deltaReportEntry decides what a change means for the contact list, as the entry to record once
+// it is applied. It returns false for changes that need no SES call.
+func deltaReportEntry(change awsic.UserChange, config types.ContactImportConfig, customer *types.CustomerAccountInfo) (ContactImportEntry, bool) {
+	entry := ContactImportEntry{Email: change.User.Email, UserName: change.User.UserName}
+	if entry.Email == "" {
+		entry.Action, entry.Reason = ContactSkipped, ReasonNoEmail
+		return entry, true
+	}
+	if isRestrictedRecipient(customer, entry.Email) {
+		entry.Action, entry.Reason = ContactSkipped, ReasonRestricted
+		return entry, true
+	}
+	offboarding := customerOffboarding(customer)
+
+	switch change.Type {
+	case awsic.UserJoined:
+		if config.RequireActiveUsers && !change.User.Active {
+			entry.Action, entry.Reason = ContactSkipped, ReasonInactive
+			return entry, true
+		}
+		topics := DetermineUserTopics(change.User, deltaMembership(change), config)
+		entry.Action, entry.NewTopics, entry.AddedTopics = ContactAdded, topics, topics
+
+	case awsic.UserLeft:
+		entry.Action, entry.Reason = ContactRemoved, ReasonNotInSource
+		if offboarding.IsProtected(entry.Email) {
+			entry.Action, entry.Reason = ContactSkipped, ReasonProtected
+		}
+
+	case awsic.UserDeactivated:
+		if !config.RequireActiveUsers {
+			return entry, false
+		}
+		entry.Action, entry.Reason = ContactRemoved, ReasonInactive
+		if offboarding.IsProtected(entry.Email) {
+			entry.Action, entry.Reason = ContactSkipped, ReasonProtected
+		}
+
+	case awsic.UserGroupsChanged:
+		topics := DetermineUserTopics(change.User, deltaMembership(change), config)
+		previous := deltaMembership(change)
+		previous.Groups = change.PreviousGroups
+		oldTopics := DetermineUserTopics(change.User, previous, config)
+		newTopics := subtractTopics(topics, oldTopics)
+		var lostTopics []string
+		if offboarding.StripsRoleTopics() && !offboarding.IsProtected(entry.Email) {
+			lostTopics = lostRoleTopics(oldTopics, topics, roleDerivedTopics(config))
+		}
+		if len(newTopics) == 0 && len(lostTopics) == 0 {
+			return entry, false
+		}
+		entry.Action, entry.Reason = ContactChanged, ReasonGroupsChanged
+		entry.OldTopics, entry.NewTopics, entry.AddedTopics, entry.RemovedTopics = oldTopics, topics, newTopics, lostTopics
+
+	case awsic.UserUpdated:
+		if change.User.PhoneNumber == "" {
+			return entry, false
+		}
+		entry.Action, entry.Reason = ContactChanged, ReasonPhoneUpdated
+
+	default:
+		return entry, false
+	}
+	return entry, true
+}
+
+// deltaMembership builds the group membership DetermineUserTopics expects from a change
+func deltaMembership(change awsic.UserChange) *types.IdentityCenterGroupMembership {
+	return &types.IdentityCenterGroupMembership{
+		UserId:      change.User.UserId,
+		UserName:    change.User.UserName,
+		DisplayName: change.User.DisplayName,
+		Email:       change.User.Email,
+		Groups:      change.Groups,
+	}
+}
+
+// subtractTopics returns the topics not in exclude
+func subtractTopics(topics, exclude []string) []string {
+	excluded := make(map[string]bool, len(exclude))
+	for _, topic := range exclude {
+		excluded[topic] = true
+	}
+	var result []string
+	for _, topic := range topics {
+		if !excluded[topic] {
+			result = append(result, topic)
+		}
+	}
+	return result
+}
+
+// isNotFound reports whether an SES error means the contact does not exist
+func isNotFound(err error) bool {
+	var notFound *sesv2Types.NotFoundException
+	return errors.As(err, &notFound)
+}
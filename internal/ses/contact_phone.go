@@ -0,0 +1,136 @@
+package ses
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+
+	"ccoe-customer-contact-manager/internal/sms"
+)
+
+// Contact attribute keys used for SMS delivery of urgent announcements
+const (
+	ContactAttributePhoneNumber = "phone_number"
+	ContactAttributePhoneSource = "phone_source"
+)
+
+// Phone number sources. Manually set numbers are never replaced by Identity Center imports.
+const (
+	PhoneSourceIdentityCenter = "identity_center"
+	PhoneSourceManual         = "manual"
+)
+
+// ContactPhoneNumber returns the phone number stored in a contact's AttributesData, if any
+func ContactPhoneNumber(attributesData string) string {
+	attributes := parseContactAttributes(attributesData)
+	phone, _ := attributes[ContactAttributePhoneNumber].(string)
+	return phone
+}
+
+// contactPhoneSource returns where a contact's phone number came from
+func contactPhoneSource(attributesData string) string {
+	source, _ := parseContactAttributes(attributesData)[ContactAttributePhoneSource].(string)
+	return source
+}
+
+// parseContactAttributes decodes AttributesData, treating missing or non-JSON data as empty
+func parseContactAttributes(attributesData string) map[string]interface{} {
+	attributes := make(map[string]interface{})
+	if strings.TrimSpace(attributesData) != "" {
+		if err := json.Unmarshal([]byte(attributesData), &attributes); err != nil {
+			return make(map[string]interface{})
+		}
+	}
+	return attributes
+}
+
+// contactAttributesWithPhone sets (or with an empty phone, clears) the phone number in
+// AttributesData while keeping any other attributes. A manual clear keeps the manual source
+// so later Identity Center imports do not restore the number.
+func contactAttributesWithPhone(attributesData, phone, source string) (string, error) {
+	attributes := parseContactAttributes(attributesData)
+	if phone == "" {
+		delete(attributes, ContactAttributePhoneNumber)
+		if source == PhoneSourceManual {
+			attributes[ContactAttributePhoneSource] = source
+		} else {
+			delete(attributes, ContactAttributePhoneSource)
+		}
+	} else {
+		attributes[ContactAttributePhoneNumber] = phone
+		attributes[ContactAttributePhoneSource] = source
+	}
+	if len(attributes) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode contact attributes: %w", err)
+	}
+	return string(data), nil
+}
+
+// phoneAttributesData returns the AttributesData for a new contact with an optional phone number.
+// Numbers that cannot be normalized are dropped so they never block the import.
+func phoneAttributesData(phone string) string {
+	if strings.TrimSpace(phone) == "" {
+		return ""
+	}
+	normalized, err := sms.NormalizePhoneNumber(phone)
+	if err != nil {
+		return ""
+	}
+	data, _ := contactAttributesWithPhone("", normalized, PhoneSourceIdentityCenter)
+	return data
+}
+
+// SetContactPhoneNumber stores a phone number on an existing contact, keeping its topic
+// subscriptions. An empty phone clears it. Identity Center numbers never replace a manually
+// set one. Returns whether the contact was updated.
+func SetContactPhoneNumber(sesClient *sesv2.Client, listName, email, phone, source string) (bool, error) {
+	if phone != "" {
+		normalized, err := sms.NormalizePhoneNumber(phone)
+		if err != nil {
+			return false, err
+		}
+		phone = normalized
+	}
+
+	contact, err := sesClient.GetContact(context.Background(), &sesv2.GetContactInput{
+		ContactListName: aws.String(listName),
+		EmailAddress:    aws.String(email),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get contact %s: %w", email, err)
+	}
+
+	current := aws.ToString(contact.AttributesData)
+	if source != PhoneSourceManual && contactPhoneSource(current) == PhoneSourceManual {
+		return false, nil
+	}
+
+	attributes, err := contactAttributesWithPhone(current, phone, source)
+	if err != nil {
+		return false, err
+	}
+	if ContactPhoneNumber(attributes) == ContactPhoneNumber(current) && contactPhoneSource(attributes) == contactPhoneSource(current) {
+		return false, nil
+	}
+
+	_, err = sesClient.UpdateContact(context.Background(), &sesv2.UpdateContactInput{
+		ContactListName:  aws.String(listName),
+		EmailAddress:     aws.String(email),
+		TopicPreferences: contact.TopicPreferences,
+		UnsubscribeAll:   contact.UnsubscribeAll,
+		AttributesData:   aws.String(attributes),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update contact %s: %w", email, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,35 @@
+package ses
+
+import "testing"
+
+func TestContactAttributesWithPhone(t *testing.T) {
+	data, err := contactAttributesWithPhone(`{"team":"platform"}`, "+15550100199", PhoneSourceIdentityCenter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ContactPhoneNumber(data) != "+15550100199" || contactPhoneSource(data) != PhoneSourceIdentityCenter {
+		t.Errorf("Expected phone to be set, got %s", data)
+	}
+	if parseContactAttributes(data)["team"] != "platform" {
+		t.Errorf("Expected other attributes to be kept, got %s", data)
+	}
+
+	// A manual clear remembers the manual source so imports do not restore the number
+	cleared, err := contactAttributesWithPhone(data, "", PhoneSourceManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ContactPhoneNumber(cleared) != "" || contactPhoneSource(cleared) != PhoneSourceManual {
+		t.Errorf("Unexpected attributes after manual clear: %s", cleared)
+	}
+
+	if got := ContactPhoneNumber("not json"); got != "" {
+		t.Errorf("Expected no phone for invalid attributes, got %q", got)
+	}
+	if got := phoneAttributesData("555-010-0199"); ContactPhoneNumber(got) != "+15550100199" {
+		t.Errorf("Expected normalized phone for new contact, got %s", got)
+	}
+	if got := phoneAttributesData("invalid"); got != "" {
+		t.Errorf("Expected invalid phone to be dropped, got %s", got)
+	}
+}
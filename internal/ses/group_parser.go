@@ -0,0 +1,155 @@
+package ses
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// CCOECloudGroupPattern names the built-in ccoe-cloud-<account>-<id>-...-idp-<app>-<role> format,
+// which is tried after any configured group patterns
+const CCOECloudGroupPattern = "ccoe-cloud"
+
+// GroupPatternAttempt is the outcome of trying one pattern against a group name
+type GroupPatternAttempt struct {
+	Pattern string
+	Result  CCOECloudGroupParseResult
+	Error   string // Set when the pattern's regex does not compile
+}
+
+// UnmatchedGroup is a group that no group pattern could parse, with the number of users in it
+type UnmatchedGroup struct {
+	Group string
+	Users int
+}
+
+// ParseGroup parses a group name with the configured patterns, in order, and then with the
+// ccoe-cloud format. The first pattern that matches and captures a role wins.
+func ParseGroup(groupName string, patterns []types.GroupPattern) CCOECloudGroupParseResult {
+	for _, pattern := range patterns {
+		if result := parseGroupPattern(groupName, pattern); result.IsValid {
+			return result
+		}
+	}
+
+	result := ParseCCOECloudGroup(groupName)
+	if result.IsValid {
+		result.Pattern = CCOECloudGroupPattern
+	}
+	return result
+}
+
+// TraceGroupParse tries every pattern against a group name, including the ccoe-cloud format, and
+// returns each outcome so a new naming convention can be checked before it is deployed
+func TraceGroupParse(groupName string, patterns []types.GroupPattern) []GroupPatternAttempt {
+	var attempts []GroupPatternAttempt
+	for _, pattern := range patterns {
+		attempt := GroupPatternAttempt{Pattern: pattern.Name}
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			attempt.Error = err.Error()
+		} else {
+			attempt.Result = parseGroupPattern(groupName, pattern)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	result := ParseCCOECloudGroup(groupName)
+	if result.IsValid {
+		result.Pattern = CCOECloudGroupPattern
+	}
+	return append(attempts, GroupPatternAttempt{Pattern: CCOECloudGroupPattern, Result: result})
+}
+
+// FindUnmatchedGroups returns the groups among the memberships that no pattern parses, most
+// populated first. Users in them get only default topics and rules that do not need a parsed group.
+func FindUnmatchedGroups(memberships []types.IdentityCenterGroupMembership, patterns []types.GroupPattern) []UnmatchedGroup {
+	counts := make(map[string]int)
+	parsed := make(map[string]bool)
+	for _, membership := range memberships {
+		for _, group := range membership.Groups {
+			valid, seen := parsed[group]
+			if !seen {
+				valid = ParseGroup(group, patterns).IsValid
+				parsed[group] = valid
+			}
+			if !valid {
+				counts[group]++
+			}
+		}
+	}
+
+	unmatched := make([]UnmatchedGroup, 0, len(counts))
+	for group, users := range counts {
+		unmatched = append(unmatched, UnmatchedGroup{Group: group, Users: users})
+	}
+	sort.Slice(unmatched, func(i, j int) bool {
+		if unmatched[i].Users != unmatched[j].Users {
+			return unmatched[i].Users > unmatched[j].Users
+		}
+		return unmatched[i].Group < unmatched[j].Group
+	})
+	return unmatched
+}
+
+// maxReportedUnmatchedGroups caps how many unmatched groups an import lists by name
+const maxReportedUnmatchedGroups = 20
+
+// reportUnmatchedGroups logs the groups no pattern could parse
+func reportUnmatchedGroups(logger Logger, unmatched []UnmatchedGroup) {
+	if len(unmatched) == 0 {
+		return
+	}
+
+	logger.Printf("⚠️  %d groups matched no group pattern; their users get no role-based topics:", len(unmatched))
+	for i, group := range unmatched {
+		if i == maxReportedUnmatchedGroups {
+			logger.Printf("   ... and %d more", len(unmatched)-maxReportedUnmatchedGroups)
+			break
+		}
+		logger.Printf("   - %s (%d users)", group.Group, group.Users)
+	}
+}
+
+// parseGroupPattern applies one configured pattern. Groups without a role capture are not valid.
+// When the pattern has no environment capture, the environment is taken from a multi-part account
+// name as in the ccoe-cloud format.
+func parseGroupPattern(groupName string, pattern types.GroupPattern) CCOECloudGroupParseResult {
+	result := CCOECloudGroupParseResult{GroupName: groupName}
+
+	// Patterns share the topic rules' regex cache; an invalid pattern matches nothing
+	re := compileGroupRegex(pattern.Regex)
+	if re == nil {
+		return result
+	}
+	match := re.FindStringSubmatch(groupName)
+	if match == nil {
+		return result
+	}
+
+	for i, capture := range re.SubexpNames() {
+		switch capture {
+		case types.GroupCaptureAccountName:
+			result.AccountName = match[i]
+		case types.GroupCaptureAccountID:
+			result.AccountId = match[i]
+		case types.GroupCaptureEnvironment:
+			result.Environment = match[i]
+		case types.GroupCaptureAppPrefix:
+			result.ApplicationPrefix = match[i]
+		case types.GroupCaptureRole:
+			result.RoleName = match[i]
+		}
+	}
+
+	if re.SubexpIndex(types.GroupCaptureEnvironment) < 0 {
+		if i := strings.LastIndex(result.AccountName, "-"); i >= 0 {
+			result.Environment = result.AccountName[i+1:]
+		}
+	}
+
+	result.Pattern = pattern.Name
+	result.IsValid = result.RoleName != ""
+	return result
+}
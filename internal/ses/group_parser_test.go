@@ -0,0 +1,69 @@
+package ses
+
+import (
+	"reflect"
+	"testing"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+var testGroupPatterns = []types.GroupPattern{
+	{Name: "okta", Regex: `^aws-(?P<account_name>[a-z]+-[a-z]+)-(?P<role>[a-z]+)$`},
+	{Name: "entra", Regex: `^AZ-AWS-(?P<account_id>\d{12})-(?P<environment>[A-Z]+)-(?P<app_prefix>[A-Z]+)-(?P<role>[A-Za-z]+)$`},
+	{Name: "broken", Regex: `^(`},
+}
+
+func TestParseGroup(t *testing.T) {
+	tests := []struct {
+		group string
+		want  CCOECloudGroupParseResult
+	}{
+		{"aws-hts-prod-admin", CCOECloudGroupParseResult{AccountName: "hts-prod", Environment: "prod", RoleName: "admin", Pattern: "okta", IsValid: true}},
+		{"AZ-AWS-123456789012-PROD-HTS-Security", CCOECloudGroupParseResult{AccountId: "123456789012", Environment: "PROD", ApplicationPrefix: "HTS", RoleName: "Security", Pattern: "entra", IsValid: true}},
+		{"ccoe-cloud-hts-prod-123456789012-idp-hts-admin", CCOECloudGroupParseResult{AccountName: "hts-prod", AccountId: "123456789012", Environment: "prod", ApplicationPrefix: "hts", RoleName: "admin", Pattern: CCOECloudGroupPattern, IsValid: true}},
+		{"finance-readers", CCOECloudGroupParseResult{}},
+	}
+
+	for _, tt := range tests {
+		tt.want.GroupName = tt.group
+		if got := ParseGroup(tt.group, testGroupPatterns); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseGroup(%q) = %+v, want %+v", tt.group, got, tt.want)
+		}
+	}
+}
+
+func TestTraceGroupParse(t *testing.T) {
+	attempts := TraceGroupParse("aws-hts-prod-admin", testGroupPatterns)
+	if len(attempts) != 4 {
+		t.Fatalf("Expected an attempt per pattern plus ccoe-cloud, got %d", len(attempts))
+	}
+	if !attempts[0].Result.IsValid || attempts[1].Result.IsValid || attempts[2].Error == "" || attempts[3].Pattern != CCOECloudGroupPattern {
+		t.Errorf("Unexpected attempts: %+v", attempts)
+	}
+}
+
+func TestFindUnmatchedGroups(t *testing.T) {
+	memberships := []types.IdentityCenterGroupMembership{
+		{Groups: []string{"aws-hts-prod-admin", "finance-readers", "all-staff"}},
+		{Groups: []string{"all-staff", "ccoe-cloud-hts-prod-123456789012-idp-hts-admin"}},
+	}
+
+	want := []UnmatchedGroup{{Group: "all-staff", Users: 2}, {Group: "finance-readers", Users: 1}}
+	if got := FindUnmatchedGroups(memberships, testGroupPatterns); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindUnmatchedGroups() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExplainUserTopicsUsesGroupPatterns(t *testing.T) {
+	config := types.ContactImportConfig{
+		RoleMappings:  []types.RoleTopicMapping{{Roles: []string{"admin"}, Topics: []string{"approval"}}},
+		Rules:         []types.TopicRule{{Name: "prod", Topics: []string{"calendar"}, Environments: []string{"prod"}}},
+		GroupPatterns: testGroupPatterns,
+	}
+	membership := &types.IdentityCenterGroupMembership{Groups: []string{"aws-hts-prod-admin"}}
+
+	topics := DetermineUserTopics(types.IdentityCenterUser{Email: "jane@example.com"}, membership, config)
+	if !reflect.DeepEqual(topics, []string{"approval", "calendar"}) {
+		t.Errorf("Topics = %v", topics)
+	}
+}
@@ -0,0 +1,290 @@
+package ses
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// ImportReportSchemaVersion is bumped when the report layout changes, so reports from different
+// runs are only compared when they share a layout
+const ImportReportSchemaVersion = 1
+
+// Contact import outcomes
+const (
+	ContactAdded   = "added"
+	ContactRemoved = "removed"
+	ContactChanged = "changed"
+	ContactSkipped = "skipped"
+	ContactFailed  = "failed"
+)
+
+// Reasons a contact was skipped or removed
+const (
+	ReasonInactive      = "inactive"       // User is not active and active users are required
+	ReasonRestricted    = "restricted"     // Not on the customer's restricted_recipients list
+	ReasonNoEmail       = "no_email"       // User has no email address
+	ReasonAlreadyExists = "already_exists" // Contact already in the list; users manage their own topics
+	ReasonNotInSource   = "not_in_source"  // Contact is no longer in the contact source
+	ReasonNotFound      = "not_found"      // Contact to remove was already gone
+	ReasonGroupsChanged = "groups_changed" // New groups imply new topics
+	ReasonPhoneUpdated  = "phone_updated"  // Phone number refreshed from the contact source
+)
+
+// ContactImportEntry records what an import did to one contact
+type ContactImportEntry struct {
+	Email         string    `json:"email"`
+	UserName      string    `json:"user_name,omitempty"`
+	Action        string    `json:"action"`
+	Reason        string    `json:"reason,omitempty"`
+	OldTopics     []string  `json:"old_topics,omitempty"`
+	NewTopics     []string  `json:"new_topics,omitempty"`
+	AddedTopics   []string  `json:"added_topics,omitempty"`   // Topics the contact was subscribed to by this run
+	RemovedTopics []string  `json:"removed_topics,omitempty"` // Role topics the contact was opted out of by this run
+	Error         string    `json:"error,omitempty"`
+	At            time.Time `json:"at"`
+}
+
+// ContactImportReport is the per-contact record of one customer's import. A nil report records
+// nothing, so callers that do not need a report can pass nil.
+type ContactImportReport struct {
+	CustomerCode string               `json:"customer_code"`
+	Mode         string               `json:"mode"` // full, delta or single
+	DryRun       bool                 `json:"dry_run"`
+	Success      bool                 `json:"success"`
+	Error        string               `json:"error,omitempty"`
+	StartedAt    time.Time            `json:"started_at"`
+	FinishedAt   time.Time            `json:"finished_at"`
+	Counts       map[string]int       `json:"counts"`
+	Contacts     []ContactImportEntry `json:"contacts"`
+}
+
+// ImportReport collects the customer reports of one import run
+type ImportReport struct {
+	SchemaVersion int                    `json:"schema_version"`
+	GeneratedAt   time.Time              `json:"generated_at"`
+	DryRun        bool                   `json:"dry_run"`
+	Customers     []*ContactImportReport `json:"customers"`
+}
+
+// NewContactImportReport starts a report for a customer's import
+func NewContactImportReport(customerCode string, dryRun bool) *ContactImportReport {
+	return &ContactImportReport{
+		CustomerCode: customerCode,
+		DryRun:       dryRun,
+		StartedAt:    time.Now().UTC(),
+		Counts:       make(map[string]int),
+	}
+}
+
+// Record adds an entry, stamping it with the current time
+func (r *ContactImportReport) Record(entry ContactImportEntry) {
+	if r == nil {
+		return
+	}
+	entry.At = time.Now().UTC()
+	r.Contacts = append(r.Contacts, entry)
+	r.Counts[entry.Action]++
+}
+
+// SetMode records whether the import was full, delta or single
+func (r *ContactImportReport) SetMode(mode string) {
+	if r != nil {
+		r.Mode = mode
+	}
+}
+
+// Finish records the outcome of the import
+func (r *ContactImportReport) Finish(err error) {
+	if r == nil {
+		return
+	}
+	r.FinishedAt = time.Now().UTC()
+	r.Success = err == nil
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// Count returns the number of contacts with an outcome
+func (r *ContactImportReport) Count(action string) int {
+	if r == nil {
+		return 0
+	}
+	return r.Counts[action]
+}
+
+// NewImportReport combines customer reports, ordering customers by code and contacts by email so
+// reports from different runs can be compared line by line
+func NewImportReport(customers []*ContactImportReport, dryRun bool) *ImportReport {
+	report := &ImportReport{SchemaVersion: ImportReportSchemaVersion, GeneratedAt: time.Now().UTC(), DryRun: dryRun}
+	for _, customer := range customers {
+		if customer == nil {
+			continue
+		}
+		sort.SliceStable(customer.Contacts, func(i, j int) bool {
+			if customer.Contacts[i].Email != customer.Contacts[j].Email {
+				return customer.Contacts[i].Email < customer.Contacts[j].Email
+			}
+			return customer.Contacts[i].UserName < customer.Contacts[j].UserName
+		})
+		report.Customers = append(report.Customers, customer)
+	}
+	sort.Slice(report.Customers, func(i, j int) bool {
+		return report.Customers[i].CustomerCode < report.Customers[j].CustomerCode
+	})
+	return report
+}
+
+// ImportReportFileName names a report by the time it was generated
+func ImportReportFileName(generatedAt time.Time) string {
+	return fmt.Sprintf("contact-import-report-%s.json", generatedAt.UTC().Format("20060102T150405Z"))
+}
+
+// WriteImportReport writes the report to a local file. An empty path writes it to the config path
+// under its generated name. The path written is returned.
+func WriteImportReport(report *ImportReport, path string) (string, error) {
+	if path == "" {
+		path = GetConfigPath() + ImportReportFileName(report.GeneratedAt)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal import report: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write import report: %w", err)
+	}
+	return path, nil
+}
+
+// UploadImportReport uploads the report to s3://bucket/prefix under its generated name and
+// returns the object URI
+func UploadImportReport(ctx context.Context, report *ImportReport, location string) (string, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(location, "s3://"), "/")
+	if !strings.HasPrefix(location, "s3://") || bucket == "" {
+		return "", fmt.Errorf("invalid report location %q (expected s3://bucket/prefix)", location)
+	}
+
+	key := ImportReportFileName(report.GeneratedAt)
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal import report: %w", err)
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	_, err = s3.NewFromConfig(awsCfg).PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload import report: %w", err)
+	}
+	return fmt.Sprintf("s3://%s/%s", bucket, key), nil
+}
+
+// LoadImportReport reads a report written by WriteImportReport
+func LoadImportReport(path string) (*ImportReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import report: %w", err)
+	}
+	var report ImportReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse import report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// ImportReportDifference is a contact whose outcome differs between two reports
+type ImportReportDifference struct {
+	CustomerCode string
+	Email        string
+	Old          string // Outcome in the older report, empty when the contact was not in it
+	New          string // Outcome in the newer report, empty when the contact was not in it
+}
+
+// CompareImportReports lists the contacts whose outcomes differ between two runs, e.g. a dry run
+// and the real run, or yesterday's and today's imports
+func CompareImportReports(older, newer *ImportReport) ([]ImportReportDifference, error) {
+	if older.SchemaVersion != newer.SchemaVersion {
+		return nil, fmt.Errorf("reports have different schema versions (%d and %d)", older.SchemaVersion, newer.SchemaVersion)
+	}
+
+	oldOutcomes := importReportOutcomes(older)
+	newOutcomes := importReportOutcomes(newer)
+
+	var differences []ImportReportDifference
+	for key, oldOutcome := range oldOutcomes {
+		if newOutcome := newOutcomes[key]; newOutcome != oldOutcome {
+			differences = append(differences, ImportReportDifference{CustomerCode: key[0], Email: key[1], Old: oldOutcome, New: newOutcome})
+		}
+	}
+	for key, newOutcome := range newOutcomes {
+		if _, ok := oldOutcomes[key]; !ok {
+			differences = append(differences, ImportReportDifference{CustomerCode: key[0], Email: key[1], New: newOutcome})
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].CustomerCode != differences[j].CustomerCode {
+			return differences[i].CustomerCode < differences[j].CustomerCode
+		}
+		return differences[i].Email < differences[j].Email
+	})
+	return differences, nil
+}
+
+// importReportOutcomes describes each contact's outcomes, keyed by customer and email (or user
+// name for users without one). Times are left out, as they always differ between runs.
+func importReportOutcomes(report *ImportReport) map[[2]string]string {
+	outcomes := make(map[[2]string]string)
+	for _, customer := range report.Customers {
+		for _, entry := range customer.Contacts {
+			id := entry.Email
+			if id == "" {
+				id = entry.UserName
+			}
+			outcome := entry.Action
+			if entry.Reason != "" {
+				outcome += " (" + entry.Reason + ")"
+			}
+			if len(entry.AddedTopics) > 0 {
+				outcome += fmt.Sprintf(" +%v", entry.AddedTopics)
+			}
+			if len(entry.RemovedTopics) > 0 {
+				outcome += fmt.Sprintf(" -%v", entry.RemovedTopics)
+			}
+			key := [2]string{customer.CustomerCode, id}
+			if existing, ok := outcomes[key]; ok {
+				outcome = existing + ", " + outcome
+			}
+			outcomes[key] = outcome
+		}
+	}
+	return outcomes
+}
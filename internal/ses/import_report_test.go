@@ -0,0 +1,121 @@
+package ses
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	awsic "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestContactImportReportNilSafe(t *testing.T) {
+	var report *ContactImportReport
+	report.SetMode("full")
+	report.Record(ContactImportEntry{Email: "jane@example.com", Action: ContactAdded})
+	report.Finish(errors.New("boom"))
+	if report.Count(ContactAdded) != 0 {
+		t.Error("Expected a nil report to count nothing")
+	}
+}
+
+func TestImportReportWriteLoadAndCompare(t *testing.T) {
+	dryRun := NewContactImportReport("beta", true)
+	dryRun.Record(ContactImportEntry{Email: "sam@example.com", Action: ContactAdded, NewTopics: []string{"approval"}, AddedTopics: []string{"approval"}})
+	dryRun.Record(ContactImportEntry{Email: "jane@example.com", Action: ContactSkipped, Reason: ReasonRestricted})
+	dryRun.Finish(nil)
+	alpha := NewContactImportReport("alpha", true)
+	alpha.Finish(errors.New("failed to assume SES role"))
+
+	report := NewImportReport([]*ContactImportReport{dryRun, alpha, nil}, true)
+	if report.Customers[0].CustomerCode != "alpha" || report.Customers[1].Contacts[0].Email != "jane@example.com" {
+		t.Fatalf("Expected customers and contacts in order, got %+v", report.Customers)
+	}
+	if report.Customers[0].Success || report.Customers[0].Error == "" || report.Customers[1].Counts[ContactAdded] != 1 {
+		t.Errorf("Unexpected customer reports: %+v", report.Customers)
+	}
+
+	path, err := WriteImportReport(report, filepath.Join(t.TempDir(), "reports", "dry-run.json"))
+	if err != nil {
+		t.Fatalf("WriteImportReport failed: %v", err)
+	}
+	older, err := LoadImportReport(path)
+	if err != nil {
+		t.Fatalf("LoadImportReport failed: %v", err)
+	}
+
+	run := NewContactImportReport("beta", false)
+	run.Record(ContactImportEntry{Email: "sam@example.com", Action: ContactFailed, NewTopics: []string{"approval"}, Error: "throttled"})
+	run.Record(ContactImportEntry{Email: "jane@example.com", Action: ContactSkipped, Reason: ReasonRestricted})
+	run.Record(ContactImportEntry{Email: "lee@example.com", Action: ContactRemoved, Reason: ReasonInactive})
+	newer := NewImportReport([]*ContactImportReport{run}, false)
+
+	differences, err := CompareImportReports(older, newer)
+	if err != nil {
+		t.Fatalf("CompareImportReports failed: %v", err)
+	}
+	want := []ImportReportDifference{
+		{CustomerCode: "beta", Email: "lee@example.com", New: "removed (inactive)"},
+		{CustomerCode: "beta", Email: "sam@example.com", Old: "added +[approval]", New: "failed"},
+	}
+	if !reflect.DeepEqual(differences, want) {
+		t.Errorf("Differences = %+v, want %+v", differences, want)
+	}
+
+	newer.SchemaVersion++
+	if _, err := CompareImportReports(older, newer); err == nil {
+		t.Error("Expected an error for different schema versions")
+	}
+}
+
+func TestDeltaReportEntry(t *testing.T) {
+	config := types.ContactImportConfig{
+		DefaultTopics:      []string{"announce"},
+		RequireActiveUsers: true,
+		RoleMappings:       []types.RoleTopicMapping{{Roles: []string{"admin"}, Topics: []string{"approval"}}},
+	}
+	customer := &types.CustomerAccountInfo{RestrictedRecipients: []string{"jane@example.com", "sam@example.com"}}
+	jane := types.IdentityCenterUser{UserName: "jane", Email: "jane@example.com", Active: true}
+	adminGroup := "ccoe-cloud-hts-prod-123456789012-idp-hts-admin"
+
+	tests := []struct {
+		name   string
+		change awsic.UserChange
+		want   ContactImportEntry
+		ok     bool
+	}{
+		{"joined", awsic.UserChange{Type: awsic.UserJoined, User: jane},
+			ContactImportEntry{Action: ContactAdded, NewTopics: []string{"announce"}, AddedTopics: []string{"announce"}}, true},
+		{"joined inactive", awsic.UserChange{Type: awsic.UserJoined, User: types.IdentityCenterUser{UserName: "jane", Email: "jane@example.com"}},
+			ContactImportEntry{Action: ContactSkipped, Reason: ReasonInactive}, true},
+		{"restricted", awsic.UserChange{Type: awsic.UserJoined, User: types.IdentityCenterUser{UserName: "jane", Email: "lee@example.com", Active: true}},
+			ContactImportEntry{Email: "lee@example.com", Action: ContactSkipped, Reason: ReasonRestricted}, true},
+		{"no email", awsic.UserChange{Type: awsic.UserLeft, User: types.IdentityCenterUser{UserName: "jane"}},
+			ContactImportEntry{Email: "", Action: ContactSkipped, Reason: ReasonNoEmail}, true},
+		{"groups changed", awsic.UserChange{Type: awsic.UserGroupsChanged, User: jane, Groups: []string{adminGroup}},
+			ContactImportEntry{Action: ContactChanged, Reason: ReasonGroupsChanged, OldTopics: []string{"announce"}, NewTopics: []string{"announce", "approval"}, AddedTopics: []string{"approval"}}, true},
+		{"groups changed without new topics", awsic.UserChange{Type: awsic.UserGroupsChanged, User: jane, PreviousGroups: []string{adminGroup}}, ContactImportEntry{}, false},
+		{"deactivated", awsic.UserChange{Type: awsic.UserDeactivated, User: jane},
+			ContactImportEntry{Action: ContactRemoved, Reason: ReasonInactive}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := deltaReportEntry(tt.change, config, customer)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if tt.want.Email == "" && tt.name != "no email" {
+				tt.want.Email = "jane@example.com"
+			}
+			tt.want.UserName = "jane"
+			if !reflect.DeepEqual(entry, tt.want) {
+				t.Errorf("Entry = %+v, want %+v", entry, tt.want)
+			}
+		})
+	}
+}
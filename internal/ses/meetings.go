@@ -23,6 +23,8 @@ import (
 
 	internalconfig "ccoe-customer-contact-manager/internal/config"
 	"ccoe-customer-contact-manager/internal/datetime"
+	"ccoe-customer-contact-manager/internal/jira"
+	"ccoe-customer-contact-manager/internal/servicenow"
 	"ccoe-customer-contact-manager/internal/types"
 )
 
@@ -168,9 +170,9 @@ func loadAzureCredentialsFromSSM(ctx context.Context) error {
 	return nil
 }
 
-// loadTypeformAPITokenFromSSM loads Typeform API token from Parameter Store
-// and sets it as an environment variable
-func loadTypeformAPITokenFromSSM(ctx context.Context) error {
+// LoadTypeformAPITokenFromSSM loads Typeform API token from Parameter Store
+// and sets it as an environment variable. Entry points that only need Typeform call it directly.
+func LoadTypeformAPITokenFromSSM(ctx context.Context) error {
 	cfg, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
@@ -199,8 +201,88 @@ func loadTypeformAPITokenFromSSM(ctx context.Context) error {
 	return nil
 }
 
+// LoadServiceNowCredentialsFromSSM loads the ServiceNow integration user from Parameter Store
+// and sets it as environment variables. Entry points that only need ServiceNow call it directly.
+func LoadServiceNowCredentialsFromSSM(ctx context.Context) error {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := ssm.NewFromConfig(cfg)
+
+	result, err := client.GetParameters(ctx, &ssm.GetParametersInput{
+		Names: []string{
+			"/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/SERVICENOW_USERNAME",
+			"/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/SERVICENOW_PASSWORD",
+		},
+		WithDecryption: aws.Bool(true), // Important for SecureString parameters
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get ServiceNow parameters from SSM: %w", err)
+	}
+
+	for _, param := range result.Parameters {
+		switch *param.Name {
+		case "/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/SERVICENOW_USERNAME":
+			os.Setenv(servicenow.EnvUsername, *param.Value)
+		case "/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/SERVICENOW_PASSWORD":
+			os.Setenv(servicenow.EnvPassword, *param.Value)
+		}
+	}
+
+	for _, varName := range []string{servicenow.EnvUsername, servicenow.EnvPassword} {
+		if os.Getenv(varName) == "" {
+			return fmt.Errorf("failed to load %s from Parameter Store", varName)
+		}
+	}
+
+	fmt.Println("✅ Successfully loaded ServiceNow credentials from Parameter Store")
+	return nil
+}
+
+// LoadJiraCredentialsFromSSM loads the Jira API token (and the account it belongs to, for Jira
+// Cloud) from Parameter Store and sets them as environment variables
+func LoadJiraCredentialsFromSSM(ctx context.Context) error {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := ssm.NewFromConfig(cfg)
+
+	result, err := client.GetParameters(ctx, &ssm.GetParametersInput{
+		Names: []string{
+			"/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/JIRA_USERNAME",
+			"/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/JIRA_API_TOKEN",
+		},
+		WithDecryption: aws.Bool(true), // Important for SecureString parameters
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get Jira parameters from SSM: %w", err)
+	}
+
+	for _, param := range result.Parameters {
+		switch *param.Name {
+		case "/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/JIRA_USERNAME":
+			os.Setenv(jira.EnvUsername, *param.Value)
+		case "/hts/std-app-prod/ccoe-customer-contact-manager/us-east-1/JIRA_API_TOKEN":
+			os.Setenv(jira.EnvAPIToken, *param.Value)
+		}
+	}
+
+	// The username is only needed for Jira Cloud basic authentication
+	if os.Getenv(jira.EnvAPIToken) == "" {
+		return fmt.Errorf("failed to load %s from Parameter Store", jira.EnvAPIToken)
+	}
+
+	fmt.Println("✅ Successfully loaded Jira credentials from Parameter Store")
+	return nil
+}
+
 // LoadAllCredentialsFromSSM loads all required credentials from Parameter Store
-// This includes Azure credentials and Typeform API token
+// This includes Azure credentials and Typeform API token, plus ServiceNow and Jira
+// credentials when they are provisioned
 func LoadAllCredentialsFromSSM(ctx context.Context) error {
 	// Load Azure credentials
 	if err := loadAzureCredentialsFromSSM(ctx); err != nil {
@@ -208,10 +290,18 @@ func LoadAllCredentialsFromSSM(ctx context.Context) error {
 	}
 
 	// Load Typeform API token
-	if err := loadTypeformAPITokenFromSSM(ctx); err != nil {
+	if err := LoadTypeformAPITokenFromSSM(ctx); err != nil {
 		return fmt.Errorf("failed to load Typeform API token: %w", err)
 	}
 
+	// ServiceNow is optional - only deployments with the integration provision these parameters
+	if err := LoadServiceNowCredentialsFromSSM(ctx); err != nil {
+		fmt.Printf("ℹ️  ServiceNow credentials not loaded: %v\n", err)
+	}
+	if err := LoadJiraCredentialsFromSSM(ctx); err != nil {
+		fmt.Printf("ℹ️  Jira credentials not loaded: %v\n", err)
+	}
+
 	return nil
 }
 
@@ -1451,9 +1541,9 @@ END:VCALENDAR`,
 		senderEmail,
 		senderEmail,
 		attendeesICS.String(),
-		metadata.MeetingInvite.Title,
-		strings.ReplaceAll(metadata.ChangeMetadata.Description, "\n", "\\n"),
-		metadata.MeetingInvite.Location,
+		escapeICSText(metadata.MeetingInvite.Title),
+		escapeICSText(metadata.ChangeMetadata.Description),
+		escapeICSText(metadata.MeetingInvite.Location),
 	)
 
 	return icsContent, nil
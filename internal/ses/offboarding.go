@@ -0,0 +1,139 @@
+package ses
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Offboarding reasons, alongside the import reasons in import_report.go
+const (
+	ReasonProtected      = "protected"        // On the customer's protected_contacts allowlist
+	ReasonRoleTopicsLost = "role_topics_lost" // No longer qualifies for role-derived topics
+)
+
+// offboardingBackup backs up a contact list once, before the first contact is removed or changed
+type offboardingBackup struct {
+	sesClient *sesv2.Client
+	listName  string
+	logger    Logger
+	done      bool
+	err       error
+}
+
+// ensure creates the backup on first use and returns its error on every use, so nothing is
+// offboarded from a list that could not be backed up
+func (b *offboardingBackup) ensure() error {
+	if b.done {
+		return b.err
+	}
+	b.done = true
+
+	b.logger.Printf("📦 Backing up contact list %s before offboarding", b.listName)
+	backupFile, err := CreateContactListBackup(b.sesClient, b.listName, "offboarding")
+	if err != nil {
+		b.err = fmt.Errorf("offboarding skipped, failed to back up contact list: %w", err)
+		b.logger.Printf("❌ %v", b.err)
+		return b.err
+	}
+	b.logger.Printf("📦 Contact list backed up to %s", backupFile)
+	return nil
+}
+
+// customerOffboarding returns the customer's offboarding settings, if there is a customer
+func customerOffboarding(customer *types.CustomerAccountInfo) *types.OffboardingConfig {
+	if customer == nil {
+		return nil
+	}
+	return customer.Offboarding
+}
+
+// roleDerivedTopics returns the topics users only get through role mappings or include rules
+func roleDerivedTopics(config types.ContactImportConfig) map[string]bool {
+	topics := make(map[string]bool)
+	for _, mapping := range config.RoleMappings {
+		for _, topic := range mapping.Topics {
+			topics[topic] = true
+		}
+	}
+	for _, rule := range config.Rules {
+		if rule.GetAction() != types.TopicRuleInclude {
+			continue
+		}
+		for _, topic := range rule.Topics {
+			topics[topic] = true
+		}
+	}
+	for _, topic := range config.DefaultTopics {
+		delete(topics, topic)
+	}
+	return topics
+}
+
+// lostRoleTopics returns the role-derived topics a contact is subscribed to but no longer qualifies for
+func lostRoleTopics(subscribed, qualified []string, roleTopics map[string]bool) []string {
+	var lost []string
+	for _, topic := range subtractTopics(subscribed, qualified) {
+		if roleTopics[topic] {
+			lost = append(lost, topic)
+		}
+	}
+	return lost
+}
+
+// OptOutContactTopics opts a contact out of topics, keeping its other preferences and attributes
+// such as the phone number (unlike RemoveContactTopics, which re-creates the contact)
+func OptOutContactTopics(sesClient *sesv2.Client, listName string, email string, topics []string) error {
+	if len(topics) == 0 {
+		return fmt.Errorf("no topics specified")
+	}
+
+	contact, err := sesClient.GetContact(context.Background(), &sesv2.GetContactInput{
+		ContactListName: aws.String(listName),
+		EmailAddress:    aws.String(email),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get contact %s: %w", email, err)
+	}
+
+	optOut := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		optOut[topic] = true
+	}
+
+	var preferences []sesv2Types.TopicPreference
+	for _, pref := range contact.TopicPreferences {
+		if optOut[aws.ToString(pref.TopicName)] {
+			pref.SubscriptionStatus = sesv2Types.SubscriptionStatusOptOut
+			delete(optOut, aws.ToString(pref.TopicName))
+		}
+		preferences = append(preferences, pref)
+	}
+	// Topics subscribed by default have no preference yet
+	for _, topic := range topics {
+		if optOut[topic] {
+			preferences = append(preferences, sesv2Types.TopicPreference{
+				TopicName:          aws.String(topic),
+				SubscriptionStatus: sesv2Types.SubscriptionStatusOptOut,
+			})
+			delete(optOut, topic)
+		}
+	}
+
+	_, err = sesClient.UpdateContact(context.Background(), &sesv2.UpdateContactInput{
+		ContactListName:  aws.String(listName),
+		EmailAddress:     aws.String(email),
+		TopicPreferences: preferences,
+		UnsubscribeAll:   contact.UnsubscribeAll,
+		AttributesData:   contact.AttributesData,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update contact %s: %w", email, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package ses
+
+import (
+	"reflect"
+	"testing"
+
+	awsic "ccoe-customer-contact-manager/internal/aws"
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestRoleDerivedTopics(t *testing.T) {
+	config := types.ContactImportConfig{
+		DefaultTopics: []string{"announce"},
+		RoleMappings:  []types.RoleTopicMapping{{Roles: []string{"admin"}, Topics: []string{"approval", "announce"}}},
+		Rules: []types.TopicRule{
+			{Topics: []string{"calendar"}, Roles: []string{"admin"}},
+			{Topics: []string{"newsletter"}, Action: types.TopicRuleExclude},
+		},
+	}
+
+	want := map[string]bool{"approval": true, "calendar": true}
+	if got := roleDerivedTopics(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("roleDerivedTopics() = %v, want %v", got, want)
+	}
+
+	lost := lostRoleTopics([]string{"announce", "approval", "calendar", "newsletter"}, []string{"announce", "calendar"}, want)
+	if !reflect.DeepEqual(lost, []string{"approval"}) {
+		t.Errorf("lostRoleTopics() = %v", lost)
+	}
+}
+
+func TestOffboardingProtectedContacts(t *testing.T) {
+	offboarding := &types.OffboardingConfig{ProtectedContacts: []string{"ops@example.com", "*@service.example.com"}}
+	for email, want := range map[string]bool{
+		"Ops@Example.com":            true,
+		"alerts@service.example.com": true,
+		"jane@example.com":           false,
+	} {
+		if got := offboarding.IsProtected(email); got != want {
+			t.Errorf("IsProtected(%s) = %v, want %v", email, got, want)
+		}
+	}
+
+	var unset *types.OffboardingConfig
+	if unset.IsProtected("ops@example.com") || unset.StripsRoleTopics() {
+		t.Error("Expected no protection or stripping without offboarding config")
+	}
+}
+
+func TestDeltaReportEntryOffboarding(t *testing.T) {
+	config := types.ContactImportConfig{
+		DefaultTopics:      []string{"announce"},
+		RequireActiveUsers: true,
+		RoleMappings:       []types.RoleTopicMapping{{Roles: []string{"admin"}, Topics: []string{"approval"}}},
+	}
+	customer := &types.CustomerAccountInfo{Offboarding: &types.OffboardingConfig{
+		ProtectedContacts: []string{"ops@example.com"},
+		StripRoleTopics:   true,
+	}}
+	jane := types.IdentityCenterUser{UserName: "jane", Email: "jane@example.com", Active: true}
+	adminGroup := "ccoe-cloud-hts-prod-123456789012-idp-hts-admin"
+
+	// Losing the admin group strips the role topic
+	entry, ok := deltaReportEntry(awsic.UserChange{Type: awsic.UserGroupsChanged, User: jane, PreviousGroups: []string{adminGroup}}, config, customer)
+	if !ok || entry.Action != ContactChanged || !reflect.DeepEqual(entry.RemovedTopics, []string{"approval"}) || len(entry.AddedTopics) != 0 {
+		t.Errorf("Unexpected entry for lost group: %+v", entry)
+	}
+
+	// Protected contacts are neither removed nor stripped
+	ops := types.IdentityCenterUser{UserName: "ops", Email: "ops@example.com", Active: true}
+	if entry, ok := deltaReportEntry(awsic.UserChange{Type: awsic.UserLeft, User: ops}, config, customer); !ok || entry.Action != ContactSkipped || entry.Reason != ReasonProtected {
+		t.Errorf("Unexpected entry for protected leaver: %+v", entry)
+	}
+	if _, ok := deltaReportEntry(awsic.UserChange{Type: awsic.UserGroupsChanged, User: ops, PreviousGroups: []string{adminGroup}}, config, customer); ok {
+		t.Error("Expected no change for a protected contact losing a group")
+	}
+
+	// Without strip_role_topics, losing a group needs no SES call
+	customer.Offboarding.StripRoleTopics = false
+	if _, ok := deltaReportEntry(awsic.UserChange{Type: awsic.UserGroupsChanged, User: jane, PreviousGroups: []string{adminGroup}}, config, customer); ok {
+		t.Error("Expected no change without strip_role_topics")
+	}
+}
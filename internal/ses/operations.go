@@ -18,6 +18,7 @@ import (
 	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
 
 	awsic "ccoe-customer-contact-manager/internal/aws"
+	internalconfig "ccoe-customer-contact-manager/internal/config"
 	"ccoe-customer-contact-manager/internal/ses/templates"
 	"ccoe-customer-contact-manager/internal/types"
 )
@@ -675,9 +676,18 @@ func validateContactListTopics(sesClient *sesv2.Client, listName string, config
 		}
 	}
 
-	// Check role mapping topics
+	// Check role mapping and rule topics
+	requiredTopics := make([][]string, 0, len(config.RoleMappings)+len(config.Rules))
 	for _, mapping := range config.RoleMappings {
-		for _, topic := range mapping.Topics {
+		requiredTopics = append(requiredTopics, mapping.Topics)
+	}
+	for _, rule := range config.Rules {
+		if rule.GetAction() == types.TopicRuleInclude {
+			requiredTopics = append(requiredTopics, rule.Topics)
+		}
+	}
+	for _, topics := range requiredTopics {
+		for _, topic := range topics {
 			if !existingTopics[topic] {
 				found := false
 				for _, missing := range missingTopics {
@@ -1973,53 +1983,9 @@ func GetSESConfigFilePath() string {
 }
 
 // DetermineUserTopics determines which topics a user should be subscribed to based on their group memberships
+// and the topic rules. ExplainUserTopics reports why.
 func DetermineUserTopics(user types.IdentityCenterUser, membership *types.IdentityCenterGroupMembership, config types.ContactImportConfig) []string {
-	var topics []string
-	topicSet := make(map[string]bool)
-
-	// Add default topics for all active users
-	if !config.RequireActiveUsers || user.Active {
-		for _, topic := range config.DefaultTopics {
-			if !topicSet[topic] {
-				topics = append(topics, topic)
-				topicSet[topic] = true
-			}
-		}
-	}
-
-	// Check role mappings if user has group memberships
-	if membership != nil {
-		// Parse CCOE cloud groups to extract roles
-		var userRoles []string
-		for _, group := range membership.Groups {
-			if strings.HasPrefix(group, "ccoe-cloud-") {
-				parsed := ParseCCOECloudGroup(group)
-				if parsed.IsValid {
-					userRoles = append(userRoles, parsed.RoleName)
-				}
-			}
-		}
-
-		// Check each role mapping
-		for _, mapping := range config.RoleMappings {
-			for _, userRole := range userRoles {
-				for _, mappingRole := range mapping.Roles {
-					if strings.EqualFold(userRole, mappingRole) {
-						// User has a matching role, add the topics
-						for _, topic := range mapping.Topics {
-							if !topicSet[topic] {
-								topics = append(topics, topic)
-								topicSet[topic] = true
-							}
-						}
-						break
-					}
-				}
-			}
-		}
-	}
-
-	return topics
+	return ExplainUserTopics(user, membership, config).Topics
 }
 
 // BuildContactImportConfigFromSES builds a ContactImportConfig from SES configuration
@@ -2050,6 +2016,7 @@ func BuildContactImportConfigFromSES(sesConfig types.SESConfig) types.ContactImp
 		RoleMappings:       roleMappings,
 		DefaultTopics:      defaultTopics,
 		RequireActiveUsers: true,
+		Rules:              sesConfig.TopicRules,
 	}
 }
 
@@ -2068,9 +2035,12 @@ func GetDefaultContactImportConfig() types.ContactImportConfig {
 }
 
 // ImportSingleAWSContactWithData imports a single user from Identity Center to SES
-// If icData is provided, uses in-memory data; otherwise loads from files
-func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId string, icData *awsic.IdentityCenterData, userName string, dryRun bool) error {
+// If icData is provided, uses in-memory data; otherwise loads from files. The customer's group
+// patterns and restricted_recipients apply when customer is set, and the outcome is recorded in
+// report, which may be nil.
+func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId string, icData *awsic.IdentityCenterData, customer *types.CustomerAccountInfo, userName string, dryRun bool, report *ContactImportReport) error {
 	fmt.Printf("🔍 Importing single AWS contact: %s\n", userName)
+	report.SetMode("single")
 
 	var users []types.IdentityCenterUser
 	var memberships []types.IdentityCenterGroupMembership
@@ -2115,20 +2085,27 @@ func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId st
 	}
 
 	// Load SES config and build configuration
-	configPath := GetConfigPath()
-	sesConfigFile := GetSESConfigFilePath()
-	sesJson, err := os.ReadFile(configPath + sesConfigFile)
+	config, err := loadContactImportConfig()
 	if err != nil {
-		return fmt.Errorf("error reading SES config file: %v", err)
+		return err
 	}
-
-	var sesConfig types.SESConfig
-	err = json.Unmarshal(sesJson, &sesConfig)
-	if err != nil {
-		return fmt.Errorf("error parsing SES config: %v", err)
+	if err := internalconfig.ValidateGroupPatterns(customerGroupPatterns(customer)); err != nil {
+		return err
 	}
+	config.GroupPatterns = customerGroupPatterns(customer)
 
-	config := BuildContactImportConfigFromSES(sesConfig)
+	entry := ContactImportEntry{Email: targetUser.Email, UserName: targetUser.UserName}
+	switch {
+	case targetUser.Email == "":
+		entry.Action, entry.Reason = ContactSkipped, ReasonNoEmail
+	case isRestrictedRecipient(customer, targetUser.Email):
+		entry.Action, entry.Reason = ContactSkipped, ReasonRestricted
+	}
+	if entry.Action != "" {
+		fmt.Printf("⏭️  Skipping %s: %s\n", userName, entry.Reason)
+		report.Record(entry)
+		return nil
+	}
 
 	// Determine topics for this user
 	topics := DetermineUserTopics(*targetUser, targetMembership, config)
@@ -2139,9 +2116,11 @@ func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId st
 	}
 
 	fmt.Printf("📋 User %s will be subscribed to topics: %v\n", userName, topics)
+	entry.Action, entry.NewTopics, entry.AddedTopics = ContactAdded, topics, topics
 
 	if dryRun {
 		fmt.Printf("🔍 DRY RUN: Would add %s (%s) to topics: %v\n", targetUser.DisplayName, targetUser.Email, topics)
+		report.Record(entry)
 		return nil
 	}
 
@@ -2159,6 +2138,17 @@ func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId st
 
 	if _, exists := existingContacts[targetUser.Email]; exists {
 		fmt.Printf("ℹ️  Contact %s (%s) already exists - skipping (users manage their own subscriptions)\n", targetUser.DisplayName, targetUser.Email)
+		entry = ContactImportEntry{Email: targetUser.Email, UserName: targetUser.UserName, Action: ContactSkipped, Reason: ReasonAlreadyExists}
+		if targetUser.PhoneNumber != "" {
+			if updated, err := SetContactPhoneNumber(sesClient, accountListName, targetUser.Email, targetUser.PhoneNumber, PhoneSourceIdentityCenter); err != nil {
+				fmt.Printf("⚠️  Failed to update phone number for %s: %v\n", targetUser.Email, err)
+				entry.Action, entry.Reason, entry.Error = ContactFailed, ReasonPhoneUpdated, err.Error()
+			} else if updated {
+				fmt.Printf("📱 Updated phone number for %s from Identity Center\n", targetUser.Email)
+				entry.Action, entry.Reason = ContactChanged, ReasonPhoneUpdated
+			}
+		}
+		report.Record(entry)
 		return nil
 	}
 
@@ -2167,17 +2157,21 @@ func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId st
 	defer rateLimiter.Stop()
 
 	rateLimiter.Wait()
-	err = AddContactToListQuiet(sesClient, accountListName, targetUser.Email, topics)
+	err = AddContactToListWithPhone(sesClient, accountListName, targetUser.Email, topics, targetUser.PhoneNumber)
 	if err != nil {
 		// Check if it's an AlreadyExistsException
 		errMsg := err.Error()
 		if strings.Contains(errMsg, "AlreadyExistsException") || strings.Contains(errMsg, "already exists") {
 			fmt.Printf("ℹ️  Contact %s (%s) already exists - skipping\n", targetUser.DisplayName, targetUser.Email)
+			report.Record(ContactImportEntry{Email: targetUser.Email, UserName: targetUser.UserName, Action: ContactSkipped, Reason: ReasonAlreadyExists})
 			return nil
 		}
+		entry.Action, entry.Error = ContactFailed, err.Error()
+		report.Record(entry)
 		return fmt.Errorf("failed to add contact %s to SES: %w", targetUser.Email, err)
 	}
 
+	report.Record(entry)
 	fmt.Printf("✅ Successfully imported contact: %s (%s) with topics: %v\n", targetUser.DisplayName, targetUser.Email, topics)
 	return nil
 }
@@ -2185,11 +2179,17 @@ func ImportSingleAWSContactWithData(sesClient *sesv2.Client, identityCenterId st
 // ImportSingleAWSContact imports a single user from Identity Center to SES (file-based mode)
 // This is a wrapper for backward compatibility
 func ImportSingleAWSContact(sesClient *sesv2.Client, identityCenterId string, userName string, dryRun bool) error {
-	return ImportSingleAWSContactWithData(sesClient, identityCenterId, nil, userName, dryRun)
+	return ImportSingleAWSContactWithData(sesClient, identityCenterId, nil, nil, userName, dryRun, nil)
 }
 
 // AddContactToListQuiet adds an email contact to a contact list without verbose output
 func AddContactToListQuiet(sesClient *sesv2.Client, listName string, email string, topics []string) error {
+	return AddContactToListWithPhone(sesClient, listName, email, topics, "")
+}
+
+// AddContactToListWithPhone adds a contact with topic subscriptions and an optional phone number
+// (stored in AttributesData for urgent SMS) without printing output
+func AddContactToListWithPhone(sesClient *sesv2.Client, listName string, email string, topics []string, phone string) error {
 	var topicPreferences []sesv2Types.TopicPreference
 	for _, topic := range topics {
 		// Skip empty or blank topic names
@@ -2206,6 +2206,9 @@ func AddContactToListQuiet(sesClient *sesv2.Client, listName string, email strin
 		EmailAddress:     aws.String(email),
 		TopicPreferences: topicPreferences,
 	}
+	if attributes := phoneAttributesData(phone); attributes != "" {
+		input.AttributesData = aws.String(attributes)
+	}
 
 	_, err := sesClient.CreateContact(context.Background(), input)
 	return err
@@ -2216,8 +2219,10 @@ type CCOECloudGroupParseResult struct {
 	GroupName         string
 	AccountName       string
 	AccountId         string
+	Environment       string // Last part of a multi-part account name, e.g. prod for hts-prod
 	ApplicationPrefix string
 	RoleName          string
+	Pattern           string // Name of the group pattern that matched, set by ParseGroup
 	IsValid           bool
 }
 
@@ -2264,6 +2269,9 @@ func ParseCCOECloudGroup(groupName string) CCOECloudGroupParseResult {
 	accountNameParts := parts[:accountIdIndex]
 	result.AccountName = strings.Join(accountNameParts, "-")
 	result.AccountId = parts[accountIdIndex]
+	if len(accountNameParts) > 1 {
+		result.Environment = accountNameParts[len(accountNameParts)-1]
+	}
 
 	// Find "idp" marker and extract application prefix and role name
 	idpIndex := -1
@@ -2361,12 +2369,18 @@ func (l *DefaultLogger) Printf(format string, args ...interface{}) {
 // ImportAllAWSContacts imports all users from Identity Center to SES
 // If identityCenterData is provided, it uses the in-memory data; otherwise, it loads from files
 func ImportAllAWSContacts(sesClient *sesv2.Client, identityCenterId string, identityCenterData *awsic.IdentityCenterData, dryRun bool, requestsPerSecond int) error {
-	return ImportAllAWSContactsWithLogger(sesClient, identityCenterId, identityCenterData, dryRun, requestsPerSecond, &DefaultLogger{})
+	return ImportAllAWSContactsWithLogger(sesClient, identityCenterId, identityCenterData, nil, dryRun, requestsPerSecond, &DefaultLogger{}, nil)
 }
 
-// ImportAllAWSContactsWithLogger imports all users with custom logger
-func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId string, identityCenterData *awsic.IdentityCenterData, dryRun bool, requestsPerSecond int, logger Logger) error {
+// ImportAllAWSContactsWithLogger imports all users with custom logger, parsing their groups with the
+// customer's group patterns before the ccoe-cloud format. Contacts that are no longer eligible users
+// are offboarded (see offboarding.go), except protected contacts and users not on the customer's
+// restricted_recipients list, who are left alone. What happened to each contact is
+// recorded in report, which may be nil; customer may be nil when no customer settings apply.
+func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId string, identityCenterData *awsic.IdentityCenterData, customer *types.CustomerAccountInfo, dryRun bool, requestsPerSecond int, logger Logger, report *ContactImportReport) error {
 	logger.Printf("🔍 Importing all AWS contacts from Identity Center")
+	report.SetMode("full")
+	groupPatterns := customerGroupPatterns(customer)
 
 	var users []types.IdentityCenterUser
 	var memberships []types.IdentityCenterGroupMembership
@@ -2399,20 +2413,14 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 	}
 
 	// Load SES config and build configuration
-	configPath := GetConfigPath()
-	sesConfigFile := GetSESConfigFilePath()
-	sesJson, err := os.ReadFile(configPath + sesConfigFile)
+	config, err := loadContactImportConfig()
 	if err != nil {
-		return fmt.Errorf("error reading SES config file: %v", err)
+		return err
 	}
-
-	var sesConfig types.SESConfig
-	err = json.Unmarshal(sesJson, &sesConfig)
-	if err != nil {
-		return fmt.Errorf("error parsing SES config: %v", err)
+	if err := internalconfig.ValidateGroupPatterns(groupPatterns); err != nil {
+		return err
 	}
-
-	config := BuildContactImportConfigFromSES(sesConfig)
+	config.GroupPatterns = groupPatterns
 
 	// Create rate limiter for SES operations
 	// Use 1 request per second for contact operations to avoid AlreadyExistsException and rate limiting
@@ -2459,11 +2467,27 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 		topics     []string
 	})
 
+	// Users kept out of the list: inactive users are removed if present, restricted users are left alone
+	inactiveUsers := make(map[string]types.IdentityCenterUser)
+	restrictedUsers := make(map[string]bool)
+
 	fmt.Printf("👥 Processing %d Identity Center users...\n", len(users))
 
 	for _, user := range users {
+		if user.Email == "" {
+			report.Record(ContactImportEntry{UserName: user.UserName, Action: ContactSkipped, Reason: ReasonNoEmail})
+			continue
+		}
+
+		if isRestrictedRecipient(customer, user.Email) {
+			restrictedUsers[user.Email] = true
+			report.Record(ContactImportEntry{Email: user.Email, UserName: user.UserName, Action: ContactSkipped, Reason: ReasonRestricted})
+			continue
+		}
+
 		// Skip inactive users if required
 		if config.RequireActiveUsers && !user.Active {
+			inactiveUsers[user.Email] = user
 			continue
 		}
 
@@ -2483,6 +2507,7 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 	}
 
 	fmt.Printf("✅ Found %d valid Identity Center users (including users with no initial topics)\n", len(validUsers))
+	reportUnmatchedGroups(logger, FindUnmatchedGroups(memberships, groupPatterns))
 
 	// Determine who to add and who to remove
 	var usersToAdd []string
@@ -2495,19 +2520,87 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 		}
 	}
 
-	// Find contacts to remove (in SES but not in Identity Center)
+	// Find contacts to offboard (in SES but no longer an eligible Identity Center user), leaving
+	// protected contacts alone
+	offboarding := customerOffboarding(customer)
 	for email := range existingContacts {
-		if _, exists := validUsers[email]; !exists {
-			contactsToRemove = append(contactsToRemove, email)
+		if _, exists := validUsers[email]; exists || restrictedUsers[email] {
+			continue
 		}
+		if offboarding.IsProtected(email) {
+			report.Record(ContactImportEntry{Email: email, Action: ContactSkipped, Reason: ReasonProtected, OldTopics: existingContacts[email]})
+			continue
+		}
+		contactsToRemove = append(contactsToRemove, email)
+	}
+	sort.Strings(contactsToRemove)
+
+	// Find role-derived topics existing contacts no longer qualify for
+	topicsToStrip := make(map[string][]string)
+	var contactsToStrip []string
+	if offboarding.StripsRoleTopics() {
+		roleTopics := roleDerivedTopics(config)
+		for email, userData := range validUsers {
+			subscribed, exists := existingContacts[email]
+			if !exists || offboarding.IsProtected(email) {
+				continue
+			}
+			if lost := lostRoleTopics(subscribed, userData.topics, roleTopics); len(lost) > 0 {
+				topicsToStrip[email] = lost
+				contactsToStrip = append(contactsToStrip, email)
+			}
+		}
+		sort.Strings(contactsToStrip)
+	}
+	stripEntry := func(email string) ContactImportEntry {
+		return ContactImportEntry{Email: email, UserName: validUsers[email].user.UserName, Action: ContactChanged, Reason: ReasonRoleTopicsLost,
+			OldTopics: existingContacts[email], NewTopics: subtractTopics(existingContacts[email], topicsToStrip[email]), RemovedTopics: topicsToStrip[email]}
+	}
+
+	for email, user := range inactiveUsers {
+		if _, exists := existingContacts[email]; !exists {
+			report.Record(ContactImportEntry{Email: email, UserName: user.UserName, Action: ContactSkipped, Reason: ReasonInactive})
+		}
+	}
+
+	removalReason := func(email string) string {
+		if _, inactive := inactiveUsers[email]; inactive {
+			return ReasonInactive
+		}
+		return ReasonNotInSource
 	}
 
 	fmt.Printf("\n📊 Sync Summary:\n")
 	fmt.Printf("   ➕ Users to add: %d\n", len(usersToAdd))
 	fmt.Printf("   ➖ Contacts to remove: %d\n", len(contactsToRemove))
+	if offboarding.StripsRoleTopics() {
+		fmt.Printf("   ✂️  Contacts losing role topics: %d\n", len(contactsToStrip))
+	}
 	fmt.Printf("   ✅ Already in sync: %d\n", len(validUsers)-len(usersToAdd))
 
 	if dryRun {
+		for _, email := range usersToAdd {
+			userData := validUsers[email]
+			report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactAdded, NewTopics: userData.topics, AddedTopics: userData.topics})
+		}
+		for _, email := range contactsToRemove {
+			report.Record(ContactImportEntry{Email: email, Action: ContactRemoved, Reason: removalReason(email), OldTopics: existingContacts[email]})
+		}
+		for _, email := range contactsToStrip {
+			report.Record(stripEntry(email))
+		}
+		if len(contactsToStrip) > 0 {
+			fmt.Printf("\n🔍 Would opt these contacts out of role topics:\n")
+			for i, email := range contactsToStrip {
+				if i < 5 {
+					fmt.Printf("   - %s → %v\n", email, topicsToStrip[email])
+				}
+			}
+			if len(contactsToStrip) > 5 {
+				fmt.Printf("   ... and %d more\n", len(contactsToStrip)-5)
+			}
+		}
+
 		if len(usersToAdd) > 0 {
 			fmt.Printf("\n🔍 Would add these users:\n")
 			for i, email := range usersToAdd {
@@ -2552,12 +2645,13 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 			rateLimiter.Wait()
 
 			// Add contact to SES
-			err = AddContactToListQuiet(sesClient, accountListName, email, userData.topics)
+			err = AddContactToListWithPhone(sesClient, accountListName, email, userData.topics, userData.user.PhoneNumber)
 			if err != nil {
 				// Check if it's an AlreadyExistsException
 				errMsg := err.Error()
 				if strings.Contains(errMsg, "AlreadyExistsException") || strings.Contains(errMsg, "already exists") {
 					// Already exists, count as success
+					report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactSkipped, Reason: ReasonAlreadyExists})
 					addedCount++
 					continue
 				}
@@ -2565,15 +2659,35 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 				if addErrorCount < 3 {
 					fmt.Printf("   ❌ Failed to add contact %s: %v\n", email, err)
 				}
+				report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactFailed, NewTopics: userData.topics, Error: err.Error()})
 				addErrorCount++
 				continue
 			}
 
+			report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactAdded, NewTopics: userData.topics, AddedTopics: userData.topics})
 			addedCount++
 		}
 	}
 
-	// Remove old contacts
+	// Offboarding: remove contacts that are no longer eligible users and opt the rest out of role
+	// topics they lost, once the list has been backed up
+	backup := &offboardingBackup{sesClient: sesClient, listName: accountListName, logger: logger}
+	var offboardingErr error
+	if len(contactsToRemove) > 0 || len(contactsToStrip) > 0 {
+		offboardingErr = backup.ensure()
+	}
+	if offboardingErr != nil {
+		for _, email := range contactsToRemove {
+			report.Record(ContactImportEntry{Email: email, Action: ContactFailed, Reason: removalReason(email), OldTopics: existingContacts[email], Error: offboardingErr.Error()})
+		}
+		for _, email := range contactsToStrip {
+			entry := stripEntry(email)
+			entry.Action, entry.Error = ContactFailed, offboardingErr.Error()
+			report.Record(entry)
+		}
+		contactsToRemove, contactsToStrip = nil, nil
+	}
+
 	removedCount := 0
 	removeErrorCount := 0
 	if len(contactsToRemove) > 0 {
@@ -2595,21 +2709,67 @@ func ImportAllAWSContactsWithLogger(sesClient *sesv2.Client, identityCenterId st
 				if removeErrorCount < 3 {
 					fmt.Printf("   ❌ Failed to remove contact %s: %v\n", email, err)
 				}
+				report.Record(ContactImportEntry{Email: email, Action: ContactFailed, Reason: removalReason(email), OldTopics: existingContacts[email], Error: err.Error()})
 				removeErrorCount++
 				continue
 			}
 
+			logger.Printf("🗑️  Offboarded %s (%s, topics: %v)", email, removalReason(email), existingContacts[email])
+			report.Record(ContactImportEntry{Email: email, Action: ContactRemoved, Reason: removalReason(email), OldTopics: existingContacts[email]})
 			removedCount++
 		}
 	}
 
+	strippedCount := 0
+	for _, email := range contactsToStrip {
+		rateLimiter.Wait()
+		entry := stripEntry(email)
+		if err := OptOutContactTopics(sesClient, accountListName, email, topicsToStrip[email]); err != nil {
+			fmt.Printf("   ❌ Failed to opt %s out of %v: %v\n", email, topicsToStrip[email], err)
+			entry.Action, entry.Error = ContactFailed, err.Error()
+			report.Record(entry)
+			removeErrorCount++
+			continue
+		}
+		logger.Printf("✂️  Opted %s out of role topics %v (%s)", email, topicsToStrip[email], ReasonRoleTopicsLost)
+		report.Record(entry)
+		strippedCount++
+	}
+
+	// Keep phone numbers of existing contacts in step with Identity Center
+	phoneUpdatedCount := 0
+	for email, userData := range validUsers {
+		if _, exists := existingContacts[email]; !exists || userData.user.PhoneNumber == "" {
+			continue
+		}
+
+		rateLimiter.Wait()
+		updated, err := SetContactPhoneNumber(sesClient, accountListName, email, userData.user.PhoneNumber, PhoneSourceIdentityCenter)
+		if err != nil {
+			fmt.Printf("   ⚠️  Failed to update phone number for %s: %v\n", email, err)
+			report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactFailed, Reason: ReasonPhoneUpdated, Error: err.Error()})
+			continue
+		}
+		if updated {
+			report.Record(ContactImportEntry{Email: email, UserName: userData.user.UserName, Action: ContactChanged, Reason: ReasonPhoneUpdated})
+			phoneUpdatedCount++
+		}
+	}
+
 	fmt.Printf("\n📊 Final Summary:\n")
 	fmt.Printf("   ➕ Added: %d\n", addedCount)
+	fmt.Printf("   📱 Phone numbers updated: %d\n", phoneUpdatedCount)
 	fmt.Printf("   ➖ Removed: %d\n", removedCount)
+	if offboarding.StripsRoleTopics() {
+		fmt.Printf("   ✂️  Role topics removed: %d\n", strippedCount)
+	}
 	fmt.Printf("   ❌ Add errors: %d\n", addErrorCount)
 	fmt.Printf("   ❌ Remove errors: %d\n", removeErrorCount)
 	fmt.Printf("   ✅ Total in sync: %d\n", len(validUsers))
 
+	if offboardingErr != nil {
+		return offboardingErr
+	}
 	if addErrorCount > 0 || removeErrorCount > 0 {
 		return fmt.Errorf("failed to sync %d contacts", addErrorCount+removeErrorCount)
 	}
@@ -2637,6 +2797,12 @@ func SendEmailWithTemplate(
 		return fmt.Errorf("failed to get template: %w", err)
 	}
 
+	return SendTemplateToTopic(ctx, sesClient, emailConfig, emailTemplate, topicName, nil)
+}
+
+// SendTemplateToTopic sends an already-rendered email template to every contact subscribed to a topic.
+// If customerInfo is set, its restricted_recipients list is honored.
+func SendTemplateToTopic(ctx context.Context, sesClient *sesv2.Client, emailConfig types.EmailConfig, emailTemplate templates.EmailTemplate, topicName string, customerInfo *types.CustomerAccountInfo) error {
 	// Get account contact list
 	accountListName, err := GetAccountContactList(sesClient)
 	if err != nil {
@@ -2661,6 +2827,11 @@ func SendEmailWithTemplate(
 	errorCount := 0
 
 	for _, contact := range subscribedContacts {
+		if customerInfo != nil && !customerInfo.IsRecipientAllowed(*contact.EmailAddress) {
+			log.Printf("⏭️  Skipping %s (not on restricted recipient list)", *contact.EmailAddress)
+			continue
+		}
+
 		sendInput := &sesv2.SendEmailInput{
 			FromEmailAddress: aws.String(emailConfig.SenderAddress),
 			Destination: &sesv2Types.Destination{
@@ -1004,3 +1004,29 @@ func (b *AnnouncementTemplateBuilder) buildCancellationText(data CancellationDat
 
 	return sb.String()
 }
+
+// BuildInProgress builds an implementation started notification email for announcements
+func (b *AnnouncementTemplateBuilder) BuildInProgress(data ImplementationUpdateData) EmailTemplate {
+	category := CategoryType(data.Category)
+	return buildImplementationUpdate(data, NotificationInProgress, category, b.headerColor(category), b.config.PortalBaseURL)
+}
+
+// BuildFailure builds an implementation failed notification email for announcements
+func (b *AnnouncementTemplateBuilder) BuildFailure(data ImplementationUpdateData) EmailTemplate {
+	category := CategoryType(data.Category)
+	return buildImplementationUpdate(data, NotificationFailed, category, b.headerColor(category), b.config.PortalBaseURL)
+}
+
+// BuildRollback builds a rolled back notification email for announcements
+func (b *AnnouncementTemplateBuilder) BuildRollback(data ImplementationUpdateData) EmailTemplate {
+	category := CategoryType(data.Category)
+	return buildImplementationUpdate(data, NotificationRolledBack, category, b.headerColor(category), b.config.PortalBaseURL)
+}
+
+// headerColor returns the header background color for a category, falling back to light blue
+func (b *AnnouncementTemplateBuilder) headerColor(category CategoryType) string {
+	if color := categoryColors[category]; color != "" {
+		return color
+	}
+	return "#007bff"
+}
@@ -66,6 +66,22 @@ type CancellationData struct {
 	CancelledAt      time.Time
 }
 
+// ImplementationUpdateData contains data for implementation lifecycle notifications
+// (implementation started, implementation failed, rolled back)
+type ImplementationUpdateData struct {
+	BaseTemplateData
+	UpdatedBy      string
+	UpdatedByEmail string
+	UpdatedAt      time.Time
+	Reason         string // Failure or rollback reason supplied with the status change
+	RollbackPlan   string
+	WindowStart    time.Time
+	WindowEnd      time.Time
+	WindowTimezone string
+	SurveyURL      string // Typeform survey URL with hidden parameters (failed/rolled back only)
+	SurveyQRCode   string // Base64-encoded QR code image for survey
+}
+
 // EmailTemplate represents a complete email with subject and body
 type EmailTemplate struct {
 	Subject  string
@@ -80,6 +96,9 @@ type TemplateBuilder interface {
 	BuildMeetingInvitation(data MeetingData) EmailTemplate
 	BuildCompletion(data CompletionData) EmailTemplate
 	BuildCancellation(data CancellationData) EmailTemplate
+	BuildInProgress(data ImplementationUpdateData) EmailTemplate
+	BuildFailure(data ImplementationUpdateData) EmailTemplate
+	BuildRollback(data ImplementationUpdateData) EmailTemplate
 }
 
 // TemplateRegistry manages template builders for different event types
@@ -153,6 +172,20 @@ func (r *TemplateRegistry) GetTemplate(
 		}
 		return builder.BuildCancellation(cancellationData), nil
 
+	case NotificationInProgress, NotificationFailed, NotificationRolledBack:
+		updateData, ok := data.(ImplementationUpdateData)
+		if !ok {
+			return EmailTemplate{}, fmt.Errorf("invalid data type for %s notification: expected ImplementationUpdateData", notificationType)
+		}
+		switch notificationType {
+		case NotificationInProgress:
+			return builder.BuildInProgress(updateData), nil
+		case NotificationFailed:
+			return builder.BuildFailure(updateData), nil
+		default:
+			return builder.BuildRollback(updateData), nil
+		}
+
 	default:
 		return EmailTemplate{}, fmt.Errorf("unknown notification type: %s", notificationType)
 	}
@@ -976,3 +976,18 @@ func (b *ChangeTemplateBuilder) buildCancellationText(data CancellationData, emo
 
 	return sb.String()
 }
+
+// BuildInProgress builds an implementation started notification email for changes
+func (b *ChangeTemplateBuilder) BuildInProgress(data ImplementationUpdateData) EmailTemplate {
+	return buildImplementationUpdate(data, NotificationInProgress, CategoryChange, changeColor, b.config.PortalBaseURL)
+}
+
+// BuildFailure builds an implementation failed notification email for changes
+func (b *ChangeTemplateBuilder) BuildFailure(data ImplementationUpdateData) EmailTemplate {
+	return buildImplementationUpdate(data, NotificationFailed, CategoryChange, changeColor, b.config.PortalBaseURL)
+}
+
+// BuildRollback builds a rolled back notification email for changes
+func (b *ChangeTemplateBuilder) BuildRollback(data ImplementationUpdateData) EmailTemplate {
+	return buildImplementationUpdate(data, NotificationRolledBack, CategoryChange, changeColor, b.config.PortalBaseURL)
+}
@@ -9,6 +9,12 @@ const (
 	NotificationCompleted       NotificationType = "completed"
 	NotificationCancelled       NotificationType = "cancelled"
 	NotificationMeeting         NotificationType = "meeting"
+	NotificationInProgress      NotificationType = "in_progress"
+	NotificationFailed          NotificationType = "failed"
+	NotificationRolledBack      NotificationType = "rolled_back"
+	NotificationOverdue         NotificationType = "overdue_reminder"
+	NotificationOverdueEscalate NotificationType = "overdue_escalation"
+	NotificationSurveyReminder  NotificationType = "survey_reminder"
 )
 
 // CategoryType represents the category of the event
@@ -33,6 +39,13 @@ const (
 	EmojiInnerSource     = "🔧"  // Wrench
 	EmojiGeneral         = "📢"  // Megaphone
 	EmojiMeeting         = "📅"  // Calendar
+	EmojiInProgress      = "🚧"  // Construction (implementation started)
+	EmojiFailed          = "🔴"  // Red circle (implementation failed)
+	EmojiRolledBack      = "⏪"  // Rewind (change rolled back)
+	EmojiOverdue         = "⏰"  // Alarm clock (overdue reminder)
+	EmojiEscalation      = "🚨"  // Siren (overdue escalation)
+	EmojiReport          = "📊"  // Bar chart (periodic reports)
+	EmojiSurvey          = "📝"  // Memo (survey reminder)
 	EmojiDefault         = "📧"  // Email (fallback)
 )
 
@@ -58,6 +71,22 @@ func GetEmojiForNotification(notificationType NotificationType, category Categor
 		return EmojiMeeting
 	}
 
+	// For implementation lifecycle updates, use the status-specific emoji
+	switch notificationType {
+	case NotificationInProgress:
+		return EmojiInProgress
+	case NotificationFailed:
+		return EmojiFailed
+	case NotificationRolledBack:
+		return EmojiRolledBack
+	case NotificationOverdue:
+		return EmojiOverdue
+	case NotificationOverdueEscalate:
+		return EmojiEscalation
+	case NotificationSurveyReminder:
+		return EmojiSurvey
+	}
+
 	// For approved notifications, use category-specific emojis for announcements
 	// and green circle for changes
 	if notificationType == NotificationApproved {
@@ -0,0 +1,289 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// implementationBoxStyle holds the callout colors used for an implementation update
+type implementationBoxStyle struct {
+	Heading         string
+	BackgroundColor string
+	BorderColor     string
+	TextColor       string
+}
+
+// implementationBoxStyles maps implementation notification types to their callout styling
+var implementationBoxStyles = map[NotificationType]implementationBoxStyle{
+	NotificationInProgress: {Heading: "Implementation Started", BackgroundColor: "#fff3cd", BorderColor: "#ffc107", TextColor: "#856404"},
+	NotificationFailed:     {Heading: "Implementation Failed", BackgroundColor: "#f8d7da", BorderColor: "#dc3545", TextColor: "#721c24"},
+	NotificationRolledBack: {Heading: "Rolled Back", BackgroundColor: "#ffe5d0", BorderColor: "#fd7e14", TextColor: "#8a3b00"},
+}
+
+// buildImplementationUpdate builds a complete implementation update email for the given notification type
+func buildImplementationUpdate(data ImplementationUpdateData, notificationType NotificationType, category CategoryType, backgroundColor string, baseURL string) EmailTemplate {
+	emoji := GetEmojiForNotification(notificationType, category)
+	subject := buildSubject(emoji, data.Title)
+
+	return EmailTemplate{
+		Subject:  sanitizeSubject(subject),
+		HTMLBody: buildImplementationUpdateHTML(data, notificationType, backgroundColor, baseURL),
+		TextBody: buildImplementationUpdateText(data, notificationType, emoji, baseURL),
+	}
+}
+
+// buildImplementationUpdateHTML builds the HTML body for implementation update notifications
+func buildImplementationUpdateHTML(data ImplementationUpdateData, notificationType NotificationType, backgroundColor string, baseURL string) string {
+	style := implementationBoxStyles[notificationType]
+
+	var sb strings.Builder
+
+	// HTML structure
+	sb.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            margin: 0;
+            padding: 0;
+        }
+        .email-container {
+            max-width: 600px;
+            margin: 0 auto;
+        }
+        .content {
+            padding: 20px;
+            background-color: #ffffff;
+        }
+        @media only screen and (max-width: 600px) {
+            .email-container {
+                width: 100% !important;
+            }
+        }
+    </style>
+</head>
+<body>
+`)
+
+	// Email container
+	sb.WriteString(`    <div class="email-container">` + "\n")
+
+	// Header
+	sb.WriteString("        ")
+	statusWord := getStatusWordForNotification(notificationType)
+	sb.WriteString(renderHTMLHeader(statusWord, data.Title, backgroundColor))
+	sb.WriteString("\n")
+
+	// Content section
+	sb.WriteString(`        <div class="content">` + "\n")
+
+	// Status subtitle
+	sb.WriteString("            ")
+	sb.WriteString(renderStatusSubtitle(data.Status))
+	sb.WriteString("\n")
+
+	// Status callout (who, when, why)
+	sb.WriteString(fmt.Sprintf(`            <div style="margin-bottom: 20px; padding: 15px; background-color: %s; border-left: 4px solid %s;">
+                <h3 style="font-size: 1em; color: %s; margin: 0 0 10px 0;">%s</h3>`,
+		style.BackgroundColor, style.BorderColor, style.TextColor, style.Heading))
+	sb.WriteString("\n")
+
+	if data.UpdatedBy != "" {
+		sb.WriteString(fmt.Sprintf(`                <div style="margin-bottom: 8px;">
+                    <strong>By:</strong> %s`, formatContentForHTML(data.UpdatedBy)))
+		if data.UpdatedByEmail != "" {
+			sb.WriteString(fmt.Sprintf(` (%s)`, formatContentForHTML(data.UpdatedByEmail)))
+		}
+		sb.WriteString(`</div>`)
+		sb.WriteString("\n")
+	}
+
+	if !data.UpdatedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf(`                <div style="margin-bottom: 8px;">
+                    <strong>At:</strong> %s
+                </div>`, data.UpdatedAt.Format("2006-01-02 15:04 MST")))
+		sb.WriteString("\n")
+	}
+
+	if window := formatImplementationWindow(data.WindowStart, data.WindowEnd, data.WindowTimezone); window != "" {
+		sb.WriteString(fmt.Sprintf(`                <div style="margin-bottom: 8px;">
+                    <strong>Scheduled Window:</strong> %s
+                </div>`, formatContentForHTML(window)))
+		sb.WriteString("\n")
+	}
+
+	if data.Reason != "" {
+		sb.WriteString(fmt.Sprintf(`                <div>
+                    <strong>Reason:</strong> %s
+                </div>`, formatContentForHTML(data.Reason)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(`            </div>`)
+	sb.WriteString("\n")
+
+	// Survey section (failed and rolled back changes still collect feedback)
+	if data.SurveyURL != "" {
+		sb.WriteString(`            <div style="margin-top: 20px; padding: 15px; background-color: #e7f3ff; border-left: 4px solid #0066cc;">
+                <h3 style="font-size: 1em; color: #004085; margin: 0 0 10px 0;">📋 Share Your Feedback</h3>
+                <p style="margin: 0 0 15px 0;">Help us improve by taking a quick survey about this change.</p>`)
+		sb.WriteString("\n")
+
+		sb.WriteString(fmt.Sprintf(`                <div style="margin-bottom: 15px;">
+                    <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #0066cc; color: white; text-decoration: none; border-radius: 4px; font-weight: bold;">Take Survey</a>
+                </div>`, data.SurveyURL))
+		sb.WriteString("\n")
+
+		if data.SurveyQRCode != "" {
+			sb.WriteString(fmt.Sprintf(`                <div style="margin-top: 15px;">
+                    <p style="margin: 0 0 10px 0; font-size: 0.9em; color: #666;">Or scan this QR code:</p>
+                    <img src="data:image/png;base64,%s" alt="Survey QR Code" style="width: 150px; height: 150px; border: 1px solid #ddd; padding: 5px; background: white;" />
+                </div>`, data.SurveyQRCode))
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString(`            </div>`)
+		sb.WriteString("\n")
+	}
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(fmt.Sprintf(`            <p style="font-weight: bold; margin-bottom: 15px;">%s</p>`, formatContentForHTML(data.Summary)))
+		sb.WriteString("\n")
+	}
+
+	// Content
+	if data.Content != "" {
+		sb.WriteString(fmt.Sprintf(`            <div style="margin-bottom: 20px;">%s</div>`, formatContentForHTML(data.Content)))
+		sb.WriteString("\n")
+	}
+
+	// Rollback plan is only relevant once the implementation has gone wrong
+	if data.RollbackPlan != "" && notificationType != NotificationInProgress {
+		sb.WriteString(fmt.Sprintf(`            <div style="margin-bottom: 20px;">
+                <h3 style="font-size: 1em; color: #495057; margin: 0 0 10px 0;">Rollback Plan</h3>
+                <div>%s</div>
+            </div>`, formatContentForHTML(data.RollbackPlan)))
+		sb.WriteString("\n")
+	}
+
+	// Attachments
+	if len(data.Attachments) > 0 {
+		sb.WriteString("            ")
+		sb.WriteString(renderAttachments(data.Attachments))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(`        </div>` + "\n")
+
+	// Footer
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLFooter(data.EventID, data.EventType, baseURL))
+	sb.WriteString("\n")
+
+	// SES Macro
+	sb.WriteString("        ")
+	sb.WriteString(renderSESMacro(data.Timestamp))
+	sb.WriteString("\n")
+
+	sb.WriteString(`    </div>` + "\n")
+
+	// Hidden metadata (at end for email client compatibility)
+	sb.WriteString("    ")
+	sb.WriteString(renderHiddenMetadata(data.EventID, data.EventType, string(notificationType)))
+	sb.WriteString("\n")
+
+	sb.WriteString(`</body>
+</html>`)
+
+	return sb.String()
+}
+
+// buildImplementationUpdateText builds the plain text body for implementation update notifications
+func buildImplementationUpdateText(data ImplementationUpdateData, notificationType NotificationType, emoji string, baseURL string) string {
+	style := implementationBoxStyles[notificationType]
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(renderTextHeader(emoji, data.Title))
+
+	// Status
+	sb.WriteString(renderTextStatusLine(data.Status))
+
+	// Status details
+	sb.WriteString(fmt.Sprintf("%s:\n", style.Heading))
+	if data.UpdatedBy != "" {
+		sb.WriteString(fmt.Sprintf("  By: %s", data.UpdatedBy))
+		if data.UpdatedByEmail != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", data.UpdatedByEmail))
+		}
+		sb.WriteString("\n")
+	}
+	if !data.UpdatedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("  At: %s\n", data.UpdatedAt.Format("2006-01-02 15:04 MST")))
+	}
+	if window := formatImplementationWindow(data.WindowStart, data.WindowEnd, data.WindowTimezone); window != "" {
+		sb.WriteString(fmt.Sprintf("  Scheduled Window: %s\n", window))
+	}
+	if data.Reason != "" {
+		sb.WriteString(fmt.Sprintf("  Reason: %s\n", data.Reason))
+	}
+	sb.WriteString("\n")
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(data.Summary)
+		sb.WriteString("\n\n")
+	}
+
+	// Content
+	if data.Content != "" {
+		sb.WriteString(data.Content)
+		sb.WriteString("\n\n")
+	}
+
+	// Rollback plan
+	if data.RollbackPlan != "" && notificationType != NotificationInProgress {
+		sb.WriteString("Rollback Plan:\n")
+		sb.WriteString(data.RollbackPlan)
+		sb.WriteString("\n\n")
+	}
+
+	// Survey section
+	if data.SurveyURL != "" {
+		sb.WriteString("📋 Share Your Feedback:\n")
+		sb.WriteString("Help us improve by taking a quick survey about this change.\n")
+		sb.WriteString(fmt.Sprintf("Survey: %s\n\n", data.SurveyURL))
+	}
+
+	// Attachments
+	sb.WriteString(renderTextAttachments(data.Attachments))
+
+	// Footer
+	sb.WriteString(renderTextFooter(data.EventID, data.EventType, baseURL, data.Timestamp))
+
+	return sb.String()
+}
+
+// formatImplementationWindow formats the scheduled implementation window in the change's timezone
+func formatImplementationWindow(start, end time.Time, timezone string) string {
+	if start.IsZero() || end.IsZero() {
+		return ""
+	}
+
+	if timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			start = start.In(loc)
+			end = end.In(loc)
+		}
+	}
+
+	return fmt.Sprintf("%s - %s", start.Format("2006-01-02 15:04 MST"), end.Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,82 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestImplementationUpdateTemplates(t *testing.T) {
+	registry := NewTemplateRegistry(types.EmailConfig{PortalBaseURL: "https://portal.example.com"})
+
+	testCases := []struct {
+		name             string
+		notificationType NotificationType
+		status           string
+		expectedEmoji    string
+		expectedHeading  string
+		expectSurvey     bool
+	}{
+		{"in progress", NotificationInProgress, "in_progress", EmojiInProgress, "Implementation Started", false},
+		{"failed", NotificationFailed, "failed", EmojiFailed, "Implementation Failed", true},
+		{"rolled back", NotificationRolledBack, "rolled_back", EmojiRolledBack, "Rolled Back", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := ImplementationUpdateData{
+				BaseTemplateData: BaseTemplateData{
+					EventID:   "CHG-123",
+					EventType: "change",
+					Category:  "change",
+					Status:    tc.status,
+					Title:     "Upgrade <database>",
+					Timestamp: time.Now(),
+				},
+				UpdatedBy:    "jdoe",
+				Reason:       "Replication lag exceeded threshold",
+				RollbackPlan: "Restore snapshot",
+				WindowStart:  time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC),
+				WindowEnd:    time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC),
+			}
+			if tc.expectSurvey {
+				data.SurveyURL = "https://form.typeform.com/to/abc"
+			}
+
+			template, err := registry.GetTemplate("change", tc.notificationType, data)
+			if err != nil {
+				t.Fatalf("GetTemplate returned error: %v", err)
+			}
+
+			if !strings.HasPrefix(template.Subject, tc.expectedEmoji) {
+				t.Errorf("Expected subject to start with %s, got %q", tc.expectedEmoji, template.Subject)
+			}
+			if !strings.Contains(template.HTMLBody, tc.expectedHeading) {
+				t.Errorf("Expected HTML body to contain %q", tc.expectedHeading)
+			}
+			if strings.Contains(template.HTMLBody, "<database>") {
+				t.Error("Expected title to be HTML-escaped")
+			}
+			if !strings.Contains(template.TextBody, "Replication lag exceeded threshold") {
+				t.Error("Expected text body to contain the status reason")
+			}
+			if hasSurvey := strings.Contains(template.HTMLBody, "Take Survey"); hasSurvey != tc.expectSurvey {
+				t.Errorf("Expected survey section present=%v, got %v", tc.expectSurvey, hasSurvey)
+			}
+			if hasRollback := strings.Contains(template.TextBody, "Rollback Plan:"); hasRollback == (tc.notificationType == NotificationInProgress) {
+				t.Errorf("Unexpected rollback plan presence %v for %s", hasRollback, tc.notificationType)
+			}
+		})
+	}
+}
+
+func TestImplementationUpdateRejectsWrongDataType(t *testing.T) {
+	registry := NewTemplateRegistry(types.EmailConfig{})
+
+	_, err := registry.GetTemplate("announcement", NotificationFailed, CompletionData{})
+	if err == nil {
+		t.Error("Expected error for mismatched data type")
+	}
+}
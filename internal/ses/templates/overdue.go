@@ -0,0 +1,214 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// OverdueReminderData contains data for reminders sent to the creator of an approved change
+// whose implementation window has passed without the change being completed or cancelled
+type OverdueReminderData struct {
+	BaseTemplateData
+	ImplementationEnd time.Time
+	Timezone          string
+	OverdueBy         time.Duration
+	Escalated         bool      // Second (escalation) reminder
+	AutoCompleteAt    time.Time // Zero if auto-completion is disabled
+}
+
+// BuildOverdueReminder builds the reminder (or escalation) email for an overdue change
+func BuildOverdueReminder(data OverdueReminderData, config types.EmailConfig) EmailTemplate {
+	notificationType := NotificationOverdue
+	if data.Escalated {
+		notificationType = NotificationOverdueEscalate
+	}
+
+	emoji := GetEmojiForNotification(notificationType, CategoryChange)
+	subject := buildSubject(emoji, fmt.Sprintf("%s: %s", getStatusWordForNotification(notificationType), data.Title))
+	editURL := fmt.Sprintf("%s/edit-change.html?changeId=%s", config.PortalBaseURL, data.EventID)
+
+	return EmailTemplate{
+		Subject:  sanitizeSubject(subject),
+		HTMLBody: buildOverdueReminderHTML(data, notificationType, editURL, config.PortalBaseURL),
+		TextBody: buildOverdueReminderText(data, emoji, editURL, config.PortalBaseURL),
+	}
+}
+
+// buildOverdueReminderHTML builds the HTML body for the overdue reminder
+func buildOverdueReminderHTML(data OverdueReminderData, notificationType NotificationType, editURL string, baseURL string) string {
+	headerColor := "#fd7e14" // Orange
+	if data.Escalated {
+		headerColor = "#dc3545" // Red
+	}
+
+	var sb strings.Builder
+
+	// HTML structure
+	sb.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            margin: 0;
+            padding: 0;
+        }
+        .email-container {
+            max-width: 600px;
+            margin: 0 auto;
+        }
+        .content {
+            padding: 20px;
+            background-color: #ffffff;
+        }
+        @media only screen and (max-width: 600px) {
+            .email-container {
+                width: 100% !important;
+            }
+        }
+    </style>
+</head>
+<body>
+`)
+
+	// Email container
+	sb.WriteString(`    <div class="email-container">` + "\n")
+
+	// Header
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLHeader(getStatusWordForNotification(notificationType), data.Title, headerColor))
+	sb.WriteString("\n")
+
+	// Content section
+	sb.WriteString(`        <div class="content">` + "\n")
+
+	// Status subtitle
+	sb.WriteString("            ")
+	sb.WriteString(renderStatusSubtitle(data.Status))
+	sb.WriteString("\n")
+
+	// Explanation
+	sb.WriteString(fmt.Sprintf(`            <p>This change is still marked <strong>Approved</strong>, but its implementation window ended %s ago (%s).</p>`,
+		html.EscapeString(formatOverdueDuration(data.OverdueBy)),
+		html.EscapeString(formatImplementationEnd(data.ImplementationEnd, data.Timezone))))
+	sb.WriteString("\n")
+	sb.WriteString(`            <p>Please mark it as <strong>completed</strong> or <strong>cancelled</strong> so dashboards and notifications stay accurate.</p>`)
+	sb.WriteString("\n")
+
+	if data.Escalated {
+		sb.WriteString(`            <div style="margin-top: 20px; padding: 15px; background-color: #f8d7da; border-left: 4px solid #dc3545;">
+                <strong>This reminder has been escalated</strong> because the change is still open after the first reminder.
+            </div>`)
+		sb.WriteString("\n")
+	}
+
+	if !data.AutoCompleteAt.IsZero() {
+		sb.WriteString(fmt.Sprintf(`            <p style="color: #6c757d;">If no action is taken, the change will be marked <em>Completed (Unconfirmed)</em> on %s.</p>`,
+			html.EscapeString(formatImplementationEnd(data.AutoCompleteAt, data.Timezone))))
+		sb.WriteString("\n")
+	}
+
+	// Action button
+	sb.WriteString(fmt.Sprintf(`            <div style="margin: 20px 0;">
+                <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #0066cc; color: white; text-decoration: none; border-radius: 4px; font-weight: bold;">Update Change</a>
+            </div>`, html.EscapeString(editURL)))
+	sb.WriteString("\n")
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(fmt.Sprintf(`            <p style="font-weight: bold; margin-bottom: 15px;">%s</p>`, formatContentForHTML(data.Summary)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(`        </div>` + "\n")
+
+	// Footer
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLFooter(data.EventID, data.EventType, baseURL))
+	sb.WriteString("\n")
+
+	sb.WriteString(`    </div>` + "\n")
+
+	// Hidden metadata (at end for email client compatibility)
+	sb.WriteString("    ")
+	sb.WriteString(renderHiddenMetadata(data.EventID, data.EventType, string(notificationType)))
+	sb.WriteString("\n")
+
+	sb.WriteString(`</body>
+</html>`)
+
+	return sb.String()
+}
+
+// buildOverdueReminderText builds the plain text body for the overdue reminder
+func buildOverdueReminderText(data OverdueReminderData, emoji string, editURL string, baseURL string) string {
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(renderTextHeader(emoji, data.Title))
+
+	// Status
+	sb.WriteString(renderTextStatusLine(data.Status))
+
+	sb.WriteString(fmt.Sprintf("This change is still marked Approved, but its implementation window ended %s ago (%s).\n",
+		formatOverdueDuration(data.OverdueBy), formatImplementationEnd(data.ImplementationEnd, data.Timezone)))
+	sb.WriteString("Please mark it as completed or cancelled so dashboards and notifications stay accurate.\n\n")
+
+	if data.Escalated {
+		sb.WriteString("This reminder has been escalated because the change is still open after the first reminder.\n\n")
+	}
+
+	if !data.AutoCompleteAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("If no action is taken, the change will be marked Completed (Unconfirmed) on %s.\n\n",
+			formatImplementationEnd(data.AutoCompleteAt, data.Timezone)))
+	}
+
+	sb.WriteString(fmt.Sprintf("Update the change: %s\n\n", editURL))
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(data.Summary)
+		sb.WriteString("\n\n")
+	}
+
+	// Footer (no SES unsubscribe macro - this is a direct, transactional email)
+	sb.WriteString(strings.Repeat("-", 70))
+	sb.WriteString("\n")
+	sb.WriteString(buildTaglineText(data.EventID, data.EventType, baseURL))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// formatOverdueDuration formats an overdue duration in days and hours
+func formatOverdueDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+
+	switch {
+	case days == 0:
+		return fmt.Sprintf("%d hours", hours)
+	case days == 1:
+		return fmt.Sprintf("1 day %d hours", hours)
+	default:
+		return fmt.Sprintf("%d days %d hours", days, hours)
+	}
+}
+
+// formatImplementationEnd formats a timestamp in the change's timezone when it is known
+func formatImplementationEnd(t time.Time, timezone string) string {
+	if timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			t = t.In(loc)
+		}
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
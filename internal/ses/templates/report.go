@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// ReportMetric is a single headline number in a report
+type ReportMetric struct {
+	Label string
+	Value string
+}
+
+// ReportSection is a titled table in a report
+type ReportSection struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+	Empty   string // Shown when the section has no rows
+}
+
+// ReportData contains data for periodic report emails
+type ReportData struct {
+	Title       string
+	Subtitle    string
+	Metrics     []ReportMetric
+	Sections    []ReportSection
+	GeneratedAt time.Time
+}
+
+// BuildReport builds a report email (also used as a standalone HTML document)
+func BuildReport(data ReportData) EmailTemplate {
+	subject := buildSubject(EmojiReport, data.Title)
+	if data.Subtitle != "" {
+		subject = fmt.Sprintf("%s (%s)", subject, data.Subtitle)
+	}
+
+	return EmailTemplate{
+		Subject:  sanitizeSubject(subject),
+		HTMLBody: buildReportHTML(data),
+		TextBody: buildReportText(data),
+	}
+}
+
+// buildReportHTML builds the HTML body for a report
+func buildReportHTML(data ReportData) string {
+	var sb strings.Builder
+
+	// HTML structure
+	sb.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            margin: 0;
+            padding: 0;
+        }
+        .email-container {
+            max-width: 800px;
+            margin: 0 auto;
+        }
+        .content {
+            padding: 20px;
+            background-color: #ffffff;
+        }
+        table {
+            border-collapse: collapse;
+            width: 100%;
+            margin-bottom: 20px;
+            font-size: 0.9em;
+        }
+        th, td {
+            border: 1px solid #dee2e6;
+            padding: 6px 10px;
+            text-align: left;
+            vertical-align: top;
+        }
+        th {
+            background-color: #f8f9fa;
+        }
+        @media only screen and (max-width: 600px) {
+            .email-container {
+                width: 100% !important;
+            }
+        }
+    </style>
+</head>
+<body>
+`)
+
+	// Email container
+	sb.WriteString(`    <div class="email-container">` + "\n")
+
+	// Header
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLHeader("Report", data.Title, "#0066cc"))
+	sb.WriteString("\n")
+
+	// Content section
+	sb.WriteString(`        <div class="content">` + "\n")
+
+	if data.Subtitle != "" {
+		sb.WriteString(fmt.Sprintf(`            <div class="status-subtitle" style="color: #6c757d; font-size: 0.9em; margin-bottom: 15px;">%s</div>`, html.EscapeString(data.Subtitle)))
+		sb.WriteString("\n")
+	}
+
+	// Headline metrics
+	if len(data.Metrics) > 0 {
+		sb.WriteString(`            <div style="margin-bottom: 20px;">` + "\n")
+		for _, metric := range data.Metrics {
+			sb.WriteString(fmt.Sprintf(`                <div style="display: inline-block; min-width: 140px; margin: 0 10px 10px 0; padding: 10px 15px; background-color: #e7f3ff; border-left: 4px solid #0066cc;">
+                    <div style="font-size: 1.4em; font-weight: bold; color: #004085;">%s</div>
+                    <div style="font-size: 0.85em; color: #666;">%s</div>
+                </div>`, html.EscapeString(metric.Value), html.EscapeString(metric.Label)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(`            </div>` + "\n")
+	}
+
+	// Sections
+	for _, section := range data.Sections {
+		sb.WriteString(fmt.Sprintf(`            <h3 style="font-size: 1em; color: #495057; margin: 20px 0 10px 0;">%s</h3>`, html.EscapeString(section.Title)))
+		sb.WriteString("\n")
+
+		if len(section.Rows) == 0 {
+			empty := section.Empty
+			if empty == "" {
+				empty = "None"
+			}
+			sb.WriteString(fmt.Sprintf(`            <p style="color: #6c757d;">%s</p>`, html.EscapeString(empty)))
+			sb.WriteString("\n")
+			continue
+		}
+
+		sb.WriteString("            <table>\n                <tr>")
+		for _, column := range section.Columns {
+			sb.WriteString(fmt.Sprintf("<th>%s</th>", html.EscapeString(column)))
+		}
+		sb.WriteString("</tr>\n")
+		for _, row := range section.Rows {
+			sb.WriteString("                <tr>")
+			for _, cell := range row {
+				sb.WriteString(fmt.Sprintf("<td>%s</td>", formatContentForHTML(cell)))
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("            </table>\n")
+	}
+
+	sb.WriteString(`        </div>` + "\n")
+
+	// Footer
+	sb.WriteString(fmt.Sprintf(`        <div class="footer" style="background-color: #f5f5f5; padding: 15px 20px; font-size: 0.9em; color: #666;">
+            <p style="margin: 0;">Generated %s by the <a href="https://github.com/hts-ccoe-source/ccoe-customer-contact-manager" style="color: #007bff; text-decoration: none;">CCOE customer contact manager</a></p>
+        </div>`, html.EscapeString(data.GeneratedAt.Format("2006-01-02 15:04 MST"))))
+	sb.WriteString("\n")
+
+	// SES Macro
+	sb.WriteString("        ")
+	sb.WriteString(renderSESMacro(data.GeneratedAt))
+	sb.WriteString("\n")
+
+	sb.WriteString(`    </div>` + "\n")
+	sb.WriteString(`</body>
+</html>`)
+
+	return sb.String()
+}
+
+// buildReportText builds the plain text body for a report
+func buildReportText(data ReportData) string {
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(renderTextHeader(EmojiReport, data.Title))
+
+	if data.Subtitle != "" {
+		sb.WriteString(data.Subtitle)
+		sb.WriteString("\n\n")
+	}
+
+	for _, metric := range data.Metrics {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", metric.Label, metric.Value))
+	}
+	if len(data.Metrics) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, section := range data.Sections {
+		sb.WriteString(fmt.Sprintf("%s:\n", section.Title))
+		if len(section.Rows) == 0 {
+			empty := section.Empty
+			if empty == "" {
+				empty = "None"
+			}
+			sb.WriteString(fmt.Sprintf("  %s\n\n", empty))
+			continue
+		}
+		for _, row := range section.Rows {
+			sb.WriteString(fmt.Sprintf("  - %s\n", strings.Join(row, " | ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Repeat("-", 70))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("Generated %s by the CCOE customer contact manager\n\n", data.GeneratedAt.Format("2006-01-02 15:04 MST")))
+	sb.WriteString("Manage Email Preferences or Unsubscribe: {{amazonSESUnsubscribeUrl}}\n")
+
+	return sb.String()
+}
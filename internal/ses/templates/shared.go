@@ -55,6 +55,18 @@ func getStatusWordForNotification(notificationType NotificationType) string {
 		return "Cancelled"
 	case NotificationMeeting:
 		return "Meeting Invitation"
+	case NotificationInProgress:
+		return "Implementation Started"
+	case NotificationFailed:
+		return "Implementation Failed"
+	case NotificationRolledBack:
+		return "Rolled Back"
+	case NotificationOverdue:
+		return "Action Required"
+	case NotificationOverdueEscalate:
+		return "Overdue"
+	case NotificationSurveyReminder:
+		return "Survey Reminder"
 	default:
 		return "Notification"
 	}
@@ -197,13 +209,16 @@ func buildSubject(emoji string, title string) string {
 // getStatusDisplay maps internal status codes to user-friendly display text
 func getStatusDisplay(status string) string {
 	statusMap := map[string]string{
-		"pending_approval": "Pending Approval",
-		"approved":         "Approved",
-		"completed":        "Completed",
-		"cancelled":        "Cancelled",
-		"in_progress":      "In Progress",
-		"scheduled":        "Scheduled",
-		"draft":            "Draft",
+		"pending_approval":      "Pending Approval",
+		"approved":              "Approved",
+		"completed":             "Completed",
+		"cancelled":             "Cancelled",
+		"in_progress":           "In Progress",
+		"failed":                "Failed",
+		"rolled_back":           "Rolled Back",
+		"completed_unconfirmed": "Completed (Unconfirmed)",
+		"scheduled":             "Scheduled",
+		"draft":                 "Draft",
 	}
 
 	if display, ok := statusMap[status]; ok {
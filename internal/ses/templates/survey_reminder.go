@@ -0,0 +1,158 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// SurveyReminderData contains data for the single reminder sent to recipients of a change
+// completion email who have not yet answered its survey
+type SurveyReminderData struct {
+	BaseTemplateData
+	SurveyURL string    // Typeform survey URL personalised for the recipient
+	ClosesAt  time.Time // Zero if the form is not closed automatically
+}
+
+// BuildSurveyReminder builds the survey reminder email for a change
+func BuildSurveyReminder(data SurveyReminderData, config types.EmailConfig) EmailTemplate {
+	emoji := GetEmojiForNotification(NotificationSurveyReminder, CategoryChange)
+	subject := buildSubject(emoji, fmt.Sprintf("%s: %s", getStatusWordForNotification(NotificationSurveyReminder), data.Title))
+
+	return EmailTemplate{
+		Subject:  sanitizeSubject(subject),
+		HTMLBody: buildSurveyReminderHTML(data, config.PortalBaseURL),
+		TextBody: buildSurveyReminderText(data, emoji, config.PortalBaseURL),
+	}
+}
+
+// buildSurveyReminderHTML builds the HTML body for the survey reminder
+func buildSurveyReminderHTML(data SurveyReminderData, baseURL string) string {
+	headerColor := "#0066cc" // Blue
+
+	var sb strings.Builder
+
+	// HTML structure
+	sb.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            margin: 0;
+            padding: 0;
+        }
+        .email-container {
+            max-width: 600px;
+            margin: 0 auto;
+        }
+        .content {
+            padding: 20px;
+            background-color: #ffffff;
+        }
+        @media only screen and (max-width: 600px) {
+            .email-container {
+                width: 100% !important;
+            }
+        }
+    </style>
+</head>
+<body>
+`)
+
+	// Email container
+	sb.WriteString(`    <div class="email-container">` + "\n")
+
+	// Header
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLHeader(getStatusWordForNotification(NotificationSurveyReminder), data.Title, headerColor))
+	sb.WriteString("\n")
+
+	// Content section
+	sb.WriteString(`        <div class="content">` + "\n")
+
+	// Status subtitle
+	sb.WriteString("            ")
+	sb.WriteString(renderStatusSubtitle(data.Status))
+	sb.WriteString("\n")
+
+	// Explanation
+	sb.WriteString(`            <p>We haven't received your feedback on this change yet. The survey takes about a minute and helps us improve how changes are planned and communicated.</p>`)
+	sb.WriteString("\n")
+
+	// Action button
+	sb.WriteString(fmt.Sprintf(`            <div style="margin: 20px 0;">
+                <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #0066cc; color: white; text-decoration: none; border-radius: 4px; font-weight: bold;">Take Survey</a>
+            </div>`, html.EscapeString(data.SurveyURL)))
+	sb.WriteString("\n")
+
+	if !data.ClosesAt.IsZero() {
+		sb.WriteString(fmt.Sprintf(`            <p style="color: #6c757d;">The survey closes on %s. This is the only reminder you will receive.</p>`,
+			html.EscapeString(data.ClosesAt.Format("2006-01-02"))))
+		sb.WriteString("\n")
+	}
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(fmt.Sprintf(`            <p style="font-weight: bold; margin-bottom: 15px;">%s</p>`, formatContentForHTML(data.Summary)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(`        </div>` + "\n")
+
+	// Footer
+	sb.WriteString("        ")
+	sb.WriteString(renderHTMLFooter(data.EventID, data.EventType, baseURL))
+	sb.WriteString("\n")
+
+	sb.WriteString(`    </div>` + "\n")
+
+	// Hidden metadata (at end for email client compatibility)
+	sb.WriteString("    ")
+	sb.WriteString(renderHiddenMetadata(data.EventID, data.EventType, string(NotificationSurveyReminder)))
+	sb.WriteString("\n")
+
+	sb.WriteString(`</body>
+</html>`)
+
+	return sb.String()
+}
+
+// buildSurveyReminderText builds the plain text body for the survey reminder
+func buildSurveyReminderText(data SurveyReminderData, emoji string, baseURL string) string {
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString(renderTextHeader(emoji, data.Title))
+
+	// Status
+	sb.WriteString(renderTextStatusLine(data.Status))
+
+	sb.WriteString("We haven't received your feedback on this change yet. The survey takes about a minute and helps us improve how changes are planned and communicated.\n\n")
+	sb.WriteString(fmt.Sprintf("Survey: %s\n\n", data.SurveyURL))
+
+	if !data.ClosesAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("The survey closes on %s. This is the only reminder you will receive.\n\n", data.ClosesAt.Format("2006-01-02")))
+	}
+
+	// Summary
+	if data.Summary != "" {
+		sb.WriteString(data.Summary)
+		sb.WriteString("\n\n")
+	}
+
+	// Footer (no SES unsubscribe macro - this is a direct, transactional email)
+	sb.WriteString(strings.Repeat("-", 70))
+	sb.WriteString("\n")
+	sb.WriteString(buildTaglineText(data.EventID, data.EventType, baseURL))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
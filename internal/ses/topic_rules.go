@@ -0,0 +1,278 @@
+package ses
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+// Topic decision sources, from lowest to highest precedence
+const (
+	TopicSourceDefault    = "default"      // DefaultSubscriptionStatus OPT_IN
+	TopicSourceOptInRoles = "opt_in_roles" // OptInRoles role mapping
+	TopicSourceRule       = "rule"         // topic_rules entry
+)
+
+// TopicDecision records why a topic was or was not assigned to a user
+type TopicDecision struct {
+	Topic     string
+	Included  bool
+	Source    string
+	Rule      string // Rule name, for rule decisions
+	Reason    string // What matched, e.g. "role admin in group ccoe-cloud-..."
+	Overrides string // The source a rule decision overrode, if any
+}
+
+// TopicExplanation is the result of evaluating a user's topics
+type TopicExplanation struct {
+	Topics    []string // Assigned topics, in assignment order
+	Decisions []TopicDecision
+}
+
+// regexCache holds compiled group_regex and group pattern regexes, which are evaluated for every user
+var regexCache sync.Map
+
+// ExplainUserTopics determines a user's topics and records which default, role mapping or rule
+// produced each one. Defaults apply to active users (or all users when active users are not
+// required), role mappings to users with a parsed group (see ParseGroup) for an opted-in role, and rules
+// override both: for each topic the matching rule with the highest priority decides.
+func ExplainUserTopics(user types.IdentityCenterUser, membership *types.IdentityCenterGroupMembership, config types.ContactImportConfig) TopicExplanation {
+	var topics []string
+	decisions := make(map[string]*TopicDecision)
+	var order []string
+
+	decide := func(decision TopicDecision) {
+		if existing, ok := decisions[decision.Topic]; ok {
+			if existing.Source == decision.Source || decision.Source != TopicSourceRule {
+				return
+			}
+			decision.Overrides = existing.Source
+		} else {
+			order = append(order, decision.Topic)
+		}
+		decisions[decision.Topic] = &decision
+	}
+
+	// Add default topics for all active users
+	if !config.RequireActiveUsers || user.Active {
+		for _, topic := range config.DefaultTopics {
+			decide(TopicDecision{Topic: topic, Included: true, Source: TopicSourceDefault, Reason: "subscribed by default"})
+		}
+	}
+
+	var groups []string
+	if membership != nil {
+		groups = membership.Groups
+	}
+	parsedGroups := parseGroups(groups, config.GroupPatterns)
+
+	// Check each role mapping against the roles parsed from the user's groups
+	for _, mapping := range config.RoleMappings {
+		for _, group := range parsedGroups {
+			if !containsFold(mapping.Roles, group.RoleName) {
+				continue
+			}
+			for _, topic := range mapping.Topics {
+				decide(TopicDecision{Topic: topic, Included: true, Source: TopicSourceOptInRoles,
+					Reason: fmt.Sprintf("role %s in group %s", group.RoleName, group.GroupName)})
+			}
+			break
+		}
+	}
+
+	// Rules, highest priority first; the first matching rule decides each topic
+	ruleDecided := make(map[string]bool)
+	for _, rule := range sortedTopicRules(config.Rules) {
+		reason, ok := matchTopicRule(rule, user, groups, parsedGroups)
+		if !ok {
+			continue
+		}
+		for _, topic := range rule.Topics {
+			if ruleDecided[topic] {
+				continue
+			}
+			ruleDecided[topic] = true
+			decide(TopicDecision{Topic: topic, Included: rule.GetAction() == types.TopicRuleInclude, Source: TopicSourceRule,
+				Rule: rule.Name, Reason: reason})
+		}
+	}
+
+	explanation := TopicExplanation{}
+	for _, topic := range order {
+		decision := decisions[topic]
+		if decision.Included {
+			topics = append(topics, topic)
+		}
+		explanation.Decisions = append(explanation.Decisions, *decision)
+	}
+	explanation.Topics = topics
+	return explanation
+}
+
+// ValidateTopicRules checks that every rule has topics, a known action and valid patterns
+func ValidateTopicRules(rules []types.TopicRule) error {
+	var problems []string
+	for i, rule := range rules {
+		name := topicRuleName(rule, i)
+		if len(rule.Topics) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: topics is required", name))
+		}
+		if action := rule.GetAction(); action != types.TopicRuleInclude && action != types.TopicRuleExclude {
+			problems = append(problems, fmt.Sprintf("%s: action must be include or exclude, got %q", name, rule.Action))
+		}
+		for _, pattern := range rule.AccountNamePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid account name pattern %q", name, pattern))
+			}
+		}
+		if rule.GroupRegex != "" {
+			if _, err := regexp.Compile(rule.GroupRegex); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid group_regex: %v", name, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid topic_rules:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// sortedTopicRules orders rules by descending priority, keeping config order for ties, and
+// names unnamed rules by position
+func sortedTopicRules(rules []types.TopicRule) []types.TopicRule {
+	sorted := make([]types.TopicRule, len(rules))
+	for i, rule := range rules {
+		rule.Name = topicRuleName(rule, i)
+		sorted[i] = rule
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
+
+func topicRuleName(rule types.TopicRule, index int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return fmt.Sprintf("topic_rules[%d]", index)
+}
+
+// matchTopicRule reports whether a user meets every condition of a rule, and describes what matched
+func matchTopicRule(rule types.TopicRule, user types.IdentityCenterUser, groups []string, parsedGroups []CCOECloudGroupParseResult) (string, bool) {
+	var reasons []string
+
+	if len(rule.Emails) > 0 {
+		if !containsFold(rule.Emails, user.Email) {
+			return "", false
+		}
+		reasons = append(reasons, "email "+user.Email)
+	}
+
+	if len(rule.EmailDomains) > 0 {
+		_, domain, _ := strings.Cut(user.Email, "@")
+		if !containsFold(rule.EmailDomains, domain) {
+			return "", false
+		}
+		reasons = append(reasons, "email domain "+domain)
+	}
+
+	if rule.GroupRegex != "" {
+		re := compileGroupRegex(rule.GroupRegex)
+		matched := ""
+		for _, group := range groups {
+			if re != nil && re.MatchString(group) {
+				matched = group
+				break
+			}
+		}
+		if matched == "" {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("group %s matches /%s/", matched, rule.GroupRegex))
+	}
+
+	if rule.HasGroupConditions() {
+		matched := ""
+		for _, group := range parsedGroups {
+			if matchesGroupConditions(rule, group) {
+				matched = group.GroupName
+				break
+			}
+		}
+		if matched == "" {
+			return "", false
+		}
+		reasons = append(reasons, "group "+matched)
+	}
+
+	if len(reasons) == 0 {
+		return "applies to all users", true
+	}
+	return strings.Join(reasons, ", "), true
+}
+
+// matchesGroupConditions checks a parsed group against the rule's group conditions
+func matchesGroupConditions(rule types.TopicRule, group CCOECloudGroupParseResult) bool {
+	if len(rule.Roles) > 0 && !containsFold(rule.Roles, group.RoleName) {
+		return false
+	}
+	if len(rule.Environments) > 0 && !containsFold(rule.Environments, group.Environment) {
+		return false
+	}
+	if len(rule.ApplicationPrefixes) > 0 && !containsFold(rule.ApplicationPrefixes, group.ApplicationPrefix) {
+		return false
+	}
+	if len(rule.AccountNamePatterns) > 0 {
+		matched := false
+		for _, pattern := range rule.AccountNamePatterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(group.AccountName)); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// parseGroups returns the user's groups that a group pattern or the ccoe-cloud format can parse
+func parseGroups(groups []string, patterns []types.GroupPattern) []CCOECloudGroupParseResult {
+	var parsed []CCOECloudGroupParseResult
+	for _, group := range groups {
+		if result := ParseGroup(group, patterns); result.IsValid {
+			parsed = append(parsed, result)
+		}
+	}
+	return parsed
+}
+
+func compileGroupRegex(pattern string) *regexp.Regexp {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// ValidateTopicRules reports this; an invalid pattern matches nothing
+		return nil
+	}
+	regexCache.Store(pattern, re)
+	return re
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
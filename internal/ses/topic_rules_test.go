@@ -0,0 +1,119 @@
+package ses
+
+import (
+	"reflect"
+	"testing"
+
+	"ccoe-customer-contact-manager/internal/types"
+)
+
+func TestParseCCOECloudGroupEnvironment(t *testing.T) {
+	parsed := ParseCCOECloudGroup("ccoe-cloud-hts-prod-123456789012-idp-hts-admin")
+	if !parsed.IsValid || parsed.AccountName != "hts-prod" || parsed.Environment != "prod" || parsed.ApplicationPrefix != "hts" || parsed.RoleName != "admin" {
+		t.Errorf("Unexpected parse result: %+v", parsed)
+	}
+
+	if parsed := ParseCCOECloudGroup("ccoe-cloud-sandbox-123456789012-idp-hts-admin"); parsed.Environment != "" {
+		t.Errorf("Expected no environment for a single-part account name, got %q", parsed.Environment)
+	}
+}
+
+func TestExplainUserTopics(t *testing.T) {
+	config := types.ContactImportConfig{
+		DefaultTopics:      []string{"announce"},
+		RequireActiveUsers: true,
+		RoleMappings: []types.RoleTopicMapping{
+			{Roles: []string{"security"}, Topics: []string{"approval"}},
+		},
+		Rules: []types.TopicRule{
+			{Name: "prod-admins", Topics: []string{"calendar"}, Roles: []string{"admin"}, Environments: []string{"prod"}},
+			{Name: "contractors", Topics: []string{"announce", "calendar"}, Action: types.TopicRuleExclude, EmailDomains: []string{"contractor.example.com"}, Priority: 10},
+			{Name: "finops", Topics: []string{"finops-announce"}, GroupRegex: "^finance-"},
+			{Name: "hts-apps", Topics: []string{"cic-announce"}, AccountNamePatterns: []string{"hts-*"}, ApplicationPrefixes: []string{"web"}},
+			{Name: "vip", Topics: []string{"approval"}, Emails: []string{"Boss@Example.com"}},
+		},
+	}
+
+	user := types.IdentityCenterUser{Email: "jane@example.com", Active: true}
+	membership := &types.IdentityCenterGroupMembership{Groups: []string{
+		"ccoe-cloud-hts-prod-123456789012-idp-hts-admin",
+		"ccoe-cloud-hts-nonprod-210987654321-idp-web-security",
+		"finance-readers",
+	}}
+
+	explanation := ExplainUserTopics(user, membership, config)
+	want := []string{"announce", "approval", "calendar", "finops-announce", "cic-announce"}
+	if !reflect.DeepEqual(explanation.Topics, want) {
+		t.Errorf("Topics = %v, want %v", explanation.Topics, want)
+	}
+
+	sources := make(map[string]TopicDecision)
+	for _, decision := range explanation.Decisions {
+		sources[decision.Topic] = decision
+	}
+	if d := sources["approval"]; d.Source != TopicSourceOptInRoles || d.Reason != "role security in group ccoe-cloud-hts-nonprod-210987654321-idp-web-security" {
+		t.Errorf("Unexpected approval decision: %+v", d)
+	}
+	if d := sources["calendar"]; d.Source != TopicSourceRule || d.Rule != "prod-admins" {
+		t.Errorf("Unexpected calendar decision: %+v", d)
+	}
+
+	// Exclusions outrank defaults and lower-priority includes
+	contractor := types.IdentityCenterUser{Email: "sam@contractor.example.com", Active: true}
+	explanation = ExplainUserTopics(contractor, membership, config)
+	want = []string{"approval", "finops-announce", "cic-announce"}
+	if !reflect.DeepEqual(explanation.Topics, want) {
+		t.Errorf("Contractor topics = %v, want %v", explanation.Topics, want)
+	}
+	for _, decision := range explanation.Decisions {
+		if decision.Topic == "announce" && (decision.Included || decision.Overrides != TopicSourceDefault || decision.Rule != "contractors") {
+			t.Errorf("Unexpected announce decision: %+v", decision)
+		}
+	}
+
+	// Explicit emails match regardless of case
+	boss := types.IdentityCenterUser{Email: "boss@example.com", Active: true}
+	if topics := DetermineUserTopics(boss, nil, config); !reflect.DeepEqual(topics, []string{"announce", "approval"}) {
+		t.Errorf("Boss topics = %v", topics)
+	}
+}
+
+func TestExplainUserTopicsMatchesRoleMappingsWithoutRules(t *testing.T) {
+	config := types.ContactImportConfig{
+		DefaultTopics:      []string{"announce"},
+		RequireActiveUsers: true,
+		RoleMappings: []types.RoleTopicMapping{
+			{Roles: []string{"admin", "security"}, Topics: []string{"approval", "calendar"}},
+		},
+	}
+	membership := &types.IdentityCenterGroupMembership{Groups: []string{"ccoe-cloud-hts-prod-123456789012-idp-hts-Admin"}}
+
+	// Inactive users get no defaults, but role mappings still apply
+	topics := DetermineUserTopics(types.IdentityCenterUser{Email: "jane@example.com"}, membership, config)
+	if !reflect.DeepEqual(topics, []string{"approval", "calendar"}) {
+		t.Errorf("Topics = %v", topics)
+	}
+}
+
+func TestValidateTopicRules(t *testing.T) {
+	valid := []types.TopicRule{{Name: "ok", Topics: []string{"announce"}, GroupRegex: "^ccoe-", AccountNamePatterns: []string{"hts-*"}}}
+	if err := ValidateTopicRules(valid); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	invalid := []types.TopicRule{
+		{Name: "no-topics"},
+		{Topics: []string{"announce"}, Action: "drop"},
+		{Topics: []string{"announce"}, GroupRegex: "("},
+		{Topics: []string{"announce"}, AccountNamePatterns: []string{"["}},
+	}
+	err := ValidateTopicRules(invalid)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	for _, fragment := range []string{"no-topics: topics is required", "topic_rules[1]: action", "topic_rules[2]: invalid group_regex", "topic_rules[3]: invalid account name pattern"} {
+		if !contains(err.Error(), fragment) {
+			t.Errorf("Expected %q in %v", fragment, err)
+		}
+	}
+}
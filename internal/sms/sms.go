@@ -0,0 +1,233 @@
+// Package sms sends short text messages for urgent announcements through a pluggable provider
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxMessageLength keeps a message within a single GSM-7 SMS segment
+const MaxMessageLength = 160
+
+// Delivery statuses recorded in delivery logs
+const (
+	StatusSent        = "sent"
+	StatusFailed      = "failed"
+	StatusRateLimited = "rate_limited"
+)
+
+// Provider delivers a text message to one phone number
+type Provider interface {
+	// Name identifies the provider in delivery logs
+	Name() string
+	// Send delivers the message and returns the provider's message ID
+	Send(ctx context.Context, phoneNumber, message string) (string, error)
+}
+
+// Recipient is a topic subscriber with a phone number
+type Recipient struct {
+	Email       string `json:"email"`
+	PhoneNumber string `json:"-"`
+}
+
+// Delivery records the outcome of one message. Phone numbers are masked so the log can be
+// shared without exposing them.
+type Delivery struct {
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Status    string    `json:"status"`
+	MessageID string    `json:"message_id,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+var (
+	nonDigits = regexp.MustCompile(`[^0-9]`)
+	// phoneLabel matches a label before the number, such as "mobile: " or "Tel "
+	phoneLabel = regexp.MustCompile(`^[^+0-9]*`)
+	// phoneExtension matches a trailing extension such as "x123" or "ext. 123"
+	phoneExtension = regexp.MustCompile(`(?i)\s*(?:x|ext\.?|extension)\s*\d+$`)
+)
+
+// NormalizePhoneNumber converts a phone number to E.164. Numbers without a country code are
+// assumed to be North American when they have 10 digits; anything else without a leading +
+// is rejected.
+func NormalizePhoneNumber(phone string) (string, error) {
+	trimmed := strings.TrimSpace(phone)
+	if trimmed == "" {
+		return "", fmt.Errorf("phone number is empty")
+	}
+
+	// Drop labels such as "mobile:" and extensions such as "x123" or "ext. 123"
+	trimmed = phoneLabel.ReplaceAllString(trimmed, "")
+	trimmed = strings.TrimSpace(phoneExtension.ReplaceAllString(trimmed, ""))
+
+	international := strings.HasPrefix(trimmed, "+") || strings.HasPrefix(trimmed, "00")
+	digits := nonDigits.ReplaceAllString(trimmed, "")
+	if strings.HasPrefix(trimmed, "00") {
+		digits = strings.TrimPrefix(digits, "00")
+	}
+
+	switch {
+	case international:
+	case len(digits) == 10:
+		digits = "1" + digits
+	case len(digits) == 11 && strings.HasPrefix(digits, "1"):
+	default:
+		return "", fmt.Errorf("phone number %q has no country code", phone)
+	}
+
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", fmt.Errorf("phone number %q is not a valid E.164 number", phone)
+	}
+	return "+" + digits, nil
+}
+
+// MaskPhoneNumber hides all but the last four digits of a phone number
+func MaskPhoneNumber(phone string) string {
+	if len(phone) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
+
+// BuildMessage composes a single-segment message from the announcement title, summary and
+// link. The summary is shortened first, then the title, so the link always survives.
+func BuildMessage(customerName, title, summary, url string) string {
+	prefix := "URGENT"
+	if customerName != "" {
+		prefix = fmt.Sprintf("URGENT (%s)", customerName)
+	}
+
+	title = collapseSpace(title)
+	summary = collapseSpace(summary)
+
+	suffix := ""
+	if url != "" {
+		suffix = " " + url
+	}
+
+	budget := MaxMessageLength - len(prefix) - len(": ") - len(suffix)
+	if budget <= 0 {
+		return truncate(prefix+suffix, MaxMessageLength)
+	}
+
+	body := truncate(title, budget)
+	if remaining := budget - len(body) - len(" - "); summary != "" && remaining > 10 {
+		body += " - " + truncate(summary, remaining)
+	}
+
+	return prefix + ": " + body + suffix
+}
+
+// Send delivers the message to each recipient, sending at most limit messages. Recipients
+// beyond the limit are recorded as rate limited. A failed send never stops the others.
+func Send(ctx context.Context, provider Provider, recipients []Recipient, message string, limit int) []Delivery {
+	deliveries := make([]Delivery, 0, len(recipients))
+	sent := 0
+
+	for _, recipient := range recipients {
+		delivery := Delivery{
+			Email: recipient.Email,
+			Phone: MaskPhoneNumber(recipient.PhoneNumber),
+			Time:  time.Now().UTC(),
+		}
+
+		if sent >= limit {
+			delivery.Status = StatusRateLimited
+			deliveries = append(deliveries, delivery)
+			continue
+		}
+
+		messageID, err := provider.Send(ctx, recipient.PhoneNumber, message)
+		sent++
+		if err != nil {
+			log.Printf("❌ Failed to send SMS to %s (%s) via %s: %v", recipient.Email, delivery.Phone, provider.Name(), err)
+			delivery.Status = StatusFailed
+			delivery.Error = err.Error()
+		} else {
+			delivery.Status = StatusSent
+			delivery.MessageID = messageID
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
+
+// collapseSpace joins whitespace runs, including newlines, into single spaces
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate shortens s to at most n bytes on a rune boundary, marking the cut with "..."
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if n <= 3 {
+		return ""
+	}
+	cut := n - 3
+	for cut > 0 && !isRuneStart(s[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(s[:cut]) + "..."
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// DeliveryLog is the per-announcement, per-customer record of an SMS send
+type DeliveryLog struct {
+	AnnouncementID string     `json:"announcement_id"`
+	CustomerCode   string     `json:"customer_code"`
+	Provider       string     `json:"provider"`
+	Topic          string     `json:"topic"`
+	Message        string     `json:"message"`
+	StartedAt      time.Time  `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	Sent           int        `json:"sent"`
+	Failed         int        `json:"failed"`
+	RateLimited    int        `json:"rate_limited"`
+	Deliveries     []Delivery `json:"deliveries"`
+}
+
+// Complete records the deliveries and their totals
+func (l *DeliveryLog) Complete(deliveries []Delivery) {
+	now := time.Now().UTC()
+	l.CompletedAt = &now
+	l.Deliveries = deliveries
+	l.Sent, l.Failed, l.RateLimited = 0, 0, 0
+	for _, delivery := range deliveries {
+		switch delivery.Status {
+		case StatusSent:
+			l.Sent++
+		case StatusFailed:
+			l.Failed++
+		case StatusRateLimited:
+			l.RateLimited++
+		}
+	}
+}
+
+// Allowance returns how many of the requested messages may be sent given the per-announcement
+// cap and the messages already sent this hour against the hourly cap
+func Allowance(requested, perAnnouncement, usedThisHour, perHour int) int {
+	allowed := requested
+	if allowed > perAnnouncement {
+		allowed = perAnnouncement
+	}
+	if remaining := perHour - usedThisHour; allowed > remaining {
+		allowed = remaining
+	}
+	if allowed < 0 {
+		return 0
+	}
+	return allowed
+}
@@ -0,0 +1,117 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeProvider records sends and fails for selected numbers
+type fakeProvider struct {
+	sent []string
+	fail map[string]bool
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Send(_ context.Context, phoneNumber, _ string) (string, error) {
+	if f.fail[phoneNumber] {
+		return "", errors.New("carrier rejected")
+	}
+	f.sent = append(f.sent, phoneNumber)
+	return "msg-" + phoneNumber, nil
+}
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"+1 (555) 010-0199", "+15550100199", false},
+		{"555.010.0199", "+15550100199", false},
+		{"1-555-010-0199", "+15550100199", false},
+		{"+44 20 7946 0958", "+442079460958", false},
+		{"0044 20 7946 0958", "+442079460958", false},
+		{"+1 555 010 0199 ext. 42", "+15550100199", false},
+		{"555-010-0199x7", "+15550100199", false},
+		{"mobile: +1 555 010 0199", "+15550100199", false},
+		{"Tel +44 20 7946 0958", "+442079460958", false},
+		{"020 7946 0958", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhoneNumber(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizePhoneNumber(%q) = %q, %v; want %q (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMaskPhoneNumber(t *testing.T) {
+	if got := MaskPhoneNumber("+15550100199"); got != "********0199" {
+		t.Errorf("Unexpected mask: %s", got)
+	}
+}
+
+func TestBuildMessageKeepsLink(t *testing.T) {
+	url := "https://portal.example.com/edit-announcement.html?announcementId=CIC-123"
+	message := BuildMessage("HTS Prod", strings.Repeat("Security incident ", 10), "Rotate\ncredentials now", url)
+
+	if len(message) > MaxMessageLength {
+		t.Errorf("Message is %d characters, want at most %d", len(message), MaxMessageLength)
+	}
+	if !strings.HasPrefix(message, "URGENT (HTS Prod): Security incident") {
+		t.Errorf("Unexpected prefix: %s", message)
+	}
+	if !strings.HasSuffix(message, " "+url) {
+		t.Errorf("Expected link to survive truncation: %s", message)
+	}
+
+	short := BuildMessage("", "VPN outage", "Use the backup endpoint", "")
+	if short != "URGENT: VPN outage - Use the backup endpoint" {
+		t.Errorf("Unexpected message: %s", short)
+	}
+}
+
+func TestSendAppliesLimit(t *testing.T) {
+	provider := &fakeProvider{fail: map[string]bool{"+15550100002": true}}
+	recipients := []Recipient{
+		{Email: "a@example.com", PhoneNumber: "+15550100001"},
+		{Email: "b@example.com", PhoneNumber: "+15550100002"},
+		{Email: "c@example.com", PhoneNumber: "+15550100003"},
+	}
+
+	deliveries := Send(context.Background(), provider, recipients, "hello", 2)
+
+	var log DeliveryLog
+	log.Complete(deliveries)
+	if log.Sent != 1 || log.Failed != 1 || log.RateLimited != 1 {
+		t.Fatalf("Unexpected totals: sent=%d failed=%d rate_limited=%d", log.Sent, log.Failed, log.RateLimited)
+	}
+	if len(provider.sent) != 1 || deliveries[0].MessageID != "msg-+15550100001" {
+		t.Errorf("Unexpected sends: %v", provider.sent)
+	}
+	if deliveries[1].Error == "" || deliveries[2].Status != StatusRateLimited {
+		t.Errorf("Unexpected deliveries: %+v", deliveries)
+	}
+	if strings.Contains(deliveries[0].Phone, "0100001") {
+		t.Errorf("Expected masked phone in delivery log, got %s", deliveries[0].Phone)
+	}
+}
+
+func TestAllowance(t *testing.T) {
+	tests := []struct {
+		requested, perAnnouncement, used, perHour, want int
+	}{
+		{10, 50, 0, 200, 10},
+		{80, 50, 0, 200, 50},
+		{80, 50, 190, 200, 10},
+		{5, 50, 250, 200, 0},
+	}
+	for _, tt := range tests {
+		if got := Allowance(tt.requested, tt.perAnnouncement, tt.used, tt.perHour); got != tt.want {
+			t.Errorf("Allowance(%d, %d, %d, %d) = %d, want %d", tt.requested, tt.perAnnouncement, tt.used, tt.perHour, got, tt.want)
+		}
+	}
+}
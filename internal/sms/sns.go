@@ -0,0 +1,62 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snsTypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+// SNSProvider sends transactional SMS directly to phone numbers through Amazon SNS
+type SNSProvider struct {
+	client            *sns.Client
+	senderID          string
+	originationNumber string
+}
+
+// NewSNSProvider creates an SNS provider. senderID and originationNumber are optional and
+// only honoured in countries that support them.
+func NewSNSProvider(client *sns.Client, senderID, originationNumber string) *SNSProvider {
+	return &SNSProvider{
+		client:            client,
+		senderID:          senderID,
+		originationNumber: originationNumber,
+	}
+}
+
+// Name implements Provider
+func (p *SNSProvider) Name() string { return "sns" }
+
+// Send implements Provider
+func (p *SNSProvider) Send(ctx context.Context, phoneNumber, message string) (string, error) {
+	attributes := map[string]snsTypes.MessageAttributeValue{
+		"AWS.SNS.SMS.SMSType": {
+			DataType:    aws.String("String"),
+			StringValue: aws.String("Transactional"),
+		},
+	}
+	if p.senderID != "" {
+		attributes["AWS.SNS.SMS.SenderID"] = snsTypes.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(p.senderID),
+		}
+	}
+	if p.originationNumber != "" {
+		attributes["AWS.MM.SMS.OriginationNumber"] = snsTypes.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(p.originationNumber),
+		}
+	}
+
+	output, err := p.client.Publish(ctx, &sns.PublishInput{
+		PhoneNumber:       aws.String(phoneNumber),
+		Message:           aws.String(message),
+		MessageAttributes: attributes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("sns publish failed: %w", err)
+	}
+	return aws.ToString(output.MessageId), nil
+}
@@ -0,0 +1,395 @@
+// Package surveys reads stored Typeform responses back and aggregates NPS, CSAT and response
+// rates per customer, quarter, survey type and event
+package surveys
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ccoe-customer-contact-manager/internal/typeform"
+)
+
+// Grouping dimensions used in reports
+const (
+	DimensionCustomer   = "customer"
+	DimensionQuarter    = "quarter"
+	DimensionSurveyType = "survey_type"
+	DimensionEvent      = "event"
+)
+
+// DefaultRatingScale is the number of steps assumed for rating fields when computing CSAT
+const DefaultRatingScale = 5
+
+// Response is a parsed survey response
+type Response struct {
+	Token        string
+	FormID       string
+	CustomerCode string
+	Quarter      string // e.g. 2025-Q1
+	SurveyType   string // change, cic, innersource, finops or general
+	ObjectID     string
+	UserLogin    string
+	SubmittedAt  time.Time
+	Scores       []int             // Opinion scale answers (0-10)
+	Ratings      []int             // Rating answers
+	YesNo        []bool            // Yes/no answers
+	Choices      map[string]string // Field title (or ref) -> selected label(s)
+	Comments     []string          // Non-empty text answers
+}
+
+// ParseResponse extracts the answers and hidden fields of a stored webhook payload. Answers are
+// classified by field type, falling back to the answer type for payloads without one.
+func ParseResponse(payload typeform.WebhookPayload) Response {
+	form := payload.FormResponse
+	hidden := form.Hidden
+
+	response := Response{
+		Token:        form.Token,
+		FormID:       form.FormID,
+		CustomerCode: hidden["customer_code"],
+		ObjectID:     hidden["object_id"],
+		UserLogin:    hidden["user_login"],
+		SurveyType:   surveyType(hidden["event_type"], hidden["event_subtype"]),
+		Choices:      make(map[string]string),
+	}
+
+	if t, err := time.Parse(time.RFC3339, form.SubmittedAt); err == nil {
+		response.SubmittedAt = t.UTC()
+	}
+	response.Quarter = quarterLabel(hidden["year"], hidden["quarter"], response.SubmittedAt)
+
+	for _, answer := range form.Answers {
+		fieldType := answer.Field.Type
+		if fieldType == "" {
+			fieldType = fieldTypeForAnswer(answer.Type)
+		}
+
+		switch fieldType {
+		case "opinion_scale", "nps":
+			if answer.Number != nil {
+				response.Scores = append(response.Scores, *answer.Number)
+			}
+		case "rating":
+			if answer.Number != nil {
+				response.Ratings = append(response.Ratings, *answer.Number)
+			}
+		case "yes_no", "legal":
+			if answer.Boolean != nil {
+				response.YesNo = append(response.YesNo, *answer.Boolean)
+			}
+		case "multiple_choice", "dropdown", "picture_choice":
+			if label := choiceLabel(answer); label != "" {
+				response.Choices[fieldName(answer.Field)] = label
+			}
+		case "long_text", "short_text":
+			if answer.Text != nil && strings.TrimSpace(*answer.Text) != "" {
+				response.Comments = append(response.Comments, strings.TrimSpace(*answer.Text))
+			}
+		}
+	}
+
+	return response
+}
+
+// Stats aggregates the responses in one group
+type Stats struct {
+	Dimension        string                    `json:"dimension"`
+	Key              string                    `json:"key"`
+	Responses        int                       `json:"responses"`
+	Surveys          int                       `json:"surveys"`           // Surveys sent (forms created)
+	RespondedSurveys int                       `json:"responded_surveys"` // Surveys with at least one response
+	ResponseRate     *float64                  `json:"response_rate"`     // Percent of surveys with a response
+	ScoreCount       int                       `json:"score_count"`
+	Promoters        int                       `json:"promoters"`
+	Passives         int                       `json:"passives"`
+	Detractors       int                       `json:"detractors"`
+	NPS              *float64                  `json:"nps"` // -100 to 100
+	AverageScore     *float64                  `json:"average_score"`
+	CSATCount        int                       `json:"csat_count"`
+	Satisfied        int                       `json:"satisfied"`
+	CSAT             *float64                  `json:"csat"` // Percent satisfied
+	Choices          map[string]map[string]int `json:"choices,omitempty"`
+	Comments         []string                  `json:"comments,omitempty"`
+
+	scoreTotal int
+}
+
+// Report is the full analysis of a set of responses
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Filter      Filter    `json:"filter"`
+	Overall     *Stats    `json:"overall"`
+	Groups      []*Stats  `json:"groups"`
+}
+
+// Filter narrows the responses and surveys included in a report. Empty fields match everything.
+type Filter struct {
+	CustomerCode string `json:"customer_code,omitempty"`
+	Quarter      string `json:"quarter,omitempty"`
+	SurveyType   string `json:"survey_type,omitempty"`
+	ObjectID     string `json:"object_id,omitempty"`
+}
+
+// Matches reports whether a response or survey with the given attributes passes the filter
+func (f Filter) Matches(customerCode, quarter, surveyType, objectID string) bool {
+	return (f.CustomerCode == "" || strings.EqualFold(f.CustomerCode, customerCode)) &&
+		(f.Quarter == "" || strings.EqualFold(f.Quarter, quarter)) &&
+		(f.SurveyType == "" || strings.EqualFold(f.SurveyType, surveyType)) &&
+		(f.ObjectID == "" || f.ObjectID == objectID)
+}
+
+// Options tune the analysis
+type Options struct {
+	RatingScale int // Steps on rating fields (default 5); the top two count as satisfied
+}
+
+// Analyze aggregates responses and sent surveys into overall and per-group statistics.
+// Responses are deduplicated by token, so overlapping stores never double count.
+func Analyze(responses []Response, forms []Form, filter Filter, opts Options, now time.Time) *Report {
+	scale := opts.RatingScale
+	if scale <= 0 {
+		scale = DefaultRatingScale
+	}
+
+	report := &Report{GeneratedAt: now, Filter: filter, Overall: newStats("overall", "all")}
+	groups := make(map[string]*Stats)
+	group := func(dimension, key string) *Stats {
+		if key == "" {
+			key = "unknown"
+		}
+		id := dimension + "\x00" + key
+		if groups[id] == nil {
+			groups[id] = newStats(dimension, key)
+			report.Groups = append(report.Groups, groups[id])
+		}
+		return groups[id]
+	}
+
+	// Forms only carry their creation time, so attribute them to the quarter and survey type
+	// their responses report
+	responded := make(map[string]Response)
+
+	seen := make(map[string]bool)
+	for _, response := range responses {
+		if !filter.Matches(response.CustomerCode, response.Quarter, response.SurveyType, response.ObjectID) {
+			continue
+		}
+		if response.Token != "" {
+			if seen[response.Token] {
+				continue
+			}
+			seen[response.Token] = true
+		}
+
+		if response.ObjectID != "" {
+			responded[response.ObjectID] = response
+		}
+
+		report.Overall.add(response, scale)
+		group(DimensionCustomer, response.CustomerCode).add(response, scale)
+		group(DimensionQuarter, response.Quarter).add(response, scale)
+		group(DimensionSurveyType, response.SurveyType).add(response, scale)
+		event := group(DimensionEvent, response.ObjectID)
+		event.add(response, scale)
+		event.Comments = append(event.Comments, response.Comments...)
+	}
+
+	// A survey counts as responded when anyone answered it. Its form is stored under the first
+	// customer of the change while responses carry each respondent's customer code.
+	for _, form := range forms {
+		response, ok := responded[form.ObjectID]
+		if ok {
+			form.Quarter, form.SurveyType = response.Quarter, response.SurveyType
+		}
+		if !filter.Matches(form.CustomerCode, form.Quarter, form.SurveyType, form.ObjectID) {
+			continue
+		}
+		report.Overall.addSurvey(ok)
+		group(DimensionCustomer, form.CustomerCode).addSurvey(ok)
+		group(DimensionQuarter, form.Quarter).addSurvey(ok)
+		group(DimensionSurveyType, form.SurveyType).addSurvey(ok)
+		group(DimensionEvent, form.ObjectID).addSurvey(ok)
+	}
+
+	report.Overall.finish()
+	for _, stats := range report.Groups {
+		stats.finish()
+	}
+
+	order := map[string]int{DimensionCustomer: 0, DimensionQuarter: 1, DimensionSurveyType: 2, DimensionEvent: 3}
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if a.Dimension != b.Dimension {
+			return order[a.Dimension] < order[b.Dimension]
+		}
+		return a.Key < b.Key
+	})
+
+	return report
+}
+
+// GroupsFor returns the groups of one dimension
+func (r *Report) GroupsFor(dimension string) []*Stats {
+	var groups []*Stats
+	for _, stats := range r.Groups {
+		if stats.Dimension == dimension {
+			groups = append(groups, stats)
+		}
+	}
+	return groups
+}
+
+func newStats(dimension, key string) *Stats {
+	return &Stats{Dimension: dimension, Key: key, Choices: make(map[string]map[string]int)}
+}
+
+// add folds a response into the group. Opinion scale answers of 9-10 are promoters and 0-6
+// detractors; ratings in the top two steps and "yes" answers count as satisfied.
+func (s *Stats) add(response Response, ratingScale int) {
+	s.Responses++
+
+	for _, score := range response.Scores {
+		if score < 0 || score > 10 {
+			continue
+		}
+		s.ScoreCount++
+		s.scoreTotal += score
+		switch {
+		case score >= 9:
+			s.Promoters++
+		case score <= 6:
+			s.Detractors++
+		default:
+			s.Passives++
+		}
+	}
+
+	for _, rating := range response.Ratings {
+		s.CSATCount++
+		if rating >= ratingScale-1 {
+			s.Satisfied++
+		}
+	}
+	for _, yes := range response.YesNo {
+		s.CSATCount++
+		if yes {
+			s.Satisfied++
+		}
+	}
+
+	for field, label := range response.Choices {
+		if s.Choices[field] == nil {
+			s.Choices[field] = make(map[string]int)
+		}
+		s.Choices[field][label]++
+	}
+}
+
+// addSurvey counts a sent survey toward the group's response rate
+func (s *Stats) addSurvey(responded bool) {
+	s.Surveys++
+	if responded {
+		s.RespondedSurveys++
+	}
+}
+
+// finish computes the derived percentages. Metrics without samples are left nil so they
+// render as "n/a" rather than zero.
+func (s *Stats) finish() {
+	if s.Surveys > 0 {
+		s.ResponseRate = percent(s.RespondedSurveys, s.Surveys)
+	}
+	if s.ScoreCount > 0 {
+		nps := float64(s.Promoters-s.Detractors) / float64(s.ScoreCount) * 100
+		average := float64(s.scoreTotal) / float64(s.ScoreCount)
+		s.NPS, s.AverageScore = &nps, &average
+	}
+	if s.CSATCount > 0 {
+		s.CSAT = percent(s.Satisfied, s.CSATCount)
+	}
+	if len(s.Choices) == 0 {
+		s.Choices = nil
+	}
+}
+
+func percent(n, total int) *float64 {
+	value := float64(n) / float64(total) * 100
+	return &value
+}
+
+// surveyType maps the event_type/event_subtype hidden fields to the survey template used
+func surveyType(eventType, eventSubtype string) string {
+	switch strings.ToLower(eventType) {
+	case "change":
+		return string(typeform.SurveyTypeChange)
+	case "announcement":
+		switch typeform.SurveyType(strings.ToLower(eventSubtype)) {
+		case typeform.SurveyTypeCIC, typeform.SurveyTypeInnerSource, typeform.SurveyTypeFinOps:
+			return strings.ToLower(eventSubtype)
+		}
+	}
+	return string(typeform.SurveyTypeGeneral)
+}
+
+// quarterLabel formats the year/quarter hidden fields as "2025-Q1", falling back to the
+// submission time when they are missing
+func quarterLabel(year, quarter string, submittedAt time.Time) string {
+	quarter = strings.ToUpper(strings.TrimSpace(quarter))
+	if year != "" && quarter != "" {
+		if !strings.HasPrefix(quarter, "Q") {
+			quarter = "Q" + quarter
+		}
+		return fmt.Sprintf("%s-%s", strings.TrimSpace(year), quarter)
+	}
+	if submittedAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d-Q%d", submittedAt.Year(), (int(submittedAt.Month())-1)/3+1)
+}
+
+// fieldTypeForAnswer infers the field type from the answer type for payloads without one
+func fieldTypeForAnswer(answerType string) string {
+	switch answerType {
+	case "number":
+		return "opinion_scale"
+	case "boolean":
+		return "yes_no"
+	case "choice", "choices":
+		return "multiple_choice"
+	case "text":
+		return "long_text"
+	}
+	return ""
+}
+
+// choiceLabel returns the selected label, joining multiple selections with "; "
+func choiceLabel(answer typeform.Answer) string {
+	if label, ok := answer.Choice["label"].(string); ok {
+		return label
+	}
+	if labels, ok := answer.Choices["labels"].([]interface{}); ok {
+		var parts []string
+		for _, label := range labels {
+			if s, ok := label.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, "; ")
+	}
+	return ""
+}
+
+// fieldName identifies a choice field in reports
+func fieldName(field typeform.Field) string {
+	switch {
+	case field.Title != "":
+		return field.Title
+	case field.Ref != "":
+		return field.Ref
+	case field.ID != "":
+		return field.ID
+	}
+	return "choice"
+}
@@ -0,0 +1,160 @@
+package surveys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/typeform"
+)
+
+// S3 prefixes written by the survey creation and webhook code
+const (
+	ResultsPrefix = "surveys/results/"
+	FormsPrefix   = "surveys/forms/"
+)
+
+// Form is a survey that was created and sent for a change or announcement
+type Form struct {
+	FormID       string
+	CustomerCode string
+	ObjectID     string
+	SurveyType   string
+	Quarter      string
+	CreatedAt    time.Time
+}
+
+// LoadResults loads the stored webhook payloads under surveys/results/, optionally for a
+// single customer. Malformed objects are logged and skipped.
+func LoadResults(ctx context.Context, s3Client *s3.Client, bucket, customerCode string) ([]typeform.WebhookPayload, error) {
+	var results []typeform.WebhookPayload
+
+	err := forEachObject(ctx, s3Client, bucket, customerPrefix(ResultsPrefix, customerCode), func(key string, body []byte) {
+		var payload typeform.WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Printf("⚠️  Skipping malformed survey result %s: %v", key, err)
+			return
+		}
+		results = append(results, payload)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load survey results: %w", err)
+	}
+	return results, nil
+}
+
+// LoadForms lists the surveys created under surveys/forms/{customer}/{object}/{timestamp}-{form}.json,
+// optionally for a single customer. Only the keys are read.
+func LoadForms(ctx context.Context, s3Client *s3.Client, bucket, customerCode string) ([]Form, error) {
+	var forms []Form
+
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(customerPrefix(FormsPrefix, customerCode)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list survey forms: %w", err)
+		}
+		for _, obj := range page.Contents {
+			if form, ok := ParseFormKey(aws.ToString(obj.Key)); ok {
+				forms = append(forms, form)
+			}
+		}
+	}
+	return forms, nil
+}
+
+// ParseFormKey parses a survey form key. The survey type is inferred from the object ID prefix
+// (CHG, CIC, FIN, INN) and the quarter from the creation timestamp.
+func ParseFormKey(key string) (Form, bool) {
+	parts := strings.Split(strings.TrimPrefix(key, FormsPrefix), "/")
+	if !strings.HasPrefix(key, FormsPrefix) || len(parts) != 3 {
+		return Form{}, false
+	}
+
+	name := strings.TrimSuffix(parts[2], ".json")
+	timestamp, formID, ok := strings.Cut(name, "-")
+	if !ok || formID == "" {
+		return Form{}, false
+	}
+
+	form := Form{
+		FormID:       formID,
+		CustomerCode: parts[0],
+		ObjectID:     parts[1],
+		SurveyType:   surveyTypeForObjectID(parts[1]),
+	}
+	if seconds, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		form.CreatedAt = time.Unix(seconds, 0).UTC()
+		form.Quarter = quarterLabel("", "", form.CreatedAt)
+	}
+	return form, true
+}
+
+// surveyTypeForObjectID infers the survey type from the object ID prefix
+func surveyTypeForObjectID(objectID string) string {
+	prefix, _, _ := strings.Cut(strings.ToUpper(objectID), "-")
+	switch prefix {
+	case "CHG":
+		return string(typeform.SurveyTypeChange)
+	case "CIC":
+		return string(typeform.SurveyTypeCIC)
+	case "FIN":
+		return string(typeform.SurveyTypeFinOps)
+	case "INN":
+		return string(typeform.SurveyTypeInnerSource)
+	}
+	return string(typeform.SurveyTypeGeneral)
+}
+
+func customerPrefix(prefix, customerCode string) string {
+	if customerCode == "" {
+		return prefix
+	}
+	return path.Join(prefix, customerCode) + "/"
+}
+
+// forEachObject downloads every object under a prefix and passes its body to fn
+func forEachObject(ctx context.Context, s3Client *s3.Client, bucket, prefix string, fn func(key string, body []byte)) error {
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			output, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to download %s: %w", key, err)
+			}
+
+			body, err := io.ReadAll(output.Body)
+			output.Body.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", key, err)
+			}
+			fn(key, body)
+		}
+	}
+	return nil
+}
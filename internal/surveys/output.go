@@ -0,0 +1,159 @@
+package surveys
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"ccoe-customer-contact-manager/internal/ses/templates"
+)
+
+// csvColumns are the per-group columns written by WriteCSV
+var csvColumns = []string{
+	"dimension", "key", "responses", "surveys", "responded_surveys", "response_rate",
+	"promoters", "passives", "detractors", "nps", "average_score", "csat_count", "csat",
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes one row per group, starting with the overall totals
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, stats := range append([]*Stats{r.Overall}, r.Groups...) {
+		row := []string{
+			stats.Dimension,
+			stats.Key,
+			strconv.Itoa(stats.Responses),
+			strconv.Itoa(stats.Surveys),
+			strconv.Itoa(stats.RespondedSurveys),
+			formatValue(stats.ResponseRate, "%.1f"),
+			strconv.Itoa(stats.Promoters),
+			strconv.Itoa(stats.Passives),
+			strconv.Itoa(stats.Detractors),
+			formatValue(stats.NPS, "%.1f"),
+			formatValue(stats.AverageScore, "%.2f"),
+			strconv.Itoa(stats.CSATCount),
+			formatValue(stats.CSAT, "%.1f"),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// TemplateData converts the report into the shared report layout used for the HTML summary
+func (r *Report) TemplateData() templates.ReportData {
+	subtitle := "All responses"
+	if label := r.filterLabel(); label != "" {
+		subtitle = label
+	}
+
+	data := templates.ReportData{
+		Title:       "Survey Results",
+		Subtitle:    subtitle,
+		GeneratedAt: r.GeneratedAt,
+		Metrics: []templates.ReportMetric{
+			{Label: "Responses", Value: strconv.Itoa(r.Overall.Responses)},
+			{Label: "Net promoter score", Value: displayValue(r.Overall.NPS, "%+.0f", "")},
+			{Label: "CSAT", Value: displayValue(r.Overall.CSAT, "%.0f", "%")},
+			{Label: "Response rate", Value: displayValue(r.Overall.ResponseRate, "%.0f", "%")},
+		},
+	}
+
+	titles := map[string]string{
+		DimensionCustomer:   "By Customer",
+		DimensionQuarter:    "By Quarter",
+		DimensionSurveyType: "By Survey Type",
+		DimensionEvent:      "By Event",
+	}
+	for _, dimension := range []string{DimensionCustomer, DimensionQuarter, DimensionSurveyType, DimensionEvent} {
+		section := templates.ReportSection{
+			Title:   titles[dimension],
+			Columns: []string{"Key", "Responses", "Response rate", "NPS", "CSAT"},
+			Empty:   "No survey responses",
+		}
+		for _, stats := range r.GroupsFor(dimension) {
+			section.Rows = append(section.Rows, []string{
+				stats.Key,
+				strconv.Itoa(stats.Responses),
+				displayValue(stats.ResponseRate, "%.0f", "%"),
+				displayValue(stats.NPS, "%+.0f", ""),
+				displayValue(stats.CSAT, "%.0f", "%"),
+			})
+		}
+		data.Sections = append(data.Sections, section)
+	}
+
+	choiceSection := templates.ReportSection{Title: "Choices", Columns: []string{"Question", "Answer", "Count"}, Empty: "No choice questions answered"}
+	for _, field := range sortedKeys(r.Overall.Choices) {
+		for _, label := range sortedKeys(r.Overall.Choices[field]) {
+			choiceSection.Rows = append(choiceSection.Rows, []string{field, label, strconv.Itoa(r.Overall.Choices[field][label])})
+		}
+	}
+	data.Sections = append(data.Sections, choiceSection)
+
+	commentSection := templates.ReportSection{Title: "Comments", Columns: []string{"Event", "Comment"}, Empty: "No comments"}
+	for _, stats := range r.GroupsFor(DimensionEvent) {
+		for _, comment := range stats.Comments {
+			commentSection.Rows = append(commentSection.Rows, []string{stats.Key, comment})
+		}
+	}
+	data.Sections = append(data.Sections, commentSection)
+
+	return data
+}
+
+// filterLabel describes the active filter, e.g. "hts · 2025-Q1"
+func (r *Report) filterLabel() string {
+	label := ""
+	for _, part := range []string{r.Filter.CustomerCode, r.Filter.Quarter, r.Filter.SurveyType, r.Filter.ObjectID} {
+		if part == "" {
+			continue
+		}
+		if label != "" {
+			label += " · "
+		}
+		label += part
+	}
+	return label
+}
+
+// formatValue formats an optional metric for machine-readable output, leaving it empty when unset
+func formatValue(value *float64, format string) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf(format, *value)
+}
+
+// displayValue formats an optional metric for people, showing n/a when unset
+func displayValue(value *float64, format, suffix string) string {
+	if value == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf(format, *value) + suffix
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,256 @@
+package surveys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"ccoe-customer-contact-manager/internal/typeform"
+)
+
+// RecipientsPrefix holds who was sent each change survey, written when the completion email goes out
+const RecipientsPrefix = "surveys/recipients/"
+
+// Distribution records the recipients of a survey link for one customer and event. The reminder
+// sweep compares it with the stored responses to find who has not answered.
+type Distribution struct {
+	ObjectID     string     `json:"object_id"`
+	CustomerCode string     `json:"customer_code"`
+	FormID       string     `json:"form_id"`
+	SurveyURL    string     `json:"survey_url"` // Survey URL with the shared hidden fields, without user_login
+	Title        string     `json:"title"`
+	Summary      string     `json:"summary,omitempty"`
+	Topic        string     `json:"topic,omitempty"` // Topic the survey email went to; reminders skip recipients who left it
+	Recipients   []string   `json:"recipients"`
+	SentAt       time.Time  `json:"sent_at"`
+	RemindedAt   *time.Time `json:"reminded_at,omitempty"`
+	Reminded     []string   `json:"reminded,omitempty"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+}
+
+// ReminderAction is the next step the sweep takes for a distribution
+type ReminderAction string
+
+// Reminder actions, in the order they happen
+const (
+	ReminderActionNone   ReminderAction = ""
+	ReminderActionRemind ReminderAction = "remind"
+	ReminderActionClose  ReminderAction = "close"
+)
+
+// DistributionKey returns surveys/recipients/{customer}/{object}.json
+func DistributionKey(customerCode, objectID string) string {
+	return path.Join(RecipientsPrefix, customerCode, objectID+".json")
+}
+
+// PersonalizeSurveyURL adds the recipient as the user_login hidden field so their response can be
+// matched to them
+func PersonalizeSurveyURL(surveyURL, userLogin string) string {
+	if surveyURL == "" || userLogin == "" {
+		return surveyURL
+	}
+	separator := "?"
+	if strings.Contains(surveyURL, "?") {
+		separator = "&"
+	}
+	return surveyURL + separator + "user_login=" + url.QueryEscape(userLogin)
+}
+
+// NextAction decides what the sweep should do with a distribution. A survey gets one reminder
+// once remindAfter has passed, and is closed once closeAfter has passed. A distribution that is
+// already past the deadline is closed without reminding.
+func (d *Distribution) NextAction(now time.Time, remindAfter, closeAfter time.Duration) ReminderAction {
+	if d.ClosedAt != nil || d.SentAt.IsZero() {
+		return ReminderActionNone
+	}
+	age := now.Sub(d.SentAt)
+	switch {
+	case age >= closeAfter:
+		return ReminderActionClose
+	case age >= remindAfter && d.RemindedAt == nil:
+		return ReminderActionRemind
+	}
+	return ReminderActionNone
+}
+
+// NonRespondents returns the recipients with no response for the distribution's customer and
+// event. Responses are matched on the user_login, customer_code and object_id hidden fields,
+// ignoring case.
+func (d *Distribution) NonRespondents(responses []Response) []string {
+	responded := make(map[string]bool)
+	for _, response := range responses {
+		if strings.EqualFold(response.CustomerCode, d.CustomerCode) && strings.EqualFold(response.ObjectID, d.ObjectID) {
+			responded[strings.ToLower(response.UserLogin)] = true
+		}
+	}
+
+	var pending []string
+	for _, recipient := range d.Recipients {
+		if !responded[strings.ToLower(recipient)] {
+			pending = append(pending, recipient)
+		}
+	}
+	return pending
+}
+
+// addRecipients merges recipients into the distribution, ignoring case and keeping them sorted
+func (d *Distribution) addRecipients(recipients []string) {
+	seen := make(map[string]bool, len(d.Recipients))
+	for _, recipient := range d.Recipients {
+		seen[strings.ToLower(recipient)] = true
+	}
+	for _, recipient := range recipients {
+		if recipient != "" && !seen[strings.ToLower(recipient)] {
+			seen[strings.ToLower(recipient)] = true
+			d.Recipients = append(d.Recipients, recipient)
+		}
+	}
+	sort.Strings(d.Recipients)
+}
+
+// distributionMaxAttempts bounds retries when a distribution record is updated concurrently
+const distributionMaxAttempts = 5
+
+// RecordDistribution adds the recipients of a survey email to its distribution record. A change
+// that completes and is later rolled back keeps one record: recipients are merged, the reminder
+// clock starts at the first send, and a new form restarts it.
+func RecordDistribution(ctx context.Context, s3Client *s3.Client, bucket string, sent Distribution) error {
+	if sent.CustomerCode == "" || sent.ObjectID == "" || len(sent.Recipients) == 0 {
+		return nil
+	}
+
+	return UpdateDistribution(ctx, s3Client, bucket, sent.CustomerCode, sent.ObjectID, func(existing *Distribution) *Distribution {
+		record := sent
+		record.Recipients = nil
+		if existing != nil && existing.FormID == sent.FormID {
+			record = *existing
+			record.SurveyURL = sent.SurveyURL
+			record.Title = sent.Title
+			record.Summary = sent.Summary
+			record.Topic = sent.Topic
+		}
+		record.addRecipients(sent.Recipients)
+		return &record
+	})
+}
+
+// UpdateDistribution applies update to the current distribution record (nil if there is none)
+// and writes the result with ETag locking, retrying with a fresh copy when the record changed
+// concurrently. update returns nil to leave the record as it is.
+func UpdateDistribution(ctx context.Context, s3Client *s3.Client, bucket, customerCode, objectID string, update func(existing *Distribution) *Distribution) error {
+	key := DistributionKey(customerCode, objectID)
+
+	for attempt := 1; attempt <= distributionMaxAttempts; attempt++ {
+		existing, etag, err := loadDistribution(ctx, s3Client, bucket, key)
+		if err != nil {
+			return err
+		}
+
+		record := update(existing)
+		if record == nil {
+			return nil
+		}
+
+		err = saveDistribution(ctx, s3Client, bucket, key, record, etag)
+		if !typeform.IsPreconditionFailed(err) {
+			return err
+		}
+		log.Printf("🔄 Survey distribution %s changed concurrently, retrying (attempt %d/%d)", key, attempt, distributionMaxAttempts)
+	}
+
+	return fmt.Errorf("survey distribution %s kept changing after %d attempts", key, distributionMaxAttempts)
+}
+
+// LoadDistribution reads a distribution record, returning nil if there is none
+func LoadDistribution(ctx context.Context, s3Client *s3.Client, bucket, customerCode, objectID string) (*Distribution, error) {
+	distribution, _, err := loadDistribution(ctx, s3Client, bucket, DistributionKey(customerCode, objectID))
+	return distribution, err
+}
+
+// loadDistribution reads a distribution record and its ETag, returning nil and no ETag if there is none
+func loadDistribution(ctx context.Context, s3Client *s3.Client, bucket, key string) (*Distribution, string, error) {
+	output, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer output.Body.Close()
+
+	body, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	var distribution Distribution
+	if err := json.Unmarshal(body, &distribution); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+	return &distribution, aws.ToString(output.ETag), nil
+}
+
+// LoadDistributions loads every distribution record, optionally for a single customer. Malformed
+// records are skipped.
+func LoadDistributions(ctx context.Context, s3Client *s3.Client, bucket, customerCode string) ([]*Distribution, error) {
+	var distributions []*Distribution
+
+	err := forEachObject(ctx, s3Client, bucket, customerPrefix(RecipientsPrefix, customerCode), func(key string, body []byte) {
+		var distribution Distribution
+		if err := json.Unmarshal(body, &distribution); err != nil {
+			log.Printf("⚠️  Skipping malformed survey distribution %s: %v", key, err)
+			return
+		}
+		distributions = append(distributions, &distribution)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load survey distributions: %w", err)
+	}
+	return distributions, nil
+}
+
+// saveDistribution writes a distribution record. With an ETag the write only succeeds if the
+// record is unchanged; with an empty ETag it only succeeds if there is no record yet.
+func saveDistribution(ctx context.Context, s3Client *s3.Client, bucket, key string, distribution *Distribution, etag string) error {
+	data, err := json.MarshalIndent(distribution, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal survey distribution: %w", err)
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	}
+	if etag != "" {
+		input.IfMatch = aws.String(etag)
+	} else {
+		input.IfNoneMatch = aws.String("*")
+	}
+
+	if _, err := s3Client.PutObject(ctx, input); err != nil {
+		if typeform.IsPreconditionFailed(err) {
+			return err
+		}
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package surveys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/typeform"
+)
+
+func intPtr(v int) *int          { return &v }
+func boolPtr(v bool) *bool       { return &v }
+func strPtr(v string) *string    { return &v }
+func floatOf(v *float64) float64 { return *v }
+
+func payload(token, customer, objectID, eventType, subtype string, score int, excellent bool, comment string) typeform.WebhookPayload {
+	return typeform.WebhookPayload{
+		EventID: "evt-" + token,
+		FormResponse: typeform.FormResponse{
+			FormID:      "form-" + objectID,
+			Token:       token,
+			SubmittedAt: "2025-02-10T12:00:00Z",
+			Hidden: map[string]string{
+				"customer_code": customer,
+				"object_id":     objectID,
+				"event_type":    eventType,
+				"event_subtype": subtype,
+				"year":          "2025",
+				"quarter":       "Q1",
+			},
+			Answers: []typeform.Answer{
+				{Type: "boolean", Field: typeform.Field{Type: "yes_no"}, Boolean: boolPtr(excellent)},
+				{Type: "number", Field: typeform.Field{Type: "opinion_scale"}, Number: intPtr(score)},
+				{Type: "text", Field: typeform.Field{Type: "long_text"}, Text: strPtr(comment)},
+			},
+		},
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	p := payload("t1", "hts", "CIC-1", "announcement", "cic", 9, true, "  Great  ")
+	p.FormResponse.Answers = append(p.FormResponse.Answers,
+		typeform.Answer{Type: "number", Field: typeform.Field{Type: "rating"}, Number: intPtr(4)},
+		typeform.Answer{Type: "choice", Field: typeform.Field{Type: "multiple_choice", Title: "Channel"}, Choice: map[string]interface{}{"label": "Email"}},
+	)
+
+	response := ParseResponse(p)
+	if response.SurveyType != "cic" || response.Quarter != "2025-Q1" || response.CustomerCode != "hts" {
+		t.Errorf("Unexpected hidden fields: %+v", response)
+	}
+	if len(response.Scores) != 1 || len(response.Ratings) != 1 || len(response.YesNo) != 1 {
+		t.Errorf("Unexpected answers: %+v", response)
+	}
+	if response.Choices["Channel"] != "Email" || response.Comments[0] != "Great" {
+		t.Errorf("Unexpected choices/comments: %+v", response)
+	}
+
+	// Answers without a field type fall back to the answer type; missing quarter uses submitted_at
+	legacy := typeform.WebhookPayload{FormResponse: typeform.FormResponse{
+		SubmittedAt: "2025-08-01T00:00:00Z",
+		Hidden:      map[string]string{"event_type": "change"},
+		Answers:     []typeform.Answer{{Type: "number", Number: intPtr(3)}},
+	}}
+	response = ParseResponse(legacy)
+	if response.SurveyType != "change" || response.Quarter != "2025-Q3" || len(response.Scores) != 1 {
+		t.Errorf("Unexpected legacy parse: %+v", response)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	payloads := []typeform.WebhookPayload{
+		payload("t1", "hts", "CHG-1", "change", "general", 10, true, "Smooth"),
+		payload("t1", "hts", "CHG-1", "change", "general", 10, true, "Smooth"), // duplicate delivery
+		payload("t2", "cds", "CHG-1", "change", "general", 8, true, ""),
+		payload("t3", "hts", "CIC-2", "announcement", "cic", 3, false, "Too long"),
+	}
+	var responses []Response
+	for _, p := range payloads {
+		responses = append(responses, ParseResponse(p))
+	}
+
+	forms := []Form{
+		{CustomerCode: "hts", ObjectID: "CHG-1"},
+		{CustomerCode: "hts", ObjectID: "CIC-2"},
+		{CustomerCode: "hts", ObjectID: "CHG-3", SurveyType: "change", Quarter: "2025-Q1"},
+	}
+
+	report := Analyze(responses, forms, Filter{}, Options{}, time.Now())
+	overall := report.Overall
+	if overall.Responses != 3 {
+		t.Fatalf("Expected duplicate token to be ignored, got %d responses", overall.Responses)
+	}
+	// One promoter, one passive, one detractor
+	if floatOf(overall.NPS) != 0 || overall.Passives != 1 {
+		t.Errorf("Unexpected NPS: %+v", overall)
+	}
+	if int(floatOf(overall.CSAT)) != 66 {
+		t.Errorf("Expected 2 of 3 satisfied, got %v", floatOf(overall.CSAT))
+	}
+	if overall.Surveys != 3 || overall.RespondedSurveys != 2 {
+		t.Errorf("Unexpected response rate inputs: %+v", overall)
+	}
+
+	var hts *Stats
+	for _, stats := range report.GroupsFor(DimensionCustomer) {
+		if stats.Key == "hts" {
+			hts = stats
+		}
+	}
+	if hts == nil || hts.Responses != 2 || hts.Surveys != 3 {
+		t.Errorf("Unexpected customer group: %+v", hts)
+	}
+
+	events := report.GroupsFor(DimensionEvent)
+	if len(events) != 3 || events[1].Key != "CHG-3" || events[1].ResponseRate == nil || floatOf(events[1].ResponseRate) != 0 {
+		t.Errorf("Unexpected event groups: %+v", events)
+	}
+
+	filtered := Analyze(responses, forms, Filter{SurveyType: "cic"}, Options{}, time.Now())
+	if filtered.Overall.Responses != 1 || filtered.Overall.Surveys != 1 {
+		t.Errorf("Unexpected filtered report: %+v", filtered.Overall)
+	}
+}
+
+func TestParseFormKey(t *testing.T) {
+	form, ok := ParseFormKey("surveys/forms/hts/FIN-abc/1735732800-aBcD12.json")
+	if !ok || form.FormID != "aBcD12" || form.CustomerCode != "hts" || form.SurveyType != "finops" || form.Quarter != "2025-Q1" {
+		t.Errorf("Unexpected form: %+v (ok=%v)", form, ok)
+	}
+	if _, ok := ParseFormKey("surveys/forms/hts/readme.txt"); ok {
+		t.Error("Expected malformed key to be rejected")
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	report := Analyze([]Response{ParseResponse(payload("t1", "hts", "CHG-1", "change", "general", 9, true, "Nice"))}, nil, Filter{CustomerCode: "hts"}, Options{}, time.Now())
+
+	var csvOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csvOut.String(), "overall,all,1,0,0,,1,0,0,100.0,9.00,1,100.0") {
+		t.Errorf("Unexpected CSV:\n%s", csvOut.String())
+	}
+
+	data := report.TemplateData()
+	if data.Subtitle != "hts" || data.Metrics[3].Value != "n/a" {
+		t.Errorf("Unexpected template data: %+v", data)
+	}
+}
+
+func TestResultKey(t *testing.T) {
+	p := payload("t1", "cds", "CHG-1", "change", "general", 9, true, "")
+	p.FormResponse.FormID = "aBcD12"
+	if key := resultKey(p.FormResponse); key != "surveys/results/cds/2025/Q1/1739188800-aBcD12-t1.json" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+
+	// Missing hidden fields use the same fallback as the webhook
+	p.FormResponse.Hidden = nil
+	p.FormResponse.SubmittedAt = "2025-11-03T08:00:00Z"
+	if key := resultKey(p.FormResponse); key != "surveys/results/unknown/2025/Q4/1762156800-aBcD12-t1.json" {
+		t.Errorf("Unexpected fallback key: %s", key)
+	}
+}
+
+func TestResultPrefixes(t *testing.T) {
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	forms := []Form{
+		{FormID: "f1", CustomerCode: "hts", CreatedAt: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{FormID: "f2", CustomerCode: "hts", CreatedAt: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{FormID: "f3", CustomerCode: "cds"},
+	}
+
+	want := []string{
+		"surveys/results/cds/",
+		"surveys/results/hts/2025/Q1/",
+		"surveys/results/hts/2025/Q2/",
+		"surveys/results/hts/2025/Q3/",
+	}
+	if got := resultPrefixes(forms, now); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("resultPrefixes = %v, want %v", got, want)
+	}
+}
+
+func TestPersonalizeSurveyURL(t *testing.T) {
+	base := "https://form.typeform.com/to/aBcD12?customer_code=hts&object_id=CHG-1"
+	if got := PersonalizeSurveyURL(base, "Jane.Doe+ops@example.com"); got != base+"&user_login=Jane.Doe%2Bops%40example.com" {
+		t.Errorf("Unexpected URL: %s", got)
+	}
+	if got := PersonalizeSurveyURL("https://form.typeform.com/to/aBcD12", "a@example.com"); got != "https://form.typeform.com/to/aBcD12?user_login=a%40example.com" {
+		t.Errorf("Unexpected URL without query: %s", got)
+	}
+}
+
+func TestDistributionNextAction(t *testing.T) {
+	sent := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	remindAfter, closeAfter := 3*24*time.Hour, 14*24*time.Hour
+	d := &Distribution{SentAt: sent}
+
+	if action := d.NextAction(sent.Add(48*time.Hour), remindAfter, closeAfter); action != ReminderActionNone {
+		t.Errorf("Expected no action before the reminder, got %q", action)
+	}
+	if action := d.NextAction(sent.Add(remindAfter), remindAfter, closeAfter); action != ReminderActionRemind {
+		t.Errorf("Expected remind, got %q", action)
+	}
+
+	reminded := sent.Add(remindAfter)
+	d.RemindedAt = &reminded
+	if action := d.NextAction(sent.Add(5*24*time.Hour), remindAfter, closeAfter); action != ReminderActionNone {
+		t.Errorf("Expected a single reminder, got %q", action)
+	}
+	if action := d.NextAction(sent.Add(closeAfter), remindAfter, closeAfter); action != ReminderActionClose {
+		t.Errorf("Expected close, got %q", action)
+	}
+
+	closed := sent.Add(closeAfter)
+	d.ClosedAt = &closed
+	if action := d.NextAction(sent.Add(30*24*time.Hour), remindAfter, closeAfter); action != ReminderActionNone {
+		t.Errorf("Expected no action once closed, got %q", action)
+	}
+}
+
+func TestDistributionNonRespondents(t *testing.T) {
+	d := &Distribution{CustomerCode: "hts", ObjectID: "CHG-1"}
+	d.addRecipients([]string{"b@example.com", "a@example.com", "c@example.com"})
+	d.addRecipients([]string{"A@example.com", ""})
+	if strings.Join(d.Recipients, ",") != "a@example.com,b@example.com,c@example.com" {
+		t.Fatalf("Unexpected recipients: %v", d.Recipients)
+	}
+
+	responses := []Response{
+		{CustomerCode: "HTS", ObjectID: "chg-1", UserLogin: "A@Example.com"}, // Matches ignoring case
+		{CustomerCode: "cds", ObjectID: "CHG-1", UserLogin: "b@example.com"}, // Other customer
+		{CustomerCode: "hts", ObjectID: "CHG-2", UserLogin: "c@example.com"}, // Other change
+		{CustomerCode: "hts", ObjectID: "CHG-1"},                             // Anonymous
+	}
+	if pending := d.NonRespondents(responses); strings.Join(pending, ",") != "b@example.com,c@example.com" {
+		t.Errorf("Unexpected non-respondents: %v", pending)
+	}
+}
+
+// conditionalStore is an S3 stand-in for a single bucket that honours If-Match and If-None-Match
+type conditionalStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	versions map[string]int
+	onPut    func(key string) // Called before a conditional write is checked
+}
+
+func (c *conditionalStore) serve(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	if r.Method == http.MethodPut && c.onPut != nil {
+		c.onPut(key)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	body, exists := c.objects[key]
+	etag := fmt.Sprintf(`"v%d"`, c.versions[key])
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write(body)
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); (match != "" && (!exists || match != etag)) || (r.Header.Get("If-None-Match") == "*" && exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `<Error><Code>PreconditionFailed</Code></Error>`)
+			return
+		}
+		c.objects[key], _ = io.ReadAll(r.Body)
+		c.versions[key]++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, c.versions[key]))
+	}
+}
+
+func (c *conditionalStore) put(key string, value interface{}) {
+	data, _ := json.Marshal(value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = data
+	c.versions[key]++
+}
+
+func TestRecordDistributionKeepsConcurrentUpdates(t *testing.T) {
+	store := &conditionalStore{objects: make(map[string][]byte), versions: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(store.serve))
+	defer server.Close()
+
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	})
+	ctx := context.Background()
+	key := DistributionKey("hts", "CHG-1")
+	sentAt := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	// The sweep stamps the reminder between our read and our write
+	remindedAt := sentAt.Add(72 * time.Hour)
+	store.put(key, Distribution{ObjectID: "CHG-1", CustomerCode: "hts", FormID: "f1", Recipients: []string{"a@example.com"}, SentAt: sentAt})
+	store.onPut = func(string) {
+		store.onPut = nil
+		store.put(key, Distribution{ObjectID: "CHG-1", CustomerCode: "hts", FormID: "f1", Recipients: []string{"a@example.com"}, SentAt: sentAt, RemindedAt: &remindedAt})
+	}
+
+	if err := RecordDistribution(ctx, s3Client, "bucket", Distribution{ObjectID: "CHG-1", CustomerCode: "hts", FormID: "f1", Recipients: []string{"b@example.com"}, SentAt: sentAt.Add(time.Hour)}); err != nil {
+		t.Fatalf("RecordDistribution failed: %v", err)
+	}
+
+	got, err := LoadDistribution(ctx, s3Client, "bucket", "hts", "CHG-1")
+	if err != nil {
+		t.Fatalf("LoadDistribution failed: %v", err)
+	}
+	if strings.Join(got.Recipients, ",") != "a@example.com,b@example.com" || got.RemindedAt == nil || !got.SentAt.Equal(sentAt) {
+		t.Errorf("Expected merged recipients and the concurrent reminder to be kept, got %+v", got)
+	}
+}
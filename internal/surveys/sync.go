@@ -0,0 +1,208 @@
+package surveys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"ccoe-customer-contact-manager/internal/typeform"
+)
+
+// errResultExists is returned when a response is already stored under its key
+var errResultExists = errors.New("survey result already exists")
+
+// ResponseLister pages through the responses of a Typeform form. *typeform.Client implements it.
+type ResponseLister interface {
+	ListResponses(ctx context.Context, formID string) ([]typeform.FormResponse, error)
+}
+
+// SyncOptions narrow a sync to the forms of one customer or event
+type SyncOptions struct {
+	CustomerCode string
+	ObjectID     string
+	DryRun       bool
+}
+
+// SyncResult summarizes a sync
+type SyncResult struct {
+	Forms    int      // Forms queried
+	Fetched  int      // Responses returned by Typeform
+	Existing int      // Responses already stored
+	Stored   int      // Responses written (or that would be written in a dry run)
+	Failed   int      // Forms or responses that could not be synced
+	Keys     []string // Keys written
+}
+
+// Sync pulls every response for the recorded survey forms from the Typeform Responses API and
+// stores the ones missing from surveys/results/, deduplicating by response token. This recovers
+// responses lost while the webhook was down or rejecting signatures.
+func Sync(ctx context.Context, lister ResponseLister, s3Client *s3.Client, bucket string, opts SyncOptions) (*SyncResult, error) {
+	forms, err := LoadForms(ctx, s3Client, bucket, opts.CustomerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []Form
+	seenForms := make(map[string]bool)
+	for _, form := range forms {
+		if seenForms[form.FormID] || (opts.ObjectID != "" && form.ObjectID != opts.ObjectID) {
+			continue
+		}
+		seenForms[form.FormID] = true
+		selected = append(selected, form)
+	}
+
+	// Only the results the selected forms can have been stored under are scanned for tokens.
+	// A response stored elsewhere is still caught by the create-only write below.
+	known, err := loadKnownTokens(ctx, s3Client, bucket, resultPrefixes(selected, time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	for _, form := range selected {
+		result.Forms++
+
+		responses, err := lister.ListResponses(ctx, form.FormID)
+		if err != nil {
+			log.Printf("⚠️  Failed to fetch responses for form %s (%s): %v", form.FormID, form.ObjectID, err)
+			result.Failed++
+			continue
+		}
+		result.Fetched += len(responses)
+
+		for _, response := range responses {
+			if response.Token == "" || known[response.Token] {
+				result.Existing++
+				continue
+			}
+			known[response.Token] = true
+
+			payload := typeform.WebhookPayload{EventType: "form_response", FormResponse: response}
+			key := resultKey(response)
+			if opts.DryRun {
+				result.Stored++
+				result.Keys = append(result.Keys, key)
+				continue
+			}
+
+			err := storeResult(ctx, s3Client, bucket, key, payload)
+			if errors.Is(err, errResultExists) {
+				result.Existing++
+				continue
+			}
+			if err != nil {
+				log.Printf("⚠️  Failed to store response %s for form %s: %v", response.Token, form.FormID, err)
+				result.Failed++
+				continue
+			}
+			result.Stored++
+			result.Keys = append(result.Keys, key)
+		}
+	}
+
+	return result, nil
+}
+
+// resultPrefixes returns the surveys/results/ prefixes responses to the forms are stored under:
+// the form's customer for every quarter from the form's creation up to now, since the year and
+// quarter hidden fields are set when the survey is sent. Forms without a creation time map to
+// all of their customer's results.
+func resultPrefixes(forms []Form, now time.Time) []string {
+	var prefixes []string
+	seen := make(map[string]bool)
+	add := func(prefix string) {
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	now = now.UTC()
+	for _, form := range forms {
+		if form.CreatedAt.IsZero() {
+			add(customerPrefix(ResultsPrefix, form.CustomerCode))
+			continue
+		}
+		created := form.CreatedAt.UTC()
+		quarter := time.Date(created.Year(), time.Month((int(created.Month())-1)/3*3+1), 1, 0, 0, 0, 0, time.UTC)
+		for ; !quarter.After(now); quarter = quarter.AddDate(0, 3, 0) {
+			add(fmt.Sprintf("%s%s/%d/Q%d/", ResultsPrefix, form.CustomerCode, quarter.Year(), (int(quarter.Month())-1)/3+1))
+		}
+	}
+
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// loadKnownTokens returns the response tokens stored under the prefixes. A customer-wide prefix
+// covers the quarter prefixes under it, so those are not scanned twice.
+func loadKnownTokens(ctx context.Context, s3Client *s3.Client, bucket string, prefixes []string) (map[string]bool, error) {
+	known := make(map[string]bool)
+	scanned := ""
+	for _, prefix := range prefixes {
+		if scanned != "" && strings.HasPrefix(prefix, scanned) {
+			continue
+		}
+		if strings.Count(strings.TrimPrefix(prefix, ResultsPrefix), "/") == 1 {
+			scanned = prefix
+		}
+
+		err := forEachObject(ctx, s3Client, bucket, prefix, func(key string, body []byte) {
+			var payload typeform.WebhookPayload
+			if err := json.Unmarshal(body, &payload); err != nil {
+				log.Printf("⚠️  Skipping malformed survey result %s: %v", key, err)
+				return
+			}
+			if token := payload.FormResponse.Token; token != "" {
+				known[token] = true
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load survey results: %w", err)
+		}
+	}
+	return known, nil
+}
+
+// storeResult writes a payload unless its key already exists, e.g. because the webhook stored the
+// response while the sync was running
+func storeResult(ctx context.Context, s3Client *s3.Client, bucket, key string, payload typeform.WebhookPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+		IfNoneMatch: aws.String("*"),
+	})
+	if typeform.IsPreconditionFailed(err) {
+		return errResultExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// resultKey places a synced response at the key the webhook uses for it
+func resultKey(response typeform.FormResponse) string {
+	submittedAt, err := time.Parse(time.RFC3339, response.SubmittedAt)
+	if err != nil {
+		submittedAt = time.Now()
+	}
+	return typeform.ResultKey(response, submittedAt)
+}
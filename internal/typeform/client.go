@@ -9,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 )
@@ -28,6 +29,7 @@ type Client struct {
 	logger         *slog.Logger
 	workspaceCache map[string]string // Cache of workspace name -> ID
 	themeCache     map[string]string // Cache of theme name -> theme href
+	surveyConfig   *SurveyConfig     // Survey templates, workspaces and themes from TYPEFORM_SURVEY_CONFIG
 }
 
 // NewClient creates a new Typeform API client
@@ -362,3 +364,25 @@ func (c *Client) CreateForm(ctx context.Context, request *CreateFormRequest) (*C
 
 	return &result, nil
 }
+
+// PatchOperation is a JSON Patch operation applied to a form
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// CloseForm stops a form from accepting responses by making it private. Respondents who open
+// the link afterwards see Typeform's closed-form screen.
+func (c *Client) CloseForm(ctx context.Context, formID string) error {
+	patch := []PatchOperation{{Op: "replace", Path: "/settings/is_public", Value: false}}
+
+	resp, err := c.doRequest(ctx, http.MethodPatch, "/forms/"+url.PathEscape(formID), patch)
+	if err != nil {
+		return fmt.Errorf("failed to close form %s: %w", formID, err)
+	}
+	resp.Body.Close()
+
+	c.logger.Info("typeform survey closed", "survey_id", formID)
+	return nil
+}
@@ -21,6 +21,7 @@ type CreateFormRequest struct {
 	Theme     *Theme        `json:"theme,omitempty"`
 	Fields    []Field       `json:"fields"`
 	Hidden    []string      `json:"hidden,omitempty"`
+	Logic     []Logic       `json:"logic,omitempty"`
 }
 
 // WorkspaceRef represents a reference to a Typeform workspace
@@ -59,71 +60,39 @@ type SurveyMetadata struct {
 
 // CreateSurvey creates a Typeform survey for a completed object
 func (c *Client) CreateSurvey(ctx context.Context, s3Client *s3.Client, bucketName string, metadata *SurveyMetadata, surveyType SurveyType) (*CreateFormResponse, error) {
-	// 1. Retrieve customer logo from S3 with fallback to default
-	logoData, err := c.getCustomerLogoWithFallback(ctx, s3Client, bucketName, metadata.CustomerCode)
+	// Load the survey configuration (templates, workspaces, themes) if one is configured
+	surveyConfig, err := c.loadSurveyConfig(ctx, s3Client)
 	if err != nil {
-		c.logger.Warn("failed to retrieve logo, continuing without logo",
-			"customer_code", metadata.CustomerCode,
-			"error", err)
+		return nil, err
 	}
 
-	// 2. Create or get theme with logo
+	// 1. Use the theme configured for this event type, or create one with the customer logo
 	var theme *Theme
-	if len(logoData) > 0 {
-		// Upload image to Typeform and get image ID and src URL
-		fileName := fmt.Sprintf("%s-logo.png", metadata.CustomerCode)
-		imageID, imageSrc, err := c.UploadImage(ctx, logoData, fileName)
-		if err != nil {
-			c.logger.Warn("failed to upload logo to typeform, continuing without logo",
-				"customer_code", metadata.CustomerCode,
-				"error", err)
-		} else {
-			c.logger.Info("logo uploaded to typeform",
-				"customer_code", metadata.CustomerCode,
-				"image_id", imageID,
-				"image_src", imageSrc,
-				"size_bytes", len(logoData))
-
-			// Create theme with the logo using the image src URL
-			// Theme name format: {event_type}-{event_subtype} (e.g., "announcement-cic", "change-general")
-			themeName := fmt.Sprintf("%s-%s", metadata.EventType, metadata.EventSubtype)
-			themeResponse, err := c.CreateTheme(ctx, themeName, imageSrc, surveyType)
-			if err != nil {
-				c.logger.Warn("failed to create theme, continuing without theme",
-					"theme_name", themeName,
-					"error", err)
-			} else {
-				// Use the theme href from the response
-				if themeResponse.Links != nil {
-					if href, ok := themeResponse.Links["self"]; ok {
-						theme = &Theme{
-							Href: href,
-						}
-						c.logger.Info("theme created and will be applied to form",
-							"theme_id", themeResponse.ID,
-							"theme_name", themeName)
-					}
-				}
-			}
-		}
+	if href := surveyConfig.ThemeHref(metadata.EventType, metadata.EventSubtype); href != "" {
+		theme = &Theme{Href: href}
+	} else {
+		theme = c.createLogoTheme(ctx, s3Client, bucketName, metadata, surveyType)
 	}
 
-	// 3. Get survey template for type
-	template := GetSurveyTemplate(surveyType)
+	// 2. Get survey template for type
+	template := surveyConfig.Template(surveyType)
 
-	// 4. Customize the "Was this excellent?" question with the actual title
+	// 3. Customize the "Was this excellent?" question with the actual title
 	fields := make([]Field, len(template.Fields))
 	copy(fields, template.Fields)
 
-	// Update the first field (yes/no question) with the title as description
+	// Update the first field (yes/no question) with the title as description. The properties are
+	// copied so the shared template is left untouched.
 	if len(fields) > 0 && metadata.ObjectTitle != "" {
-		if fields[0].Properties == nil {
-			fields[0].Properties = make(map[string]interface{})
+		properties := make(map[string]interface{}, len(fields[0].Properties)+1)
+		for key, value := range fields[0].Properties {
+			properties[key] = value
 		}
-		fields[0].Properties["description"] = metadata.ObjectTitle
+		properties["description"] = metadata.ObjectTitle
+		fields[0].Properties = properties
 	}
 
-	// 5. Build create request
+	// 4. Build create request
 	// Use ObjectTitle if available, otherwise fall back to ObjectID
 	title := metadata.ObjectTitle
 	if title == "" {
@@ -131,7 +100,7 @@ func (c *Client) CreateSurvey(ctx context.Context, s3Client *s3.Client, bucketNa
 	}
 
 	// Determine workspace based on survey type
-	workspaceID := getWorkspaceNameForSurveyType(surveyType)
+	workspaceID := surveyConfig.WorkspaceID(surveyType)
 	var workspace *WorkspaceRef
 	if workspaceID != "" {
 		workspace = &WorkspaceRef{
@@ -145,15 +114,8 @@ func (c *Client) CreateSurvey(ctx context.Context, s3Client *s3.Client, bucketNa
 		Workspace: workspace,
 		Theme:     theme, // Include theme with logo if available
 		Fields:    fields,
-		Hidden: []string{
-			"user_login",
-			"customer_code",
-			"year",
-			"quarter",
-			"event_type",
-			"event_subtype",
-			"object_id",
-		},
+		Hidden:    template.HiddenFields(),
+		Logic:     template.Logic,
 	}
 
 	// 5. Call Typeform Create API
@@ -188,6 +150,85 @@ func (c *Client) CreateSurvey(ctx context.Context, s3Client *s3.Client, bucketNa
 	return response, nil
 }
 
+// createLogoTheme uploads the customer logo (or the default one) and creates a theme with it.
+// Failures are logged and yield no theme, so the survey is still created.
+func (c *Client) createLogoTheme(ctx context.Context, s3Client *s3.Client, bucketName string, metadata *SurveyMetadata, surveyType SurveyType) *Theme {
+	// Retrieve customer logo from S3 with fallback to default
+	logoData, err := c.getCustomerLogoWithFallback(ctx, s3Client, bucketName, metadata.CustomerCode)
+	if err != nil {
+		c.logger.Warn("failed to retrieve logo, continuing without logo",
+			"customer_code", metadata.CustomerCode,
+			"error", err)
+	}
+
+	// Create or get theme with logo
+	var theme *Theme
+	if len(logoData) > 0 {
+		// Upload image to Typeform and get image ID and src URL
+		fileName := fmt.Sprintf("%s-logo.png", metadata.CustomerCode)
+		imageID, imageSrc, err := c.UploadImage(ctx, logoData, fileName)
+		if err != nil {
+			c.logger.Warn("failed to upload logo to typeform, continuing without logo",
+				"customer_code", metadata.CustomerCode,
+				"error", err)
+		} else {
+			c.logger.Info("logo uploaded to typeform",
+				"customer_code", metadata.CustomerCode,
+				"image_id", imageID,
+				"image_src", imageSrc,
+				"size_bytes", len(logoData))
+
+			// Create theme with the logo using the image src URL
+			// Theme name format: {event_type}-{event_subtype} (e.g., "announcement-cic", "change-general")
+			themeName := fmt.Sprintf("%s-%s", metadata.EventType, metadata.EventSubtype)
+			themeResponse, err := c.CreateTheme(ctx, themeName, imageSrc, surveyType)
+			if err != nil {
+				c.logger.Warn("failed to create theme, continuing without theme",
+					"theme_name", themeName,
+					"error", err)
+			} else {
+				// Use the theme href from the response
+				if themeResponse.Links != nil {
+					if href, ok := themeResponse.Links["self"]; ok {
+						theme = &Theme{
+							Href: href,
+						}
+						c.logger.Info("theme created and will be applied to form",
+							"theme_id", themeResponse.ID,
+							"theme_name", themeName)
+					}
+				}
+			}
+		}
+	}
+
+	return theme
+}
+
+// loadSurveyConfig loads the survey configuration named by TYPEFORM_SURVEY_CONFIG. Without one the
+// built-in templates are used. An invalid configuration is an error rather than a silent fallback.
+func (c *Client) loadSurveyConfig(ctx context.Context, s3Client *s3.Client) (*SurveyConfig, error) {
+	if c.surveyConfig != nil {
+		return c.surveyConfig, nil
+	}
+
+	source := os.Getenv(SurveyConfigEnvVar)
+	if source == "" {
+		return nil, nil
+	}
+
+	surveyConfig, err := LoadSurveyConfig(ctx, s3Client, source)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Info("survey config loaded",
+		"source", source,
+		"templates", len(surveyConfig.Templates))
+
+	c.surveyConfig = surveyConfig
+	return surveyConfig, nil
+}
+
 // getCustomerLogoWithFallback retrieves customer logo with fallback to default
 func (c *Client) getCustomerLogoWithFallback(ctx context.Context, s3Client *s3.Client, bucketName, customerCode string) ([]byte, error) {
 	// Try customer-specific logo
@@ -0,0 +1,84 @@
+package typeform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ResponsesPageSize is the largest page the Responses API returns
+const ResponsesPageSize = 1000
+
+// ListResponsesResponse is one page of the Responses API
+type ListResponsesResponse struct {
+	TotalItems int            `json:"total_items"`
+	PageCount  int            `json:"page_count"`
+	Items      []ResponseItem `json:"items"`
+}
+
+// ResponseItem is a single response returned by the Responses API
+type ResponseItem struct {
+	LandingID   string            `json:"landing_id"`
+	Token       string            `json:"token"`
+	ResponseID  string            `json:"response_id"`
+	LandedAt    string            `json:"landed_at"`
+	SubmittedAt string            `json:"submitted_at"`
+	Hidden      map[string]string `json:"hidden"`
+	Answers     []Answer          `json:"answers"`
+}
+
+// FormResponse converts the item into the form_response shape delivered by webhooks
+func (item ResponseItem) FormResponse(formID string) FormResponse {
+	return FormResponse{
+		FormID:      formID,
+		Token:       item.Token,
+		SubmittedAt: item.SubmittedAt,
+		Hidden:      item.Hidden,
+		Answers:     item.Answers,
+	}
+}
+
+// ListResponses pages through every completed response of a form, newest first
+func (c *Client) ListResponses(ctx context.Context, formID string) ([]FormResponse, error) {
+	var responses []FormResponse
+	before := ""
+
+	for {
+		query := url.Values{}
+		query.Set("page_size", strconv.Itoa(ResponsesPageSize))
+		query.Set("completed", "true")
+		if before != "" {
+			query.Set("before", before)
+		}
+
+		resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/forms/%s/responses?%s", url.PathEscape(formID), query.Encode()), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list responses for form %s: %w", formID, err)
+		}
+
+		var page ListResponsesResponse
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode responses for form %s: %w", formID, err)
+		}
+
+		for _, item := range page.Items {
+			responses = append(responses, item.FormResponse(formID))
+		}
+
+		c.logger.Debug("retrieved typeform responses page",
+			"form_id", formID,
+			"items", len(page.Items),
+			"total_items", page.TotalItems)
+
+		// Pages are ordered newest first, so the next page starts before the oldest token seen
+		if len(page.Items) < ResponsesPageSize {
+			return responses, nil
+		}
+		before = page.Items[len(page.Items)-1].Token
+	}
+}
@@ -0,0 +1,384 @@
+package typeform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"sigs.k8s.io/yaml"
+)
+
+// SurveyConfigEnvVar names the file path or s3://bucket/key of the survey configuration
+const SurveyConfigEnvVar = "TYPEFORM_SURVEY_CONFIG"
+
+// SurveyConfig defines survey templates, workspaces and themes outside the code. Anything it
+// leaves out falls back to the built-in templates and workspaces.
+type SurveyConfig struct {
+	Templates  map[SurveyType]*SurveyTemplate `json:"templates,omitempty"`
+	Workspaces map[SurveyType]string          `json:"workspaces,omitempty"` // Survey type -> workspace ID
+	Themes     map[string]string              `json:"themes,omitempty"`     // "{event_type}-{event_subtype}" -> theme ID or href
+}
+
+// fieldTypes are the Typeform field types a template may use
+var fieldTypes = map[string]bool{
+	"short_text": true, "long_text": true, "statement": true, "email": true, "number": true,
+	"date": true, "yes_no": true, "legal": true, "opinion_scale": true, "nps": true,
+	"rating": true, "multiple_choice": true, "dropdown": true, "picture_choice": true, "ranking": true,
+}
+
+// choiceFieldTypes need at least one choice in properties.choices
+var choiceFieldTypes = map[string]bool{
+	"multiple_choice": true, "dropdown": true, "picture_choice": true, "ranking": true,
+}
+
+// logicActions are the actions a logic rule may take
+var logicActions = map[string]bool{
+	"jump": true, "add": true, "subtract": true, "multiply": true, "divide": true, "set": true,
+}
+
+var (
+	refPattern    = regexp.MustCompile(`^[A-Za-z0-9_-]{1,255}$`)
+	hiddenPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// LoadSurveyConfig reads a survey configuration from a local file or an s3://bucket/key URL.
+// Files ending in .yaml or .yml are parsed as YAML, anything else as JSON. The configuration is
+// validated before it is returned.
+func LoadSurveyConfig(ctx context.Context, s3Client *s3.Client, source string) (*SurveyConfig, error) {
+	var data []byte
+	var err error
+
+	if bucket, key, ok := strings.Cut(strings.TrimPrefix(source, "s3://"), "/"); strings.HasPrefix(source, "s3://") {
+		if !ok || bucket == "" || key == "" {
+			return nil, fmt.Errorf("invalid survey config location %s: expected s3://bucket/key", source)
+		}
+		if s3Client == nil {
+			return nil, fmt.Errorf("an S3 client is required to load survey config from %s", source)
+		}
+		data, err = readS3Object(ctx, s3Client, bucket, key)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read survey config %s: %w", source, err)
+	}
+
+	config, err := ParseSurveyConfig(data, path.Ext(source))
+	if err != nil {
+		return nil, fmt.Errorf("invalid survey config %s: %w", source, err)
+	}
+	return config, nil
+}
+
+// readS3Object downloads an object whatever its content type
+func readS3Object(ctx context.Context, s3Client *s3.Client, bucket, key string) ([]byte, error) {
+	result, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer result.Body.Close()
+
+	return io.ReadAll(result.Body)
+}
+
+// ParseSurveyConfig parses and validates a survey configuration. ext selects the format (".yaml",
+// ".yml" or anything else for JSON); unknown keys are rejected so typos do not go unnoticed.
+func ParseSurveyConfig(data []byte, ext string) (*SurveyConfig, error) {
+	var config SurveyConfig
+	var err error
+
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, &config)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks every template, workspace and theme and reports all problems at once
+func (c *SurveyConfig) Validate() error {
+	var errs []error
+
+	for _, surveyType := range sortedKeys(c.Templates) {
+		template := c.Templates[surveyType]
+		if !isKnownSurveyType(surveyType) {
+			errs = append(errs, fmt.Errorf("templates: unknown survey type %q", surveyType))
+			continue
+		}
+		if template == nil {
+			errs = append(errs, fmt.Errorf("templates.%s: template is empty", surveyType))
+			continue
+		}
+		for _, err := range template.validate() {
+			errs = append(errs, fmt.Errorf("templates.%s: %w", surveyType, err))
+		}
+	}
+
+	for _, surveyType := range sortedKeys(c.Workspaces) {
+		workspaceID := c.Workspaces[surveyType]
+		if !isKnownSurveyType(surveyType) {
+			errs = append(errs, fmt.Errorf("workspaces: unknown survey type %q", surveyType))
+		} else if strings.TrimSpace(workspaceID) == "" {
+			errs = append(errs, fmt.Errorf("workspaces.%s: workspace ID is empty", surveyType))
+		}
+	}
+
+	for _, key := range sortedKeys(c.Themes) {
+		theme := c.Themes[key]
+		eventType, eventSubtype, ok := strings.Cut(key, "-")
+		if !ok || eventType == "" || eventSubtype == "" {
+			errs = append(errs, fmt.Errorf("themes: key %q must be {event_type}-{event_subtype}", key))
+		} else if strings.TrimSpace(theme) == "" {
+			errs = append(errs, fmt.Errorf("themes.%s: theme is empty", key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks the fields, hidden fields and logic of a template
+func (t *SurveyTemplate) validate() []error {
+	var errs []error
+
+	if len(t.Fields) == 0 {
+		errs = append(errs, fmt.Errorf("at least one field is required"))
+	}
+
+	refs := make(map[string]bool)
+	for i, field := range t.Fields {
+		name := fmt.Sprintf("fields[%d]", i)
+		if field.Ref != "" {
+			name = fmt.Sprintf("fields[%d] (%s)", i, field.Ref)
+			if !refPattern.MatchString(field.Ref) {
+				errs = append(errs, fmt.Errorf("%s: ref may only contain letters, digits, _ and -", name))
+			} else if refs[field.Ref] {
+				errs = append(errs, fmt.Errorf("%s: duplicate ref", name))
+			}
+			refs[field.Ref] = true
+		}
+
+		if !fieldTypes[field.Type] {
+			errs = append(errs, fmt.Errorf("%s: unsupported field type %q", name, field.Type))
+		}
+		if strings.TrimSpace(field.Title) == "" {
+			errs = append(errs, fmt.Errorf("%s: title is required", name))
+		}
+		if choiceFieldTypes[field.Type] && !hasChoices(field.Properties) {
+			errs = append(errs, fmt.Errorf("%s: %s needs properties.choices with a label for each choice", name, field.Type))
+		}
+		if err := checkSteps(field); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	hidden := make(map[string]bool)
+	for _, name := range DefaultHiddenFields {
+		hidden[name] = true
+	}
+	for _, name := range t.Hidden {
+		if !hiddenPattern.MatchString(name) {
+			errs = append(errs, fmt.Errorf("hidden: %q may only contain lowercase letters, digits and _", name))
+		}
+		hidden[name] = true
+	}
+
+	for i, logic := range t.Logic {
+		name := fmt.Sprintf("logic[%d]", i)
+		switch logic.Type {
+		case "field":
+			if !refs[logic.Ref] {
+				errs = append(errs, fmt.Errorf("%s: no field with ref %q", name, logic.Ref))
+			}
+		case "hidden":
+			if !hidden[logic.Ref] {
+				errs = append(errs, fmt.Errorf("%s: no hidden field %q", name, logic.Ref))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: type must be field or hidden", name))
+		}
+
+		if len(logic.Actions) == 0 {
+			errs = append(errs, fmt.Errorf("%s: at least one action is required", name))
+		}
+		for j, action := range logic.Actions {
+			actionName := fmt.Sprintf("%s.actions[%d]", name, j)
+			if !logicActions[action.Action] {
+				errs = append(errs, fmt.Errorf("%s: unsupported action %q", actionName, action.Action))
+				continue
+			}
+			if len(action.Condition) == 0 {
+				errs = append(errs, fmt.Errorf("%s: condition is required", actionName))
+			}
+			if action.Action == "jump" {
+				if err := checkJumpTarget(action.Details, refs); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", actionName, err))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// HiddenFields returns the default hidden fields followed by the template's extra ones
+func (t SurveyTemplate) HiddenFields() []string {
+	hidden := append([]string(nil), DefaultHiddenFields...)
+	seen := make(map[string]bool)
+	for _, name := range hidden {
+		seen[name] = true
+	}
+	for _, name := range t.Hidden {
+		if !seen[name] {
+			seen[name] = true
+			hidden = append(hidden, name)
+		}
+	}
+	return hidden
+}
+
+// Template returns the configured template for a survey type, or the built-in one
+func (c *SurveyConfig) Template(surveyType SurveyType) SurveyTemplate {
+	if c != nil {
+		if template, ok := c.Templates[surveyType]; ok && template != nil {
+			return *template
+		}
+	}
+	return GetSurveyTemplate(surveyType)
+}
+
+// WorkspaceID returns the configured workspace for a survey type, or the built-in one
+func (c *SurveyConfig) WorkspaceID(surveyType SurveyType) string {
+	if c != nil {
+		if workspaceID, ok := c.Workspaces[surveyType]; ok {
+			return workspaceID
+		}
+	}
+	return getWorkspaceNameForSurveyType(surveyType)
+}
+
+// ThemeHref returns the href of the theme configured for an event type and subtype, or "" when
+// the survey should get a theme generated from the customer logo
+func (c *SurveyConfig) ThemeHref(eventType, eventSubtype string) string {
+	theme := ""
+	if c != nil {
+		theme = c.Themes[fmt.Sprintf("%s-%s", eventType, eventSubtype)]
+	}
+	if theme == "" {
+		theme = getThemeIDForEventType(eventType, eventSubtype)
+	}
+	if theme == "" || strings.HasPrefix(theme, "https://") {
+		return theme
+	}
+	return fmt.Sprintf("%s/themes/%s", TypeformAPIBaseURL, theme)
+}
+
+// sortedKeys returns map keys in order so validation errors are reported consistently
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func isKnownSurveyType(surveyType SurveyType) bool {
+	switch surveyType {
+	case SurveyTypeChange, SurveyTypeCIC, SurveyTypeInnerSource, SurveyTypeFinOps, SurveyTypeGeneral:
+		return true
+	}
+	return false
+}
+
+// hasChoices reports whether properties.choices is a non-empty list of choices with labels
+func hasChoices(properties map[string]interface{}) bool {
+	choices, ok := properties["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		return false
+	}
+	for _, choice := range choices {
+		values, ok := choice.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if label, _ := values["label"].(string); strings.TrimSpace(label) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// checkSteps enforces Typeform's step limits on rating fields. Opinion scales must be 0-10
+// because survey analytics read every opinion scale answer as an NPS score.
+func checkSteps(field Field) error {
+	if field.Type == "opinion_scale" {
+		if startAtOne, _ := field.Properties["start_at_one"].(bool); startAtOne {
+			return fmt.Errorf("opinion_scale must start at 0 (0-10 NPS scale)")
+		}
+	}
+
+	value, ok := field.Properties["steps"]
+	if !ok {
+		return nil
+	}
+	steps, ok := value.(float64)
+	if !ok {
+		return fmt.Errorf("properties.steps must be a number")
+	}
+
+	switch field.Type {
+	case "opinion_scale":
+		if steps != 11 {
+			return fmt.Errorf("opinion_scale steps must be 11 (0-10 NPS scale); use a rating field for other scales")
+		}
+	case "rating":
+		if steps < 3 || steps > 10 {
+			return fmt.Errorf("rating steps must be between 3 and 10")
+		}
+	}
+	return nil
+}
+
+// checkJumpTarget checks that a jump goes to an existing field or to a thank you screen
+func checkJumpTarget(details map[string]interface{}, refs map[string]bool) error {
+	to, _ := details["to"].(map[string]interface{})
+	targetType, _ := to["type"].(string)
+	target, _ := to["value"].(string)
+
+	switch targetType {
+	case "field":
+		if !refs[target] {
+			return fmt.Errorf("jump target %q is not a field ref", target)
+		}
+	case "thankyou":
+		if target == "" {
+			return fmt.Errorf("jump target thank you screen has no ref")
+		}
+	default:
+		return fmt.Errorf("details.to.type must be field or thankyou")
+	}
+	return nil
+}
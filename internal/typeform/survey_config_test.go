@@ -0,0 +1,162 @@
+package typeform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestParseSurveyConfigExample(t *testing.T) {
+	data, err := os.ReadFile("../../SurveyConfig.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ParseSurveyConfig(data, ".yaml")
+	if err != nil {
+		t.Fatalf("Expected example config to be valid: %v", err)
+	}
+
+	template := config.Template(SurveyTypeChange)
+	if len(template.Fields) != 4 || template.Fields[0].Validations == nil || !template.Fields[0].Validations.Required {
+		t.Errorf("Unexpected change template: %+v", template)
+	}
+	if len(template.Logic) != 1 || template.Logic[0].Actions[0].Action != "jump" {
+		t.Errorf("Unexpected logic: %+v", template.Logic)
+	}
+	if hidden := template.HiddenFields(); len(hidden) != len(DefaultHiddenFields)+1 || hidden[len(hidden)-1] != "change_window" {
+		t.Errorf("Unexpected hidden fields: %v", hidden)
+	}
+
+	// Types without a configured template or workspace keep the built-in ones
+	if fields := config.Template(SurveyTypeFinOps).Fields; len(fields) != 3 || !strings.Contains(fields[0].Title, "FinOps") {
+		t.Errorf("Expected built-in FinOps template, got %+v", fields)
+	}
+	if config.WorkspaceID(SurveyTypeFinOps) != "uVr3cK" {
+		t.Errorf("Expected built-in FinOps workspace, got %s", config.WorkspaceID(SurveyTypeFinOps))
+	}
+
+	if href := config.ThemeHref("announcement", "cic"); href != "https://api.typeform.com/themes/qHWOQ7" {
+		t.Errorf("Unexpected theme href: %s", href)
+	}
+	if href := config.ThemeHref("change", "general"); href != "" {
+		t.Errorf("Expected no theme for change-general, got %s", href)
+	}
+}
+
+func TestParseSurveyConfigJSON(t *testing.T) {
+	data := []byte(`{"templates": {"general": {"fields": [{"type": "nps", "title": "Recommend us?"}]}}}`)
+	config, err := ParseSurveyConfig(data, ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Template(SurveyTypeGeneral).Fields[0].Type != "nps" {
+		t.Errorf("Unexpected template: %+v", config.Template(SurveyTypeGeneral))
+	}
+
+	if _, err := ParseSurveyConfig([]byte(`{"templatez": {}}`), ".json"); err == nil {
+		t.Error("Expected unknown keys to be rejected")
+	}
+
+	var nilConfig *SurveyConfig
+	if len(nilConfig.Template(SurveyTypeCIC).Fields) != 3 || nilConfig.WorkspaceID(SurveyTypeCIC) != "SUXyVp" {
+		t.Error("Expected a nil config to use the built-in templates")
+	}
+}
+
+func TestSurveyConfigValidation(t *testing.T) {
+	data := []byte(`
+workspaces:
+  changes: abc
+themes:
+  cic: qHWOQ7
+templates:
+  cic:
+    fields:
+      - ref: q1
+        type: multiple_choice
+        title: Pick one
+      - ref: q1
+        type: opinion_scale
+        title: Scale
+        properties:
+          steps: 20
+      - ref: q2
+        type: opinion_scale
+        title: Satisfaction
+        properties:
+          start_at_one: true
+          steps: 11
+      - type: slider
+        title: ""
+    hidden:
+      - Bad-Name
+    logic:
+      - type: field
+        ref: missing
+        actions:
+          - action: jump
+            details:
+              to:
+                type: field
+                value: nowhere
+            condition:
+              op: always
+`)
+
+	_, err := ParseSurveyConfig(data, ".yml")
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	for _, want := range []string{
+		`workspaces: unknown survey type "changes"`,
+		`themes: key "cic" must be`,
+		"multiple_choice needs properties.choices",
+		"duplicate ref",
+		"opinion_scale steps must be 11",
+		"(q2): opinion_scale must start at 0",
+		`unsupported field type "slider"`,
+		"title is required",
+		`hidden: "Bad-Name"`,
+		`no field with ref "missing"`,
+		`jump target "nowhere" is not a field ref`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadSurveyConfigFromS3(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config-bucket/surveys/config.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"workspaces": {"general": "ws123"}}`))
+	}))
+	defer server.Close()
+
+	s3Client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	})
+
+	config, err := LoadSurveyConfig(context.Background(), s3Client, "s3://config-bucket/surveys/config.json")
+	if err != nil {
+		t.Fatalf("LoadSurveyConfig failed: %v", err)
+	}
+	if config.WorkspaceID(SurveyTypeGeneral) != "ws123" {
+		t.Errorf("Expected configured workspace, got %s", config.WorkspaceID(SurveyTypeGeneral))
+	}
+}
@@ -13,14 +13,49 @@ const (
 
 // Field represents a Typeform field
 type Field struct {
-	Type       string                 `json:"type"`
-	Title      string                 `json:"title"`
-	Properties map[string]interface{} `json:"properties,omitempty"`
+	ID          string                 `json:"id,omitempty"`
+	Ref         string                 `json:"ref,omitempty"`
+	Type        string                 `json:"type"`
+	Title       string                 `json:"title"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+	Validations *FieldValidations      `json:"validations,omitempty"`
+}
+
+// FieldValidations holds the validation rules of a Typeform field
+type FieldValidations struct {
+	Required bool `json:"required"`
+}
+
+// Logic is a Typeform logic rule evaluated after the field (or hidden field) with Ref is answered
+type Logic struct {
+	Type    string        `json:"type"` // field or hidden
+	Ref     string        `json:"ref"`
+	Actions []LogicAction `json:"actions"`
+}
+
+// LogicAction is a single logic action, e.g. a jump to another field when a condition holds
+type LogicAction struct {
+	Action    string                 `json:"action"` // jump, add, subtract, multiply, divide or set
+	Details   map[string]interface{} `json:"details"`
+	Condition map[string]interface{} `json:"condition"`
 }
 
 // SurveyTemplate defines the structure for each survey type
 type SurveyTemplate struct {
-	Fields []Field
+	Fields []Field  `json:"fields"`
+	Hidden []string `json:"hidden,omitempty"` // Hidden fields in addition to DefaultHiddenFields
+	Logic  []Logic  `json:"logic,omitempty"`
+}
+
+// DefaultHiddenFields are passed on every survey link and read back by the webhook and analytics
+var DefaultHiddenFields = []string{
+	"user_login",
+	"customer_code",
+	"year",
+	"quarter",
+	"event_type",
+	"event_subtype",
+	"object_id",
 }
 
 // GetSurveyTemplate returns the template for a given survey type
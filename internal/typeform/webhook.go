@@ -7,9 +7,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -40,6 +45,7 @@ type Answer struct {
 	Boolean *bool                  `json:"boolean,omitempty"`
 	Text    *string                `json:"text,omitempty"`
 	Choice  map[string]interface{} `json:"choice,omitempty"`
+	Choices map[string]interface{} `json:"choices,omitempty"`
 }
 
 // ValidateWebhookSignature validates the HMAC-SHA256 signature
@@ -51,51 +57,138 @@ func ValidateWebhookSignature(payload []byte, signature string, secret string) b
 	return hmac.Equal([]byte(signature), []byte(expectedSignature))
 }
 
+// ValidateWebhookSignatures reports whether the signature matches any of the active secrets, so a
+// new secret can be added in Typeform before the old one is retired
+func ValidateWebhookSignatures(payload []byte, signature string, secrets []string) bool {
+	for _, secret := range secrets {
+		if secret != "" && ValidateWebhookSignature(payload, signature, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWebhookSecrets splits a secret value holding one or more secrets separated by commas or newlines
+func ParseWebhookSecrets(value string) []string {
+	var secrets []string
+	for _, secret := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// DefaultSubmittedAtTolerance is how old a response may be before its webhook is rejected as a replay.
+// It covers Typeform's retry window; older responses can still be recovered with surveys sync.
+const DefaultSubmittedAtTolerance = 72 * time.Hour
+
+// maxClockSkew is how far in the future submitted_at may be
+const maxClockSkew = 5 * time.Minute
+
+// errDuplicateResult is returned when a survey response has already been stored
+var errDuplicateResult = errors.New("survey response already stored")
+
 // WebhookHandler handles Typeform webhook processing
 type WebhookHandler struct {
 	s3Client   *s3.Client
 	bucketName string
 	logger     *slog.Logger
+
+	// Secrets are the active webhook secrets. When empty they are read from TYPEFORM_WEBHOOK_SECRET.
+	Secrets []string
+	// SubmittedAtTolerance is the maximum age of a response; zero disables the check
+	SubmittedAtTolerance time.Duration
 }
 
-// NewWebhookHandler creates a new webhook handler
+// NewWebhookHandler creates a new webhook handler. The submitted_at tolerance defaults to
+// DefaultSubmittedAtTolerance and can be set with TYPEFORM_WEBHOOK_TOLERANCE (e.g. "48h").
 func NewWebhookHandler(s3Client *s3.Client, bucketName string, logger *slog.Logger) *WebhookHandler {
 	if logger == nil {
 		logger = slog.Default()
 	}
 
+	tolerance := DefaultSubmittedAtTolerance
+	if value := os.Getenv("TYPEFORM_WEBHOOK_TOLERANCE"); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			tolerance = parsed
+		} else {
+			logger.Warn("invalid TYPEFORM_WEBHOOK_TOLERANCE, using default",
+				"value", value,
+				"default", tolerance)
+		}
+	}
+
 	return &WebhookHandler{
-		s3Client:   s3Client,
-		bucketName: bucketName,
-		logger:     logger,
+		s3Client:             s3Client,
+		bucketName:           bucketName,
+		logger:               logger,
+		SubmittedAtTolerance: tolerance,
 	}
 }
 
-// HandleWebhook processes incoming Typeform webhooks
-func (h *WebhookHandler) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+// HandleWebhook processes incoming Typeform webhooks and returns the HTTP status to answer with.
+// Typeform retries 5xx responses, so only failures that may succeed later use them:
+//   - 200: stored, or a duplicate delivery that was already stored
+//   - 400: malformed payload
+//   - 401: missing or invalid signature
+//   - 422: submitted_at outside the tolerance (replay)
+//   - 500: webhook secret not configured
+//   - 503: storage failed
+func (h *WebhookHandler) HandleWebhook(ctx context.Context, payload []byte, signature string) (int, error) {
 	// 1. Validate signature
-	secret := os.Getenv("TYPEFORM_WEBHOOK_SECRET")
-	if secret == "" {
-		return fmt.Errorf("TYPEFORM_WEBHOOK_SECRET environment variable not set")
+	secrets := h.Secrets
+	if len(secrets) == 0 {
+		secrets = GetWebhookSecrets()
+	}
+	if len(secrets) == 0 {
+		return http.StatusInternalServerError, fmt.Errorf("TYPEFORM_WEBHOOK_SECRET environment variable not set")
 	}
 
-	if !ValidateWebhookSignature(payload, signature, secret) {
+	if signature == "" {
+		return http.StatusUnauthorized, fmt.Errorf("missing webhook signature")
+	}
+	if !ValidateWebhookSignatures(payload, signature, secrets) {
 		h.logger.Warn("invalid webhook signature received")
-		return fmt.Errorf("invalid webhook signature")
+		return http.StatusUnauthorized, fmt.Errorf("invalid webhook signature")
 	}
 
 	// 2. Parse payload
 	var webhook WebhookPayload
 	if err := json.Unmarshal(payload, &webhook); err != nil {
-		return fmt.Errorf("failed to parse webhook payload: %w", err)
+		return http.StatusBadRequest, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+	if webhook.FormResponse.Token == "" || webhook.FormResponse.FormID == "" {
+		return http.StatusBadRequest, fmt.Errorf("webhook payload has no form_id or response token")
 	}
 
 	h.logger.Info("webhook received",
 		"event_id", webhook.EventID,
 		"event_type", webhook.EventType,
-		"form_id", webhook.FormResponse.FormID)
+		"form_id", webhook.FormResponse.FormID,
+		"token", webhook.FormResponse.Token)
+
+	// 3. Reject replays of old responses
+	submittedAt, err := CheckSubmittedAt(webhook.FormResponse.SubmittedAt, h.SubmittedAtTolerance, time.Now())
+	if err != nil {
+		h.logger.Warn("webhook rejected",
+			"event_id", webhook.EventID,
+			"submitted_at", webhook.FormResponse.SubmittedAt,
+			"error", err)
+		if submittedAt.IsZero() {
+			return http.StatusBadRequest, err
+		}
+		return http.StatusUnprocessableEntity, err
+	}
 
-	// 3. Extract metadata from hidden fields
+	// 4. Skip events that were already processed
+	if webhook.EventID != "" && h.objectExists(ctx, webhookEventKey(webhook.EventID)) {
+		h.logger.Info("duplicate webhook event ignored",
+			"event_id", webhook.EventID)
+		return http.StatusOK, nil
+	}
+
+	// 5. Extract metadata from hidden fields
 	customerCode := webhook.FormResponse.Hidden["customer_code"]
 	year := webhook.FormResponse.Hidden["year"]
 	quarter := webhook.FormResponse.Hidden["quarter"]
@@ -108,20 +201,52 @@ func (h *WebhookHandler) HandleWebhook(ctx context.Context, payload []byte, sign
 		// Continue processing but log the issue
 	}
 
-	// 4. Store survey results in S3
-	if err := h.storeSurveyResults(ctx, &webhook, customerCode, year, quarter); err != nil {
-		return fmt.Errorf("failed to store survey results: %w", err)
+	// 6. Store survey results in S3 under a key derived from the response, so retries cannot duplicate it
+	key := ResultKey(webhook.FormResponse, submittedAt)
+	if err := h.storeSurveyResults(ctx, &webhook, key); err != nil {
+		if errors.Is(err, errDuplicateResult) {
+			h.logger.Info("duplicate survey response ignored",
+				"event_id", webhook.EventID,
+				"token", webhook.FormResponse.Token,
+				"key", key)
+		} else {
+			return http.StatusServiceUnavailable, fmt.Errorf("failed to store survey results: %w", err)
+		}
 	}
 
-	return nil
+	// 7. Record the event so later deliveries are skipped before touching the results
+	if webhook.EventID != "" {
+		if err := h.recordWebhookEvent(ctx, &webhook, key); err != nil {
+			h.logger.Warn("failed to record webhook event",
+				"event_id", webhook.EventID,
+				"error", err)
+		}
+	}
+
+	return http.StatusOK, nil
 }
 
-// storeSurveyResults stores survey results in S3 with retry logic
-func (h *WebhookHandler) storeSurveyResults(ctx context.Context, webhook *WebhookPayload, customerCode, year, quarter string) error {
-	timestamp := time.Now().Unix()
-	key := fmt.Sprintf("surveys/results/%s/%s/%s/%d-%s.json",
-		customerCode, year, quarter, timestamp, webhook.FormResponse.FormID)
+// CheckSubmittedAt parses submitted_at and rejects responses older than the tolerance or
+// meaningfully in the future. The parsed time is returned whenever it could be parsed.
+func CheckSubmittedAt(value string, tolerance time.Duration, now time.Time) (time.Time, error) {
+	submittedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid submitted_at %q: %w", value, err)
+	}
+
+	age := now.Sub(submittedAt)
+	if age < -maxClockSkew {
+		return submittedAt, fmt.Errorf("submitted_at %s is in the future", value)
+	}
+	if tolerance > 0 && age > tolerance {
+		return submittedAt, fmt.Errorf("submitted_at %s is older than the %s tolerance", value, tolerance)
+	}
+	return submittedAt, nil
+}
 
+// storeSurveyResults stores survey results in S3 with retry logic. The write only succeeds if the
+// key does not exist yet; otherwise errDuplicateResult is returned.
+func (h *WebhookHandler) storeSurveyResults(ctx context.Context, webhook *WebhookPayload, key string) error {
 	data, err := json.Marshal(webhook)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
@@ -137,6 +262,7 @@ func (h *WebhookHandler) storeSurveyResults(ctx context.Context, webhook *Webhoo
 			Key:         aws.String(key),
 			Body:        bytes.NewReader(data),
 			ContentType: aws.String("application/json"),
+			IfNoneMatch: aws.String("*"),
 		})
 
 		if err == nil {
@@ -145,6 +271,9 @@ func (h *WebhookHandler) storeSurveyResults(ctx context.Context, webhook *Webhoo
 				"form_id", webhook.FormResponse.FormID)
 			return nil
 		}
+		if IsPreconditionFailed(err) {
+			return errDuplicateResult
+		}
 
 		h.logger.Warn("s3 storage attempt failed",
 			"attempt", i+1,
@@ -160,7 +289,87 @@ func (h *WebhookHandler) storeSurveyResults(ctx context.Context, webhook *Webhoo
 	return fmt.Errorf("failed to store after %d retries: %w", maxRetries, err)
 }
 
+// recordWebhookEvent writes the event marker pointing at the stored result
+func (h *WebhookHandler) recordWebhookEvent(ctx context.Context, webhook *WebhookPayload, resultKey string) error {
+	data, err := json.Marshal(map[string]string{
+		"event_id":    webhook.EventID,
+		"token":       webhook.FormResponse.Token,
+		"result_key":  resultKey,
+		"received_at": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(h.bucketName),
+		Key:         aws.String(webhookEventKey(webhook.EventID)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+		IfNoneMatch: aws.String("*"),
+	})
+	if err != nil && !IsPreconditionFailed(err) {
+		return err
+	}
+	return nil
+}
+
+// objectExists reports whether an object exists. Lookup errors are treated as "not found" so a
+// transient failure falls through to the create-only result write, which still dedupes.
+func (h *WebhookHandler) objectExists(ctx context.Context, key string) bool {
+	_, err := h.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(h.bucketName),
+		Key:    aws.String(key),
+	})
+	return err == nil
+}
+
+// webhookEventKey returns the marker key for a processed webhook event
+func webhookEventKey(eventID string) string {
+	return fmt.Sprintf("surveys/webhook-events/%s.json", url.PathEscape(eventID))
+}
+
+// IsPreconditionFailed reports whether an S3 error is a failed conditional write
+func IsPreconditionFailed(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "PreconditionFailed") || strings.Contains(err.Error(), "412"))
+}
+
+// UnknownCustomerCode is the customer segment of result keys for responses without a customer_code
+const UnknownCustomerCode = "unknown"
+
+// ResultKey returns the S3 key a response is stored under. The customer_code, year and quarter
+// hidden fields place it; a response without a customer is filed under UnknownCustomerCode and one
+// without a year or quarter under the quarter it was submitted in. The webhook and the Responses
+// API sync both use it, so a response maps to the same object whichever path stores it.
+func ResultKey(response FormResponse, submittedAt time.Time) string {
+	customerCode := response.Hidden["customer_code"]
+	if customerCode == "" {
+		customerCode = UnknownCustomerCode
+	}
+
+	submittedAt = submittedAt.UTC()
+	year, quarter := response.Hidden["year"], response.Hidden["quarter"]
+	if year == "" || quarter == "" {
+		year = strconv.Itoa(submittedAt.Year())
+		quarter = fmt.Sprintf("Q%d", (int(submittedAt.Month())-1)/3+1)
+	}
+
+	return SurveyResultKey(customerCode, year, quarter, submittedAt, response.FormID, response.Token)
+}
+
+// SurveyResultKey returns the S3 key a survey response is stored under. The key only depends on
+// the response, so every delivery of the same response maps to the same object.
+func SurveyResultKey(customerCode, year, quarter string, submittedAt time.Time, formID, token string) string {
+	return fmt.Sprintf("surveys/results/%s/%s/%s/%d-%s-%s.json",
+		customerCode, year, quarter, submittedAt.Unix(), formID, url.PathEscape(token))
+}
+
 // GetWebhookSecret returns the webhook secret from environment
 func GetWebhookSecret() string {
 	return os.Getenv("TYPEFORM_WEBHOOK_SECRET")
 }
+
+// GetWebhookSecrets returns the active webhook secrets from the environment
+func GetWebhookSecrets() []string {
+	return ParseWebhookSecrets(GetWebhookSecret())
+}
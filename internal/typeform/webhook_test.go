@@ -0,0 +1,92 @@
+package typeform
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidateWebhookSignatures(t *testing.T) {
+	payload := []byte(`{"event_id":"evt"}`)
+	secrets := ParseWebhookSecrets(" new-secret ,\nold-secret\n")
+	if len(secrets) != 2 || secrets[0] != "new-secret" || secrets[1] != "old-secret" {
+		t.Fatalf("Unexpected secrets: %q", secrets)
+	}
+
+	for _, secret := range []string{"new-secret", "old-secret"} {
+		if !ValidateWebhookSignatures(payload, sign(payload, secret), secrets) {
+			t.Errorf("Expected signature with %s to be accepted", secret)
+		}
+	}
+	if ValidateWebhookSignatures(payload, sign(payload, "retired"), secrets) {
+		t.Error("Expected signature with a retired secret to be rejected")
+	}
+	if ValidateWebhookSignatures(payload, sign(payload, ""), []string{""}) {
+		t.Error("Expected empty secrets to be ignored")
+	}
+}
+
+func TestCheckSubmittedAt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		value     string
+		tolerance time.Duration
+		wantErr   bool
+		wantZero  bool
+	}{
+		{"recent", "2025-03-10T11:00:00Z", time.Hour * 2, false, false},
+		{"too old", "2025-03-01T11:00:00Z", time.Hour * 72, true, false},
+		{"tolerance disabled", "2024-03-01T11:00:00Z", 0, false, false},
+		{"small clock skew", "2025-03-10T12:03:00Z", time.Hour, false, false},
+		{"future", "2025-03-10T13:00:00Z", time.Hour, true, false},
+		{"unparseable", "yesterday", time.Hour, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submittedAt, err := CheckSubmittedAt(tt.value, tt.tolerance, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if submittedAt.IsZero() != tt.wantZero {
+				t.Errorf("Unexpected submitted_at %v", submittedAt)
+			}
+		})
+	}
+}
+
+func TestSurveyResultKey(t *testing.T) {
+	submittedAt := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	key := SurveyResultKey("hts", "2025", "Q1", submittedAt, "aBcD12", "tok123")
+	if key != "surveys/results/hts/2025/Q1/1739188800-aBcD12-tok123.json" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+	if again := SurveyResultKey("hts", "2025", "Q1", submittedAt, "aBcD12", "tok123"); again != key {
+		t.Error("Expected the same response to map to the same key")
+	}
+}
+
+func TestResultKeyFallbacks(t *testing.T) {
+	submittedAt := time.Date(2025, 11, 3, 8, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	response := FormResponse{FormID: "aBcD12", Token: "tok123", Hidden: map[string]string{"customer_code": "hts", "year": "2025"}}
+
+	// A missing quarter falls back to the UTC submission quarter for both year and quarter
+	if key := ResultKey(response, submittedAt); key != "surveys/results/hts/2025/Q4/1762174800-aBcD12-tok123.json" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+
+	response.Hidden = nil
+	if key := ResultKey(response, submittedAt); key != "surveys/results/unknown/2025/Q4/1762174800-aBcD12-tok123.json" {
+		t.Errorf("Unexpected key without hidden fields: %s", key)
+	}
+}
@@ -4,6 +4,7 @@ package types
 import (
 	"fmt"
 	"log"
+	"path"
 	"strings"
 	"time"
 )
@@ -16,7 +17,8 @@ type Organization struct {
 	ManagementAccountId string `json:"management_account_id"`
 }
 
-// AlternateContactConfig represents the configuration for alternate contacts
+// AlternateContactConfig represents the configuration for alternate contacts. The flat fields are
+// the global contacts; overrides replace them for selected organizations, OUs, accounts or tags.
 type AlternateContactConfig struct {
 	SecurityEmail   string `json:"security_email"`
 	SecurityName    string `json:"security_name"`
@@ -30,6 +32,31 @@ type AlternateContactConfig struct {
 	OperationsName  string `json:"operations_name"`
 	OperationsTitle string `json:"operations_title"`
 	OperationsPhone string `json:"operations_phone"`
+
+	Overrides []AlternateContactOverride `json:"overrides,omitempty"`
+}
+
+// AlternateContactOverride sets contacts for the accounts matching all of its selectors. For each
+// contact type the most specific matching override wins: an account selector over an OU selector
+// (deeper OUs first) over an organization selector, then the override with more tags, then the
+// one listed first.
+type AlternateContactOverride struct {
+	Name               string                   `json:"name"`                          // Reported as the source of the contacts it sets
+	Organization       string                   `json:"organization,omitempty"`        // Organization prefix from OrgConfig.json
+	OrganizationalUnit string                   `json:"organizational_unit,omitempty"` // OU ID; accounts in nested OUs match too
+	AccountID          string                   `json:"account_id,omitempty"`
+	Tags               map[string]string        `json:"tags,omitempty"` // Account tags that must all match
+	Security           *AlternateContactDetails `json:"security,omitempty"`
+	Billing            *AlternateContactDetails `json:"billing,omitempty"`
+	Operations         *AlternateContactDetails `json:"operations,omitempty"`
+}
+
+// AlternateContactDetails are the fields of one alternate contact
+type AlternateContactDetails struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
 }
 
 // SESTopicConfig represents the configuration for SES topics
@@ -46,6 +73,28 @@ type SESConfig struct {
 	TopicGroupPrefix  []string         `json:"topic_group_prefix"`
 	TopicGroupMembers []SESTopicConfig `json:"topic_group_members"`
 	Topics            []SESTopicConfig `json:"topics"`
+	TopicRules        []TopicRule      `json:"topic_rules,omitempty"` // Optional: rules beyond OptInRoles, see TopicRule
+}
+
+// AlternateContactBackup is a snapshot of the alternate contacts of accounts, taken before they
+// are changed so they can be restored
+type AlternateContactBackup struct {
+	Accounts       []AlternateContactAccountBackup `json:"accounts"`
+	BackupMetadata struct {
+		Timestamp string `json:"timestamp"`
+		Tool      string `json:"tool"`
+		Action    string `json:"action"`
+	} `json:"backup_metadata"`
+}
+
+// AlternateContactAccountBackup holds one account's contacts; a nil contact was not set
+type AlternateContactAccountBackup struct {
+	Organization string                   `json:"organization"`
+	AccountID    string                   `json:"account_id"`
+	AccountName  string                   `json:"account_name,omitempty"`
+	Security     *AlternateContactDetails `json:"security"`
+	Billing      *AlternateContactDetails `json:"billing"`
+	Operations   *AlternateContactDetails `json:"operations"`
 }
 
 // SESBackup represents a backup of SES contact list data
@@ -89,6 +138,107 @@ type CustomerAccountInfo struct {
 	IdentityCenterRoleArn  string   `json:"identity_center_role_arn,omitempty"`     // Optional: IAM role ARN for Identity Center data retrieval
 	DeliverabilitySnsTopic string   `json:"deliverability_sns_topic_arn,omitempty"` // Optional: SNS topic ARN for SES event notifications (per customer)
 	RestrictedRecipients   []string `json:"restricted_recipients,omitempty"`        // Optional: Whitelist of email addresses allowed to receive emails (for non-prod safety)
+
+	NotificationChannels []NotificationChannel `json:"notification_channels,omitempty"` // Optional: Slack/Teams channels that mirror SES topic notifications
+	UrgentSMS            bool                  `json:"urgent_sms,omitempty"`            // Optional: text topic subscribers with a phone number about urgent announcements
+
+	ContactSource *ContactSourceConfig `json:"contact_source,omitempty"` // Optional: where contacts are imported from (defaults to Identity Center)
+	GroupPatterns []GroupPattern       `json:"group_patterns,omitempty"` // Optional: group name patterns tried in order before the ccoe-cloud format
+	Offboarding   *OffboardingConfig   `json:"offboarding,omitempty"`    // Optional: how imports clean up contacts who are no longer eligible
+}
+
+// OffboardingConfig controls the offboarding phase of contact imports, which removes contacts that
+// no longer resolve to an eligible user and, optionally, role-derived topics users no longer qualify for
+type OffboardingConfig struct {
+	ProtectedContacts []string `json:"protected_contacts,omitempty"` // Emails or patterns (e.g. *@ops.example.com) imports never remove or change
+	StripRoleTopics   bool     `json:"strip_role_topics,omitempty"`  // Opt users out of role-derived topics they no longer qualify for
+}
+
+// IsProtected reports whether an email address matches the protected contacts allowlist
+func (o *OffboardingConfig) IsProtected(email string) bool {
+	if o == nil {
+		return false
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, protected := range o.ProtectedContacts {
+		if matched, _ := path.Match(strings.ToLower(strings.TrimSpace(protected)), email); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// StripsRoleTopics reports whether role-derived topics are removed from users who lost their roles
+func (o *OffboardingConfig) StripsRoleTopics() bool {
+	return o != nil && o.StripRoleTopics
+}
+
+// Group pattern capture group names
+const (
+	GroupCaptureAccountName = "account_name"
+	GroupCaptureAccountID   = "account_id"
+	GroupCaptureEnvironment = "environment"
+	GroupCaptureAppPrefix   = "app_prefix"
+	GroupCaptureRole        = "role"
+)
+
+// GroupPatternCaptures lists the capture group names a GroupPattern may use
+var GroupPatternCaptures = []string{GroupCaptureAccountName, GroupCaptureAccountID, GroupCaptureEnvironment, GroupCaptureAppPrefix, GroupCaptureRole}
+
+// GroupPattern is a named regular expression that parses a group naming convention. Named capture
+// groups (?P<account_name>...), (?P<account_id>...), (?P<environment>...), (?P<app_prefix>...) and
+// (?P<role>...) fill the parsed fields; role is required.
+type GroupPattern struct {
+	Name  string `json:"name"`
+	Regex string `json:"regex"`
+}
+
+// Contact source types
+const (
+	ContactSourceIdentityCenter = "identity_center"
+	ContactSourceSCIM           = "scim"
+	ContactSourceFile           = "file"
+)
+
+// ContactSourceConfig selects where a customer's users and group memberships come from. Okta and
+// Entra ID are read through their SCIM 2.0 endpoints or from group exports.
+type ContactSourceConfig struct {
+	Type           string `json:"type"`                      // identity_center (default), scim or file
+	URL            string `json:"url,omitempty"`             // scim: base URL, e.g. https://example.okta.com/scim/v2
+	TokenParameter string `json:"token_parameter,omitempty"` // scim: Parameter Store name of the bearer token
+	TokenEnv       string `json:"token_env,omitempty"`       // scim: environment variable holding the bearer token (overrides token_parameter)
+	Path           string `json:"path,omitempty"`            // file: local path or s3://bucket/key of the export
+	Format         string `json:"format,omitempty"`          // file: json or csv (defaults to the file extension)
+}
+
+// GetType returns the source type, defaulting to Identity Center
+func (c *ContactSourceConfig) GetType() string {
+	if c == nil || c.Type == "" {
+		return ContactSourceIdentityCenter
+	}
+	return c.Type
+}
+
+// NotificationChannel is a chat channel that receives lifecycle notifications alongside email.
+// The incoming webhook URL is a credential, so only the Parameter Store name holding it is configured.
+type NotificationChannel struct {
+	Name                string   `json:"name,omitempty"`        // Label used in logs (defaults to type and position)
+	Type                string   `json:"type"`                  // slack or teams
+	WebhookURLParameter string   `json:"webhook_url_parameter"` // SecureString parameter holding the incoming webhook URL
+	Topics              []string `json:"topics,omitempty"`      // SES topics to mirror (e.g. aws-announce, cic-announce); empty mirrors all
+}
+
+// MirrorsTopic reports whether the channel receives notifications sent to an SES topic
+func (n NotificationChannel) MirrorsTopic(topic string) bool {
+	if len(n.Topics) == 0 {
+		return true
+	}
+	for _, t := range n.Topics {
+		if strings.EqualFold(strings.TrimSpace(t), topic) {
+			return true
+		}
+	}
+	return false
 }
 
 // IsRecipientAllowed checks if an email address is allowed to receive emails
@@ -199,6 +349,223 @@ type Route53Config struct {
 	RoleARN string `json:"role_arn"` // IAM role to assume in DNS account
 }
 
+// OverdueSweeperConfig controls reminders for approved changes whose implementation window has passed
+type OverdueSweeperConfig struct {
+	GracePeriodHours     int      `json:"grace_period_hours"`    // Hours after implementation end before the first reminder (default 24)
+	EscalationHours      int      `json:"escalation_hours"`      // Hours after implementation end before escalating (default 72)
+	EscalationRecipients []string `json:"escalation_recipients"` // Additional recipients for escalation emails
+	AutoComplete         bool     `json:"auto_complete"`         // Mark long-overdue changes as completed_unconfirmed
+	AutoCompleteHours    int      `json:"auto_complete_hours"`   // Hours after implementation end before auto-completion (default 168)
+}
+
+// Default overdue sweeper thresholds
+const (
+	DefaultOverdueGracePeriodHours  = 24
+	DefaultOverdueEscalationHours   = 72
+	DefaultOverdueAutoCompleteHours = 168
+)
+
+// WithDefaults returns a copy of the sweeper configuration with unset thresholds defaulted
+func (c *OverdueSweeperConfig) WithDefaults() OverdueSweeperConfig {
+	var out OverdueSweeperConfig
+	if c != nil {
+		out = *c
+	}
+	if out.GracePeriodHours <= 0 {
+		out.GracePeriodHours = DefaultOverdueGracePeriodHours
+	}
+	if out.EscalationHours <= 0 {
+		out.EscalationHours = DefaultOverdueEscalationHours
+	}
+	if out.AutoCompleteHours <= 0 {
+		out.AutoCompleteHours = DefaultOverdueAutoCompleteHours
+	}
+	return out
+}
+
+// CalendarFeedConfig controls the per-customer iCalendar feed of approved change windows
+type CalendarFeedConfig struct {
+	Enabled     bool   `json:"enabled"`
+	Bucket      string `json:"bucket,omitempty"` // Defaults to the bucket the change was processed from
+	KeyTemplate string `json:"key_template"`     // Object key with a {customer_code} placeholder (default calendars/{customer_code}.ics)
+}
+
+// DefaultCalendarFeedKeyTemplate is where feeds are published when no key template is configured
+const DefaultCalendarFeedKeyTemplate = "calendars/{customer_code}.ics"
+
+// FeedKey returns the S3 key of a customer's .ics feed
+func (c *CalendarFeedConfig) FeedKey(customerCode string) string {
+	template := DefaultCalendarFeedKeyTemplate
+	if c != nil && c.KeyTemplate != "" {
+		template = c.KeyTemplate
+	}
+	return strings.ReplaceAll(template, "{customer_code}", customerCode)
+}
+
+// StateKey returns the S3 key of the JSON event list the feed is rendered from
+func (c *CalendarFeedConfig) StateKey(customerCode string) string {
+	return strings.TrimSuffix(c.FeedKey(customerCode), ".ics") + ".events.json"
+}
+
+// AnnouncementFeedConfig controls the per-customer Atom feeds and JSON indexes of announcements
+type AnnouncementFeedConfig struct {
+	Enabled       bool   `json:"enabled"`
+	Bucket        string `json:"bucket,omitempty"`          // Defaults to the bucket the announcement was processed from
+	Prefix        string `json:"prefix"`                    // Key prefix (default feeds/announcements)
+	PublicBaseURL string `json:"public_base_url,omitempty"` // URL the prefix is served from, used for Atom self links
+	PageSize      int    `json:"page_size"`                 // Entries per JSON page and in the Atom feed (default 25)
+}
+
+// Default announcement feed settings
+const (
+	DefaultAnnouncementFeedPrefix   = "feeds/announcements"
+	DefaultAnnouncementFeedPageSize = 25
+)
+
+// WithDefaults returns a copy of the feed configuration with unset values defaulted
+func (c *AnnouncementFeedConfig) WithDefaults() AnnouncementFeedConfig {
+	var out AnnouncementFeedConfig
+	if c != nil {
+		out = *c
+	}
+	out.Prefix = strings.Trim(out.Prefix, "/")
+	if out.Prefix == "" {
+		out.Prefix = DefaultAnnouncementFeedPrefix
+	}
+	if out.PageSize <= 0 {
+		out.PageSize = DefaultAnnouncementFeedPageSize
+	}
+	return out
+}
+
+// ServiceNowConfig controls syncing change lifecycle transitions to the ServiceNow change
+// request referenced by a change's snowTicket. Credentials come from Parameter Store.
+type ServiceNowConfig struct {
+	Enabled       bool              `json:"enabled"`
+	InstanceURL   string            `json:"instance_url"`             // e.g. https://example.service-now.com
+	States        map[string]string `json:"states,omitempty"`         // Transition -> change_request state value (overrides defaults)
+	PullApprovals bool              `json:"pull_approvals,omitempty"` // Record ServiceNow approval state on submitted changes
+	MaxAttempts   int               `json:"max_attempts,omitempty"`   // Total attempts per transition including sweeper retries (default 3)
+}
+
+// DefaultServiceNowMaxAttempts is the default number of attempts per transition
+const DefaultServiceNowMaxAttempts = 3
+
+// IsEnabled reports whether ServiceNow sync is configured
+func (c *ServiceNowConfig) IsEnabled() bool {
+	return c != nil && c.Enabled && c.InstanceURL != ""
+}
+
+// JiraConfig controls commenting on, and optionally transitioning, the Jira issue referenced
+// by a change's jiraTicket. Credentials come from Parameter Store.
+type JiraConfig struct {
+	Enabled     bool                         `json:"enabled"`
+	BaseURL     string                       `json:"base_url"`               // e.g. https://example.atlassian.net
+	AuthType    string                       `json:"auth_type,omitempty"`    // basic (Jira Cloud, default) or bearer (Server/Data Center token)
+	Transitions map[string]string            `json:"transitions,omitempty"`  // Lifecycle event -> workflow transition name
+	Projects    map[string]JiraProjectConfig `json:"projects,omitempty"`     // Customer code -> Jira project mapping
+	MaxAttempts int                          `json:"max_attempts,omitempty"` // Total attempts per event including sweeper retries (default 3)
+}
+
+// JiraProjectConfig maps a customer to its Jira project
+type JiraProjectConfig struct {
+	ProjectKey  string            `json:"project_key"`           // Used for bare issue numbers in jiraTicket
+	Transitions map[string]string `json:"transitions,omitempty"` // Overrides the global transitions for issues in this project
+}
+
+// DefaultJiraMaxAttempts is the default number of attempts per lifecycle event
+const DefaultJiraMaxAttempts = 3
+
+// IsEnabled reports whether Jira sync is configured
+func (c *JiraConfig) IsEnabled() bool {
+	return c != nil && c.Enabled && c.BaseURL != ""
+}
+
+// SMSConfig controls the short text messages sent for urgent announcements. Customers opt in
+// with urgent_sms in their customer mapping.
+type SMSConfig struct {
+	Enabled            bool   `json:"enabled"`
+	Region             string `json:"region,omitempty"`               // SNS region (defaults to aws_region)
+	SenderID           string `json:"sender_id,omitempty"`            // Alphanumeric sender ID where supported
+	OriginationNumber  string `json:"origination_number,omitempty"`   // E.164 number or toll-free number to send from
+	MaxPerAnnouncement int    `json:"max_per_announcement,omitempty"` // Per customer (default 50)
+	MaxPerHour         int    `json:"max_per_hour,omitempty"`         // Per customer across announcements (default 200)
+	LogPrefix          string `json:"log_prefix,omitempty"`           // S3 prefix for delivery logs (default sms-logs)
+}
+
+// Default SMS rate caps and delivery log prefix
+const (
+	DefaultSMSMaxPerAnnouncement = 50
+	DefaultSMSMaxPerHour         = 200
+	DefaultSMSLogPrefix          = "sms-logs"
+)
+
+// IsEnabled reports whether urgent SMS is configured
+func (c *SMSConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetMaxPerAnnouncement returns the per-announcement cap with its default applied
+func (c *SMSConfig) GetMaxPerAnnouncement() int {
+	if c == nil || c.MaxPerAnnouncement <= 0 {
+		return DefaultSMSMaxPerAnnouncement
+	}
+	return c.MaxPerAnnouncement
+}
+
+// GetMaxPerHour returns the hourly cap with its default applied
+func (c *SMSConfig) GetMaxPerHour() int {
+	if c == nil || c.MaxPerHour <= 0 {
+		return DefaultSMSMaxPerHour
+	}
+	return c.MaxPerHour
+}
+
+// GetLogPrefix returns the delivery log prefix with its default applied
+func (c *SMSConfig) GetLogPrefix() string {
+	if c == nil || c.LogPrefix == "" {
+		return DefaultSMSLogPrefix
+	}
+	return strings.Trim(c.LogPrefix, "/")
+}
+
+// SurveyReminderConfig controls the follow-up for change surveys. Recipients of the completion
+// email who have not responded get one reminder, and the form is closed after the deadline.
+type SurveyReminderConfig struct {
+	Enabled           bool `json:"enabled"`
+	ReminderAfterDays int  `json:"reminder_after_days,omitempty"` // Days after the completion email before reminding (default 3)
+	CloseAfterDays    int  `json:"close_after_days,omitempty"`    // Days after the completion email before closing the form (default 14)
+}
+
+// Default survey reminder thresholds
+const (
+	DefaultSurveyReminderAfterDays = 3
+	DefaultSurveyCloseAfterDays    = 14
+)
+
+// IsEnabled reports whether survey reminders are configured
+func (c *SurveyReminderConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// GetReminderAfter returns the delay before the reminder with its default applied
+func (c *SurveyReminderConfig) GetReminderAfter() time.Duration {
+	days := DefaultSurveyReminderAfterDays
+	if c != nil && c.ReminderAfterDays > 0 {
+		days = c.ReminderAfterDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetCloseAfter returns the delay before the form is closed with its default applied
+func (c *SurveyReminderConfig) GetCloseAfter() time.Duration {
+	days := DefaultSurveyCloseAfterDays
+	if c != nil && c.CloseAfterDays > 0 {
+		days = c.CloseAfterDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 // Config represents the application configuration
 type Config struct {
 	AWSRegion        string                         `json:"aws_region"`
@@ -207,7 +574,14 @@ type Config struct {
 	ContactConfig    AlternateContactConfig         `json:"contact_config"`
 	S3Config         S3Config                       `json:"s3_config"`
 	EmailConfig      EmailConfig                    `json:"email_config"`
-	Route53Config    *Route53Config                 `json:"route53_config,omitempty"` // Optional: Route53 configuration for SES domain validation
+	Route53Config    *Route53Config                 `json:"route53_config,omitempty"`    // Optional: Route53 configuration for SES domain validation
+	OverdueSweeper   *OverdueSweeperConfig          `json:"overdue_sweeper,omitempty"`   // Optional: reminders for approved changes past their window
+	CalendarFeed     *CalendarFeedConfig            `json:"calendar_feed,omitempty"`     // Optional: per-customer .ics feed of change windows
+	AnnouncementFeed *AnnouncementFeedConfig        `json:"announcement_feed,omitempty"` // Optional: per-customer Atom/JSON announcement feeds
+	ServiceNow       *ServiceNowConfig              `json:"servicenow,omitempty"`        // Optional: sync lifecycle transitions to ServiceNow change requests
+	Jira             *JiraConfig                    `json:"jira,omitempty"`              // Optional: comment on and transition Jira issues
+	SMS              *SMSConfig                     `json:"sms,omitempty"`               // Optional: text urgent announcements to opted-in customers
+	SurveyReminders  *SurveyReminderConfig          `json:"survey_reminders,omitempty"`  // Optional: remind survey non-respondents and close forms
 }
 
 // EmailRequest represents an email sending request
@@ -270,11 +644,23 @@ type S3EventNotification struct {
 
 // ModificationEntry represents a single modification entry in the change history
 type ModificationEntry struct {
-	Timestamp        time.Time        `json:"timestamp"`
-	UserID           string           `json:"user_id"`
-	ModificationType string           `json:"modification_type"`
-	CustomerCode     string           `json:"customer_code,omitempty"`
-	MeetingMetadata  *MeetingMetadata `json:"meeting_metadata,omitempty"`
+	Timestamp        time.Time          `json:"timestamp"`
+	UserID           string             `json:"user_id"`
+	ModificationType string             `json:"modification_type"`
+	Reason           string             `json:"reason,omitempty"` // Reason given for a status change
+	CustomerCode     string             `json:"customer_code,omitempty"`
+	MeetingMetadata  *MeetingMetadata   `json:"meeting_metadata,omitempty"`
+	External         *ExternalReference `json:"external,omitempty"`
+}
+
+// ExternalReference records an interaction with an external ticketing system
+type ExternalReference struct {
+	System string `json:"system"`          // e.g. "servicenow"
+	Ticket string `json:"ticket"`          // Ticket number in the external system
+	Event  string `json:"event,omitempty"` // Lifecycle transition that was synced
+	State  string `json:"state,omitempty"` // State reported by or pushed to the external system
+	Key    string `json:"key,omitempty"`   // Idempotency key for the sync
+	Error  string `json:"error,omitempty"` // Failure reported by the external system
 }
 
 // MeetingMetadata represents Microsoft Graph meeting information
@@ -300,6 +686,7 @@ type AnnouncementMetadata struct {
 	IncludeMeeting   bool             `json:"include_meeting"`
 	MeetingMetadata  *MeetingMetadata `json:"meeting_metadata,omitempty"`
 	Attachments      []string         `json:"attachments"`
+	Priority         string           `json:"priority,omitempty"` // normal (default) or urgent
 
 	// Meeting scheduling fields (from frontend)
 	MeetingTitle    string `json:"meeting_title,omitempty"`
@@ -370,6 +757,10 @@ type ChangeMetadata struct {
 	PriorStatus string `json:"prior_status"`
 	Version     int    `json:"version"`
 
+	// StatusReason is the reason supplied with the latest status transition
+	// (e.g. why an implementation failed or was rolled back)
+	StatusReason string `json:"statusReason,omitempty"`
+
 	// Enhanced modification tracking array
 	Modifications []ModificationEntry `json:"modifications"`
 
@@ -576,8 +967,19 @@ const (
 	ModificationTypeMeetingScheduled = "meeting_scheduled"
 	ModificationTypeMeetingCancelled = "meeting_cancelled"
 	ModificationTypeProcessed        = "processed"
+	ModificationTypeInProgress       = "in_progress"
+	ModificationTypeFailed           = "failed"
+	ModificationTypeRolledBack       = "rolled_back"
+	ModificationTypeOverdueReminder  = "overdue_reminder"
+	ModificationTypeOverdueEscalated = "overdue_escalated"
+	ModificationTypeAutoCompleted    = "completed_unconfirmed"
+	ModificationTypeExternalSync     = "external_sync"
+	ModificationTypeExternalApproval = "external_approval"
 )
 
+// ChangeStatusCompletedUnconfirmed is set on changes auto-completed by the overdue sweeper
+const ChangeStatusCompletedUnconfirmed = "completed_unconfirmed"
+
 // Backend user ID for system-generated modifications
 // Deprecated: Use the actual Lambda execution role ARN instead
 const BackendUserID = "backend-system"
@@ -631,6 +1033,23 @@ func NewMeetingCancelledEntry(userID string) (ModificationEntry, error) {
 	return entry, nil
 }
 
+// NewExternalEntry creates a modification entry recording an external ticketing system interaction
+func NewExternalEntry(modificationType, userID string, external *ExternalReference) (ModificationEntry, error) {
+	entry := ModificationEntry{
+		Timestamp:        time.Now(),
+		UserID:           userID,
+		ModificationType: modificationType,
+		External:         external,
+	}
+
+	// Validate the entry before returning
+	if err := entry.ValidateModificationEntry(); err != nil {
+		return ModificationEntry{}, fmt.Errorf("invalid external entry: %w", err)
+	}
+
+	return entry, nil
+}
+
 // AddModificationEntry adds a modification entry to the change metadata after validation
 func (c *ChangeMetadata) AddModificationEntry(entry ModificationEntry) error {
 	// Validate the modification entry before adding
@@ -779,6 +1198,14 @@ func (e *ModificationEntry) ValidateModificationEntry() error {
 		ModificationTypeMeetingScheduled: true,
 		ModificationTypeMeetingCancelled: true,
 		ModificationTypeProcessed:        true,
+		ModificationTypeInProgress:       true,
+		ModificationTypeFailed:           true,
+		ModificationTypeRolledBack:       true,
+		ModificationTypeOverdueReminder:  true,
+		ModificationTypeOverdueEscalated: true,
+		ModificationTypeAutoCompleted:    true,
+		ModificationTypeExternalSync:     true,
+		ModificationTypeExternalApproval: true,
 	}
 
 	if !validTypes[e.ModificationType] {
@@ -797,6 +1224,15 @@ func (e *ModificationEntry) ValidateModificationEntry() error {
 		return fmt.Errorf("meeting_metadata should only be present for meeting_scheduled type")
 	}
 
+	// Validate external reference for external system entries
+	if e.ModificationType == ModificationTypeExternalSync || e.ModificationType == ModificationTypeExternalApproval {
+		if e.External == nil || strings.TrimSpace(e.External.System) == "" || strings.TrimSpace(e.External.Ticket) == "" {
+			return fmt.Errorf("external system and ticket are required for %s type", e.ModificationType)
+		}
+	} else if e.External != nil {
+		return fmt.Errorf("external should only be present for external_sync and external_approval types")
+	}
+
 	return nil
 }
 
@@ -981,6 +1417,17 @@ func (a *AnnouncementMetadata) ValidateLegacyMetadata() error {
 	return nil
 }
 
+// Announcement priorities
+const (
+	AnnouncementPriorityNormal = "normal"
+	AnnouncementPriorityUrgent = "urgent"
+)
+
+// IsUrgent reports whether the announcement should also be sent by SMS
+func (a *AnnouncementMetadata) IsUrgent() bool {
+	return strings.EqualFold(strings.TrimSpace(a.Priority), AnnouncementPriorityUrgent)
+}
+
 // Identity Center types for AWS contact import
 type IdentityCenterUser struct {
 	UserId      string `json:"user_id"`
@@ -989,6 +1436,7 @@ type IdentityCenterUser struct {
 	Email       string `json:"email"`
 	GivenName   string `json:"given_name"`
 	FamilyName  string `json:"family_name"`
+	PhoneNumber string `json:"phone_number,omitempty"` // Primary (or first) phone number, if any
 	Active      bool   `json:"active"`
 }
 
@@ -1022,6 +1470,48 @@ type ContactImportConfig struct {
 	RoleMappings       []RoleTopicMapping `json:"role_mappings"`
 	DefaultTopics      []string           `json:"default_topics"`
 	RequireActiveUsers bool               `json:"require_active_users"`
+	Rules              []TopicRule        `json:"rules,omitempty"`
+	GroupPatterns      []GroupPattern     `json:"group_patterns,omitempty"` // Customer group patterns, tried before the ccoe-cloud format
+}
+
+// Topic rule actions
+const (
+	TopicRuleInclude = "include"
+	TopicRuleExclude = "exclude"
+)
+
+// TopicRule subscribes users to topics, or keeps them off topics, when they match every condition
+// the rule sets. Within a condition any listed value matches. Group conditions (roles,
+// environments, account names, application prefixes and group_regex) must all be met by the same
+// ccoe-cloud group, except group_regex, which matches any group name. For each topic, the matching
+// rule with the highest priority decides, overriding default topics and OptInRoles; ties go to the
+// rule listed first.
+type TopicRule struct {
+	Name     string   `json:"name"`
+	Topics   []string `json:"topics"`
+	Action   string   `json:"action,omitempty"`   // include (default) or exclude
+	Priority int      `json:"priority,omitempty"` // Higher wins
+
+	Roles               []string `json:"roles,omitempty"`                 // Role name from the group, e.g. admin
+	Environments        []string `json:"environments,omitempty"`          // Last part of the account name, e.g. prod
+	AccountNamePatterns []string `json:"account_name_patterns,omitempty"` // Glob patterns, e.g. hts-*
+	ApplicationPrefixes []string `json:"application_prefixes,omitempty"`  // Application prefix from the group
+	GroupRegex          string   `json:"group_regex,omitempty"`           // Any group name matching this regular expression
+	EmailDomains        []string `json:"email_domains,omitempty"`         // e.g. example.com
+	Emails              []string `json:"emails,omitempty"`                // Explicit users
+}
+
+// GetAction returns the rule action, defaulting to include
+func (r TopicRule) GetAction() string {
+	if r.Action == "" {
+		return TopicRuleInclude
+	}
+	return r.Action
+}
+
+// HasGroupConditions reports whether the rule needs a matching ccoe-cloud group
+func (r TopicRule) HasGroupConditions() bool {
+	return len(r.Roles) > 0 || len(r.Environments) > 0 || len(r.AccountNamePatterns) > 0 || len(r.ApplicationPrefixes) > 0
 }
 
 // DNSRecord represents a DNS record to be created or updated
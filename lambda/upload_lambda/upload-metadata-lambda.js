@@ -8,6 +8,47 @@ const sqs = new AWS.SQS();
 // Initialize datetime utilities with default config
 const dateTime = new DateTime();
 
+// Change status transitions (see docs/CHANGE_WORKFLOW_STATE_MACHINE.md)
+const CHANGE_STATUS_TRANSITIONS = {
+    'draft': ['submitted', 'cancelled'],
+    'submitted': ['approved', 'cancelled'],
+    'approved': ['submitted', 'in_progress', 'completed', 'cancelled'],
+    'in_progress': ['completed', 'failed', 'rolled_back'],
+    'failed': ['rolled_back'],
+    // Set by the overdue sweeper; owners confirm it (completed) or reopen it (in_progress, failed, rolled_back)
+    'completed_unconfirmed': ['completed', 'in_progress', 'failed', 'rolled_back'],
+    'completed': [],
+    'rolled_back': [],
+    'cancelled': []
+};
+
+// Implementation statuses set through POST /changes/{id}/status; each one sends its own notification
+const CHANGE_IMPLEMENTATION_STATUSES = ['in_progress', 'failed', 'rolled_back'];
+
+// Check whether a change may move from one status to another
+function isValidChangeTransition(currentStatus, newStatus) {
+    return (CHANGE_STATUS_TRANSITIONS[currentStatus] || []).includes(newStatus);
+}
+
+// Record a status change on a change: prior status, reason and a modification entry
+function applyChangeStatus(change, newStatus, userEmail, timestamp, reason) {
+    change.prior_status = change.status;
+    change.status = newStatus;
+    change.statusReason = reason || '';
+    if (!change.modifications) {
+        change.modifications = [];
+    }
+    const entry = {
+        timestamp: timestamp,
+        user_id: userEmail,
+        modification_type: newStatus
+    };
+    if (reason) {
+        entry.reason = reason;
+    }
+    change.modifications.push(entry);
+}
+
 export const handler = async (event) => {
     try {
         console.log('📥 Request received:', {
@@ -111,6 +152,8 @@ export const handler = async (event) => {
             return await handleGetChange(event, userEmail);
         } else if (path.startsWith('/changes/') && path.includes('/approve') && method === 'POST') {
             return await handleApproveChange(event, userEmail);
+        } else if (path.startsWith('/changes/') && path.includes('/status') && method === 'POST') {
+            return await handleChangeStatus(event, userEmail);
         } else if (path.startsWith('/changes/') && path.includes('/complete') && method === 'POST') {
             return await handleCompleteChange(event, userEmail);
         } else if (path.startsWith('/changes/') && path.includes('/cancel') && method === 'POST') {
@@ -2539,6 +2582,26 @@ async function handleUpdateChange(event, userEmail) {
                 modification_type: 'completed'
             });
         }
+        // Implementation statuses follow the state machine and record their reason
+        else if (CHANGE_IMPLEMENTATION_STATUSES.includes(newStatus) && oldStatus !== newStatus) {
+            if (!isValidChangeTransition(oldStatus, newStatus)) {
+                return {
+                    statusCode: 400,
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Access-Control-Allow-Origin': '*'
+                    },
+                    body: JSON.stringify({
+                        error: `Invalid status transition from ${oldStatus} to ${newStatus}`,
+                        currentStatus: oldStatus,
+                        requestedStatus: newStatus,
+                        allowedTransitions: CHANGE_STATUS_TRANSITIONS[oldStatus] || []
+                    })
+                };
+            }
+            updatedChange.status = oldStatus;
+            applyChangeStatus(updatedChange, newStatus, userEmail, updateTimestamp, updatedChange.statusReason);
+        }
 
 
 
@@ -2898,14 +2961,14 @@ async function handleCompleteChange(event, userEmail) {
             };
         }
 
-        if (existingChange.status !== 'approved') {
+        if (!isValidChangeTransition(existingChange.status, 'completed')) {
             return {
                 statusCode: 400,
                 headers: {
                     'Content-Type': 'application/json',
                     'Access-Control-Allow-Origin': '*'
                 },
-                body: JSON.stringify({ error: 'Only approved changes can be completed' })
+                body: JSON.stringify({ error: 'Only approved, in progress or unconfirmed changes can be completed' })
             };
         }
 
@@ -3129,39 +3192,44 @@ async function handleCancelChange(event, userEmail) {
         }
 
         // Check if change is in a state that can be cancelled
-        if (existingChange.status === 'cancelled') {
+        if (!isValidChangeTransition(existingChange.status, 'cancelled')) {
             return {
-                statusCode: 400,
+                statusCode: 409,
                 headers: {
                     'Content-Type': 'application/json',
                     'Access-Control-Allow-Origin': '*'
                 },
-                body: JSON.stringify({ error: 'Change is already cancelled' })
+                body: JSON.stringify({
+                    error: `Cannot cancel a change that is ${existingChange.status}`,
+                    currentStatus: existingChange.status,
+                    requestedStatus: 'cancelled',
+                    allowedTransitions: CHANGE_STATUS_TRANSITIONS[existingChange.status] || []
+                })
             };
         }
 
-        if (existingChange.status === 'completed') {
-            return {
-                statusCode: 400,
-                headers: {
-                    'Content-Type': 'application/json',
-                    'Access-Control-Allow-Origin': '*'
-                },
-                body: JSON.stringify({ error: 'Cannot cancel a completed change' })
-            };
+        // The cancellation reason is optional and recorded on the change and its cancelled entry
+        let reason = '';
+        if (event.body) {
+            try {
+                reason = String(JSON.parse(event.body).reason || '').trim();
+            } catch (error) {
+                console.warn('⚠️  Ignoring unparseable cancellation body:', error.message);
+            }
         }
 
         // Update change with cancellation information
+        const now = toRFC3339(new Date());
         const cancelledChange = {
             ...existingChange,
-            prior_status: existingChange.status,
-            status: 'cancelled',
-            cancelledAt: toRFC3339(new Date()),
+            modifications: [...(existingChange.modifications || [])],
+            cancelledAt: now,
             cancelledBy: userEmail,
-            modifiedAt: toRFC3339(new Date()),
+            modifiedAt: now,
             modifiedBy: userEmail,
             version: (existingChange.version || 1) + 1
         };
+        applyChangeStatus(cancelledChange, 'cancelled', userEmail, now, reason);
 
         // Save version history before updating
         const versionKey = `versions/${changeId}/v${existingChange.version || 1}.json`;
@@ -3268,6 +3336,187 @@ async function handleCancelChange(event, userEmail) {
     }
 }
 
+// Move a change through implementation (in_progress, failed, rolled_back), with an optional reason
+async function handleChangeStatus(event, userEmail) {
+    const changeId = event.pathParameters?.changeId || (event.path || event.rawPath).split('/').filter(p => p && p !== 'status').pop();
+    const payload = JSON.parse(event.body || '{}');
+    const newStatus = payload.status;
+    const reason = (payload.reason || '').trim();
+
+    const bucketName = process.env.S3_BUCKET_NAME || '4cm-prod-ccoe-change-management-metadata';
+    const archiveKey = `archive/${changeId}.json`;
+
+    if (!CHANGE_IMPLEMENTATION_STATUSES.includes(newStatus)) {
+        return {
+            statusCode: 400,
+            headers: {
+                'Content-Type': 'application/json',
+                'Access-Control-Allow-Origin': '*'
+            },
+            body: JSON.stringify({ error: `Invalid status: ${newStatus}. Expected one of: ${CHANGE_IMPLEMENTATION_STATUSES.join(', ')}` })
+        };
+    }
+
+    try {
+        // Load the change with its ETag so a concurrent update is not overwritten
+        const { metadata: existingChange, etag } = await loadArchiveWithETag(changeId);
+        if (!existingChange) {
+            return {
+                statusCode: 404,
+                headers: {
+                    'Content-Type': 'application/json',
+                    'Access-Control-Allow-Origin': '*'
+                },
+                body: JSON.stringify({ error: 'Change not found' })
+            };
+        }
+
+        const currentStatus = existingChange.status;
+        if (!isValidChangeTransition(currentStatus, newStatus)) {
+            return {
+                statusCode: 400,
+                headers: {
+                    'Content-Type': 'application/json',
+                    'Access-Control-Allow-Origin': '*'
+                },
+                body: JSON.stringify({
+                    error: `Invalid status transition from ${currentStatus} to ${newStatus}`,
+                    currentStatus,
+                    requestedStatus: newStatus,
+                    allowedTransitions: CHANGE_STATUS_TRANSITIONS[currentStatus] || []
+                })
+            };
+        }
+
+        const timestamp = toRFC3339(new Date());
+        const updatedChange = {
+            ...existingChange,
+            modifications: [...(existingChange.modifications || [])],
+            modifiedAt: timestamp,
+            modifiedBy: userEmail,
+            version: (existingChange.version || 1) + 1
+        };
+        applyChangeStatus(updatedChange, newStatus, userEmail, timestamp, reason);
+
+        // Save version history before updating
+        const versionKey = `versions/${changeId}/v${existingChange.version || 1}.json`;
+        await s3.putObject({
+            Bucket: bucketName,
+            Key: versionKey,
+            Body: JSON.stringify(existingChange, null, 2),
+            ContentType: 'application/json',
+            Metadata: {
+                'change-id': changeId,
+                'version': String(existingChange.version || 1),
+                'created-by': existingChange.createdBy || existingChange.submittedBy
+            }
+        }).promise();
+
+        // Update the main change record, only if nobody else changed it since it was read
+        try {
+            await s3.putObject({
+                Bucket: bucketName,
+                Key: archiveKey,
+                Body: JSON.stringify(updatedChange, null, 2),
+                ContentType: 'application/json',
+                IfMatch: etag,
+                Metadata: {
+                    'change-id': changeId,
+                    'version': String(updatedChange.version),
+                    'status': newStatus,
+                    'modified-by': userEmail,
+                    'modified-at': timestamp
+                }
+            }).promise();
+        } catch (error) {
+            if (error.code === 'PreconditionFailed' || error.statusCode === 412) {
+                return {
+                    statusCode: 409,
+                    headers: {
+                        'Content-Type': 'application/json',
+                        'Access-Control-Allow-Origin': '*'
+                    },
+                    body: JSON.stringify({ error: 'Change was modified by another user. Please refresh and try again.' })
+                };
+            }
+            throw error;
+        }
+
+        // Upload to customer prefixes to trigger S3 events for the implementation notifications
+        const requestType = `change_${newStatus}`;
+        if (updatedChange.customers && Array.isArray(updatedChange.customers)) {
+            const customerUploadPromises = updatedChange.customers.map(async (customer) => {
+                const customerKey = `customers/${customer}/${changeId}.json`;
+
+                try {
+                    await s3.putObject({
+                        Bucket: bucketName,
+                        Key: customerKey,
+                        Body: JSON.stringify(updatedChange, null, 2),
+                        ContentType: 'application/json',
+                        Metadata: {
+                            'change-id': changeId,
+                            'customer-code': customer,
+                            'status': newStatus,
+                            'modified-by': userEmail,
+                            'modified-at': timestamp,
+                            'request-type': requestType
+                        }
+                    }).promise();
+
+                    return { customer, success: true, key: customerKey };
+                } catch (error) {
+                    console.error(`❌ Failed to upload ${newStatus} change to customer prefix ${customerKey}:`, error);
+                    return { customer, success: false, error: error.message };
+                }
+            });
+
+            const customerUploadResults = await Promise.allSettled(customerUploadPromises);
+            const failedUploads = customerUploadResults
+                .filter(result => result.status === 'rejected' || (result.status === 'fulfilled' && !result.value.success));
+
+            if (failedUploads.length > 0) {
+                console.warn(`⚠️  ${failedUploads.length} customer prefix uploads failed - some customers may not receive ${newStatus} notifications`);
+            }
+        } else {
+            console.warn(`⚠️  No customers found in ${newStatus} change - no notifications will be sent`);
+        }
+
+        return {
+            statusCode: 200,
+            headers: {
+                'Content-Type': 'application/json',
+                'Access-Control-Allow-Origin': '*'
+            },
+            body: JSON.stringify({
+                success: true,
+                changeId: changeId,
+                status: newStatus,
+                priorStatus: currentStatus,
+                statusReason: updatedChange.statusReason,
+                modifiedBy: userEmail,
+                modifiedAt: timestamp,
+                version: updatedChange.version,
+                message: `Change status updated to ${newStatus}`
+            })
+        };
+
+    } catch (error) {
+        console.error('Error updating change status:', error);
+        return {
+            statusCode: 500,
+            headers: {
+                'Content-Type': 'application/json',
+                'Access-Control-Allow-Origin': '*'
+            },
+            body: JSON.stringify({
+                error: 'Failed to update change status',
+                message: error.message
+            })
+        };
+    }
+}
+
 // Get version history for a change
 async function handleGetChangeVersions(event, userEmail) {
     const pathParts = (event.path || event.rawPath).split('/');
@@ -8,6 +8,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,17 +16,27 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/sesv2"
 	sesv2Types "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
+	"ccoe-customer-contact-manager/internal/archive"
 	"ccoe-customer-contact-manager/internal/aws"
 	"ccoe-customer-contact-manager/internal/concurrent"
 	"ccoe-customer-contact-manager/internal/config"
 	"ccoe-customer-contact-manager/internal/contacts"
+	"ccoe-customer-contact-manager/internal/contactsource"
+	"ccoe-customer-contact-manager/internal/datetime"
 	"ccoe-customer-contact-manager/internal/lambda"
+	"ccoe-customer-contact-manager/internal/reports"
 	"ccoe-customer-contact-manager/internal/route53"
+	"ccoe-customer-contact-manager/internal/servicenow"
 	"ccoe-customer-contact-manager/internal/ses"
+	"ccoe-customer-contact-manager/internal/ses/templates"
+	"ccoe-customer-contact-manager/internal/sms"
+	"ccoe-customer-contact-manager/internal/surveys"
+	"ccoe-customer-contact-manager/internal/typeform"
 	"ccoe-customer-contact-manager/internal/types"
 )
 
@@ -44,7 +55,11 @@ type CustomerImportResult struct {
 	UsersProcessed  int    `json:"users_processed"`
 	ContactsAdded   int    `json:"contacts_added"`
 	ContactsUpdated int    `json:"contacts_updated"`
+	ContactsRemoved int    `json:"contacts_removed"`
 	ContactsSkipped int    `json:"contacts_skipped"`
+	ContactsFailed  int    `json:"contacts_failed"`
+
+	Report *ses.ContactImportReport `json:"-"` // Per-contact outcomes, written to the import report
 }
 
 // assumeSESRole assumes an SES role for a customer and returns an AWS config with the assumed credentials
@@ -120,6 +135,18 @@ func main() {
 		handleTestS3EventsCommand()
 	case "validate-s3-events":
 		handleValidateS3EventsCommand()
+	case "sweep-overdue":
+		handleSweepOverdueCommand()
+	case "changes":
+		handleChangesCommand()
+	case "report":
+		handleReportCommand()
+	case "calendar-feed":
+		handleCalendarFeedCommand()
+	case "servicenow-approvals":
+		handleServiceNowApprovalsCommand()
+	case "surveys":
+		handleSurveysCommand()
 	case "version":
 		showVersion()
 	case "help", "--help", "-h":
@@ -143,6 +170,12 @@ func showUsage() {
 	fmt.Printf("  configure-s3-events   Configure S3 event notifications\n")
 	fmt.Printf("  test-s3-events        Test S3 event delivery\n")
 	fmt.Printf("  validate-s3-events    Validate S3 event configuration\n")
+	fmt.Printf("  sweep-overdue         Remind owners of approved changes past their window\n")
+	fmt.Printf("  changes               Index and search archived changes and announcements\n")
+	fmt.Printf("  report                Generate a per-customer change and announcement report\n")
+	fmt.Printf("  calendar-feed         Rebuild per-customer .ics feeds of change windows\n")
+	fmt.Printf("  servicenow-approvals  Record ServiceNow approval state on submitted changes\n")
+	fmt.Printf("  surveys               Analyze stored survey responses and backfill missed ones from Typeform\n")
 	fmt.Printf("  version               Show version information\n")
 	fmt.Printf("  help                  Show this help message\n\n")
 	fmt.Printf("Use 'ccoe-customer-contact-manager <command> --help' for command-specific help.\n")
@@ -158,44 +191,133 @@ func showVersion() {
 func handleAltContactCommand() {
 	fs := flag.NewFlagSet("alt-contact", flag.ExitOnError)
 
-	action := fs.String("action", "", "Action to perform: set-all, set-one, delete")
+	action := fs.String("action", "", "Action to perform: set-all, set-one, delete, audit, restore")
 	contactConfigFile := fs.String("contact-config-file", "ContactConfig.json", "Contact configuration file")
-	orgPrefix := fs.String("org-prefix", "", "Organization prefix (required for set-one and delete)")
+	orgPrefix := fs.String("org-prefix", "", "Organization prefix (required for set-one and delete, limits audit and restore to one organization)")
 	overwrite := fs.Bool("overwrite", false, "Overwrite existing contacts")
 	contactTypes := fs.String("contact-types", "", "Comma-separated contact types for delete action")
+	format := fs.String("format", contacts.AuditFormatJSON, "Audit report format: json or csv")
+	outputFile := fs.String("output-file", "", "Audit report file (default: alt-contact-audit-<time>.<format> in the config path)")
+	maxConcurrency := fs.Int("max-concurrency", contacts.DefaultContactMaxConcurrency, "Maximum accounts updated concurrently")
+	requestsPerSecond := fs.Int("requests-per-second", contacts.DefaultContactRequestsPerSecond, "API requests per second shared by all workers")
+	checkpointFile := fs.String("checkpoint-file", "", "Progress file for resuming failed runs (default: alt-contact-checkpoint.json in the config path)")
+	resume := fs.Bool("resume", false, "Skip accounts the checkpoint file records as finished")
+	backupLocation := fs.String("backup-location", "", "Also upload the backup taken before changes to s3://bucket/prefix")
+	backupFile := fs.String("backup-file", "", "Backup to restore (required for restore)")
+	dryRun := fs.Bool("dry-run", false, "Show what restore would change without changing it")
 
 	fs.Parse(os.Args[2:])
 
 	if *action == "" {
 		fmt.Printf("alt-contact command usage:\n")
-		fmt.Printf("  --action string         Action to perform: set-all, set-one, delete\n")
+		fmt.Printf("  --action string         Action to perform: set-all, set-one, delete, audit, restore\n")
 		fmt.Printf("  --contact-config-file   Contact configuration file (default: ContactConfig.json)\n")
-		fmt.Printf("  --org-prefix string     Organization prefix (required for set-one and delete)\n")
+		fmt.Printf("  --org-prefix string     Organization prefix (required for set-one and delete, limits audit and restore to one organization)\n")
 		fmt.Printf("  --overwrite             Overwrite existing contacts\n")
 		fmt.Printf("  --contact-types string  Comma-separated contact types for delete action\n")
+		fmt.Printf("  --format string         Audit report format: json or csv (default: json)\n")
+		fmt.Printf("  --output-file string    Audit report file (default: alt-contact-audit-<time>.<format> in the config path)\n")
+		fmt.Printf("  --max-concurrency int   Maximum accounts updated concurrently (default: %d)\n", contacts.DefaultContactMaxConcurrency)
+		fmt.Printf("  --requests-per-second   API requests per second shared by all workers (default: %d)\n", contacts.DefaultContactRequestsPerSecond)
+		fmt.Printf("  --checkpoint-file       Progress file for resuming failed runs (default: alt-contact-checkpoint.json)\n")
+		fmt.Printf("  --resume                Skip accounts the checkpoint file records as finished\n")
+		fmt.Printf("  --backup-location       Also upload the backup taken before changes to s3://bucket/prefix\n")
+		fmt.Printf("  --backup-file string    Backup to restore (required for restore)\n")
+		fmt.Printf("  --dry-run               Show what restore would change without changing it\n")
+		fmt.Printf("\nset-all, set-one, delete and restore back up the contacts of every affected account to\n")
+		fmt.Printf("alt-contact-backup-<action>-<time>.json in the config path before changing any.\n")
+		fmt.Printf("\nset-all, set-one, delete and restore exit 1 when any account fails.\n")
+		fmt.Printf("\nThe audit action exits 0 when all contacts match, 1 when any are missing or divergent,\n")
+		fmt.Printf("and 2 when any could not be read.\n")
+		return
+	}
+
+	options := contacts.ContactWriteOptions{
+		Overwrite:         *overwrite,
+		MaxConcurrency:    *maxConcurrency,
+		RequestsPerSecond: *requestsPerSecond,
+		CheckpointFile:    *checkpointFile,
+		Resume:            *resume,
+		BackupLocation:    *backupLocation,
+		DryRun:            *dryRun,
+	}
+
+	if *dryRun && *action != "restore" {
+		fmt.Println("Error: dry-run is only supported for the restore action")
 		return
 	}
 
 	switch *action {
 	case "set-all":
-		contacts.SetContactsForAllOrganizations(contactConfigFile, overwrite)
+		handleAltContactRun(contacts.SetContactsForAllOrganizations(*contactConfigFile, options))
 	case "set-one":
 		if *orgPrefix == "" {
 			fmt.Println("Error: org-prefix is required for set-one action")
 			return
 		}
-		contacts.SetContactsForSingleOrganization(contactConfigFile, orgPrefix, overwrite)
+		handleAltContactRun(contacts.SetContactsForSingleOrganization(*contactConfigFile, *orgPrefix, options))
 	case "delete":
 		if *orgPrefix == "" {
 			fmt.Println("Error: org-prefix is required for delete action")
 			return
 		}
-		contacts.DeleteContactsFromOrganization(orgPrefix, contactTypes)
+		handleAltContactRun(contacts.DeleteContactsFromOrganization(*orgPrefix, *contactTypes, options))
+	case "restore":
+		if *backupFile == "" {
+			fmt.Println("Error: backup-file is required for restore action")
+			return
+		}
+		handleAltContactRun(contacts.RestoreContacts(*backupFile, *orgPrefix, options))
+	case "audit":
+		os.Exit(handleAltContactAudit(*contactConfigFile, *orgPrefix, *format, *outputFile))
 	default:
 		fmt.Printf("Unknown action: %s\n", *action)
 	}
 }
 
+// handleAltContactRun displays the per-account summary of a write, delete or restore run, exiting 1 if the
+// run could not start or any account failed
+func handleAltContactRun(summary *contacts.ContactRunSummary, err error) {
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	contacts.DisplayContactRunSummary(summary)
+	if summary.Failed() > 0 {
+		os.Exit(1)
+	}
+}
+
+// handleAltContactAudit audits alternate contacts against the contact config, writes the report
+// and returns the exit code for CI
+func handleAltContactAudit(contactConfigFile, orgPrefix, format, outputFile string) int {
+	format = strings.ToLower(format)
+	if format != contacts.AuditFormatJSON && format != contacts.AuditFormatCSV {
+		fmt.Printf("Error: unsupported format %s (expected json or csv)\n", format)
+		return contacts.AuditExitError
+	}
+
+	report, err := contacts.AuditContactsForAllOrganizations(contactConfigFile, orgPrefix)
+	if err != nil {
+		fmt.Printf("Error: alternate contact audit failed: %v\n", err)
+		return contacts.AuditExitError
+	}
+
+	path, err := contacts.WriteContactAuditReport(report, outputFile, format)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return contacts.AuditExitError
+	}
+
+	fmt.Println()
+	fmt.Printf("Alternate contact audit report written to %s\n", path)
+	fmt.Printf("  Matching:  %d\n", report.Counts[contacts.AuditMatching])
+	fmt.Printf("  Divergent: %d\n", report.Counts[contacts.AuditDivergent])
+	fmt.Printf("  Missing:   %d\n", report.Counts[contacts.AuditMissing])
+	fmt.Printf("  Errors:    %d\n", report.Counts[contacts.AuditError])
+	return report.ExitCode()
+}
+
 func handleSESConfigureDomainAction(cfg *types.Config, customerCode *string, dryRun, configureDNS *bool, dnsRoleArn, logLevel, logFormat *string) {
 	// Validate configuration
 	if err := config.ValidateRoute53Config(cfg); err != nil {
@@ -529,6 +651,7 @@ func handleSESCommand() {
 	customerCode := fs.String("customer-code", "", "Customer code")
 	dryRun := fs.Bool("dry-run", false, "Show what would be done without making changes")
 	email := fs.String("email", "", "Email address")
+	group := fs.String("group", "", "Group name, or comma-separated names (for test-group-parse action)")
 	identityCenterID := fs.String("identity-center-id", "", "Identity Center instance ID (format: d-xxxxxxxxxx)")
 	identityCenterRoleArn := fs.String("identity-center-role-arn", "", "Identity Center role ARN for in-memory data retrieval (overrides config)")
 	logLevel := fs.String("log-level", "info", "Log level")
@@ -540,11 +663,17 @@ func handleSESCommand() {
 	suppressionReason := fs.String("suppression-reason", "bounce", "Suppression reason: bounce or complaint")
 	topicName := fs.String("topic-name", "", "Topic name")
 	topics := fs.String("topics", "", "Comma-separated topics")
+	phone := fs.String("phone", "", "Phone number for urgent SMS (for set-contact-phone action; empty clears it)")
 	username := fs.String("username", "", "Username to search for in Identity Center")
 	backupFile := fs.String("backup-file", "", "Backup file for restore operations")
 	jsonMetadata := fs.String("json-metadata", "", "Path to JSON metadata file from metadata collector")
 	htmlTemplate := fs.String("html-template", "", "Path to HTML email template file")
 	forceUpdate := fs.Bool("force-update", false, "Force update existing meetings regardless of detected changes")
+	fullResync := fs.Bool("full-resync", false, "Ignore the Identity Center snapshot and do a full import (for import-aws-contact-all action)")
+	snapshotLocation := fs.String("snapshot-location", "", "Where Identity Center snapshots are kept: a directory or s3://bucket/prefix (default: config path)")
+	reportFile := fs.String("report-file", "", "Import report file to write (default: contact-import-report-<time>.json in the config path); the newer report for compare-import-reports")
+	reportLocation := fs.String("report-location", "", "s3://bucket/prefix to upload import reports to (for import-aws-contact and import-aws-contact-all actions)")
+	compareReportFile := fs.String("compare-report-file", "", "Older import report to compare --report-file with (for compare-import-reports action)")
 	// Flags for configure-domain action
 	configureDNS := fs.Bool("configure-dns", true, "Automatically configure Route53 DNS records (for configure-domain action)")
 	dnsRoleArn := fs.String("dns-role-arn", "", "IAM role ARN for DNS account (for configure-domain action)")
@@ -565,6 +694,9 @@ func handleSESCommand() {
 	case "help":
 		showSESUsage()
 		return
+	case "compare-import-reports":
+		handleCompareImportReports(*compareReportFile, *reportFile)
+		return
 	}
 
 	// Validate that -all actions don't use single-customer flags
@@ -672,6 +804,11 @@ func handleSESCommand() {
 			log.Fatal("Configuration file and customer code are required for add-contact-topics action")
 		}
 		handleAddContactTopics(customerCode, credentialManager, email, topics, *dryRun)
+	case "set-contact-phone":
+		if credentialManager == nil {
+			log.Fatal("Configuration file and customer code are required for set-contact-phone action")
+		}
+		handleSetContactPhone(customerCode, credentialManager, email, phone, *dryRun)
 	case "remove-contact-topics":
 		if credentialManager == nil {
 			log.Fatal("Configuration file and customer code are required for remove-contact-topics action")
@@ -749,9 +886,13 @@ func handleSESCommand() {
 	case "list-group-membership-all":
 		handleListGroupMembershipAll(mgmtRoleArn, identityCenterID, *maxConcurrency, *requestsPerSecond)
 	case "import-aws-contact":
-		handleImportAWSContact(customerCode, credentialManager, mgmtRoleArn, identityCenterID, username, *maxConcurrency, *requestsPerSecond, *dryRun, configFile)
+		handleImportAWSContact(customerCode, credentialManager, mgmtRoleArn, identityCenterID, username, *maxConcurrency, *requestsPerSecond, *dryRun, configFile, *reportFile, *reportLocation)
 	case "import-aws-contact-all":
-		handleImportAWSContactAll(cfg, customerCode, identityCenterRoleArn, *maxConcurrency, *requestsPerSecond, *dryRun)
+		handleImportAWSContactAll(cfg, customerCode, identityCenterRoleArn, *maxConcurrency, *requestsPerSecond, *dryRun, *fullResync, *snapshotLocation, *reportFile, *reportLocation)
+	case "explain-topics":
+		handleExplainTopics(cfg, customerCode, email, sesConfigFile, identityCenterRoleArn, identityCenterID, *maxConcurrency, *requestsPerSecond)
+	case "test-group-parse":
+		handleTestGroupParse(cfg, customerCode, group)
 	default:
 		fmt.Printf("Unknown SES action: %s\n", *action)
 		showSESUsage()
@@ -1033,6 +1174,634 @@ func handleValidateS3EventsCommand() {
 	// 4. Validate IAM roles and policies
 	// 5. Report any configuration issues
 }
+
+func handleSweepOverdueCommand() {
+	fs := flag.NewFlagSet("sweep-overdue", flag.ExitOnError)
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be done without sending emails or updating changes")
+	logLevel := fs.String("log-level", "info", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	bucket := *bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required for sweep-overdue command")
+	}
+
+	result, err := lambda.SweepOverdueChanges(context.Background(), cfg, bucket, time.Now(), *dryRun)
+	if err != nil {
+		log.Fatalf("Overdue sweep failed: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Printf("DRY RUN: no emails sent and no changes updated\n")
+	}
+	for _, item := range result.Items {
+		status := "ok"
+		if item.Err != nil {
+			status = item.Err.Error()
+		}
+		fmt.Printf("  %-40s %-14s overdue %-10s %s\n", item.ChangeID, item.Action, item.OverdueBy.Round(time.Hour), status)
+	}
+	fmt.Printf("\nScanned: %d, Overdue: %d, Reminders: %d, Escalations: %d, Auto-completed: %d, Errors: %d\n",
+		result.Scanned, result.Overdue, result.Reminders, result.Escalations, result.AutoCompleted, result.Errors)
+}
+
+func handleServiceNowApprovalsCommand() {
+	fs := flag.NewFlagSet("servicenow-approvals", flag.ExitOnError)
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	dryRun := fs.Bool("dry-run", false, "Show approval states without updating changes")
+	logLevel := fs.String("log-level", "info", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	bucket := *bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required for servicenow-approvals command")
+	}
+
+	ctx := context.Background()
+
+	// Credentials may already be exported for local runs
+	if os.Getenv(servicenow.EnvUsername) == "" || os.Getenv(servicenow.EnvPassword) == "" {
+		if err := ses.LoadServiceNowCredentialsFromSSM(ctx); err != nil {
+			log.Fatalf("Failed to load ServiceNow credentials: %v", err)
+		}
+	}
+
+	result, err := lambda.PullServiceNowApprovals(ctx, cfg, bucket, *dryRun)
+	if err != nil {
+		log.Fatalf("ServiceNow approval pull failed: %v", err)
+	}
+
+	if *dryRun {
+		fmt.Printf("DRY RUN: no changes updated\n")
+	}
+	for _, item := range result.Items {
+		status := "unchanged"
+		if item.Err != nil {
+			status = item.Err.Error()
+		} else if item.Recorded && *dryRun {
+			status = "would record"
+		} else if item.Recorded {
+			status = "recorded"
+		}
+		fmt.Printf("  %-40s %-14s %-14s %s\n", item.ChangeID, item.Ticket, item.Approval, status)
+	}
+	fmt.Printf("\nScanned: %d, Checked: %d, Recorded: %d, Errors: %d\n",
+		result.Scanned, result.Checked, result.Recorded, result.Errors)
+}
+
+func handleChangesCommand() {
+	fs := flag.NewFlagSet("changes", flag.ExitOnError)
+	action := fs.String("action", "", "Action to perform: refresh, query")
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	indexFile := fs.String("index-file", "changes-index.jsonl", "Local index file")
+	refresh := fs.Bool("refresh", false, "Refresh the index from S3 before querying")
+	kind := fs.String("kind", "", "Filter by kind: change, announcement")
+	customer := fs.String("customer", "", "Filter by customer code")
+	status := fs.String("status", "", "Filter by status (comma-separated)")
+	from := fs.String("from", "", "Only objects whose window ends on or after this date (YYYY-MM-DD)")
+	to := fs.String("to", "", "Only objects whose window starts on or before this date (YYYY-MM-DD)")
+	ticket := fs.String("ticket", "", "Filter by ServiceNow or Jira ticket number")
+	creator := fs.String("creator", "", "Filter by creator or submitter")
+	search := fs.String("search", "", "Free text search over ID, title and description")
+	malformed := fs.Bool("malformed", false, "Only show objects that failed to load or validate")
+	output := fs.String("output", "table", "Output format: table, json, csv")
+	logLevel := fs.String("log-level", "warn", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	if *action == "" {
+		fmt.Printf("changes command usage:\n")
+		fmt.Printf("  --action string       Action to perform: refresh, query\n")
+		fmt.Printf("  --index-file string   Local index file (default: changes-index.jsonl)\n")
+		fmt.Printf("  --bucket-name string  S3 bucket name (defaults to s3_config.bucket_name)\n")
+		fmt.Printf("  --refresh             Refresh the index from S3 before querying\n")
+		fmt.Printf("  --kind, --customer, --status, --from, --to, --ticket, --creator, --search, --malformed\n")
+		fmt.Printf("                        Query filters\n")
+		fmt.Printf("  --output string       Output format: table, json, csv (default: table)\n")
+		return
+	}
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	idx, err := archive.LoadIndex(*indexFile)
+	if err != nil {
+		log.Fatalf("Failed to load index: %v", err)
+	}
+
+	switch *action {
+	case "refresh":
+		refreshChangesIndex(idx, *configFile, *bucketName, *indexFile)
+	case "query":
+		if *refresh {
+			refreshChangesIndex(idx, *configFile, *bucketName, *indexFile)
+		} else if idx.Len() == 0 {
+			fmt.Fprintf(os.Stderr, "Index %s is empty; run with --action refresh or --refresh first\n", *indexFile)
+		}
+
+		query := archive.Query{
+			Kind:          *kind,
+			Customer:      *customer,
+			Status:        *status,
+			Ticket:        *ticket,
+			Creator:       *creator,
+			Text:          *search,
+			MalformedOnly: *malformed,
+		}
+
+		dtManager := datetime.New(nil)
+		if *from != "" {
+			if query.From, err = dtManager.ParseDate(*from); err != nil {
+				log.Fatalf("Invalid --from date: %v", err)
+			}
+		}
+		if *to != "" {
+			if query.To, err = dtManager.ParseDate(*to); err != nil {
+				log.Fatalf("Invalid --to date: %v", err)
+			}
+			// Include the whole day
+			query.To = query.To.Add(24*time.Hour - time.Nanosecond)
+		}
+
+		if err := archive.Write(os.Stdout, idx.Search(query), *output); err != nil {
+			log.Fatalf("Failed to write results: %v", err)
+		}
+	default:
+		fmt.Printf("Unknown action: %s\n", *action)
+	}
+}
+
+// refreshChangesIndex incrementally refreshes the local archive index from S3 and saves it
+func refreshChangesIndex(idx *archive.Index, configFile, bucketName, indexFile string) {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	bucket := bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required to refresh the changes index")
+	}
+
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	s3Client := s3.NewFromConfig(awsCfg)
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Client, bucket)
+	if err != nil {
+		log.Fatalf("Failed to list archive: %v", err)
+	}
+
+	stats := idx.Refresh(ctx, objects, newArchiveLoader(s3Client, bucket))
+
+	if err := idx.Save(indexFile); err != nil {
+		log.Fatalf("Failed to save index: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Indexed s3://%s/%s: %d objects (%d added, %d updated, %d unchanged, %d removed, %d malformed)\n",
+		bucket, archive.ArchivePrefix, stats.Listed, stats.Added, stats.Updated, stats.Unchanged, stats.Removed, stats.Malformed)
+}
+
+// newArchiveLoader returns an archive loader backed by the same S3 parsing and validation
+// functions the Lambda uses, downloading each object once with the shared client
+func newArchiveLoader(s3Client *s3.Client, bucket string) archive.Loader {
+	return archive.Loader{
+		Load: func(ctx context.Context, key string) (*types.ChangeMetadata, *types.AnnouncementMetadata, error) {
+			return lambda.LoadArchiveObject(ctx, s3Client, bucket, key)
+		},
+		ValidateAnnouncement: lambda.ValidateAnnouncement,
+	}
+}
+
+func handleReportCommand() {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	customerCode := fs.String("customer-code", "", "Customer code (required)")
+	period := fs.String("period", "", "Reporting period: YYYY, YYYY-QN or YYYY-MM (required)")
+	format := fs.String("format", "html", "Output format: html, csv")
+	outputFile := fs.String("output-file", "", "Write the report to this file instead of stdout")
+	emailTopic := fs.String("email-topic", "", "Also email the HTML report to subscribers of this topic")
+	dryRun := fs.Bool("dry-run", false, "Build the report but do not send email")
+	logLevel := fs.String("log-level", "warn", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	if *customerCode == "" || *period == "" {
+		fmt.Printf("report command usage:\n")
+		fmt.Printf("  --customer-code string  Customer code (required)\n")
+		fmt.Printf("  --period string         Reporting period: YYYY, YYYY-QN or YYYY-MM (required)\n")
+		fmt.Printf("  --format string         Output format: html, csv (default: html)\n")
+		fmt.Printf("  --output-file string    Write the report to this file instead of stdout\n")
+		fmt.Printf("  --email-topic string    Also email the HTML report to subscribers of this topic\n")
+		fmt.Printf("  --bucket-name string    S3 bucket name (defaults to s3_config.bucket_name)\n")
+		fmt.Printf("  --dry-run               Build the report but do not send email\n")
+		return
+	}
+
+	reportPeriod, err := reports.ParsePeriod(*period)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	customerInfo, exists := cfg.CustomerMappings[*customerCode]
+	if !exists {
+		log.Fatalf("Customer %s not found in configuration", *customerCode)
+	}
+
+	bucket := *bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required for report command")
+	}
+
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+	s3Client := s3.NewFromConfig(awsCfg)
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Client, bucket)
+	if err != nil {
+		log.Fatalf("Failed to list archive: %v", err)
+	}
+	changes, announcements := reports.LoadArchive(ctx, objects, newArchiveLoader(s3Client, bucket))
+
+	surveys, err := reports.LoadSurveyResults(ctx, s3Client, bucket, *customerCode)
+	if err != nil {
+		log.Fatalf("Failed to load survey results: %v", err)
+	}
+
+	report := reports.BuildCustomerReport(*customerCode, customerInfo.CustomerName, reportPeriod, changes, announcements, surveys, time.Now())
+	emailTemplate := templates.BuildReport(report.TemplateData())
+
+	out := os.Stdout
+	if *outputFile != "" {
+		file, err := os.Create(*outputFile)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *outputFile, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch *format {
+	case "html":
+		_, err = fmt.Fprint(out, emailTemplate.HTMLBody)
+	case "csv":
+		err = report.WriteCSV(out)
+	default:
+		log.Fatalf("Unsupported format: %s (use html or csv)", *format)
+	}
+	if err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	if *emailTopic == "" {
+		return
+	}
+
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "DRY RUN: Would email report %q to topic %s for customer %s\n", emailTemplate.Subject, *emailTopic, *customerCode)
+		return
+	}
+
+	credentialManager, err := aws.NewCredentialManager(cfg.AWSRegion, cfg.CustomerMappings)
+	if err != nil {
+		log.Fatalf("Failed to create credential manager: %v", err)
+	}
+	customerConfig, err := credentialManager.GetCustomerConfig(*customerCode)
+	if err != nil {
+		log.Fatalf("Failed to get customer config for %s: %v", *customerCode, err)
+	}
+
+	if err := ses.SendTemplateToTopic(ctx, sesv2.NewFromConfig(customerConfig), cfg.EmailConfig, emailTemplate, *emailTopic, &customerInfo); err != nil {
+		log.Fatalf("Failed to email report: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Emailed report to topic %s\n", *emailTopic)
+}
+
+func handleSurveysCommand() {
+	fs := flag.NewFlagSet("surveys", flag.ExitOnError)
+	action := fs.String("action", "", "Action to perform: analyze, sync, remind, validate-templates")
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	customerCode := fs.String("customer-code", "", "Only include this customer")
+	quarter := fs.String("quarter", "", "Only include this quarter (e.g. 2025-Q1)")
+	surveyType := fs.String("survey-type", "", "Only include this survey type: change, cic, innersource, finops, general")
+	objectID := fs.String("object-id", "", "Only include this change or announcement")
+	ratingScale := fs.Int("rating-scale", surveys.DefaultRatingScale, "Steps on rating questions; the top two count as satisfied")
+	format := fs.String("format", "json", "Output format: json, csv, html")
+	outputFile := fs.String("output-file", "", "Write the report to this file instead of stdout")
+	dryRun := fs.Bool("dry-run", false, "Sync and remind: show what would be done without writing, emailing or closing forms")
+	templatesSource := fs.String("templates", "", "Validate-templates: survey config file or s3://bucket/key (defaults to TYPEFORM_SURVEY_CONFIG)")
+	logLevel := fs.String("log-level", "warn", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	if *action == "" {
+		fmt.Printf("surveys command usage:\n")
+		fmt.Printf("  --action string         Action to perform: analyze, sync, remind, validate-templates\n")
+		fmt.Printf("  --customer-code string  Only include this customer\n")
+		fmt.Printf("  --quarter string        Only include this quarter (e.g. 2025-Q1)\n")
+		fmt.Printf("  --survey-type string    Only include this survey type: change, cic, innersource, finops, general\n")
+		fmt.Printf("  --object-id string      Only include this change or announcement\n")
+		fmt.Printf("  --rating-scale int      Steps on rating questions (default: 5)\n")
+		fmt.Printf("  --format string         Output format: json, csv, html (default: json)\n")
+		fmt.Printf("  --output-file string    Write the report to this file instead of stdout\n")
+		fmt.Printf("  --dry-run               Sync and remind: show what would be done without writing, emailing or closing forms\n")
+		fmt.Printf("  --templates string      Validate-templates: survey config file or s3://bucket/key (defaults to TYPEFORM_SURVEY_CONFIG)\n")
+		fmt.Printf("  --bucket-name string    S3 bucket name (defaults to s3_config.bucket_name)\n")
+		fmt.Printf("\nThe sync action pulls missed responses from the Typeform Responses API and needs TYPEFORM_API_TOKEN.\n")
+		fmt.Printf("It honours --customer-code and --object-id.\n")
+		fmt.Printf("\nThe remind action emails change survey non-respondents once and closes forms past their deadline,\n")
+		fmt.Printf("using survey_reminders from the config file. Closing forms needs TYPEFORM_API_TOKEN.\n")
+		return
+	}
+
+	// Validating templates needs neither the config file nor a bucket unless the source is in S3
+	if *action == "validate-templates" {
+		source := *templatesSource
+		if source == "" {
+			source = os.Getenv(typeform.SurveyConfigEnvVar)
+		}
+		if source == "" {
+			log.Fatalf("--templates or %s is required for validate-templates", typeform.SurveyConfigEnvVar)
+		}
+
+		var s3Client *s3.Client
+		if strings.HasPrefix(source, "s3://") {
+			awsCfg, err := awsconfig.LoadDefaultConfig(context.Background())
+			if err != nil {
+				log.Fatalf("Failed to load AWS config: %v", err)
+			}
+			s3Client = s3.NewFromConfig(awsCfg)
+		}
+
+		surveyConfig, err := typeform.LoadSurveyConfig(context.Background(), s3Client, source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ %s is valid: %d template(s), %d workspace(s), %d theme(s)\n",
+			source, len(surveyConfig.Templates), len(surveyConfig.Workspaces), len(surveyConfig.Themes))
+		return
+	}
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	bucket := *bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required for surveys command")
+	}
+
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+	s3Client := s3.NewFromConfig(awsCfg)
+
+	switch *action {
+	case "analyze":
+		filter := surveys.Filter{
+			CustomerCode: *customerCode,
+			Quarter:      strings.ToUpper(*quarter),
+			SurveyType:   strings.ToLower(*surveyType),
+			ObjectID:     *objectID,
+		}
+
+		// Forms are stored under the first customer of a change while responses come from every
+		// customer, so all forms are loaded and filtered during analysis
+		payloads, err := surveys.LoadResults(ctx, s3Client, bucket, *customerCode)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		forms, err := surveys.LoadForms(ctx, s3Client, bucket, "")
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+
+		responses := make([]surveys.Response, 0, len(payloads))
+		for _, payload := range payloads {
+			responses = append(responses, surveys.ParseResponse(payload))
+		}
+		report := surveys.Analyze(responses, forms, filter, surveys.Options{RatingScale: *ratingScale}, time.Now().UTC())
+
+		out := os.Stdout
+		if *outputFile != "" {
+			file, err := os.Create(*outputFile)
+			if err != nil {
+				log.Fatalf("Failed to create %s: %v", *outputFile, err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		switch *format {
+		case "json":
+			err = report.WriteJSON(out)
+		case "csv":
+			err = report.WriteCSV(out)
+		case "html":
+			_, err = fmt.Fprint(out, templates.BuildReport(report.TemplateData()).HTMLBody)
+		default:
+			log.Fatalf("Unsupported format: %s (use json, csv or html)", *format)
+		}
+		if err != nil {
+			log.Fatalf("Failed to write survey report: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Analyzed %d responses across %d surveys\n", report.Overall.Responses, report.Overall.Surveys)
+	case "sync":
+		typeformClient, err := typeform.NewClient(slog.Default())
+		if err != nil {
+			log.Fatalf("Failed to create Typeform client: %v", err)
+		}
+
+		result, err := surveys.Sync(ctx, typeformClient, s3Client, bucket, surveys.SyncOptions{
+			CustomerCode: *customerCode,
+			ObjectID:     *objectID,
+			DryRun:       *dryRun,
+		})
+		if err != nil {
+			log.Fatalf("Failed to sync survey responses: %v", err)
+		}
+
+		verb := "Stored"
+		if *dryRun {
+			verb = "Would store"
+		}
+		for _, key := range result.Keys {
+			fmt.Printf("%s s3://%s/%s\n", verb, bucket, key)
+		}
+		fmt.Printf("\nForms: %d, responses fetched: %d, already stored: %d, %s: %d, failed: %d\n",
+			result.Forms, result.Fetched, result.Existing, strings.ToLower(verb), result.Stored, result.Failed)
+		if result.Failed > 0 {
+			os.Exit(1)
+		}
+	case "remind":
+		result, err := lambda.SweepSurveyReminders(ctx, cfg, bucket, time.Now(), *dryRun)
+		if err != nil {
+			log.Fatalf("Survey reminder sweep failed: %v", err)
+		}
+
+		if *dryRun {
+			fmt.Printf("DRY RUN: no emails sent and no forms closed\n")
+		}
+		for _, item := range result.Items {
+			status := "ok"
+			if item.Err != nil {
+				status = item.Err.Error()
+			}
+			fmt.Printf("  %-40s %-8s %-6s pending %-4d reminded %-4d %s\n", item.ObjectID, item.CustomerCode, item.Action, item.Pending, item.Sent, status)
+		}
+		fmt.Printf("\nScanned: %d, Surveys reminded: %d, Reminders: %d, Closed: %d, Errors: %d\n",
+			result.Scanned, result.Reminded, result.Reminders, result.Closed, result.Errors)
+		if result.Errors > 0 {
+			os.Exit(1)
+		}
+	default:
+		log.Fatalf("Unknown surveys action: %s (use analyze, sync, remind or validate-templates)", *action)
+	}
+}
+
+func handleCalendarFeedCommand() {
+	fs := flag.NewFlagSet("calendar-feed", flag.ExitOnError)
+	configFile := fs.String("config-file", "config.json", "Configuration file path")
+	bucketName := fs.String("bucket-name", "", "S3 bucket name (defaults to s3_config.bucket_name)")
+	customerCode := fs.String("customer-code", "", "Customer code to rebuild (default: all configured customers)")
+	outputFile := fs.String("output-file", "", "Write the feed to this file instead of S3 (requires --customer-code)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be published without writing to S3")
+	logLevel := fs.String("log-level", "warn", "Log level")
+
+	fs.Parse(os.Args[2:])
+
+	// Setup logging
+	config.SetupLogging(*logLevel)
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if *outputFile != "" && *customerCode == "" {
+		log.Fatal("--output-file requires --customer-code")
+	}
+	if *outputFile == "" && !*dryRun && (cfg.CalendarFeed == nil || !cfg.CalendarFeed.Enabled) {
+		log.Fatal("calendar_feed is not enabled in the configuration")
+	}
+
+	var customerCodes []string
+	if *customerCode != "" {
+		if _, exists := cfg.CustomerMappings[*customerCode]; !exists {
+			log.Fatalf("Customer %s not found in configuration", *customerCode)
+		}
+		customerCodes = []string{*customerCode}
+	} else {
+		for code := range cfg.CustomerMappings {
+			customerCodes = append(customerCodes, code)
+		}
+		sort.Strings(customerCodes)
+	}
+
+	bucket := *bucketName
+	if bucket == "" {
+		bucket = cfg.S3Config.BucketName
+	}
+	if bucket == "" {
+		log.Fatal("Bucket name is required for calendar-feed command")
+	}
+
+	ctx := context.Background()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		log.Fatalf("Failed to load AWS config: %v", err)
+	}
+
+	s3Client := s3.NewFromConfig(awsCfg)
+
+	objects, err := archive.ListArchiveObjects(ctx, s3Client, bucket)
+	if err != nil {
+		log.Fatalf("Failed to list archive: %v", err)
+	}
+	changes, _ := reports.LoadArchive(ctx, objects, newArchiveLoader(s3Client, bucket))
+
+	localOnly := *dryRun || *outputFile != ""
+	for _, code := range customerCodes {
+		feed, events, err := lambda.RebuildCalendarFeed(ctx, cfg, bucket, code, changes, localOnly)
+		if err != nil {
+			log.Fatalf("Failed to rebuild calendar feed for %s: %v", code, err)
+		}
+
+		switch {
+		case *outputFile != "":
+			if err := os.WriteFile(*outputFile, []byte(feed), 0644); err != nil {
+				log.Fatalf("Failed to write %s: %v", *outputFile, err)
+			}
+			fmt.Printf("Wrote %d events for %s to %s\n", events, code, *outputFile)
+		case *dryRun:
+			fmt.Printf("DRY RUN: Would publish %d events for %s to %s\n", events, code, cfg.CalendarFeed.FeedKey(code))
+		default:
+			fmt.Printf("Published %d events for %s to %s\n", events, code, cfg.CalendarFeed.FeedKey(code))
+		}
+	}
+}
+
 func showSESUsage() {
 	fmt.Printf("SES command usage:\n\n")
 	fmt.Printf("📧 CONTACT LIST MANAGEMENT:\n")
@@ -1047,6 +1816,7 @@ func showSESUsage() {
 	fmt.Printf("  describe-contact        Show detailed contact information\n")
 	fmt.Printf("  add-contact-topics      Add topic subscriptions to contact\n")
 	fmt.Printf("  remove-contact-topics   Remove topic subscriptions from contact\n")
+	fmt.Printf("  set-contact-phone       Set or clear a contact's phone number for urgent SMS\n")
 	fmt.Printf("  remove-all-contacts     Remove all contacts from list (with backup)\n")
 	fmt.Printf("  backup-contact-list     Create backup of contact list\n\n")
 	fmt.Printf("🏷️  TOPIC MANAGEMENT:\n")
@@ -1078,7 +1848,13 @@ func showSESUsage() {
 	fmt.Printf("  import-aws-contact            Import specific user to SES based on group memberships\n")
 	fmt.Printf("  import-aws-contact-all        Import ALL users to SES based on group memberships\n")
 	fmt.Printf("                                Supports in-memory retrieval with --identity-center-role-arn\n")
-	fmt.Printf("                                or falls back to file-based import\n\n")
+	fmt.Printf("                                or falls back to file-based import\n")
+	fmt.Printf("  explain-topics                Show which default, role or rule gives a user each topic\n")
+	fmt.Printf("                                (requires --email; uses the customer's contact source)\n")
+	fmt.Printf("  compare-import-reports        Show contacts whose import outcome differs between two reports\n")
+	fmt.Printf("                                (requires --compare-report-file and --report-file)\n")
+	fmt.Printf("  test-group-parse              Show how group names parse with the customer's group patterns\n")
+	fmt.Printf("                                (requires --group; comma-separated for several)\n\n")
 	fmt.Printf("📬 EMAIL DELIVERABILITY:\n")
 	fmt.Printf("  configure-ses-complete      Complete SES setup: DKIM + SPF + DMARC + MAIL FROM (recommended)\n")
 	fmt.Printf("  configure-domain            Configure SES domain identity and DKIM only\n")
@@ -1097,6 +1873,7 @@ func showSESUsage() {
 	fmt.Printf("                                  Used by: update-topic, manage-topic-all, subscribe, unsubscribe\n")
 	fmt.Printf("  --email string                  Email address\n")
 	fmt.Printf("  --topics string                 Comma-separated topic names\n")
+	fmt.Printf("  --phone string                  Phone number for set-contact-phone (empty clears it)\n")
 	fmt.Printf("  --topic-name string             Single topic name\n")
 	fmt.Printf("  --sender-email string           Sender email address for test emails\n")
 	fmt.Printf("  --json-metadata string          Path to JSON metadata file\n")
@@ -1113,6 +1890,9 @@ func showSESUsage() {
 	fmt.Printf("                                  (0 = unlimited, default: process all customers concurrently)\n")
 	fmt.Printf("  --requests-per-second           API requests per second rate limit (default: 9)\n")
 	fmt.Printf("  --force-update                  Force update existing meetings\n")
+	fmt.Printf("  --full-resync                   Ignore the Identity Center snapshot and import everything\n")
+	fmt.Printf("  --snapshot-location string      Directory or s3://bucket/prefix for Identity Center snapshots\n")
+	fmt.Printf("                                  (default: config path)\n")
 	fmt.Printf("  --dry-run                       Show what would be done without making changes\n")
 	fmt.Printf("  --log-level string              Log level (default: info)\n\n")
 	fmt.Printf("MULTI-CUSTOMER OPERATIONS:\n")
@@ -1153,7 +1933,22 @@ func showSESUsage() {
 	fmt.Printf("  # Import with CLI override of Identity Center role\n")
 	fmt.Printf("  ccoe-customer-contact-manager ses --action import-aws-contact-all \\\n")
 	fmt.Printf("    --identity-center-role-arn arn:aws:iam::123456789012:role/IdentityCenterRole \\\n")
-	fmt.Printf("    --max-concurrency 5 --dry-run\n")
+	fmt.Printf("    --max-concurrency 5 --dry-run\n\n")
+	fmt.Printf("  # Explain why a user gets (or does not get) each topic\n")
+	fmt.Printf("  ccoe-customer-contact-manager ses --action explain-topics \\\n")
+	fmt.Printf("    --customer-code htsnonprod --email jane@example.com\n\n")
+	fmt.Printf("  # Import and upload the per-contact report to S3\n")
+	fmt.Printf("  ccoe-customer-contact-manager ses --action import-aws-contact-all \\\n")
+	fmt.Printf("    --report-location s3://my-bucket/import-reports\n\n")
+	fmt.Printf("  # Compare a dry run's report with the real run's\n")
+	fmt.Printf("  ccoe-customer-contact-manager ses --action compare-import-reports \\\n")
+	fmt.Printf("    --compare-report-file dry-run.json --report-file contact-import-report-20260101T060000Z.json\n\n")
+	fmt.Printf("  # Check a customer's group patterns against group names\n")
+	fmt.Printf("  ccoe-customer-contact-manager ses --action test-group-parse \\\n")
+	fmt.Printf("    --customer-code htsnonprod --group aws-hts-prod-admin,ccoe-cloud-hts-prod-123456789012-idp-hts-admin\n\n")
+	fmt.Printf("  # Force a full import, ignoring the Identity Center snapshot kept in S3\n")
+	fmt.Printf("  ccoe-customer-contact-manager ses --action import-aws-contact-all \\\n")
+	fmt.Printf("    --snapshot-location s3://my-bucket/identity-center --full-resync\n")
 }
 
 func handleCreateContactList(customerCode *string, credentialManager *aws.CredentialManager, dryRun bool) {
@@ -1694,6 +2489,59 @@ func handleDescribeContact(customerCode *string, credentialManager *aws.Credenti
 	}
 }
 
+func handleSetContactPhone(customerCode *string, credentialManager *aws.CredentialManager, email *string, phone *string, dryRun bool) {
+	if *customerCode == "" {
+		log.Fatal("Customer code is required for set-contact-phone action")
+	}
+	if *email == "" {
+		log.Fatal("Email address is required for set-contact-phone action")
+	}
+
+	phoneNumber := ""
+	if *phone != "" {
+		normalized, err := sms.NormalizePhoneNumber(*phone)
+		if err != nil {
+			log.Fatalf("Invalid phone number: %v", err)
+		}
+		phoneNumber = normalized
+	}
+
+	if dryRun {
+		if phoneNumber == "" {
+			fmt.Printf("DRY RUN: Would clear the phone number of contact %s for customer %s\n", *email, *customerCode)
+		} else {
+			fmt.Printf("DRY RUN: Would set the phone number of contact %s for customer %s to %s\n", *email, *customerCode, phoneNumber)
+		}
+		return
+	}
+
+	customerConfig, err := credentialManager.GetCustomerConfig(*customerCode)
+	if err != nil {
+		log.Fatalf("Failed to get customer config: %v", err)
+	}
+
+	sesClient := sesv2.NewFromConfig(customerConfig)
+
+	listName, err := ses.GetAccountContactList(sesClient)
+	if err != nil {
+		log.Fatalf("Failed to get account contact list: %v", err)
+	}
+
+	updated, err := ses.SetContactPhoneNumber(sesClient, listName, *email, phoneNumber, ses.PhoneSourceManual)
+	if err != nil {
+		log.Fatalf("Failed to set contact phone number: %v", err)
+	}
+
+	switch {
+	case !updated:
+		fmt.Printf("✅ Contact %s already has this phone number\n", *email)
+	case phoneNumber == "":
+		fmt.Printf("✅ Cleared phone number for %s\n", *email)
+	default:
+		fmt.Printf("✅ Set phone number for %s to %s\n", *email, sms.MaskPhoneNumber(phoneNumber))
+	}
+}
+
 func handleAddContactTopics(customerCode *string, credentialManager *aws.CredentialManager, email *string, topics *string, dryRun bool) {
 	if *customerCode == "" {
 		log.Fatal("Customer code is required for add-contact-topics action")
@@ -2495,7 +3343,7 @@ func handleListGroupMembershipAll(mgmtRoleArn *string, identityCenterID *string,
 	}
 }
 
-func handleImportAWSContact(customerCode *string, credentialManager *aws.CredentialManager, mgmtRoleArn *string, identityCenterID *string, username *string, maxConcurrency int, requestsPerSecond int, dryRun bool, configFile *string) {
+func handleImportAWSContact(customerCode *string, credentialManager *aws.CredentialManager, mgmtRoleArn *string, identityCenterID *string, username *string, maxConcurrency int, requestsPerSecond int, dryRun bool, configFile *string, reportFile string, reportLocation string) {
 	if *customerCode == "" {
 		log.Fatal("Customer code is required for import-aws-contact action")
 	}
@@ -2550,7 +3398,12 @@ func handleImportAWSContact(customerCode *string, credentialManager *aws.Credent
 	sesClient := sesv2.NewFromConfig(customerConfig)
 
 	// Call the actual import function with in-memory data if available
-	err = ses.ImportSingleAWSContactWithData(sesClient, actualIdentityCenterID, icData, *username, dryRun)
+	report := ses.NewContactImportReport(*customerCode, dryRun)
+	err = ses.ImportSingleAWSContactWithData(sesClient, actualIdentityCenterID, icData, &customerInfo, *username, dryRun, report)
+	report.Finish(err)
+	if reportErr := writeContactImportReport([]*ses.ContactImportReport{report}, dryRun, reportFile, reportLocation); reportErr != nil {
+		log.Printf("Failed to write import report: %v", reportErr)
+	}
 	if err != nil {
 		log.Fatalf("Failed to import AWS contact: %v", err)
 	}
@@ -2558,7 +3411,12 @@ func handleImportAWSContact(customerCode *string, credentialManager *aws.Credent
 	fmt.Printf("✅ Successfully imported AWS contact: %s\n", *username)
 }
 
-func handleImportAWSContactAll(cfg *types.Config, customerCode *string, identityCenterRoleArn *string, maxConcurrency int, requestsPerSecond int, dryRun bool) {
+func handleImportAWSContactAll(cfg *types.Config, customerCode *string, identityCenterRoleArn *string, maxConcurrency int, requestsPerSecond int, dryRun bool, fullResync bool, snapshotLocation string, reportFile string, reportLocation string) {
+	snapshotStore, err := aws.NewSnapshotStore(context.Background(), snapshotLocation)
+	if err != nil {
+		log.Fatalf("Failed to open Identity Center snapshot store: %v", err)
+	}
+
 	// If a specific customer code is provided, process only that customer
 	// Otherwise, process all customers concurrently
 	if customerCode != nil && *customerCode != "" {
@@ -2579,13 +3437,13 @@ func handleImportAWSContactAll(cfg *types.Config, customerCode *string, identity
 		}
 
 		// Call enhanced handler with single customer
-		err := handleImportAWSContactAllEnhanced(singleCustomerConfig, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun)
+		err := handleImportAWSContactAllEnhanced(singleCustomerConfig, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun, snapshotStore, fullResync, reportFile, reportLocation)
 		if err != nil {
 			log.Fatalf("Failed to import AWS contacts: %v", err)
 		}
 	} else {
 		// Multi-customer mode - process all customers concurrently
-		err := handleImportAWSContactAllEnhanced(cfg, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun)
+		err := handleImportAWSContactAllEnhanced(cfg, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun, snapshotStore, fullResync, reportFile, reportLocation)
 		if err != nil {
 			log.Fatalf("Failed to import AWS contacts: %v", err)
 		}
@@ -2594,6 +3452,174 @@ func handleImportAWSContactAll(cfg *types.Config, customerCode *string, identity
 	fmt.Printf("✅ Successfully completed bulk import of AWS contacts\n")
 }
 
+// handleTestGroupParse shows how each group name parses with the customer's group patterns and the
+// ccoe-cloud format, so a new naming convention can be checked before an import
+func handleTestGroupParse(cfg *types.Config, customerCode *string, groups *string) {
+	if *groups == "" {
+		log.Fatal("Group is required for test-group-parse action (comma-separated for several)")
+	}
+
+	var patterns []types.GroupPattern
+	if *customerCode != "" {
+		customerInfo, exists := cfg.CustomerMappings[*customerCode]
+		if !exists {
+			log.Fatalf("Customer code %s not found in configuration", *customerCode)
+		}
+		patterns = customerInfo.GroupPatterns
+		fmt.Printf("🔎 Customer %s: %d group patterns, then %s\n", *customerCode, len(patterns), ses.CCOECloudGroupPattern)
+	} else {
+		fmt.Printf("🔎 No customer code given, using the %s format only\n", ses.CCOECloudGroupPattern)
+	}
+
+	unmatched := 0
+	for _, group := range strings.Split(*groups, ",") {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			continue
+		}
+
+		fmt.Printf("\n👥 %s\n", group)
+		matched := false
+		for _, attempt := range ses.TraceGroupParse(group, patterns) {
+			switch {
+			case attempt.Error != "":
+				fmt.Printf("   ⚠️  %-20s invalid regex: %s\n", attempt.Pattern, attempt.Error)
+			case !attempt.Result.IsValid:
+				fmt.Printf("   ➖ %-20s no match\n", attempt.Pattern)
+			case matched:
+				fmt.Printf("   ➖ %-20s would match, but an earlier pattern wins: %s\n", attempt.Pattern, formatParsedGroup(attempt.Result))
+			default:
+				matched = true
+				fmt.Printf("   ✅ %-20s %s\n", attempt.Pattern, formatParsedGroup(attempt.Result))
+			}
+		}
+		if !matched {
+			unmatched++
+			fmt.Printf("   ❌ No pattern matched; users in this group get no role-based topics\n")
+		}
+	}
+
+	if unmatched > 0 {
+		fmt.Printf("\n⚠️  %d groups matched no pattern\n", unmatched)
+		os.Exit(1)
+	}
+}
+
+// formatParsedGroup describes the fields a group name parsed to
+func formatParsedGroup(parsed ses.CCOECloudGroupParseResult) string {
+	return fmt.Sprintf("account: %s, account ID: %s, environment: %s, application: %s, role: %s",
+		parsed.AccountName, parsed.AccountId, parsed.Environment, parsed.ApplicationPrefix, parsed.RoleName)
+}
+
+// handleExplainTopics shows how a user's topics are decided: the groups they are in, what each
+// group parses to, and which default, OptInRoles mapping or topic rule decided each topic
+func handleExplainTopics(cfg *types.Config, customerCode *string, email *string, sesConfigFile *string, identityCenterRoleArn *string, identityCenterID *string, maxConcurrency int, requestsPerSecond int) {
+	if *email == "" {
+		log.Fatal("Email is required for explain-topics action")
+	}
+
+	sesConfigPath := ses.GetConfigPath() + ses.GetSESConfigFilePath()
+	if sesConfigFile != nil && *sesConfigFile != "" {
+		sesConfigPath = *sesConfigFile
+	}
+	sesConfig, err := config.LoadSESConfig(sesConfigPath)
+	if err != nil {
+		log.Fatalf("Failed to load SES config from %s: %v", sesConfigPath, err)
+	}
+	if err := ses.ValidateTopicRules(sesConfig.TopicRules); err != nil {
+		log.Fatalf("Invalid SES config %s: %v", sesConfigPath, err)
+	}
+	importConfig := ses.BuildContactImportConfigFromSES(*sesConfig)
+	customerInfo := cfg.CustomerMappings[*customerCode]
+	if err := config.ValidateGroupPatterns(customerInfo.GroupPatterns); err != nil {
+		log.Fatalf("Invalid group patterns for customer %s: %v", *customerCode, err)
+	}
+	importConfig.GroupPatterns = customerInfo.GroupPatterns
+
+	// Find the user through the customer's contact source, or the Identity Center files
+	var users []types.IdentityCenterUser
+	var memberships []types.IdentityCenterGroupMembership
+	icRoleArn := customerInfo.IdentityCenterRoleArn
+	if identityCenterRoleArn != nil && *identityCenterRoleArn != "" {
+		icRoleArn = *identityCenterRoleArn
+	}
+
+	if icRoleArn != "" || customerInfo.ContactSource.GetType() != types.ContactSourceIdentityCenter {
+		source, err := contactsource.New(context.Background(), customerInfo.ContactSource, icRoleArn, maxConcurrency, requestsPerSecond)
+		if err != nil {
+			log.Fatalf("Failed to create contact source: %v", err)
+		}
+		fmt.Printf("📥 Retrieving contacts from %s\n", source.Name())
+		data, err := source.Retrieve(context.Background(), &aws.DefaultLogger{})
+		if err != nil {
+			log.Fatalf("Failed to retrieve contacts: %v", err)
+		}
+		users, memberships = data.Users, data.Memberships
+	} else {
+		users, memberships, _, err = ses.LoadIdentityCenterDataFromFiles(*identityCenterID)
+		if err != nil {
+			log.Fatalf("Failed to load Identity Center data (use --customer-code with a contact source or role): %v", err)
+		}
+	}
+
+	var user *types.IdentityCenterUser
+	for i := range users {
+		if strings.EqualFold(users[i].Email, *email) {
+			user = &users[i]
+			break
+		}
+	}
+	if user == nil {
+		log.Fatalf("No user with email %s found", *email)
+	}
+
+	var membership *types.IdentityCenterGroupMembership
+	for i := range memberships {
+		if memberships[i].UserId == user.UserId || memberships[i].UserName == user.UserName {
+			membership = &memberships[i]
+			break
+		}
+	}
+
+	fmt.Printf("\n👤 %s (%s), active: %v\n", user.DisplayName, user.Email, user.Active)
+	fmt.Printf("\n👥 Groups:\n")
+	if membership == nil || len(membership.Groups) == 0 {
+		fmt.Printf("   (none)\n")
+	} else {
+		for _, group := range membership.Groups {
+			parsed := ses.ParseGroup(group, customerInfo.GroupPatterns)
+			if parsed.IsValid {
+				fmt.Printf("   - %s → %s\n", group, formatParsedGroup(parsed))
+			} else {
+				fmt.Printf("   - %s (matches no group pattern)\n", group)
+			}
+		}
+	}
+
+	explanation := ses.ExplainUserTopics(*user, membership, importConfig)
+
+	fmt.Printf("\n📋 Topic decisions:\n")
+	if len(explanation.Decisions) == 0 {
+		fmt.Printf("   (no defaults, role mappings or rules apply)\n")
+	}
+	for _, decision := range explanation.Decisions {
+		mark := "✅"
+		if !decision.Included {
+			mark = "🚫"
+		}
+		source := decision.Source
+		if decision.Rule != "" {
+			source += " " + decision.Rule
+		}
+		if decision.Overrides != "" {
+			source += " (overrides " + decision.Overrides + ")"
+		}
+		fmt.Printf("   %s %-24s %s: %s\n", mark, decision.Topic, source, decision.Reason)
+	}
+
+	fmt.Printf("\n📬 Topics: %v\n", explanation.Topics)
+}
+
 // CustomerLogBuffer buffers log messages for a customer to flush them as a block
 type CustomerLogBuffer struct {
 	customerCode string
@@ -2626,7 +3652,9 @@ func (b *CustomerLogBuffer) Flush() {
 }
 
 // processCustomer processes a single customer's Identity Center data retrieval and SES import
-// This function is designed to be called concurrently for multiple customers
+// This function is designed to be called concurrently for multiple customers. With a previous
+// Identity Center snapshot only the changes since it are imported; the snapshot is updated once
+// the import succeeds.
 func processCustomer(
 	cfg *types.Config,
 	customerCode string,
@@ -2634,6 +3662,8 @@ func processCustomer(
 	maxConcurrency int,
 	requestsPerSecond int,
 	dryRun bool,
+	snapshotStore aws.SnapshotStore,
+	fullResync bool,
 ) CustomerImportResult {
 	// Create log buffer for this customer
 	logBuffer := &CustomerLogBuffer{customerCode: customerCode}
@@ -2641,6 +3671,7 @@ func processCustomer(
 	result := CustomerImportResult{
 		CustomerCode: customerCode,
 		Success:      false,
+		Report:       ses.NewContactImportReport(customerCode, dryRun),
 	}
 
 	customerInfo, exists := cfg.CustomerMappings[customerCode]
@@ -2653,10 +3684,15 @@ func processCustomer(
 
 	logBuffer.Printf("🔄 Customer %s: Starting processing", customerCode)
 
-	// Determine Identity Center role ARN (CLI flag takes precedence over config)
+	// Determine the contact source: a configured SCIM or file source, otherwise Identity Center
+	// (CLI flag takes precedence over config for the role ARN)
+	sourceType := customerInfo.ContactSource.GetType()
 	icRoleArn := ""
 	dataSource := "file-based"
-	if identityCenterRoleArn != nil && *identityCenterRoleArn != "" {
+	if sourceType != types.ContactSourceIdentityCenter {
+		dataSource = sourceType
+		logBuffer.Printf("🔗 Customer %s: Using %s contact source from config", customerCode, sourceType)
+	} else if identityCenterRoleArn != nil && *identityCenterRoleArn != "" {
 		icRoleArn = *identityCenterRoleArn
 		dataSource = "in-memory"
 		logBuffer.Printf("🔐 Customer %s: Using Identity Center role from CLI flag: %s", customerCode, icRoleArn)
@@ -2667,22 +3703,32 @@ func processCustomer(
 	}
 
 	var icData *aws.IdentityCenterData
+	var icSync *aws.IdentityCenterSync
 	var identityCenterID string
 
-	// Retrieve Identity Center data if role ARN is configured
-	if icRoleArn != "" {
-		// Validate the Identity Center role ARN format
-		if err := config.ValidateIdentityCenterRoleArn(icRoleArn); err != nil {
-			result.Error = fmt.Errorf("invalid Identity Center role ARN: %w", err)
-			logBuffer.Printf("❌ Customer %s: Invalid Identity Center role ARN: %v", customerCode, err)
+	// Retrieve contact data if a role ARN or another contact source is configured
+	if icRoleArn != "" || sourceType != types.ContactSourceIdentityCenter {
+		if sourceType == types.ContactSourceIdentityCenter {
+			// Validate the Identity Center role ARN format
+			if err := config.ValidateIdentityCenterRoleArn(icRoleArn); err != nil {
+				result.Error = fmt.Errorf("invalid Identity Center role ARN: %w", err)
+				logBuffer.Printf("❌ Customer %s: Invalid Identity Center role ARN: %v", customerCode, err)
+				logBuffer.Flush()
+				return result
+			}
+
+			logBuffer.Printf("📊 Customer %s: Retrieving Identity Center data via role assumption (data source: %s)", customerCode, dataSource)
+		}
+
+		source, err := contactsource.New(context.Background(), customerInfo.ContactSource, icRoleArn, maxConcurrency, requestsPerSecond)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to create contact source: %w", err)
+			logBuffer.Printf("❌ Customer %s: Failed to create contact source: %v", customerCode, err)
 			logBuffer.Flush()
 			return result
 		}
 
-		logBuffer.Printf("📊 Customer %s: Retrieving Identity Center data via role assumption (data source: %s)", customerCode, dataSource)
-
-		var err error
-		icData, err = aws.RetrieveIdentityCenterDataWithLogger(icRoleArn, maxConcurrency, requestsPerSecond, logBuffer)
+		icSync, err = contactsource.Sync(context.Background(), source, customerCode, snapshotStore, fullResync, logBuffer)
 		if err != nil {
 			// Provide clear error message for permission issues
 			if strings.Contains(err.Error(), "AccessDenied") || strings.Contains(err.Error(), "not authorized") {
@@ -2693,19 +3739,29 @@ func processCustomer(
 					"  3. Your current credentials have sts:AssumeRole permission\n"+
 					"  4. The role has permissions to access Identity Center (identitystore:* and sso:ListInstances)", err)
 			} else {
-				result.Error = fmt.Errorf("failed to retrieve Identity Center data: %w", err)
+				result.Error = fmt.Errorf("failed to retrieve contact data: %w", err)
 			}
-			logBuffer.Printf("❌ Customer %s: Failed to retrieve Identity Center data: %v", customerCode, result.Error)
+			logBuffer.Printf("❌ Customer %s: Failed to retrieve contact data: %v", customerCode, result.Error)
 			logBuffer.Flush()
 			return result
 		}
 
+		icData = icSync.Data
 		identityCenterID = icData.InstanceID
-		logBuffer.Printf("✅ Customer %s: Retrieved %d users and %d group memberships from Identity Center (instance: %s, data source: %s)",
-			customerCode, len(icData.Users), len(icData.Memberships), identityCenterID, dataSource)
+		logBuffer.Printf("✅ Customer %s: Retrieved %d users and %d group memberships from %s (instance: %s, data source: %s)",
+			customerCode, len(icData.Users), len(icData.Memberships), source.Name(), identityCenterID, dataSource)
 
 		result.UsersProcessed = len(icData.Users)
 
+		if icSync.Delta != nil && icSync.Delta.IsEmpty() {
+			result.Success = true
+			result.Report.SetMode("delta")
+			logBuffer.Printf("✅ Customer %s: No contact changes since %s, nothing to import", customerCode, icSync.Delta.Since.Format(time.RFC3339))
+			saveIdentityCenterSnapshot(snapshotStore, icSync, customerCode, dryRun, logBuffer)
+			logBuffer.Flush()
+			return result
+		}
+
 		// Small delay to ensure all async Identity Center logs are captured before SES operations
 		time.Sleep(50 * time.Millisecond)
 	} else {
@@ -2729,8 +3785,13 @@ func processCustomer(
 	// Import contacts
 	logBuffer.Printf("📥 Customer %s: Importing contacts to SES (data source: %s)", customerCode, dataSource)
 
-	// Use ImportAllAWSContactsWithLogger to pass our buffered logger
-	err = ses.ImportAllAWSContactsWithLogger(sesClient, identityCenterID, icData, dryRun, requestsPerSecond, logBuffer)
+	// Use the WithLogger variants to pass our buffered logger
+	if icSync != nil && icSync.Delta != nil {
+		logBuffer.Printf("⚡ Customer %s: Importing %d Identity Center changes (%s)", customerCode, len(icSync.Delta.Changes), icSync.Delta.Summary())
+		err = ses.ImportAWSContactDeltaWithLogger(sesClient, icSync.Delta, &customerInfo, dryRun, logBuffer, result.Report)
+	} else {
+		err = ses.ImportAllAWSContactsWithLogger(sesClient, identityCenterID, icData, &customerInfo, dryRun, requestsPerSecond, logBuffer, result.Report)
+	}
 	if err != nil {
 		result.Error = fmt.Errorf("failed to import contacts: %w", err)
 		logBuffer.Printf("❌ Customer %s: Failed to import contacts: %v", customerCode, err)
@@ -2741,12 +3802,30 @@ func processCustomer(
 	result.Success = true
 	logBuffer.Printf("✅ Customer %s: Successfully imported contacts (data source: %s)", customerCode, dataSource)
 
+	if icSync != nil {
+		saveIdentityCenterSnapshot(snapshotStore, icSync, customerCode, dryRun, logBuffer)
+	}
+
 	// Flush all logs for this customer as a block
 	logBuffer.Flush()
 
 	return result
 }
 
+// saveIdentityCenterSnapshot records the Identity Center state the customer's contacts now reflect.
+// A failure only costs the next run a larger delta, so it is logged rather than returned.
+func saveIdentityCenterSnapshot(store aws.SnapshotStore, icSync *aws.IdentityCenterSync, customerCode string, dryRun bool, logBuffer *CustomerLogBuffer) {
+	if dryRun {
+		logBuffer.Printf("🔍 Customer %s: DRY RUN - Identity Center snapshot not saved", customerCode)
+		return
+	}
+	if err := store.Save(context.Background(), icSync.Snapshot); err != nil {
+		logBuffer.Printf("⚠️  Customer %s: Failed to save Identity Center snapshot: %v", customerCode, err)
+		return
+	}
+	logBuffer.Printf("💾 Customer %s: Saved Identity Center snapshot (%d users)", customerCode, len(icSync.Snapshot.Users))
+}
+
 // handleImportAWSContactAllEnhanced processes multiple customers concurrently with in-memory Identity Center data
 func handleImportAWSContactAllEnhanced(
 	cfg *types.Config,
@@ -2754,6 +3833,10 @@ func handleImportAWSContactAllEnhanced(
 	maxConcurrency int,
 	requestsPerSecond int,
 	dryRun bool,
+	snapshotStore aws.SnapshotStore,
+	fullResync bool,
+	reportFile string,
+	reportLocation string,
 ) error {
 	// Get list of customers to process
 	var customersToProcess []string
@@ -2785,6 +3868,7 @@ func handleImportAWSContactAllEnhanced(
 	fmt.Printf("⚙️  Requests per second: %d\n", requestsPerSecond)
 	fmt.Printf("📂 Data source mode: %s\n", dataSourceMode)
 	fmt.Printf("🔧 Dry run: %v\n", dryRun)
+	fmt.Printf("🔄 Full resync: %v\n", fullResync)
 	if identityCenterRoleArn != nil && *identityCenterRoleArn != "" {
 		fmt.Printf("🔐 Identity Center role (CLI override): %s\n", *identityCenterRoleArn)
 	}
@@ -2806,7 +3890,13 @@ func handleImportAWSContactAllEnhanced(
 			defer func() { <-semaphore }()
 
 			// Process customer
-			result := processCustomer(cfg, customerCode, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun)
+			result := processCustomer(cfg, customerCode, identityCenterRoleArn, maxConcurrency, requestsPerSecond, dryRun, snapshotStore, fullResync)
+			result.Report.Finish(result.Error)
+			result.ContactsAdded = result.Report.Count(ses.ContactAdded)
+			result.ContactsUpdated = result.Report.Count(ses.ContactChanged)
+			result.ContactsRemoved = result.Report.Count(ses.ContactRemoved)
+			result.ContactsSkipped = result.Report.Count(ses.ContactSkipped)
+			result.ContactsFailed = result.Report.Count(ses.ContactFailed)
 			results <- result
 		}(custCode)
 	}
@@ -2823,8 +3913,86 @@ func handleImportAWSContactAllEnhanced(
 		allResults = append(allResults, result)
 	}
 
+	// Write the per-contact report before the summary, so it exists even when customers failed
+	var reports []*ses.ContactImportReport
+	for _, result := range allResults {
+		reports = append(reports, result.Report)
+	}
+	reportErr := writeContactImportReport(reports, dryRun, reportFile, reportLocation)
+
 	// Aggregate and report results
-	return aggregateAndReportResults(allResults)
+	if err := aggregateAndReportResults(allResults); err != nil {
+		return err
+	}
+	return reportErr
+}
+
+// handleCompareImportReports lists the contacts whose import outcome differs between an older and
+// a newer import report
+func handleCompareImportReports(olderFile string, newerFile string) {
+	if olderFile == "" || newerFile == "" {
+		log.Fatal("--compare-report-file (older) and --report-file (newer) are required for compare-import-reports action")
+	}
+
+	older, err := ses.LoadImportReport(olderFile)
+	if err != nil {
+		log.Fatalf("Failed to load report: %v", err)
+	}
+	newer, err := ses.LoadImportReport(newerFile)
+	if err != nil {
+		log.Fatalf("Failed to load report: %v", err)
+	}
+
+	differences, err := ses.CompareImportReports(older, newer)
+	if err != nil {
+		log.Fatalf("Failed to compare reports: %v", err)
+	}
+
+	fmt.Printf("📊 Comparing %s (%s) with %s (%s)\n", olderFile, older.GeneratedAt.Format(time.RFC3339), newerFile, newer.GeneratedAt.Format(time.RFC3339))
+	if len(differences) == 0 {
+		fmt.Printf("✅ Both runs had the same outcome for every contact\n")
+		return
+	}
+
+	customer := ""
+	for _, diff := range differences {
+		if diff.CustomerCode != customer {
+			customer = diff.CustomerCode
+			fmt.Printf("\n🏢 %s\n", customer)
+		}
+		old, current := diff.Old, diff.New
+		if old == "" {
+			old = "-"
+		}
+		if current == "" {
+			current = "-"
+		}
+		fmt.Printf("   %-40s %s → %s\n", diff.Email, old, current)
+	}
+	fmt.Printf("\n📋 %d contacts differ\n", len(differences))
+}
+
+// writeContactImportReport writes the import report to reportFile (or the config path) and, when
+// reportLocation is set, uploads it to S3
+func writeContactImportReport(reports []*ses.ContactImportReport, dryRun bool, reportFile string, reportLocation string) error {
+	report := ses.NewImportReport(reports, dryRun)
+
+	path, err := ses.WriteImportReport(report, reportFile)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return err
+	}
+	fmt.Printf("📝 Import report written to %s\n", path)
+
+	if reportLocation != "" {
+		uri, err := ses.UploadImportReport(context.Background(), report, reportLocation)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return err
+		}
+		fmt.Printf("☁️  Import report uploaded to %s\n", uri)
+	}
+	return nil
 }
 
 // aggregateAndReportResults aggregates results from all customer imports and reports summary
@@ -2835,7 +4003,9 @@ func aggregateAndReportResults(results []CustomerImportResult) error {
 	totalUsersProcessed := 0
 	totalContactsAdded := 0
 	totalContactsUpdated := 0
+	totalContactsRemoved := 0
 	totalContactsSkipped := 0
+	totalContactsFailed := 0
 	var successfulCustomers []string
 	var failedCustomers []string
 	var errorMessages []string
@@ -2848,7 +4018,9 @@ func aggregateAndReportResults(results []CustomerImportResult) error {
 			totalUsersProcessed += result.UsersProcessed
 			totalContactsAdded += result.ContactsAdded
 			totalContactsUpdated += result.ContactsUpdated
+			totalContactsRemoved += result.ContactsRemoved
 			totalContactsSkipped += result.ContactsSkipped
+			totalContactsFailed += result.ContactsFailed
 		} else {
 			failureCount++
 			failedCustomers = append(failedCustomers, result.CustomerCode)
@@ -2872,7 +4044,9 @@ func aggregateAndReportResults(results []CustomerImportResult) error {
 	fmt.Printf("   Users processed: %d\n", totalUsersProcessed)
 	fmt.Printf("   Contacts added: %d\n", totalContactsAdded)
 	fmt.Printf("   Contacts updated: %d\n", totalContactsUpdated)
+	fmt.Printf("   Contacts removed: %d\n", totalContactsRemoved)
 	fmt.Printf("   Contacts skipped: %d\n", totalContactsSkipped)
+	fmt.Printf("   Contacts failed: %d\n", totalContactsFailed)
 
 	// Report successful customers
 	if successCount > 0 {